@@ -0,0 +1,26 @@
+package scores
+
+// AlertRule notifies a user about tournament events that match all of
+// its criteria, empty criteria match every event.
+type AlertRule struct {
+	M
+	Track
+	UserID    int     `json:"userId" db:"user_id"`
+	Name      string  `json:"name"`
+	Event     string  `json:"event"` // e.g. `registration-open`, see volleynet/sync for all events
+	League    string  `json:"league" db:"league_key"`
+	Gender    string  `json:"gender"`
+	PlayerID  int     `json:"playerId" db:"player_id"`
+	Latitude  float32 `json:"latitude" db:"loc_lat"`
+	Longitude float32 `json:"longitude" db:"loc_lon"`
+	Radius    int     `json:"radius"` // max. distance of the tournament in km
+}
+
+// AlertTrigger records that a rule has matched an event, this
+// prevents notifying a user more than once about the same event.
+type AlertTrigger struct {
+	M
+	Track
+	RuleID int    `json:"ruleId" db:"rule_id"`
+	Key    string `json:"key" db:"event_key"`
+}
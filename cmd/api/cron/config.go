@@ -0,0 +1,386 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores/job"
+	"github.com/raphi011/scores/services"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+// job types of the configuration
+const (
+	TypeLadder          = "ladder"           // scrapes the ladder of `Genders`
+	TypeTournaments     = "tournaments"      // scrapes the tournaments of `Leagues` and `Genders`
+	TypeHistoryCleanup  = "history-cleanup"  // deletes job executions that are older than the retention
+	TypeRatings         = "ratings"          // rates the results of new tournaments
+	TypeActivityCleanup = "activity-cleanup" // deletes activities that are older than the retention
+	TypeSignups         = "signups"          // attempts the scheduled signups of tournaments whose registration has opened
+)
+
+var validGenders = []string{"M", "W"}
+
+// Duration is a time.Duration that is (un)marshalled as a string, e.g. `"1h30m"`.
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string (see `time.ParseDuration`).
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string like \"5m\": %v", err)
+	}
+
+	parsed, err := time.ParseDuration(value)
+
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+// MarshalJSON formats the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Config is the declarative definition of all scheduled jobs.
+type Config struct {
+	Jobs []JobConfig `json:"jobs"`
+}
+
+// JobConfig defines a job, see `job.Job` for the scheduling options.
+type JobConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	Genders      []string `json:"genders"`      // ladder and tournaments
+	Leagues      []string `json:"leagues"`      // tournaments
+	Season       int      `json:"season"`       // tournaments, a fixed season, if 0 the current season is used
+	SeasonOffset int      `json:"seasonOffset"` // tournaments, added to the current season, e.g. -1 for last year
+
+	Cron        string   `json:"cron"`
+	Interval    Duration `json:"interval"`
+	Delay       Duration `json:"delay"`
+	Timeout     Duration `json:"timeout"`
+	MaxRuns     uint     `json:"maxRuns"`
+	MaxFailures uint     `json:"maxFailures"`
+	Backoff     Duration `json:"backoff"`
+	MaxBackoff  Duration `json:"maxBackoff"`
+	Cooldown    Duration `json:"cooldown"`
+	DependsOn   []string `json:"dependsOn"`
+	Triggers    []string `json:"triggers"`
+}
+
+// LoadConfig reads and validates the job configuration file at `path`.
+func LoadConfig(path string) (*Config, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "opening job config")
+	}
+
+	defer file.Close()
+
+	config := &Config{}
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(config); err != nil {
+		return nil, errors.Wrapf(err, "parsing job config %s", path)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid job config %s", path)
+	}
+
+	return config, nil
+}
+
+// DefaultConfig returns the jobs that are used if no configuration file is passed.
+func DefaultConfig() *Config {
+	return &Config{
+		Jobs: []JobConfig{
+			{
+				Name:        "Players",
+				Type:        TypeLadder,
+				Genders:     []string{"M", "W"},
+				MaxFailures: 3,
+				Interval:    Duration(1 * time.Hour),
+				Timeout:     Duration(10 * time.Minute),
+				Backoff:     Duration(1 * time.Minute),
+				Cooldown:    Duration(6 * time.Hour),
+				Triggers:    []string{"Tournaments"}, // new players are needed when syncing teams
+			},
+			{
+				Name:         "Last years tournaments",
+				Type:         TypeTournaments,
+				Genders:      []string{"M", "W"},
+				Leagues:      []string{"AMATEUR TOUR", "PRO TOUR", "JUNIOR TOUR"},
+				SeasonOffset: -1,
+				Cron:         "CRON_TZ=Europe/Vienna 0 3 * * *", // the backfill is heavy, run it at night
+				Timeout:      Duration(1 * time.Hour),
+				Backoff:      Duration(10 * time.Minute),
+				DependsOn:    []string{"Players"},
+				Triggers:     []string{"Ratings"},
+			},
+			{
+				Name:        "Tournaments",
+				Type:        TypeTournaments,
+				Genders:     []string{"M", "W"},
+				Leagues:     []string{"AMATEUR TOUR", "PRO TOUR", "JUNIOR TOUR"},
+				MaxFailures: 3,
+				Cron:        "CRON_TZ=Europe/Vienna */5 7-22 * * *",
+				Timeout:     Duration(10 * time.Minute),
+				Backoff:     Duration(1 * time.Minute),
+				Cooldown:    Duration(1 * time.Hour),
+				DependsOn:   []string{"Players"},
+				Triggers:    []string{"Ratings"}, // new results are rated after every sync
+			},
+			{
+				Name:    "Ratings",
+				Type:    TypeRatings,
+				Cron:    "@daily",
+				Timeout: Duration(30 * time.Minute),
+				Backoff: Duration(5 * time.Minute),
+			},
+			{
+				Name:    "Job history cleanup",
+				Type:    TypeHistoryCleanup,
+				Cron:    "@daily",
+				Timeout: Duration(1 * time.Minute),
+			},
+			{
+				Name:    "Activity cleanup",
+				Type:    TypeActivityCleanup,
+				Cron:    "@daily",
+				Timeout: Duration(1 * time.Minute),
+			},
+			{
+				Name:     "Scheduled signups",
+				Type:     TypeSignups,
+				Interval: Duration(5 * time.Minute), // signups are attempted right after the sync, this catches up on missed ones
+				Timeout:  Duration(5 * time.Minute),
+			},
+		},
+	}
+}
+
+// Validate returns an error listing all invalid job definitions.
+func (c *Config) Validate() error {
+	problems := []string{}
+	names := map[string]bool{}
+
+	for _, j := range c.Jobs {
+		if j.Name == "" {
+			problems = append(problems, "a job has no name")
+			continue
+		}
+
+		if names[j.Name] {
+			problems = append(problems, fmt.Sprintf("job %q is defined twice", j.Name))
+		}
+
+		names[j.Name] = true
+	}
+
+	for _, j := range c.Jobs {
+		for _, problem := range j.validate(names) {
+			problems = append(problems, fmt.Sprintf("job %q: %s", j.Name, problem))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (j *JobConfig) validate(names map[string]bool) []string {
+	problems := []string{}
+
+	switch j.Type {
+	case TypeLadder:
+	case TypeTournaments:
+		if len(j.Leagues) == 0 {
+			problems = append(problems, "no leagues")
+		}
+
+		if j.Season != 0 && j.SeasonOffset != 0 {
+			problems = append(problems, "season and seasonOffset are mutually exclusive")
+		}
+	case TypeHistoryCleanup:
+	case TypeActivityCleanup:
+	case TypeSignups:
+	case TypeRatings:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown type %q", j.Type))
+	}
+
+	if j.Type == TypeLadder || j.Type == TypeTournaments {
+		if len(j.Genders) == 0 {
+			problems = append(problems, "no genders")
+		}
+
+		for _, gender := range j.Genders {
+			if !contains(validGenders, gender) {
+				problems = append(problems, fmt.Sprintf("invalid gender %q", gender))
+			}
+		}
+	}
+
+	if j.Cron != "" {
+		if _, err := job.ParseSchedule(j.Cron); err != nil {
+			problems = append(problems, err.Error())
+		}
+	} else if j.Interval <= 0 {
+		problems = append(problems, "either cron or a positive interval is required")
+	}
+
+	durations := []struct {
+		name  string
+		value Duration
+	}{
+		{"delay", j.Delay},
+		{"timeout", j.Timeout},
+		{"backoff", j.Backoff},
+		{"maxBackoff", j.MaxBackoff},
+		{"cooldown", j.Cooldown},
+	}
+
+	for _, d := range durations {
+		if d.value < 0 {
+			problems = append(problems, fmt.Sprintf("%s must not be negative", d.name))
+		}
+	}
+
+	for _, related := range append(append([]string{}, j.DependsOn...), j.Triggers...) {
+		if !names[related] {
+			problems = append(problems, fmt.Sprintf("unknown job %q", related))
+		}
+	}
+
+	return problems
+}
+
+// Services are the dependencies of the configured jobs.
+type Services struct {
+	SyncService     *sync.Service
+	HistoryCleanup  func(ctx context.Context) error
+	ActivityCleanup func(ctx context.Context) error
+	Signups         func(ctx context.Context) error
+	RatingService   *services.Rating
+}
+
+// Build creates the jobs of the configuration.
+func (c *Config) Build(services Services) ([]job.Job, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	jobs := []job.Job{}
+
+	for _, conf := range c.Jobs {
+		j := job.Job{
+			Name:        conf.Name,
+			Cron:        conf.Cron,
+			Interval:    time.Duration(conf.Interval),
+			Delay:       time.Duration(conf.Delay),
+			Timeout:     time.Duration(conf.Timeout),
+			MaxRuns:     conf.MaxRuns,
+			MaxFailures: conf.MaxFailures,
+			Backoff:     time.Duration(conf.Backoff),
+			MaxBackoff:  time.Duration(conf.MaxBackoff),
+			Cooldown:    time.Duration(conf.Cooldown),
+			DependsOn:   conf.DependsOn,
+			Triggers:    conf.Triggers,
+		}
+
+		switch conf.Type {
+		case TypeLadder:
+			ladderJob := &LadderJob{
+				SyncService: services.SyncService,
+				Genders:     conf.Genders,
+			}
+
+			j.Do = ladderJob.Do
+			j.Summary = ladderJob.Summary
+		case TypeTournaments:
+			tournamentsJob := &TournamentsJob{
+				SyncService:  services.SyncService,
+				Genders:      conf.Genders,
+				Leagues:      conf.Leagues,
+				Season:       conf.Season,
+				SeasonOffset: conf.SeasonOffset,
+			}
+
+			j.Do = tournamentsJob.Do
+			j.Summary = tournamentsJob.Summary
+		case TypeHistoryCleanup:
+			if services.HistoryCleanup == nil {
+				return nil, fmt.Errorf("job %q: the job history is not available", conf.Name)
+			}
+
+			j.Do = services.HistoryCleanup
+		case TypeActivityCleanup:
+			if services.ActivityCleanup == nil {
+				return nil, fmt.Errorf("job %q: the activity feed is not available", conf.Name)
+			}
+
+			j.Do = services.ActivityCleanup
+		case TypeSignups:
+			if services.Signups == nil {
+				return nil, fmt.Errorf("job %q: scheduled signups are not available", conf.Name)
+			}
+
+			j.Do = services.Signups
+		case TypeRatings:
+			if services.RatingService == nil {
+				return nil, fmt.Errorf("job %q: the rating service is not available", conf.Name)
+			}
+
+			ratingsJob := &RatingsJob{RatingService: services.RatingService}
+
+			j.Do = ratingsJob.Do
+			j.Summary = ratingsJob.Summary
+		}
+
+		jobs = append(jobs, j)
+	}
+
+	return jobs, nil
+}
+
+// Names returns the names of the jobs of type `jobType`.
+func (c *Config) Names(jobType string) []string {
+	names := []string{}
+
+	for _, conf := range c.Jobs {
+		if conf.Type == jobType {
+			names = append(names, conf.Name)
+		}
+	}
+
+	return names
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores/test"
+)
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	test.Check(t, "DefaultConfig().Validate() failed", DefaultConfig().Validate())
+}
+
+func TestExampleConfig(t *testing.T) {
+	config, err := LoadConfig("../jobs.example.json")
+
+	test.Check(t, "LoadConfig() failed", err)
+	test.Compare(t, "example config differs from the default config:\n%s", DefaultConfig(), config)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		job     JobConfig
+		problem string
+	}{
+		{"unknown type", JobConfig{Name: "a", Type: "x", Interval: Duration(time.Hour)}, `unknown type "x"`},
+		{"no schedule", JobConfig{Name: "a", Type: TypeHistoryCleanup}, "either cron or a positive interval"},
+		{"invalid cron", JobConfig{Name: "a", Type: TypeHistoryCleanup, Cron: "* *"}, "job \"a\""},
+		{"invalid gender", JobConfig{Name: "a", Type: TypeLadder, Genders: []string{"X"}, Interval: Duration(time.Hour)}, `invalid gender "X"`},
+		{"no leagues", JobConfig{Name: "a", Type: TypeTournaments, Genders: []string{"M"}, Interval: Duration(time.Hour)}, "no leagues"},
+		{"unknown dependency", JobConfig{Name: "a", Type: TypeHistoryCleanup, Interval: Duration(time.Hour), DependsOn: []string{"b"}}, `unknown job "b"`},
+		{"negative backoff", JobConfig{Name: "a", Type: TypeHistoryCleanup, Interval: Duration(time.Hour), Backoff: Duration(-time.Second)}, "backoff must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Config{Jobs: []JobConfig{tt.job}}).Validate()
+
+			test.Assert(t, "expected error containing %q, got: %v", err != nil && strings.Contains(err.Error(), tt.problem), tt.problem, err)
+		})
+	}
+}
+
+func TestTournamentsJobSeason(t *testing.T) {
+	newYear := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	j := &TournamentsJob{SeasonOffset: -1, now: func() time.Time { return newYear }}
+	test.Assert(t, "expected season 2019, got %d", j.season() == 2019, j.season())
+
+	j.now = func() time.Time { return newYear.Add(-time.Second) }
+	test.Assert(t, "expected season 2018, got %d", j.season() == 2018, j.season())
+
+	j.Season = 2015
+	test.Assert(t, "expected fixed season 2015, got %d", j.season() == 2015, j.season())
+}
+
+func TestConfigNames(t *testing.T) {
+	names := DefaultConfig().Names(TypeRatings)
+
+	test.Compare(t, "unexpected ratings jobs:\n%s", []string{"Ratings"}, names)
+}
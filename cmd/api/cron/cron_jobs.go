@@ -1,9 +1,13 @@
 package cron
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/raphi011/scores/job"
+	"github.com/raphi011/scores/services"
 	"github.com/raphi011/scores/volleynet/sync"
 )
 
@@ -17,37 +21,66 @@ type JobReport struct {
 type LadderJob struct {
 	SyncService *sync.Service
 	Genders     []string
+
+	summary []string
 }
 
 // Do runs the scrape job.
-func (j *LadderJob) Do() error {
+func (j *LadderJob) Do(ctx context.Context) error {
+	j.summary = nil
+
 	for _, gender := range j.Genders {
-		_, err := j.SyncService.Ladder(gender)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		report, err := j.SyncService.Ladder(ctx, gender)
 
 		if err != nil {
 			return err
 		}
+
+		j.summary = append(j.summary, fmt.Sprintf("%s: %s", gender, report.Summary()))
 	}
 
 	return nil
 }
 
-var leagues = []string{"amateur-tour", "pro-tour", "junior-tour"}
-var genders = []string{"M", "W"}
+// Summary summarizes the changes of the last run.
+func (j *LadderJob) Summary() string {
+	return strings.Join(j.summary, "\n")
+}
 
 // TournamentsJob is a job that scrapes tournaments with the given filters.
 type TournamentsJob struct {
-	SyncService *sync.Service
-	Leagues     []string
-	Genders     []string
-	Season      int
+	SyncService  *sync.Service
+	Leagues      []string
+	Genders      []string
+	Season       int // scrapes a fixed season, if 0 the current season is used
+	SeasonOffset int // added to the current season, e.g. -1 for last year's season
+
+	now func() time.Time
+
+	summary []string
 }
 
-// Do runs the scrape job.
-func (j *TournamentsJob) Do() error {
+// Do runs the scrape job, it stops between two leagues / genders
+// if `ctx` is done.
+func (j *TournamentsJob) Do(ctx context.Context) error {
+	j.summary = nil
+	season := j.season()
+
 	for _, league := range j.Leagues {
 		for _, gender := range j.Genders {
-			err := j.SyncService.Tournaments(gender, league, j.Season)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			report, err := j.SyncService.Tournaments(ctx, gender, league, season)
+
+			if report != nil {
+				j.summary = append(j.summary, fmt.Sprintf("%s %s: %s", league, gender, report.Summary()))
+			}
 
 			if err != nil {
 				return err
@@ -57,3 +90,48 @@ func (j *TournamentsJob) Do() error {
 
 	return nil
 }
+
+// season returns the season to scrape, the current season is evaluated
+// on every run so the job moves on to the next season on new year.
+func (j *TournamentsJob) season() int {
+	if j.Season != 0 {
+		return j.Season
+	}
+
+	now := time.Now
+
+	if j.now != nil {
+		now = j.now
+	}
+
+	return now().Year() + j.SeasonOffset
+}
+
+// Summary summarizes the changes of the last run.
+func (j *TournamentsJob) Summary() string {
+	return strings.Join(j.summary, "\n")
+}
+
+// RatingsJob is a job that rates the results of new tournaments
+// or recomputes all ratings if an admin requested it.
+type RatingsJob struct {
+	RatingService *services.Rating
+
+	summary string
+}
+
+// Do runs the rating job.
+func (j *RatingsJob) Do(ctx context.Context) error {
+	report, err := j.RatingService.Run(ctx)
+
+	if report != nil {
+		j.summary = report.Summary()
+	}
+
+	return err
+}
+
+// Summary summarizes the changes of the last run.
+func (j *RatingsJob) Summary() string {
+	return j.summary
+}
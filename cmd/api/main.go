@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -11,13 +12,23 @@ import (
 var version = "undefined"
 
 func main() {
-	dbProvider := flag.String("provider", "sqlite3", "DB Driver (sqlite3, mysql or postgres)")
+	dbProvider := flag.String("provider", "sqlite3", "DB Driver (sqlite3, mysql, postgres or memory)")
 	connectionString := flag.String("connection", "./scores.db", "provider specific connectionstring")
+	autoMigrate := flag.Bool("automigrate", true, "migrate the db to the latest version on startup, if disabled use the migrate command")
 	gSecret := flag.String("gauth", "./client_secret.json", "Path to google oauth secret")
 	logstashURL := flag.String("logstash", "", "logstash url")
 	debugLevel := flag.Int("debuglevel", int(logrus.InfoLevel), "Debug level")
 	mode := flag.String("mode", "production", "debug or production")
 	host := flag.String("backendurl", "https://localhost", "backend url")
+	telegramToken := flag.String("telegram", "", "telegram bot token, the bot is disabled if empty")
+	smtpAddr := flag.String("smtp", "", "smtp server address (host:port), email notifications are disabled if empty")
+	smtpUser := flag.String("smtpuser", "", "smtp username")
+	smtpPassword := flag.String("smtppassword", "", "smtp password")
+	smtpFrom := flag.String("smtpfrom", "noreply@scores", "sender address of notification emails")
+	secret := flag.String("secret", "", "secret used to sign tokens (e.g. unsubscribe links)")
+	jobHistory := flag.Duration("jobhistory", 30*24*time.Hour, "how long job executions are kept in the history, forever if 0")
+	jobConfig := flag.String("jobs", "", "path of the job configuration (json), the default jobs are used if empty")
+	shutdownTimeout := flag.Duration("shutdowntimeout", 30*time.Second, "how long to wait for requests and jobs to finish on shutdown")
 
 	flag.Parse()
 
@@ -25,9 +36,15 @@ func main() {
 		router.WithLogstash(*logstashURL, logrus.Level(*debugLevel)),
 		router.WithVersion(version),
 		router.WithMode(*mode),
-		router.WithRepository(*dbProvider, *connectionString),
+		router.WithRepository(*dbProvider, *connectionString, *autoMigrate),
 		router.WithOAuth(*gSecret, *host),
 		router.WithEventQueue(),
+		router.WithTelegram(*telegramToken),
+		router.WithSMTP(*smtpAddr, *smtpUser, *smtpPassword, *smtpFrom),
+		router.WithSecret(*secret),
+		router.WithJobHistory(*jobHistory),
+		router.WithJobConfig(*jobConfig),
+		router.WithShutdownTimeout(*shutdownTimeout),
 	)
 
 	r.Run()
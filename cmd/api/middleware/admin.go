@@ -14,7 +14,7 @@ func Admin(userService *services.User) gin.HandlerFunc {
 		session := sessions.Default(c)
 		userID := session.Get("user-id")
 
-		if !userService.HasRole(userID.(int), "admin") {
+		if !userService.HasRole(c.Request.Context(), userID.(int), "admin") {
 			c.AbortWithStatus(http.StatusForbidden)
 			return
 		}
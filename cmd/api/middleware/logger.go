@@ -7,21 +7,26 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores"
 )
 
 // Logger middleware populates a logger with request specific fields
-// and adds it to the context
+// and adds it to the context, the request id is also added to the
+// request's context so it's available to the services
 func Logger(log logrus.FieldLogger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		log = log.WithFields(logrus.Fields{
+		requestID := uuid.New().String()
+
+		c.Set("log", log.WithFields(logrus.Fields{
 			"method":     c.Request.Method,
 			"url":        c.Request.URL.String(),
 			"ip":         ipFromRequest(c.Request),
 			"user-agent": c.Request.UserAgent(),
-			"request-id": uuid.New().String(),
-		})
+			"request-id": requestID,
+		}))
 
-		c.Set("log", log)
+		c.Request = c.Request.WithContext(scores.WithRequestID(c.Request.Context(), requestID))
 
 		c.Next()
 	}
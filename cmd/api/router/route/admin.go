@@ -36,10 +36,10 @@ func (a *Admin) PostUser(c *gin.Context) {
 		return
 	}
 
-	user, err := a.userService.ByEmail(userChanges.Email)
+	user, err := a.userService.ByEmail(c.Request.Context(), userChanges.Email)
 
 	if errors.Cause(err) == scores.ErrNotFound {
-		user, err = a.userService.New(userChanges.Email, userChanges.Password, "user")
+		user, err = a.userService.New(c.Request.Context(), userChanges.Email, userChanges.Password, "user")
 
 		if err != nil {
 			responseErr(c, err)
@@ -52,7 +52,7 @@ func (a *Admin) PostUser(c *gin.Context) {
 		return
 	}
 
-	err = a.userService.SetPassword(user.ID, userChanges.Password)
+	err = a.userService.SetPassword(c.Request.Context(), user.ID, userChanges.Password)
 
 	if err != nil {
 		responseErr(c, err)
@@ -64,7 +64,7 @@ func (a *Admin) PostUser(c *gin.Context) {
 
 // GetUsers returns all current users.
 func (a *Admin) GetUsers(c *gin.Context) {
-	users, err := a.userService.All()
+	users, err := a.userService.All(c.Request.Context())
 
 	if err != nil {
 		responseErr(c, err)
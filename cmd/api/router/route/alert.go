@@ -0,0 +1,115 @@
+package route
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/services"
+)
+
+// AlertHandler is the constructor for the alert rule routes handler.
+func AlertHandler(alertService *services.Alert) Alert {
+	return Alert{
+		alertService: alertService,
+	}
+}
+
+// Alert wraps the dependencies of the AlertHandler.
+type Alert struct {
+	alertService *services.Alert
+}
+
+// GetAlertRules returns all alert rules of the user.
+func (h *Alert) GetAlertRules(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	rules, err := h.alertService.Rules(c.Request.Context(), userID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, rules)
+}
+
+// PostAlertRule creates a new alert rule for the user.
+func (h *Alert) PostAlertRule(c *gin.Context) {
+	rule := &scores.AlertRule{}
+
+	if err := c.ShouldBindWith(rule, binding.JSON); err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	rule.ID = 0
+	rule.UserID = session.Get("user-id").(int)
+
+	rule, err := h.alertService.Create(c.Request.Context(), rule)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusCreated, rule)
+}
+
+// PutAlertRule updates an alert rule of the user.
+func (h *Alert) PutAlertRule(c *gin.Context) {
+	ruleID, err := strconv.Atoi(c.Param("ruleID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	rule := &scores.AlertRule{}
+
+	if err := c.ShouldBindWith(rule, binding.JSON); err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+	rule.ID = ruleID
+
+	err = h.alertService.Update(c.Request.Context(), userID, rule)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, rule)
+}
+
+// DeleteAlertRule deletes an alert rule of the user.
+func (h *Alert) DeleteAlertRule(c *gin.Context) {
+	ruleID, err := strconv.Atoi(c.Param("ruleID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	err = h.alertService.Delete(c.Request.Context(), userID, ruleID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	responseNoContent(c)
+}
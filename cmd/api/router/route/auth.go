@@ -63,7 +63,7 @@ func (a *Auth) PostPasswordAuthenticate(c *gin.Context) {
 		return
 	}
 
-	user, err := a.userService.ByEmail(credentials.Email)
+	user, err := a.userService.ByEmail(c.Request.Context(), credentials.Email)
 
 	if err != nil {
 		response(c, http.StatusUnauthorized, nil)
@@ -130,7 +130,7 @@ func (a *Auth) GetGoogleAuthenticate(c *gin.Context) {
 		return
 	}
 
-	user, err := a.userService.ByEmail(googleUser.Email)
+	user, err := a.userService.ByEmail(c.Request.Context(), googleUser.Email)
 
 	if err != nil {
 		c.Redirect(http.StatusFound, "/login?error=USER_NOT_FOUND")
@@ -138,7 +138,7 @@ func (a *Auth) GetGoogleAuthenticate(c *gin.Context) {
 	}
 
 	if user.ProfileImageURL != googleUser.Picture {
-		err := a.userService.SetProfileImage(user.ID, googleUser.Picture)
+		err := a.userService.SetProfileImage(c.Request.Context(), user.ID, googleUser.Picture)
 		if err != nil {
 			logger.Get(c).Warningf("setting profile image %v", err)
 		}
@@ -162,7 +162,7 @@ func (a *Auth) GetLoginRouteOrUser(c *gin.Context) {
 	session := sessions.Default(c)
 
 	if userID, ok := session.Get("user-id").(int); ok {
-		user, err := a.userService.ByID(userID)
+		user, err := a.userService.ByID(c.Request.Context(), userID)
 
 		if err != nil {
 			session.Delete("user-id")
@@ -0,0 +1,101 @@
+package route
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/raphi011/scores/services"
+)
+
+// AutoSignupHandler is the constructor for the scheduled signup routes handler.
+func AutoSignupHandler(autoSignupService *services.AutoSignup) AutoSignup {
+	return AutoSignup{
+		autoSignupService: autoSignupService,
+	}
+}
+
+// AutoSignup wraps the dependencies of the AutoSignupHandler.
+type AutoSignup struct {
+	autoSignupService *services.AutoSignup
+}
+
+type scheduleForm struct {
+	TournamentID int    `json:"tournamentId"`
+	PartnerID    int    `json:"partnerId"`
+	PartnerName  string `json:"partnerName"`
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+}
+
+// GetSignups returns the scheduled signups of the user, the latest first.
+func (h *AutoSignup) GetSignups(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	signups, err := h.autoSignupService.Signups(c.Request.Context(), userID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, signups)
+}
+
+// PostSignup schedules a signup that is performed as soon as the
+// registration of the tournament opens.
+func (h *AutoSignup) PostSignup(c *gin.Context) {
+	form := scheduleForm{}
+
+	if err := c.ShouldBindWith(&form, binding.JSON); err != nil ||
+		form.TournamentID <= 0 ||
+		form.PartnerID <= 0 {
+
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	signup, err := h.autoSignupService.Schedule(c.Request.Context(), userID, services.SignupRequest{
+		TournamentID: form.TournamentID,
+		PartnerID:    form.PartnerID,
+		PartnerName:  form.PartnerName,
+		Username:     form.Username,
+		Password:     form.Password,
+	})
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusCreated, signup)
+}
+
+// DeleteSignup cancels a scheduled signup.
+func (h *AutoSignup) DeleteSignup(c *gin.Context) {
+	signupID, err := strconv.Atoi(c.Param("signupID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	signup, err := h.autoSignupService.Cancel(c.Request.Context(), userID, signupID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, signup)
+}
@@ -0,0 +1,40 @@
+package route_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/raphi011/scores/test"
+)
+
+func TestGetSignups(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/signups")
+
+	test.Equal(t, "/signups expected status %d, got %d", http.StatusOK, w.Code)
+}
+
+func TestPostSignupsInvalidBody(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/signups", map[string]interface{}{"tournamentId": 1})
+
+	test.Equal(t, "/signups expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
+
+func TestPostSignupsUnknownTournament(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/signups", map[string]interface{}{
+		"tournamentId": 1,
+		"partnerId":    2,
+		"username":     "user",
+		"password":     "password",
+	})
+
+	test.Equal(t, "/signups expected status %d, got %d", http.StatusNotFound, w.Code)
+}
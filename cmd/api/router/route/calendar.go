@@ -0,0 +1,104 @@
+package route
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+
+	"github.com/raphi011/scores/ical"
+	"github.com/raphi011/scores/services"
+)
+
+// CalendarHandler is the constructor for the calendar routes handler.
+func CalendarHandler(calendarService *services.Calendar, baseURL string) Calendar {
+	return Calendar{
+		calendarService: calendarService,
+		baseURL:         baseURL,
+	}
+}
+
+// Calendar wraps the dependencies of the CalendarHandler.
+type Calendar struct {
+	calendarService *services.Calendar
+	// baseURL is the url of the api, used to create feed links.
+	baseURL string
+}
+
+type calendarLinkDto struct {
+	URL string `json:"url"`
+}
+
+// GetLink returns the link of the user's calendar feed.
+func (h *Calendar) GetLink(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	token, err := h.calendarService.Token(c.Request.Context(), userID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, calendarLinkDto{URL: h.link(userID, token)})
+}
+
+// PostLink creates a new link of the user's calendar feed, the previous
+// link stops working.
+func (h *Calendar) PostLink(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	token, err := h.calendarService.Rotate(c.Request.Context(), userID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, calendarLinkDto{URL: h.link(userID, token)})
+}
+
+// GetFeed returns the calendar of a user as iCalendar file, calendar apps
+// can't login so this route is authenticated via the token.
+func (h *Calendar) GetFeed(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Query("user"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	if !h.calendarService.Verify(c.Request.Context(), userID, c.Query("token")) {
+		response(c, http.StatusUnauthorized, nil)
+		return
+	}
+
+	calendar, err := h.calendarService.Calendar(c.Request.Context(), userID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	buf := &bytes.Buffer{}
+
+	if _, err := calendar.WriteTo(buf); err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, ical.ContentType, buf.Bytes())
+}
+
+func (h *Calendar) link(userID int, token string) string {
+	query := url.Values{}
+	query.Add("user", strconv.Itoa(userID))
+	query.Add("token", token)
+
+	return h.baseURL + "/api/calendar/feed.ics?" + query.Encode()
+}
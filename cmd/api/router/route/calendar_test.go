@@ -0,0 +1,59 @@
+package route_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/raphi011/scores/test"
+)
+
+func (c *testClient) calendarLink(method string) string {
+	c.t.Helper()
+
+	w := c.get("/calendar/link")
+
+	if method == "POST" {
+		w = c.post("/calendar/link", nil)
+	}
+
+	test.Equal(c.t, "/calendar/link expected status %d, got %d", http.StatusOK, w.Code)
+
+	body := struct {
+		Data struct{ URL string }
+	}{}
+
+	test.Check(c.t, "unmarshal calendar link: %v", json.Unmarshal(w.Body.Bytes(), &body))
+
+	return body.Data.URL[strings.Index(body.Data.URL, "/calendar/"):]
+}
+
+func TestGetCalendarFeed(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	link := client.calendarLink("GET")
+	test.Equal(t, "want the same link twice, got %q and %q", link, client.calendarLink("GET"))
+
+	w := client.get(link)
+
+	test.Equal(t, "/calendar/feed.ics expected status %d, got %d", http.StatusOK, w.Code)
+	test.Assert(t, "want an iCalendar file, got %q", strings.HasPrefix(w.Body.String(), "BEGIN:VCALENDAR"), w.Body.String())
+
+	rotated := client.calendarLink("POST")
+
+	w = client.get(link)
+	test.Equal(t, "/calendar/feed.ics with a rotated token expected status %d, got %d", http.StatusUnauthorized, w.Code)
+
+	w = client.get(rotated)
+	test.Equal(t, "/calendar/feed.ics expected status %d, got %d", http.StatusOK, w.Code)
+}
+
+func TestGetCalendarFeedInvalidToken(t *testing.T) {
+	client := newTestClient(t)
+
+	w := client.get("/calendar/feed.ics?user=1&token=abc")
+
+	test.Equal(t, "/calendar/feed.ics expected status %d, got %d", http.StatusUnauthorized, w.Code)
+}
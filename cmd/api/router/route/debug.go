@@ -23,10 +23,10 @@ func (a *Debug) PostCreateAdmin(c *gin.Context) {
 	testEmail := "admin@scores.network"
 	testPassword := "test123"
 
-	_, err := a.userService.ByEmail(testEmail)
+	_, err := a.userService.ByEmail(c.Request.Context(), testEmail)
 
 	if errors.Cause(err) == scores.ErrNotFound {
-		_, err = a.userService.New(testEmail, testPassword, "admin")
+		_, err = a.userService.New(c.Request.Context(), testEmail, testPassword, "admin")
 
 		if err != nil {
 			responseErr(c, err)
@@ -0,0 +1,78 @@
+package route
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/raphi011/scores/services"
+)
+
+// LadderHandler is the constructor for the ladder calculator routes handler.
+func LadderHandler(ladderService *services.Ladder, volleynetService *services.Volleynet) Ladder {
+	return Ladder{
+		ladderService:    ladderService,
+		volleynetService: volleynetService,
+	}
+}
+
+// Ladder wraps the dependencies of the LadderHandler.
+type Ladder struct {
+	ladderService    *services.Ladder
+	volleynetService *services.Volleynet
+}
+
+// GetWhatIf projects the ladder points and rank of a player if the player
+// finishes an upcoming tournament with the passed result.
+func (h *Ladder) GetWhatIf(c *gin.Context) {
+	playerID, err := strconv.Atoi(c.Query("playerId"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	tournamentID, err := strconv.Atoi(c.Query("tournamentId"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	result, err := strconv.Atoi(c.Query("result"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	whatIf, err := h.ladderService.WhatIf(c.Request.Context(), playerID, tournamentID, result)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, whatIf)
+}
+
+// GetValidation compares the calculated with the scraped ladder points
+// of a gender, default gender is "M".
+func (h *Ladder) GetValidation(c *gin.Context) {
+	gender := c.DefaultQuery("gender", "M")
+
+	if !h.volleynetService.ValidGender(gender) {
+		responseBadRequest(c)
+		return
+	}
+
+	validation, err := h.ladderService.Validate(c.Request.Context(), gender)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, validation)
+}
@@ -0,0 +1,26 @@
+package route_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/raphi011/scores/test"
+)
+
+func TestGetLadderWhatIfWithoutResult(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/ladder/what-if?playerId=1&tournamentId=1")
+
+	test.Equal(t, "/ladder/what-if expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
+
+func TestGetLadderWhatIfOfUnknownPlayer(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/ladder/what-if?playerId=1&tournamentId=1&result=3")
+
+	test.Equal(t, "/ladder/what-if expected status %d, got %d", http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,103 @@
+package route
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/email"
+	"github.com/raphi011/scores/services"
+)
+
+// NotificationHandler is the constructor for the notification routes handler.
+func NotificationHandler(userService *services.User, signer *services.Signer) Notification {
+	return Notification{
+		userService: userService,
+		signer:      signer,
+	}
+}
+
+// Notification wraps the dependencies of the NotificationHandler.
+type Notification struct {
+	userService *services.User
+	signer      *services.Signer
+}
+
+type emailNotificationsDto struct {
+	Enabled  bool   `json:"enabled"`
+	Language string `json:"language"`
+}
+
+// PostEmailNotifications enables or disables email notifications of the user
+// and optionally sets the language of the emails.
+func (h *Notification) PostEmailNotifications(c *gin.Context) {
+	dto := emailNotificationsDto{}
+
+	if err := c.ShouldBindWith(&dto, binding.JSON); err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	if dto.Language != "" && dto.Language != "en" && dto.Language != "de" {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	enabled := email.Disabled
+
+	if dto.Enabled {
+		enabled = email.Enabled
+	}
+
+	settings := []*scores.Setting{
+		{UserID: userID, Key: email.EnabledSettingKey, Type: "string", Value: enabled},
+	}
+
+	// the language is kept if it's not passed
+	if dto.Language != "" {
+		settings = append(settings, &scores.Setting{UserID: userID, Key: email.LanguageSettingKey, Type: "string", Value: dto.Language})
+	}
+
+	err := h.userService.UpdateSettings(c.Request.Context(), userID, settings...)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	responseNoContent(c)
+}
+
+// GetUnsubscribeEmail disables email notifications of a user, this route
+// is linked in every email and authenticated via the token.
+func (h *Notification) GetUnsubscribeEmail(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Query("user"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	if !h.signer.Verify(email.UnsubscribePurpose, userID, c.Query("token")) {
+		response(c, http.StatusUnauthorized, nil)
+		return
+	}
+
+	err = h.userService.UpdateSettings(c.Request.Context(), userID,
+		&scores.Setting{UserID: userID, Key: email.EnabledSettingKey, Type: "string", Value: email.Disabled},
+	)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, "You have been unsubscribed from all email notifications.")
+}
@@ -0,0 +1,255 @@
+package route
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/services"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// PartnerBoardHandler is the constructor for the partner board routes handler.
+func PartnerBoardHandler(partnerBoardService *services.PartnerBoard) PartnerBoard {
+	return PartnerBoard{
+		partnerBoardService: partnerBoardService,
+	}
+}
+
+// PartnerBoard wraps the dependencies of the PartnerBoardHandler.
+type PartnerBoard struct {
+	partnerBoardService *services.PartnerBoard
+}
+
+// listingsQuery are the query parameters of `GetListings`.
+type listingsQuery struct {
+	Own          bool      `form:"own"`
+	TournamentID int       `form:"tournamentId"`
+	League       string    `form:"league"`
+	Gender       string    `form:"gender"`
+	MinPoints    int       `form:"minPoints"`
+	MaxPoints    int       `form:"maxPoints"`
+	From         time.Time `form:"from" time_format:"2006-01-02"`
+	To           time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// GetListings returns the open listings that match the query, if `own`
+// is set all listings of the user are returned instead.
+func (h *PartnerBoard) GetListings(c *gin.Context) {
+	query := listingsQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	var listings []*volleynet.PartnerListing
+	var err error
+
+	if query.Own {
+		session := sessions.Default(c)
+		userID := session.Get("user-id").(int)
+
+		listings, err = h.partnerBoardService.UserListings(c.Request.Context(), userID)
+	} else {
+		listings, err = h.partnerBoardService.Listings(c.Request.Context(), repo.PartnerListingFilter{
+			TournamentID: query.TournamentID,
+			League:       query.League,
+			Gender:       query.Gender,
+			MinPoints:    query.MinPoints,
+			MaxPoints:    query.MaxPoints,
+			From:         query.From,
+			To:           query.To,
+		})
+	}
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, listings)
+}
+
+// PostListing posts a new listing for the player of the user.
+func (h *PartnerBoard) PostListing(c *gin.Context) {
+	listing := &volleynet.PartnerListing{}
+
+	if err := c.ShouldBindWith(listing, binding.JSON); err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	listing, err := h.partnerBoardService.Post(c.Request.Context(), userID, listing)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusCreated, listing)
+}
+
+// DeleteListing closes a listing of the user.
+func (h *PartnerBoard) DeleteListing(c *gin.Context) {
+	listingID, err := strconv.Atoi(c.Param("listingID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	err = h.partnerBoardService.Close(c.Request.Context(), userID, listingID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	responseNoContent(c)
+}
+
+// PostRequest sends a request to the player of a listing.
+func (h *PartnerBoard) PostRequest(c *gin.Context) {
+	listingID, err := strconv.Atoi(c.Param("listingID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	request := &volleynet.PartnerRequest{}
+
+	if err := c.ShouldBindWith(request, binding.JSON); err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+	request.ListingID = listingID
+
+	request, err = h.partnerBoardService.Request(c.Request.Context(), userID, request)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusCreated, request)
+}
+
+// GetRequests returns the requests the user has sent and received.
+func (h *PartnerBoard) GetRequests(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	requests, err := h.partnerBoardService.Requests(c.Request.Context(), userID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, requests)
+}
+
+// PostAcceptRequest accepts a request for a listing of the user and
+// returns the pre-filled signup.
+func (h *PartnerBoard) PostAcceptRequest(c *gin.Context) {
+	requestID, err := strconv.Atoi(c.Param("requestID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	signup, err := h.partnerBoardService.Accept(c.Request.Context(), userID, requestID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, signup)
+}
+
+// PostDeclineRequest declines a request for a listing of the user.
+func (h *PartnerBoard) PostDeclineRequest(c *gin.Context) {
+	requestID, err := strconv.Atoi(c.Param("requestID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	err = h.partnerBoardService.Decline(c.Request.Context(), userID, requestID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	responseNoContent(c)
+}
+
+// DeleteRequest withdraws a request the user has sent.
+func (h *PartnerBoard) DeleteRequest(c *gin.Context) {
+	requestID, err := strconv.Atoi(c.Param("requestID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	err = h.partnerBoardService.Withdraw(c.Request.Context(), userID, requestID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	responseNoContent(c)
+}
+
+// GetSignup returns the pre-filled signup of an accepted request.
+func (h *PartnerBoard) GetSignup(c *gin.Context) {
+	requestID, err := strconv.Atoi(c.Param("requestID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	signup, err := h.partnerBoardService.Signup(c.Request.Context(), userID, requestID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, signup)
+}
@@ -0,0 +1,35 @@
+package route_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/raphi011/scores/test"
+)
+
+func TestPostPartnerListingWithoutPlayer(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/partner-listings", map[string]interface{}{"tournamentId": 1})
+
+	test.Equal(t, "/partner-listings expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
+
+func TestGetPartnerListingsInvalidDate(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/partner-listings?from=01.06.2019")
+
+	test.Equal(t, "/partner-listings?from=01.06.2019 expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
+
+func TestGetPartnerListings(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/partner-listings?gender=M&from=2019-06-01")
+
+	test.Equal(t, "/partner-listings expected status %d, got %d", http.StatusOK, w.Code)
+}
@@ -37,7 +37,7 @@ func (h *Player) GetLadder(c *gin.Context) {
 		return
 	}
 
-	ladder, err := h.volleynetService.Ladder(gender)
+	ladder, err := h.volleynetService.Ladder(c.Request.Context(), gender)
 
 	if err != nil {
 		responseErr(c, err)
@@ -78,21 +78,21 @@ func (h *Player) PostLogin(c *gin.Context) {
 
 	session := sessions.Default(c)
 	userID := session.Get("user-id").(int)
-	user, err := h.userService.ByID(userID)
+	user, err := h.userService.ByID(c.Request.Context(), userID)
 
 	if err != nil {
 		responseErr(c, err)
 		return
 	}
 
-	err = h.userService.SetVolleynetLogin(userID, loginData.ID, login.Username)
+	err = h.userService.SetVolleynetLogin(c.Request.Context(), userID, loginData.ID, login.Username)
 
 	if err != nil {
 		responseErr(c, err)
 		return
 	}
 
-	user, err = h.userService.ByID(userID)
+	user, err = h.userService.ByID(c.Request.Context(), userID)
 
 	if err != nil {
 		responseErr(c, err)
@@ -111,7 +111,7 @@ func (h *Player) GetPartners(c *gin.Context) {
 		return
 	}
 
-	partners, err := h.volleynetService.PreviousPartners(playerID)
+	partners, err := h.volleynetService.PreviousPartners(c.Request.Context(), playerID)
 
 	if err != nil {
 		responseErr(c, err)
@@ -121,13 +121,89 @@ func (h *Player) GetPartners(c *gin.Context) {
 	response(c, http.StatusOK, partners)
 }
 
+// GetRecommendedPartners returns the players that fit best as partner of
+// a player for the tournament `tournamentId`, at most `limit` (default 10).
+func (h *Player) GetRecommendedPartners(c *gin.Context) {
+	playerID, err := strconv.Atoi(c.Param("playerID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	tournamentID, err := strconv.Atoi(c.Query("tournamentId"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	recommendations, err := h.volleynetService.RecommendPartners(c.Request.Context(), playerID, tournamentID, limit)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, recommendations)
+}
+
+// GetHistory returns all tournament entries of a player, the
+// `leagues` query parameters restrict them to these leagues.
+func (h *Player) GetHistory(c *gin.Context) {
+	playerID, err := strconv.Atoi(c.Param("playerID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	history, err := h.volleynetService.PlayerHistory(c.Request.Context(), playerID,
+		repo.PlayerHistoryFilter{Leagues: c.QueryArray("leagues")})
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, history)
+}
+
+// GetSeasonStats returns the statistics of a player per season, the
+// `leagues` query parameters restrict them to these leagues.
+func (h *Player) GetSeasonStats(c *gin.Context) {
+	playerID, err := strconv.Atoi(c.Param("playerID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	stats, err := h.volleynetService.PlayerSeasonStats(c.Request.Context(), playerID,
+		repo.PlayerHistoryFilter{Leagues: c.QueryArray("leagues")})
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, stats)
+}
+
 // GetSearchPlayers searches all players of a gender.
 func (h *Player) GetSearchPlayers(c *gin.Context) {
 	firstName := c.Query("fname")
 	lastName := c.Query("lname")
 	gender := c.Query("gender")
 
-	players, err := h.volleynetService.SearchPlayers(repo.PlayerFilter{
+	players, err := h.volleynetService.SearchPlayers(c.Request.Context(), repo.PlayerFilter{
 		FirstName: firstName,
 		LastName:  lastName,
 		Gender:    gender,
@@ -0,0 +1,35 @@
+package route_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/raphi011/scores/test"
+)
+
+func TestGetHistoryOfUnknownPlayer(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/players/history/1")
+
+	test.Equal(t, "/players/history/1 expected status %d, got %d", http.StatusNotFound, w.Code)
+}
+
+func TestGetSeasonStatsInvalidPlayer(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/players/stats/abc?leagues=pro-tour")
+
+	test.Equal(t, "/players/stats/abc expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
+
+func TestGetRecommendedPartnersWithoutTournament(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/players/recommendations/1")
+
+	test.Equal(t, "/players/recommendations/1 expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
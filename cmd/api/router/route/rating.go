@@ -0,0 +1,109 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/raphi011/scores/job"
+	"github.com/raphi011/scores/services"
+)
+
+// RatingHandler is the constructor for the rating routes handler,
+// `ratingJobs` returns the names of the configured ratings jobs.
+func RatingHandler(ratingService *services.Rating, volleynetService *services.Volleynet, jobManager *job.Manager, ratingJobs func() []string) Rating {
+	return Rating{
+		ratingService:    ratingService,
+		volleynetService: volleynetService,
+		jobManager:       jobManager,
+		ratingJobs:       ratingJobs,
+	}
+}
+
+// Rating wraps the dependencies of the RatingHandler.
+type Rating struct {
+	ratingService    *services.Rating
+	volleynetService *services.Volleynet
+	jobManager       *job.Manager
+	ratingJobs       func() []string
+}
+
+// GetLeaderboard returns the players of a gender with the highest rating.
+// Default gender is "M" and default limit is 100.
+func (h *Rating) GetLeaderboard(c *gin.Context) {
+	gender := c.DefaultQuery("gender", "M")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	if err != nil || !h.volleynetService.ValidGender(gender) {
+		responseBadRequest(c)
+		return
+	}
+
+	players, err := h.ratingService.Leaderboard(c.Request.Context(), gender, limit)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, players)
+}
+
+// GetTimeline returns the rating history of a player.
+func (h *Rating) GetTimeline(c *gin.Context) {
+	playerID, err := strconv.Atoi(c.Param("playerID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	ratings, err := h.ratingService.Timeline(c.Request.Context(), playerID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, ratings)
+}
+
+// PostRecompute makes the ratings jobs delete all ratings and rate all
+// tournaments again, e.g. after results of already rated tournaments have
+// been corrected. The request is stored and the jobs are triggered
+// immediately, if a job is already running or this instance isn't running
+// it the recompute is done by the next run of any instance.
+func (h *Rating) PostRecompute(c *gin.Context) {
+	names := h.ratingJobs()
+
+	if len(names) == 0 {
+		writeResponse(c, http.StatusNotFound, nil, job.ErrJobNotFound.Error())
+		return
+	}
+
+	if err := h.ratingService.RequestRecompute(c.Request.Context()); err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	jobs := []job.Job{}
+
+	for _, name := range names {
+		err := h.jobManager.Run(name)
+
+		if errors.Is(err, job.ErrJobNotFound) {
+			continue
+		} else if err != nil && !errors.Is(err, job.ErrInvalidState) {
+			responseErr(c, err)
+			return
+		}
+
+		if j, ok := h.jobManager.Job(name); ok {
+			jobs = append(jobs, j)
+		}
+	}
+
+	response(c, http.StatusAccepted, jobs)
+}
@@ -0,0 +1,35 @@
+package route_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/raphi011/scores/test"
+)
+
+func TestGetLeaderboardInvalidLimit(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/ratings?gender=W&limit=0")
+
+	test.Equal(t, "/ratings?limit=0 expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
+
+func TestGetRatingTimelineOfUnknownPlayer(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/players/ratings/1")
+
+	test.Equal(t, "/players/ratings/1 expected status %d, got %d", http.StatusNotFound, w.Code)
+}
+
+func TestPostRecomputeRatings(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/admin/ratings/recompute", nil)
+
+	test.Equal(t, "/admin/ratings/recompute expected status %d, got %d", http.StatusAccepted, w.Code)
+}
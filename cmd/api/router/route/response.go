@@ -36,6 +36,8 @@ func extractErrorInformation(err error) (code int, message string) {
 		code = http.StatusNotFound
 	} else if cause == scores.ErrorUnauthorized {
 		code = http.StatusUnauthorized
+	} else if cause == scores.ErrorValidation {
+		code = http.StatusBadRequest
 	}
 
 	if code == http.StatusInternalServerError {
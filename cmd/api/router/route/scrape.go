@@ -1,22 +1,29 @@
 package route
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/raphi011/scores/job"
+	"github.com/raphi011/scores/services"
 )
 
 // ScrapeHandler is the constructor for the Scrape routes handler.
-func ScrapeHandler(jobManager *job.Manager) Scrape {
+func ScrapeHandler(jobManager *job.Manager, jobHistory *services.JobHistory, reload func() error) Scrape {
 	return Scrape{
 		jobManager: jobManager,
+		jobHistory: jobHistory,
+		reload:     reload,
 	}
 }
 
 // Scrape wraps the depdencies of the ScrapeHandler.
 type Scrape struct {
 	jobManager *job.Manager
+	jobHistory *services.JobHistory
+	reload     func() error
 }
 
 // GetReport handles the Report route that returns
@@ -27,42 +34,95 @@ func (h *Scrape) GetReport(c *gin.Context) {
 	response(c, http.StatusOK, execs)
 }
 
-// func (h *Scrape) run(c *gin.Context) {
-// 	jobName := c.Query("job")
+// GetPipelines returns the status of all groups of
+// jobs that depend on or trigger each other.
+func (h *Scrape) GetPipelines(c *gin.Context) {
+	response(c, http.StatusOK, h.jobManager.Pipelines())
+}
+
+// GetHistory returns a page of past executions, optionally
+// filtered by the `job` query parameter.
+func (h *Scrape) GetHistory(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pageSize", "50"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	executions, err := h.jobHistory.Executions(c.Request.Context(), c.Query("job"), page, pageSize)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
 
-// 	exists := h.jobManager.HasJob(jobName)
+	response(c, http.StatusOK, executions)
+}
 
-// 	if !exists {
-// 		response(c, http.StatusNotFound, nil)
-// 		return
-// 	}
+// PostReload reloads the job configuration, jobs that are
+// still defined keep running.
+func (h *Scrape) PostReload(c *gin.Context) {
+	if err := h.reload(); err != nil {
+		// invalid configurations are reported to the admin
+		writeResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
 
-// 	err := h.jobManager.Run(jobName)
+	response(c, http.StatusOK, h.jobManager.Jobs())
+}
 
-// 	if err != nil {
-// 		responseErr(c, err)
-// 		return
-// 	}
+// PostRun triggers an immediate run of a job.
+func (h *Scrape) PostRun(c *gin.Context) {
+	h.control(c, h.jobManager.Run)
+}
 
-// 	response(c, http.StatusOK, nil)
-// }
+// PostPause suspends the scheduled runs of a job.
+func (h *Scrape) PostPause(c *gin.Context) {
+	h.control(c, h.jobManager.Pause)
+}
 
-// func (h *Scrape) stop(c *gin.Context) {
-// 	jobName := c.Query("job")
+// PostResume resumes a paused job.
+func (h *Scrape) PostResume(c *gin.Context) {
+	h.control(c, h.jobManager.Resume)
+}
 
-// 	exists := h.jobManager.HasJob(jobName)
+// PostStop stops a job.
+func (h *Scrape) PostStop(c *gin.Context) {
+	h.control(c, h.jobManager.StopJob)
+}
 
-// 	if !exists {
-// 		response(c, http.StatusNotFound, nil)
-// 		return
-// 	}
+// PostReset resets an errored or stopped job and schedules it again.
+func (h *Scrape) PostReset(c *gin.Context) {
+	h.control(c, h.jobManager.Reset)
+}
 
-// 	err := h.jobManager.StopJob(jobName)
+// control applies the action to the job passed in the `job` query parameter
+// and returns the job's updated state.
+func (h *Scrape) control(c *gin.Context, action func(jobName string) error) {
+	jobName := c.Query("job")
+
+	err := action(jobName)
+
+	if errors.Is(err, job.ErrJobNotFound) {
+		writeResponse(c, http.StatusNotFound, nil, err.Error())
+		return
+	} else if errors.Is(err, job.ErrInvalidState) {
+		writeResponse(c, http.StatusConflict, nil, err.Error())
+		return
+	} else if err != nil {
+		responseErr(c, err)
+		return
+	}
 
-// 	if err != nil {
-// 		responseErr(c, err)
-// 		return
-// 	}
+	j, _ := h.jobManager.Job(jobName)
 
-// 	response(c, http.StatusOK, nil)
-// }
+	response(c, http.StatusOK, j)
+}
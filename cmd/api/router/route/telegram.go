@@ -0,0 +1,50 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+
+	"github.com/raphi011/scores/telegram"
+)
+
+// TelegramHandler is the constructor for the telegram routes handler.
+func TelegramHandler(bot *telegram.Bot) Telegram {
+	return Telegram{bot: bot}
+}
+
+// Telegram wraps the dependencies of the TelegramHandler.
+type Telegram struct {
+	bot *telegram.Bot
+}
+
+type linkCodeDto struct {
+	Code    string `json:"code"`
+	Command string `json:"command"`
+}
+
+// PostLinkCode creates a code that links a telegram chat to the
+// user's account when sent to the bot.
+func (h *Telegram) PostLinkCode(c *gin.Context) {
+	if h.bot == nil {
+		// no bot token has been configured
+		response(c, http.StatusNotImplemented, nil)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	code, err := h.bot.LinkCode(c.Request.Context(), userID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, linkCodeDto{
+		Code:    code,
+		Command: "/link " + code,
+	})
+}
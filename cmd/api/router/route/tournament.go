@@ -14,11 +14,16 @@ import (
 	"github.com/raphi011/scores/volleynet/client"
 )
 
-//TournamentHandler is the constructor for the tournament routes handler.
-func TournamentHandler(volleynetService *services.Volleynet, userService *services.User) Tournament {
+// TournamentHandler is the constructor for the tournament routes handler.
+func TournamentHandler(
+	volleynetService *services.Volleynet,
+	userService *services.User,
+	watchlistService *services.Watchlist) Tournament {
+
 	return Tournament{
 		volleynetService: volleynetService,
 		userService:      userService,
+		watchlistService: watchlistService,
 	}
 }
 
@@ -26,6 +31,7 @@ func TournamentHandler(volleynetService *services.Volleynet, userService *servic
 type Tournament struct {
 	volleynetService *services.Volleynet
 	userService      *services.User
+	watchlistService *services.Watchlist
 }
 
 // GetTournaments queries all available tournaments.
@@ -40,7 +46,7 @@ func (h *Tournament) GetTournaments(c *gin.Context) {
 		Genders: gender,
 	})
 
-	tournaments, err := h.volleynetService.SearchTournaments(filters)
+	tournaments, err := h.volleynetService.SearchTournaments(c.Request.Context(), filters)
 
 	if err != nil {
 		responseErr(c, err)
@@ -50,11 +56,22 @@ func (h *Tournament) GetTournaments(c *gin.Context) {
 	session := sessions.Default(c)
 
 	if userID, ok := session.Get("user-id").(int); ok {
-		err := h.userService.UpdateTournamentFilter(userID, filters)
+		err := h.userService.UpdateTournamentFilter(c.Request.Context(), userID, filters)
 
 		if err != nil {
 			logger.Get(c).Warnf("could not update user settings %v", err)
 		}
+
+		watched, err := h.watchlistService.WatchedIDs(c.Request.Context(), userID)
+
+		if err != nil {
+			responseErr(c, err)
+			return
+		}
+
+		for _, t := range tournaments {
+			t.Watched = watched[t.ID]
+		}
 	}
 
 	response(c, http.StatusOK, tournaments)
@@ -62,7 +79,7 @@ func (h *Tournament) GetTournaments(c *gin.Context) {
 
 // GetFilterOptions returns the possible tournament filter values.
 func (h *Tournament) GetFilterOptions(c *gin.Context) {
-	filters, err := h.volleynetService.TournamentFilterOptions()
+	filters, err := h.volleynetService.TournamentFilterOptions(c.Request.Context())
 
 	if err != nil {
 		responseErr(c, err)
@@ -81,13 +98,24 @@ func (h *Tournament) GetTournament(c *gin.Context) {
 		return
 	}
 
-	tournament, err := h.volleynetService.TournamentInfo(tournamentID)
+	tournament, err := h.volleynetService.TournamentInfo(c.Request.Context(), tournamentID)
 
 	if err != nil {
 		responseErr(c, err)
 		return
 	}
 
+	if userID, ok := sessions.Default(c).Get("user-id").(int); ok {
+		watched, err := h.watchlistService.WatchedIDs(c.Request.Context(), userID)
+
+		if err != nil {
+			responseErr(c, err)
+			return
+		}
+
+		tournament.Watched = watched[tournament.ID]
+	}
+
 	response(c, http.StatusOK, tournament)
 }
 
@@ -129,7 +157,7 @@ func (h *Tournament) PostSignup(c *gin.Context) {
 	if su.RememberMe {
 		session := sessions.Default(c)
 		userID := session.Get("user-id").(int)
-		user, err := h.userService.ByID(userID)
+		user, err := h.userService.ByID(c.Request.Context(), userID)
 
 		if err != nil {
 			logger.Get(c).Warnf("loading user by email: %s failed", userID)
@@ -138,7 +166,7 @@ func (h *Tournament) PostSignup(c *gin.Context) {
 		if user != nil && user.PlayerLogin != su.Username ||
 			user.PlayerID != loginData.ID {
 
-			err = h.userService.SetVolleynetLogin(userID, loginData.ID, su.Username)
+			err = h.userService.SetVolleynetLogin(c.Request.Context(), userID, loginData.ID, su.Username)
 
 			if err != nil {
 				logger.Get(c).Warnf("updating volleynet user information failed for userID: %d", user.ID)
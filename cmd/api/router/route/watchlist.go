@@ -0,0 +1,178 @@
+package route
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/raphi011/scores/services"
+)
+
+// WatchlistHandler is the constructor for the watchlist routes handler.
+func WatchlistHandler(watchlistService *services.Watchlist) Watchlist {
+	return Watchlist{
+		watchlistService: watchlistService,
+	}
+}
+
+// Watchlist wraps the dependencies of the WatchlistHandler.
+type Watchlist struct {
+	watchlistService *services.Watchlist
+}
+
+type watchForm struct {
+	TournamentID int `json:"tournamentId"`
+}
+
+type followForm struct {
+	PlayerID int `json:"playerId"`
+}
+
+// feedQuery are the query parameters of `GetFeed`.
+type feedQuery struct {
+	Limit int `form:"limit"`
+}
+
+// GetTournaments returns the watched tournaments of the user.
+func (h *Watchlist) GetTournaments(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	tournaments, err := h.watchlistService.Tournaments(c.Request.Context(), userID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, tournaments)
+}
+
+// PostTournament adds a tournament to the watchlist of the user.
+func (h *Watchlist) PostTournament(c *gin.Context) {
+	form := watchForm{}
+
+	if err := c.ShouldBindWith(&form, binding.JSON); err != nil || form.TournamentID <= 0 {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	watch, err := h.watchlistService.Watch(c.Request.Context(), userID, form.TournamentID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusCreated, watch)
+}
+
+// DeleteTournament removes a tournament from the watchlist of the user.
+func (h *Watchlist) DeleteTournament(c *gin.Context) {
+	tournamentID, err := strconv.Atoi(c.Param("tournamentID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	err = h.watchlistService.Unwatch(c.Request.Context(), userID, tournamentID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	responseNoContent(c)
+}
+
+// GetPlayers returns the followed players of the user.
+func (h *Watchlist) GetPlayers(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	follows, err := h.watchlistService.Players(c.Request.Context(), userID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, follows)
+}
+
+// PostPlayer follows a player.
+func (h *Watchlist) PostPlayer(c *gin.Context) {
+	form := followForm{}
+
+	if err := c.ShouldBindWith(&form, binding.JSON); err != nil || form.PlayerID <= 0 {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	follow, err := h.watchlistService.Follow(c.Request.Context(), userID, form.PlayerID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusCreated, follow)
+}
+
+// DeletePlayer unfollows a player.
+func (h *Watchlist) DeletePlayer(c *gin.Context) {
+	playerID, err := strconv.Atoi(c.Param("playerID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	err = h.watchlistService.Unfollow(c.Request.Context(), userID, playerID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	responseNoContent(c)
+}
+
+// GetFeed returns the latest activities of the watched tournaments
+// and the followed players of the user.
+func (h *Watchlist) GetFeed(c *gin.Context) {
+	query := feedQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(int)
+
+	activities, err := h.watchlistService.Feed(c.Request.Context(), userID, query.Limit)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, activities)
+}
@@ -0,0 +1,48 @@
+package route_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/raphi011/scores/test"
+)
+
+func TestPostWatchlistInvalidBody(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/watchlist", map[string]interface{}{})
+
+	test.Equal(t, "/watchlist expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
+
+func TestPostWatchlistUnknownTournament(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/watchlist", map[string]interface{}{"tournamentId": 1})
+
+	test.Equal(t, "/watchlist expected status %d, got %d", http.StatusNotFound, w.Code)
+}
+
+func TestPostFollowsUnknownPlayer(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/follows", map[string]interface{}{"playerId": 1})
+
+	test.Equal(t, "/follows expected status %d, got %d", http.StatusNotFound, w.Code)
+}
+
+func TestGetFeed(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/feed")
+
+	test.Equal(t, "/feed expected status %d, got %d", http.StatusOK, w.Code)
+
+	w = client.get("/feed?limit=1000")
+
+	test.Equal(t, "/feed?limit=1000 expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
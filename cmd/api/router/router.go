@@ -1,8 +1,14 @@
 package router
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
@@ -19,11 +25,15 @@ import (
 	"github.com/raphi011/scores/cmd/api/cron"
 	"github.com/raphi011/scores/cmd/api/middleware"
 	"github.com/raphi011/scores/cmd/api/router/route"
+	"github.com/raphi011/scores/email"
 	"github.com/raphi011/scores/events"
 	"github.com/raphi011/scores/job"
+	"github.com/raphi011/scores/notify"
 	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/memory"
 	"github.com/raphi011/scores/repo/sql"
 	"github.com/raphi011/scores/services"
+	"github.com/raphi011/scores/telegram"
 	"github.com/raphi011/scores/volleynet/client"
 	"github.com/raphi011/scores/volleynet/sync"
 )
@@ -37,6 +47,24 @@ type Router struct {
 	eventBroker *events.Broker
 	version     string
 	production  bool
+
+	host          string
+	secret        []byte
+	telegramToken string
+	smtp          *email.SMTPConfig
+
+	jobHistoryRetention time.Duration
+	jobConfig           string
+	shutdownTimeout     time.Duration
+
+	// reload reloads the job configuration
+	reload func() error
+
+	// ratingJobs holds the names of the configured ratings jobs ([]string)
+	ratingJobs atomic.Value
+
+	// shutdown is called in order when the server is stopped
+	shutdown []func(ctx context.Context) error
 }
 
 // Option is used to configure a new Router.
@@ -45,7 +73,8 @@ type Option func(*Router)
 // New creates a new router and configures it with `opts`.
 func New(opts ...Option) *Router {
 	router := &Router{
-		log: logrus.New(),
+		log:             logrus.New(),
+		shutdownTimeout: 30 * time.Second,
 	}
 
 	for _, o := range opts {
@@ -59,7 +88,85 @@ func New(opts ...Option) *Router {
 func (r *Router) Build() *gin.Engine {
 	var router *gin.Engine
 
-	s := servicesFromRepository(r.repository, true, r.log)
+	if r.secret == nil {
+		WithSecret("")(r)
+	}
+
+	s := servicesFromRepository(r.repository, r.eventBroker, r.log)
+	s.Signer = &services.Signer{Secret: r.secret}
+	s.Calendar.Signer = s.Signer
+	s.AutoSignup.Cipher = &services.Cipher{Secret: r.secret}
+	s.JobHistory.Retention = r.jobHistoryRetention
+	bot, mailer := r.startNotifications(s)
+
+	if r.eventBroker != nil {
+		// we never unsubscribe
+		s.Watchlist.Listen(r.eventBroker, func(event events.Event, err error) {
+			r.log.Warnf("recording the activities of event %q failed: %v", event.Name, err)
+		})
+
+		s.AutoSignup.Listen(r.eventBroker, func(event events.Event, err error) {
+			r.log.Warnf("signing up after event %q failed: %v", event.Name, err)
+		})
+	}
+
+	if bot != nil {
+		s.Jobs = append(s.Jobs, job.Job{
+			Name:     "Telegram bot",
+			Interval: 1 * time.Minute, // another instance takes over if the polling instance stops
+
+			Do: bot.Run,
+		})
+	}
+
+	if mailer != nil {
+		s.Jobs = append(s.Jobs, job.Job{
+			Name:     "Email digest",
+			Interval: 15 * time.Minute,
+			Delay:    15 * time.Minute,
+			Timeout:  5 * time.Minute,
+			Local:    true, // notifications are queued by the instance that synced
+
+			Do: mailer.Flush,
+		})
+	}
+
+	jobs, err := r.jobs(s)
+
+	if err != nil {
+		r.log.Fatalf("could not load jobs: %v", err)
+	}
+
+	err = s.JobManager.Start(jobs...)
+
+	if err != nil {
+		r.log.Fatalf("could not start jobs: %v", err)
+	}
+
+	r.reload = func() error {
+		jobs, err := r.jobs(s)
+
+		if err != nil {
+			return err
+		}
+
+		return s.JobManager.Reload(jobs...)
+	}
+
+	if bot != nil {
+		// the bot polls until it's stopped, stop it before waiting for the jobs
+		r.shutdown = append(r.shutdown, func(context.Context) error {
+			bot.Stop()
+			return nil
+		})
+	}
+
+	r.shutdown = append(r.shutdown, s.JobManager.Shutdown)
+
+	if mailer != nil {
+		// send the digests that are still pending
+		r.shutdown = append(r.shutdown, mailer.Flush)
+	}
 
 	router = gin.New()
 	router.Use(gin.Recovery())
@@ -77,12 +184,23 @@ func (r *Router) Build() *gin.Engine {
 	)
 
 	playerHandler := route.PlayerHandler(s.Volleynet, s.User)
-	tournamentHandler := route.TournamentHandler(s.Volleynet, s.User)
-	scrapeHandler := route.ScrapeHandler(s.JobManager)
+	tournamentHandler := route.TournamentHandler(s.Volleynet, s.User, s.Watchlist)
+	scrapeHandler := route.ScrapeHandler(s.JobManager, s.JobHistory, r.reload)
 	infoHandler := route.InfoHandler(r.version)
 	adminHandler := route.AdminHandler(s.User)
 	debugHandler := route.DebugHandler(s.User)
 	cspHandler := route.CspHandler()
+	telegramHandler := route.TelegramHandler(bot)
+	notificationHandler := route.NotificationHandler(s.User, s.Signer)
+	alertHandler := route.AlertHandler(s.Alert)
+	ratingHandler := route.RatingHandler(s.Rating, s.Volleynet, s.JobManager, func() []string {
+		return r.ratingJobs.Load().([]string)
+	})
+	partnerBoardHandler := route.PartnerBoardHandler(s.PartnerBoard)
+	ladderHandler := route.LadderHandler(s.Ladder, s.Volleynet)
+	calendarHandler := route.CalendarHandler(s.Calendar, r.host)
+	watchlistHandler := route.WatchlistHandler(s.Watchlist)
+	autoSignupHandler := route.AutoSignupHandler(s.AutoSignup)
 
 	// Generate keys on startup for HMAC signing + encryption.
 	// This means that on every restart previously authenticated
@@ -106,6 +224,8 @@ func (r *Router) Build() *gin.Engine {
 	router.GET("/user-or-login", authHandler.GetLoginRouteOrUser)
 	router.GET("/auth", authHandler.GetGoogleAuthenticate)
 	router.POST("/pw-auth", authHandler.PostPasswordAuthenticate)
+	router.GET("/notifications/email/unsubscribe", notificationHandler.GetUnsubscribeEmail)
+	router.GET("/calendar/feed.ics", calendarHandler.GetFeed)
 
 	auth := router.Group("/")
 	auth.Use(middleware.Auth())
@@ -118,15 +238,55 @@ func (r *Router) Build() *gin.Engine {
 	auth.POST("/signup", tournamentHandler.PostSignup)
 
 	auth.GET("/ladder", playerHandler.GetLadder)
+	auth.GET("/ladder/what-if", ladderHandler.GetWhatIf)
+	auth.GET("/ratings", ratingHandler.GetLeaderboard)
 	auth.GET("/players/search", playerHandler.GetSearchPlayers)
 	auth.GET("/players/partners/:playerID", playerHandler.GetPartners)
+	auth.GET("/players/recommendations/:playerID", playerHandler.GetRecommendedPartners)
+	auth.GET("/players/history/:playerID", playerHandler.GetHistory)
+	auth.GET("/players/stats/:playerID", playerHandler.GetSeasonStats)
+	auth.GET("/players/ratings/:playerID", ratingHandler.GetTimeline)
 	auth.POST("/players/login", playerHandler.PostLogin)
 
+	auth.POST("/telegram/link", telegramHandler.PostLinkCode)
+	auth.POST("/notifications/email", notificationHandler.PostEmailNotifications)
+	auth.GET("/calendar/link", calendarHandler.GetLink)
+	auth.POST("/calendar/link", calendarHandler.PostLink)
+
+	auth.GET("/alerts", alertHandler.GetAlertRules)
+	auth.POST("/alerts", alertHandler.PostAlertRule)
+	auth.PUT("/alerts/:ruleID", alertHandler.PutAlertRule)
+	auth.DELETE("/alerts/:ruleID", alertHandler.DeleteAlertRule)
+
+	auth.GET("/partner-listings", partnerBoardHandler.GetListings)
+	auth.POST("/partner-listings", partnerBoardHandler.PostListing)
+	auth.DELETE("/partner-listings/:listingID", partnerBoardHandler.DeleteListing)
+	auth.POST("/partner-listings/:listingID/requests", partnerBoardHandler.PostRequest)
+	auth.GET("/partner-requests", partnerBoardHandler.GetRequests)
+	auth.POST("/partner-requests/:requestID/accept", partnerBoardHandler.PostAcceptRequest)
+	auth.POST("/partner-requests/:requestID/decline", partnerBoardHandler.PostDeclineRequest)
+	auth.DELETE("/partner-requests/:requestID", partnerBoardHandler.DeleteRequest)
+	auth.GET("/partner-requests/:requestID/signup", partnerBoardHandler.GetSignup)
+
+	auth.GET("/watchlist", watchlistHandler.GetTournaments)
+	auth.POST("/watchlist", watchlistHandler.PostTournament)
+	auth.DELETE("/watchlist/:tournamentID", watchlistHandler.DeleteTournament)
+	auth.GET("/follows", watchlistHandler.GetPlayers)
+	auth.POST("/follows", watchlistHandler.PostPlayer)
+	auth.DELETE("/follows/:playerID", watchlistHandler.DeletePlayer)
+	auth.GET("/feed", watchlistHandler.GetFeed)
+
+	auth.GET("/signups", autoSignupHandler.GetSignups)
+	auth.POST("/signups", autoSignupHandler.PostSignup)
+	auth.DELETE("/signups/:signupID", autoSignupHandler.DeleteSignup)
+
 	admin := auth.Group("/admin")
 	admin.Use(middleware.Admin(s.User))
 
 	admin.GET("/users", adminHandler.GetUsers)
 	admin.POST("/users", adminHandler.PostUser)
+	admin.POST("/ratings/recompute", ratingHandler.PostRecompute)
+	admin.GET("/ladder/validation", ladderHandler.GetValidation)
 
 	if !r.production {
 		debug := router.Group("/debug")
@@ -138,18 +298,79 @@ func (r *Router) Build() *gin.Engine {
 	volleynetAdmin := admin.Group("/volleynet")
 
 	volleynetAdmin.GET("/scrape/report", scrapeHandler.GetReport)
+	volleynetAdmin.GET("/scrape/history", scrapeHandler.GetHistory)
+	volleynetAdmin.GET("/scrape/pipelines", scrapeHandler.GetPipelines)
+	volleynetAdmin.POST("/scrape/run", scrapeHandler.PostRun)
+	volleynetAdmin.POST("/scrape/pause", scrapeHandler.PostPause)
+	volleynetAdmin.POST("/scrape/resume", scrapeHandler.PostResume)
+	volleynetAdmin.POST("/scrape/stop", scrapeHandler.PostStop)
+	volleynetAdmin.POST("/scrape/reset", scrapeHandler.PostReset)
+	volleynetAdmin.POST("/scrape/reload", scrapeHandler.PostReload)
 
 	return router
 }
 
-// Run builds the router and opens the port.
+// Run builds the router and opens the port (`PORT` env variable or 8080),
+// on SIGINT / SIGTERM the server and all jobs are shut down gracefully and
+// on SIGHUP the job configuration is reloaded.
 func (r *Router) Run() {
-	router := r.Build()
+	addr := ":8080"
 
-	err := router.Run()
+	if port := os.Getenv("PORT"); port != "" {
+		addr = ":" + port
+	}
 
-	if err != nil {
-		r.log.Errorf("could not start router: %+v", err)
+	server := &http.Server{
+		Addr:    addr,
+		Handler: r.Build(),
+	}
+
+	go func() {
+		err := server.ListenAndServe()
+
+		if err != nil && err != http.ErrServerClosed {
+			r.log.Fatalf("could not start router: %+v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	for running := true; running; {
+		select {
+		case <-hangup:
+			if err := r.reload(); err != nil {
+				r.log.Warnf("could not reload jobs: %v", err)
+			}
+		case <-quit:
+			running = false
+		}
+	}
+
+	r.log.Info("shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		r.log.Warnf("could not shut down the server gracefully: %v", err)
+	}
+
+	for _, shutdown := range r.shutdown {
+		if err := shutdown(ctx); err != nil {
+			r.log.Warnf("shutdown failed: %v", err)
+		}
+	}
+}
+
+// WithShutdownTimeout sets how long the server waits for requests and
+// jobs to finish on shutdown.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(r *Router) {
+		r.shutdownTimeout = timeout
 	}
 }
 
@@ -167,8 +388,10 @@ func WithVersion(version string) Option {
 	}
 }
 
-// WithRepository sets the repository provider and connectionstring.
-func WithRepository(provider, connectionString string) Option {
+// WithRepository sets the repository provider and connectionstring, the
+// connectionstring is ignored by the `memory` provider. If `autoMigrate`
+// is set the sql providers migrate the schema to the latest version.
+func WithRepository(provider, connectionString string, autoMigrate bool) Option {
 	return func(r *Router) {
 		var err error
 
@@ -178,7 +401,10 @@ func WithRepository(provider, connectionString string) Option {
 		case "postgres":
 			fallthrough
 		case "mysql":
-			r.repository, err = sql.Repositories(provider, connectionString)
+			r.repository, err = sql.Repositories(provider, connectionString, autoMigrate)
+		case "memory":
+			r.log.Warn("using the memory repository, all data is lost on shutdown")
+			r.repository = memory.Repositories()
 		default:
 			err = fmt.Errorf("invalid repo provider %q", provider)
 		}
@@ -189,10 +415,96 @@ func WithRepository(provider, connectionString string) Option {
 	}
 }
 
+// WithTelegram enables the telegram bot if a bot token is passed.
+func WithTelegram(token string) Option {
+	return func(r *Router) {
+		r.telegramToken = token
+	}
+}
+
+// WithJobHistory sets how long job executions are kept in the history.
+func WithJobHistory(retention time.Duration) Option {
+	return func(r *Router) {
+		r.jobHistoryRetention = retention
+	}
+}
+
+// WithJobConfig sets the path of the job configuration file,
+// the default jobs are used if it's empty.
+func WithJobConfig(path string) Option {
+	return func(r *Router) {
+		r.jobConfig = path
+	}
+}
+
+// jobs returns the configured jobs and the jobs of the enabled
+// features (e.g. the email digest).
+func (r *Router) jobs(s *handlerServices) ([]job.Job, error) {
+	config := cron.DefaultConfig()
+
+	if r.jobConfig != "" {
+		var err error
+		config, err = cron.LoadConfig(r.jobConfig)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jobs, err := config.Build(cron.Services{
+		SyncService:     s.Scrape,
+		HistoryCleanup:  s.JobHistory.Cleanup,
+		ActivityCleanup: s.Watchlist.Cleanup,
+		Signups:         s.AutoSignup.Run,
+		RatingService:   s.Rating,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.ratingJobs.Store(config.Names(cron.TypeRatings))
+
+	return append(jobs, s.Jobs...), nil
+}
+
+// WithSMTP enables email notifications if an SMTP server address is passed.
+func WithSMTP(addr, username, password, from string) Option {
+	return func(r *Router) {
+		if addr == "" {
+			return
+		}
+
+		r.smtp = &email.SMTPConfig{
+			Addr:     addr,
+			Username: username,
+			Password: password,
+			From:     from,
+		}
+	}
+}
+
+// WithSecret sets the secret that signs tokens and encrypts credentials which
+// must stay valid across restarts (e.g. unsubscribe links and the credentials
+// of scheduled signups), if empty a random secret is used.
+func WithSecret(secret string) Option {
+	return func(r *Router) {
+		if secret == "" {
+			r.log.Warn("no secret set, tokens and scheduled signups will be invalid after a restart")
+			r.secret = securecookie.GenerateRandomKey(32)
+			return
+		}
+
+		r.secret = []byte(secret)
+	}
+}
+
 // WithOAuth sets the oauth configuration.
 func WithOAuth(configPath, host string) Option {
 	return func(r *Router) {
 		var err error
+
+		r.host = host
 		r.conf, err = auth.GoogleOAuthConfig(configPath, host)
 
 		if err != nil {
@@ -267,20 +579,88 @@ func WithEventQueue() Option {
 			}
 		}()
 
+		jobEvents, _ := r.eventBroker.Subscribe("job/*")
+
+		go func() {
+			for event := range jobEvents {
+				r.log.Warnf("job event: %v", event)
+			}
+		}()
+
 		// return broker
 		return
 	}
 }
 
+// startNotifications creates the telegram bot and the mailer (if configured)
+// and dispatches notifications of tournament events to all enabled channels.
+func (r *Router) startNotifications(s *handlerServices) (*telegram.Bot, *email.Mailer) {
+	var bot *telegram.Bot
+	var mailer *email.Mailer
+	channels := []notify.Channel{}
+
+	if r.telegramToken != "" {
+		bot = &telegram.Bot{
+			Log:              r.log,
+			Client:           telegram.DefaultClient(r.telegramToken),
+			UserService:      s.User,
+			VolleynetService: s.Volleynet,
+			OffsetRepo:       r.repository.PollOffsetRepo,
+		}
+
+		channels = append(channels, bot)
+	}
+
+	if r.smtp != nil {
+		mailer = &email.Mailer{
+			Log:     r.log,
+			SMTP:    *r.smtp,
+			LogRepo: r.repository.NotificationLogRepo,
+			Signer:  s.Signer,
+			BaseURL: r.host,
+		}
+
+		channels = append(channels, mailer)
+	}
+
+	if r.eventBroker != nil && len(channels) > 0 {
+		dispatcher := &notify.Dispatcher{
+			Log:          r.log,
+			UserService:  s.User,
+			AlertService: s.Alert,
+			Channels:     channels,
+		}
+
+		// we never unsubscribe
+		dispatcher.Listen(r.eventBroker)
+	}
+
+	return bot, mailer
+}
+
 type handlerServices struct {
-	JobManager *job.Manager
-	User       *services.User
-	Volleynet  *services.Volleynet
-	Scrape     *sync.Service
-	Password   services.Password
+	JobManager   *job.Manager
+	Jobs         []job.Job
+	User         *services.User
+	Volleynet    *services.Volleynet
+	Scrape       *sync.Service
+	Password     services.Password
+	Signer       *services.Signer
+	Alert        *services.Alert
+	JobHistory   *services.JobHistory
+	Rating       *services.Rating
+	PartnerBoard *services.PartnerBoard
+	Ladder       *services.Ladder
+	Calendar     *services.Calendar
+	Watchlist    *services.Watchlist
+	AutoSignup   *services.AutoSignup
 }
 
-func servicesFromRepository(repos *repo.Repositories, startManager bool, log logrus.FieldLogger) *handlerServices {
+func servicesFromRepository(
+	repos *repo.Repositories,
+	broker *events.Broker,
+	log logrus.FieldLogger) *handlerServices {
+
 	password := &services.PBKDF2Password{
 		SaltBytes:  16,
 		Iterations: 10000,
@@ -307,50 +687,23 @@ func servicesFromRepository(repos *repo.Repositories, startManager bool, log log
 		TournamentRepo: repos.TournamentRepo,
 
 		Client: client.WithLogger(log),
-		// Subscriptions: r.eventBroker,
-	}
-
-	manager := job.NewManager(log)
-
-	ladderJob := cron.LadderJob{
-		SyncService: scrapeService,
-		Genders:     []string{"M", "W"},
 	}
 
-	tournamentsJob := cron.TournamentsJob{
-		SyncService: scrapeService,
-		Genders:     []string{"M", "W"},
-		Leagues:     []string{"AMATEUR TOUR", "PRO TOUR", "JUNIOR TOUR"},
-		Season:      time.Now().Year(),
+	if broker != nil {
+		scrapeService.Subscriptions = broker
 	}
 
-	lastYearsTournamentsJob := tournamentsJob
-	lastYearsTournamentsJob.Season = lastYearsTournamentsJob.Season - 1
-
-	if startManager {
-		manager.Start(
-			job.Job{
-				Name:        "Players",
-				MaxFailures: 3,
-				Interval:    1 * time.Hour,
-
-				Do: ladderJob.Do,
-			},
-			job.Job{
-				Name:    "Last years tournaments",
-				MaxRuns: 1, // only run once on startup
+	jobHistory := &services.JobHistory{Repo: repos.JobExecutionRepo}
 
-				Do: tournamentsJob.Do,
-			},
-			job.Job{
-				Name:        "Tournaments",
-				MaxFailures: 3,
-				Interval:    5 * time.Minute,
-				Delay:       1 * time.Minute,
+	manager := job.NewManager(log)
+	manager.History = jobHistory
+	manager.Locker = &services.JobLock{
+		Repo:  repos.JobLeaseRepo,
+		Owner: services.InstanceID(),
+	}
 
-				Do: tournamentsJob.Do,
-			},
-		)
+	if broker != nil {
+		manager.Events = broker
 	}
 
 	s := &handlerServices{
@@ -359,6 +712,50 @@ func servicesFromRepository(repos *repo.Repositories, startManager bool, log log
 		Volleynet:  volleynetService,
 		Password:   password,
 		User:       userService,
+		Alert:      &services.Alert{Repo: repos.AlertRuleRepo},
+		JobHistory: jobHistory,
+		Rating: &services.Rating{
+			Repo:       repos.RatingRepo,
+			PlayerRepo: repos.PlayerRepo,
+		},
+		PartnerBoard: &services.PartnerBoard{
+			Repo:           repos.PartnerRepo,
+			UserRepo:       repos.UserRepo,
+			PlayerRepo:     repos.PlayerRepo,
+			TournamentRepo: repos.TournamentRepo,
+			TeamRepo:       repos.TeamRepo,
+		},
+		Ladder: &services.Ladder{
+			RatingRepo:     repos.RatingRepo,
+			PlayerRepo:     repos.PlayerRepo,
+			TournamentRepo: repos.TournamentRepo,
+		},
+		Calendar: &services.Calendar{
+			User:           userService,
+			TournamentRepo: repos.TournamentRepo,
+			TeamRepo:       repos.TeamRepo,
+			PlayerRepo:     repos.PlayerRepo,
+			WatchRepo:      repos.WatchRepo,
+		},
+		Watchlist: &services.Watchlist{
+			Repo:           repos.WatchRepo,
+			ActivityRepo:   repos.ActivityRepo,
+			PlayerRepo:     repos.PlayerRepo,
+			TournamentRepo: repos.TournamentRepo,
+		},
+		AutoSignup: &services.AutoSignup{
+			Repo:           repos.SignupRepo,
+			TournamentRepo: repos.TournamentRepo,
+			TeamRepo:       repos.TeamRepo,
+			PlayerRepo:     repos.PlayerRepo,
+			NewClient: func() client.Client {
+				return client.WithLogger(log)
+			},
+		},
+	}
+
+	if broker != nil {
+		s.AutoSignup.Events = broker
 	}
 
 	return s
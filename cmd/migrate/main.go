@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores/repo/sql/migrate"
+)
+
+const usage = `Usage: migrate [flags] <command>
+
+Commands:
+  up         apply all pending migrations
+  down N     revert the last N migrations
+  goto V     migrate up or down to version V
+  force V    set the version to V (-1 if no migration has been applied) without
+             running any migrations and clear the dirty flag after a failed migration
+  status     print the current version and the pending migrations
+
+Flags:
+`
+
+func main() {
+	dbProvider := flag.String("provider", "sqlite3", "DB Driver (sqlite3, mysql or postgres)")
+	connectionString := flag.String("connection", "./scores.db", "provider specific connectionstring")
+	lockTimeout := flag.Duration("locktimeout", 1*time.Minute, "how long to wait if another instance is migrating")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	args := flag.Args()
+
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	db, err := sqlx.Open(*dbProvider, *connectionString)
+
+	if err != nil {
+		log.Fatalf("could not open db: %v", err)
+	}
+
+	migrator, err := migrate.New(*dbProvider, db)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	migrator.LockTimeout = *lockTimeout
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	go func() {
+		// stops waiting for the lock, a running migration is not interrupted
+		<-interrupt
+		cancel()
+	}()
+
+	if err := run(ctx, migrator, args[0], args[1:]); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := printStatus(migrator); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, migrator *migrate.Migrator, command string, args []string) error {
+	switch command {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		n, err := intArg(args)
+
+		if err != nil {
+			return err
+		}
+
+		return migrator.Down(ctx, n)
+	case "goto":
+		version, err := intArg(args)
+
+		if err != nil {
+			return err
+		}
+
+		if version < 1 {
+			return fmt.Errorf("invalid version %d, use `down` to revert all migrations", version)
+		}
+
+		return migrator.Goto(ctx, uint(version))
+	case "force":
+		version, err := intArg(args)
+
+		if err != nil {
+			return err
+		}
+
+		return migrator.Force(ctx, version)
+	case "status":
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q, see -help", command)
+	}
+}
+
+func intArg(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("expected one argument, got %d", len(args))
+	}
+
+	value, err := strconv.Atoi(args[0])
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid argument %q, a number is required", args[0])
+	}
+
+	return value, nil
+}
+
+func printStatus(migrator *migrate.Migrator) error {
+	status, err := migrator.Status()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("version: %d (latest: %d)\n", status.Version, status.Latest)
+
+	if status.Dirty {
+		fmt.Println("dirty: the last migration has failed, fix the schema and run `migrate force <version>`")
+	}
+
+	if len(status.Pending) > 0 {
+		pending := make([]string, len(status.Pending))
+
+		for i, v := range status.Pending {
+			pending[i] = strconv.FormatUint(uint64(v), 10)
+		}
+
+		fmt.Printf("pending: %s\n", strings.Join(pending, ", "))
+	}
+
+	return nil
+}
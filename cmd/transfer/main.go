@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores/repo/sql"
+	"github.com/raphi011/scores/repo/transfer"
+)
+
+const usage = `Usage: transfer [flags] <command> <file>
+
+Commands:
+  export FILE  write all data to FILE (- for stdout)
+  import FILE  read all data from FILE (- for stdin), the db must be empty
+
+E.g. to move from sqlite3 to postgres:
+  transfer -provider sqlite3 -connection ./scores.db export scores.jsonl
+  transfer -provider postgres -connection <connectionstring> import scores.jsonl
+
+Flags:
+`
+
+func main() {
+	dbProvider := flag.String("provider", "sqlite3", "DB Driver (sqlite3, mysql or postgres)")
+	connectionString := flag.String("connection", "./scores.db", "provider specific connectionstring")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	args := flag.Args()
+
+	if len(args) != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	go func() {
+		<-interrupt
+		cancel()
+	}()
+
+	var counts transfer.Counts
+	var err error
+
+	switch command, file := args[0], args[1]; command {
+	case "export":
+		counts, err = export(ctx, *dbProvider, *connectionString, file)
+	case "import":
+		counts, err = load(ctx, *dbProvider, *connectionString, file)
+	default:
+		err = fmt.Errorf("unknown command %q, see -help", command)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the counts are printed to stderr so they don't end up in an export to stdout
+	fmt.Fprintf(os.Stderr, "%sed %d entities (%s)\n", args[0], counts.Total(), counts)
+}
+
+// export exports the db to `file`, the schema must be up to date.
+func export(ctx context.Context, provider, connectionString, file string) (transfer.Counts, error) {
+	repos, err := sql.Repositories(provider, connectionString, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var w io.Writer = os.Stdout
+
+	if file != "-" {
+		f, err := os.Create(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	return transfer.Export(ctx, repos, w)
+}
+
+// load imports `file` into the db, the schema is migrated first.
+func load(ctx context.Context, provider, connectionString, file string) (transfer.Counts, error) {
+	repos, err := sql.Repositories(provider, connectionString, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader = os.Stdin
+
+	if file != "-" {
+		f, err := os.Open(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer f.Close()
+
+		r = f
+	}
+
+	return transfer.Import(ctx, repos, r)
+}
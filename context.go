@@ -0,0 +1,21 @@
+package scores
+
+import "context"
+
+type contextKey string
+
+const requestIDKey contextKey = "request-id"
+
+// WithRequestID returns a copy of `ctx` that carries the id of the request
+// it belongs to, e.g. to correlate queries with requests in the logs.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the id of the request `ctx` belongs to or
+// an empty string if it's not part of a request.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+
+	return requestID
+}
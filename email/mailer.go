@@ -0,0 +1,257 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/notify"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/services"
+	"github.com/raphi011/scores/volleynet"
+)
+
+const (
+	// EnabledSettingKey is the user setting that enables email notifications
+	// if it's set to `Enabled`.
+	EnabledSettingKey = "email-notifications"
+	// LanguageSettingKey is the user setting that stores the preferred language.
+	LanguageSettingKey = "language"
+	// Enabled is the value of `EnabledSettingKey` if notifications are enabled.
+	Enabled = "enabled"
+	// Disabled is the value of `EnabledSettingKey` if notifications are disabled.
+	Disabled = "disabled"
+	// UnsubscribePurpose is the purpose of the token that allows unsubscribing.
+	UnsubscribePurpose = "email-unsubscribe"
+)
+
+var _ notify.Channel = &Mailer{}
+
+// SMTPConfig contains the connection details of the SMTP server.
+type SMTPConfig struct {
+	Addr     string // host:port
+	Username string // no authentication if empty
+	Password string
+	From     string
+}
+
+// Mailer collects notifications and sends them as a digest per user via
+// SMTP when `Flush` is called. Pending notifications are kept in memory
+// and are lost on restart.
+type Mailer struct {
+	Log     logrus.FieldLogger
+	SMTP    SMTPConfig
+	LogRepo repo.NotificationLogRepository
+	Signer  *services.Signer
+
+	// BaseURL is the url of the api, used to create unsubscribe links.
+	BaseURL string
+
+	lock    sync.Mutex
+	pending map[int]*digest
+}
+
+type digest struct {
+	user          *scores.User
+	notifications []*notify.Notification
+}
+
+// Name returns the name of the notification channel.
+func (m *Mailer) Name() string {
+	return "email"
+}
+
+// Notify queues the notification for the next digest if the
+// user has enabled email notifications.
+func (m *Mailer) Notify(user *scores.User, notification *notify.Notification) error {
+	if enabled, _ := user.Settings[EnabledSettingKey].(string); enabled != Enabled || user.Email == "" {
+		return nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.pending == nil {
+		m.pending = make(map[int]*digest)
+	}
+
+	d, ok := m.pending[user.ID]
+
+	if !ok {
+		d = &digest{}
+		m.pending[user.ID] = d
+	}
+
+	d.user = user
+	d.notifications = append(d.notifications, notification)
+
+	return nil
+}
+
+// Flush sends all pending notifications, one email per user. Failed emails
+// are recorded in the notification log and not retried. If `ctx` is done
+// the digests that have not been sent yet are kept for the next flush.
+func (m *Mailer) Flush(ctx context.Context) error {
+	m.lock.Lock()
+	pending := m.pending
+	m.pending = nil
+	m.lock.Unlock()
+
+	failed := 0
+
+	for userID, d := range pending {
+		if ctx.Err() != nil {
+			m.requeue(pending)
+			return ctx.Err()
+		}
+
+		delete(pending, userID)
+		err := m.send(ctx, d)
+
+		if err != nil {
+			m.Log.Warnf("sending email to user %d failed: %v", d.user.ID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d emails could not be sent", failed)
+	}
+
+	return nil
+}
+
+// requeue adds digests that could not be sent in front of the
+// notifications that have been queued in the meantime.
+func (m *Mailer) requeue(digests map[int]*digest) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.pending == nil {
+		m.pending = make(map[int]*digest)
+	}
+
+	for userID, d := range digests {
+		if queued, ok := m.pending[userID]; ok {
+			d.notifications = append(d.notifications, queued.notifications...)
+			d.user = queued.user
+		}
+
+		m.pending[userID] = d
+	}
+}
+
+func (m *Mailer) send(ctx context.Context, d *digest) error {
+	body, subject, err := m.render(d)
+
+	if err != nil {
+		return err
+	}
+
+	err = m.sendMail(d.user.Email, subject, body)
+
+	entry := &scores.NotificationLog{
+		UserID:        d.user.ID,
+		Channel:       m.Name(),
+		Recipient:     d.user.Email,
+		Subject:       subject,
+		Notifications: len(d.notifications),
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if _, logErr := m.LogRepo.New(ctx, entry); logErr != nil {
+		m.Log.Warnf("persisting notification log failed: %v", logErr)
+	}
+
+	return err
+}
+
+func (m *Mailer) render(d *digest) (body, subject string, err error) {
+	language, _ := d.user.Settings[LanguageSettingKey].(string)
+
+	if language == "" {
+		language = defaultLanguage
+	}
+
+	data := &digestData{
+		User:           d.user,
+		UnsubscribeURL: m.UnsubscribeURL(d.user.ID),
+	}
+
+	for _, n := range d.notifications {
+		item, itemSubject, err := renderNotification(language, &notificationData{
+			Notification: n,
+			UserTeam:     userTeam(d.user, n),
+		})
+
+		if err != nil {
+			return "", "", err
+		}
+
+		data.Items = append(data.Items, item)
+		data.Subjects = append(data.Subjects, itemSubject)
+	}
+
+	return renderDigest(language, data)
+}
+
+// UnsubscribeURL returns the link that disables email notifications of a user.
+func (m *Mailer) UnsubscribeURL(userID int) string {
+	query := url.Values{}
+	query.Add("user", strconv.Itoa(userID))
+	query.Add("token", m.Signer.Token(UnsubscribePurpose, userID))
+
+	return m.BaseURL + "/api/notifications/email/unsubscribe?" + query.Encode()
+}
+
+func (m *Mailer) sendMail(to, subject, body string) error {
+	var auth smtp.Auth
+
+	if m.SMTP.Username != "" {
+		host, _, _ := net.SplitHostPort(m.SMTP.Addr)
+		auth = smtp.PlainAuth("", m.SMTP.Username, m.SMTP.Password, host)
+	}
+
+	msg := &bytes.Buffer{}
+
+	fmt.Fprintf(msg, "From: %s\r\n", m.SMTP.From)
+	fmt.Fprintf(msg, "To: %s\r\n", to)
+	fmt.Fprintf(msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(msg, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(msg, "\r\n%s", body)
+
+	err := smtp.SendMail(m.SMTP.Addr, auth, m.SMTP.From, []string{to}, msg.Bytes())
+
+	return errors.Wrapf(err, "sending mail to %s", to)
+}
+
+// userTeam returns the team of the notification's tournament the user played in.
+func userTeam(user *scores.User, n *notify.Notification) *volleynet.TournamentTeam {
+	if user.PlayerID == 0 || n.Tournament == nil {
+		return nil
+	}
+
+	for _, team := range n.Tournament.Teams {
+		if team.HasPlayer(user.PlayerID) {
+			return team
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package email
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/notify"
+	"github.com/raphi011/scores/repo/sql"
+	"github.com/raphi011/scores/services"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+// smtpStandIn is a minimal in-process SMTP server that records all
+// received messages.
+type smtpStandIn struct {
+	listener net.Listener
+	messages chan string
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	test.Check(t, "listening failed: %v", err)
+
+	s := &smtpStandIn{listener: listener, messages: make(chan string, 10)}
+
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *smtpStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+
+	c := textproto.NewConn(conn)
+	c.PrintfLine("220 localhost ESMTP")
+
+	for {
+		line, err := c.ReadLine()
+
+		if err != nil {
+			return
+		}
+
+		switch cmd := strings.ToUpper(strings.Fields(line + " ")[0]); cmd {
+		case "EHLO", "HELO":
+			c.PrintfLine("250 localhost")
+		case "DATA":
+			c.PrintfLine("354 go ahead")
+			data, _ := c.ReadDotLines()
+			s.messages <- strings.Join(data, "\n")
+			c.PrintfLine("250 ok")
+		case "QUIT":
+			c.PrintfLine("221 bye")
+			return
+		default:
+			c.PrintfLine("250 ok")
+		}
+	}
+}
+
+func (s *smtpStandIn) message(t *testing.T) string {
+	select {
+	case msg := <-s.messages:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+		return ""
+	}
+}
+
+func TestFlushDigest(t *testing.T) {
+	repos, db := sql.RepositoriesTest(t)
+	server := newSMTPStandIn(t)
+	users := sql.CreateUsers(t, db, sql.U{})
+
+	mailer := &Mailer{
+		Log:     logrus.New(),
+		SMTP:    SMTPConfig{Addr: server.listener.Addr().String(), From: "noreply@scores.network"},
+		LogRepo: repos.NotificationLogRepo,
+		Signer:  &services.Signer{Secret: []byte("secret")},
+		BaseURL: "https://scores.network",
+	}
+
+	user := &scores.User{
+		M:        scores.M{ID: users[0].ID},
+		Email:    "player@test.at",
+		Settings: scores.Settings{EnabledSettingKey: Enabled, LanguageSettingKey: "de"},
+	}
+
+	tournament := &volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{
+		Name:   "Wien Open",
+		League: "AMATEUR TOUR",
+		Start:  time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+	}}
+
+	mailer.Notify(user, &notify.Notification{Type: sync.RegistrationOpenEventType, Tournament: tournament})
+	mailer.Notify(user, &notify.Notification{Type: sync.ResultsEventType, Tournament: tournament})
+	mailer.Notify(&scores.User{Email: "disabled@test.at"}, &notify.Notification{Type: sync.ResultsEventType, Tournament: tournament})
+
+	err := mailer.Flush(context.Background())
+	test.Check(t, "mailer.Flush(context.Background()) failed: %v", err)
+
+	msg := server.message(t)
+
+	test.Assert(t, "expected the digest subject, got:\n%s", strings.Contains(msg, "2 neue Benachrichtigungen"), msg)
+	test.Assert(t, "expected the registration notification, got:\n%s", strings.Contains(msg, "Die Anmeldung für Wien Open"), msg)
+	test.Assert(t, "expected the unsubscribe link, got:\n%s", strings.Contains(msg, mailer.UnsubscribeURL(user.ID)), msg)
+
+	logs, err := repos.NotificationLogRepo.ByUserID(context.Background(), user.ID)
+	test.Check(t, "NotificationLogRepo.ByUserID() failed: %v", err)
+	test.Assert(t, "expected 1 log entry, got %d", len(logs) == 1, len(logs))
+	test.Assert(t, "expected 2 bundled notifications, got %d", logs[0].Notifications == 2, logs[0].Notifications)
+}
+
+func TestRenderSignupNotification(t *testing.T) {
+	tournament := &volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{
+		Name:  "Wien Open",
+		Start: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+	}}
+
+	body, subject, err := renderNotification("en", &notificationData{Notification: &notify.Notification{
+		Type:       services.SignupFailedEventType,
+		Tournament: tournament,
+		Signup:     &volleynet.ScheduledSignup{PartnerName: "John Doe", Message: "the tournament is full"},
+	}})
+
+	test.Check(t, "renderNotification() failed: %v", err)
+	test.Assert(t, "expected the failed subject, got: %s", subject == "Your signup for Wien Open failed", subject)
+	test.Assert(t, "expected the reason, got:\n%s", strings.Contains(body, "John Doe for Wien Open on 01.06.2019 failed: the tournament is full."), body)
+}
+
+func TestUserTeamWithoutPlayers(t *testing.T) {
+	team := &volleynet.TournamentTeam{Player2: &volleynet.Player{ID: 2}}
+	tournament := &volleynet.Tournament{Teams: []*volleynet.TournamentTeam{{}, team}}
+	n := &notify.Notification{Type: sync.ResultsEventType, Tournament: tournament}
+
+	found := userTeam(&scores.User{PlayerID: 2}, n)
+
+	test.Assert(t, "expected the team of player 2, got %+v", found == team, found)
+}
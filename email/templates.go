@@ -0,0 +1,120 @@
+package email
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gobuffalo/packr/v2"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/notify"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var (
+	templates = packr.New("email-templates", "./templates")
+)
+
+// defaultLanguage is used if a user has not set a language or there
+// are no templates available in the user's language.
+const defaultLanguage = "en"
+
+var templateFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("02.01.2006")
+	},
+	"player": func(p *volleynet.Player) string {
+		if p == nil {
+			return ""
+		}
+
+		return p.FirstName + " " + p.LastName
+	},
+}
+
+// notificationData is passed to the template of a single notification.
+type notificationData struct {
+	*notify.Notification
+	UserTeam *volleynet.TournamentTeam
+}
+
+// digestData is passed to the subject and digest templates.
+type digestData struct {
+	User           *scores.User
+	Items          []string
+	Subjects       []string
+	UnsubscribeURL string
+}
+
+// loadTemplate loads the template `name` in `language`, if it does not exist
+// it falls back to the default language.
+func loadTemplate(language, name string) (*template.Template, error) {
+	content, err := templates.FindString(path.Join(language, name+".tmpl"))
+
+	if err != nil && language != defaultLanguage {
+		return loadTemplate(defaultLanguage, name)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "loading template %q", name)
+	}
+
+	return template.New(name).Funcs(templateFuncs).Parse(content)
+}
+
+// templateName maps an event name like "volleynet/tournament/results" to
+// the template "results".
+func templateName(eventName string) string {
+	return path.Base(eventName)
+}
+
+// renderNotification renders the body and subject of a notification.
+func renderNotification(language string, data *notificationData) (body, subject string, err error) {
+	tmpl, err := loadTemplate(language, templateName(data.Type))
+
+	if err != nil {
+		return "", "", err
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err = tmpl.Execute(buf, data); err != nil {
+		return "", "", errors.Wrapf(err, "rendering template %q", tmpl.Name())
+	}
+
+	body = strings.TrimSpace(buf.String())
+	buf.Reset()
+
+	if err = tmpl.ExecuteTemplate(buf, "subject", data); err != nil {
+		return "", "", errors.Wrapf(err, "rendering subject of template %q", tmpl.Name())
+	}
+
+	return body, strings.TrimSpace(buf.String()), nil
+}
+
+// renderDigest renders the subject and body of an email containing all `data.Items`.
+func renderDigest(language string, data *digestData) (body, subject string, err error) {
+	for _, name := range []string{"subject", "digest"} {
+		tmpl, err := loadTemplate(language, name)
+
+		if err != nil {
+			return "", "", err
+		}
+
+		buf := &bytes.Buffer{}
+
+		if err = tmpl.Execute(buf, data); err != nil {
+			return "", "", errors.Wrapf(err, "rendering template %q", name)
+		}
+
+		if name == "subject" {
+			subject = strings.TrimSpace(buf.String())
+		} else {
+			body = buf.String()
+		}
+	}
+
+	return body, subject, nil
+}
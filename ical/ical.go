@@ -0,0 +1,154 @@
+// Package ical writes calendars in the iCalendar format (RFC 5545) so they
+// can be subscribed to by calendar apps.
+package ical
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of an iCalendar file.
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	prodID        = "-//scores//calendar//EN"
+	timeFormat    = "20060102T150405Z"
+	dateFormat    = "20060102"
+	maxLineLength = 75 // octets, without the line break
+)
+
+// Calendar is a named list of events.
+type Calendar struct {
+	Name   string
+	Events []*Event
+}
+
+// Event is a single event of a calendar.
+type Event struct {
+	UID         string // unique and stable, e.g. "tournament-123@scores"
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time // optional, the last day of an all-day event
+	AllDay      bool      // only the dates of `Start` and `End` in their location are written
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Canceled    bool
+	// Latitude and Longitude are only written if one of them is set.
+	Latitude  float32
+	Longitude float32
+}
+
+// WriteTo writes the calendar to `w`.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	buf := &bytes.Buffer{}
+
+	line(buf, "BEGIN", "VCALENDAR")
+	line(buf, "VERSION", "2.0")
+	line(buf, "PRODID", prodID)
+	line(buf, "CALSCALE", "GREGORIAN")
+	line(buf, "METHOD", "PUBLISH")
+
+	if c.Name != "" {
+		line(buf, "X-WR-CALNAME", Escape(c.Name))
+	}
+
+	for _, e := range c.Events {
+		e.write(buf)
+	}
+
+	line(buf, "END", "VCALENDAR")
+
+	return buf.WriteTo(w)
+}
+
+func (e *Event) write(buf *bytes.Buffer) {
+	line(buf, "BEGIN", "VEVENT")
+	line(buf, "UID", Escape(e.UID))
+	line(buf, "DTSTAMP", e.Stamp.UTC().Format(timeFormat))
+
+	if e.AllDay {
+		end := e.Start
+
+		if !e.End.IsZero() {
+			end = e.End
+		}
+
+		// the end date of all-day events is exclusive
+		line(buf, "DTSTART;VALUE=DATE", e.Start.Format(dateFormat))
+		line(buf, "DTEND;VALUE=DATE", end.AddDate(0, 0, 1).Format(dateFormat))
+	} else {
+		line(buf, "DTSTART", e.Start.UTC().Format(timeFormat))
+
+		if !e.End.IsZero() {
+			line(buf, "DTEND", e.End.UTC().Format(timeFormat))
+		}
+	}
+
+	line(buf, "SUMMARY", Escape(e.Summary))
+
+	if e.Description != "" {
+		line(buf, "DESCRIPTION", Escape(e.Description))
+	}
+	if e.Location != "" {
+		line(buf, "LOCATION", Escape(e.Location))
+	}
+	if e.Latitude != 0 || e.Longitude != 0 {
+		line(buf, "GEO", coordinate(e.Latitude)+";"+coordinate(e.Longitude))
+	}
+	if e.URL != "" {
+		line(buf, "URL", e.URL)
+	}
+
+	status := "CONFIRMED"
+
+	if e.Canceled {
+		status = "CANCELLED"
+	}
+
+	line(buf, "STATUS", status)
+	line(buf, "END", "VEVENT")
+}
+
+// line writes a content line, lines longer than 75 octets are folded
+// without splitting multi-byte characters.
+func line(buf *bytes.Buffer, name, value string) {
+	content := name + ":" + value
+	length := 0
+
+	for _, r := range content {
+		size := utf8.RuneLen(r)
+
+		if length+size > maxLineLength {
+			buf.WriteString("\r\n ")
+			length = 1 // the leading space of the continuation
+		}
+
+		buf.WriteRune(r)
+		length += size
+	}
+
+	buf.WriteString("\r\n")
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func coordinate(c float32) string {
+	return strconv.FormatFloat(float64(c), 'f', -1, 32)
+}
+
+// Escape escapes a text value.
+func Escape(text string) string {
+	return escaper.Replace(text)
+}
@@ -0,0 +1,110 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEscape(t *testing.T) {
+	got := Escape("Beach, Volleyball; Wien\\Prater\nCourt 1")
+	want := `Beach\, Volleyball\; Wien\\Prater\nCourt 1`
+
+	if got != want {
+		t.Fatalf("Escape(), want %q, got %q", want, got)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	start := time.Date(2019, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	c := &Calendar{
+		Name: "Tournaments",
+		Events: []*Event{{
+			UID:         "tournament-1@scores",
+			Stamp:       start,
+			Start:       start,
+			End:         start.Add(8 * time.Hour),
+			Summary:     "Amateur Tour, Wien",
+			Description: strings.Repeat("ä", 50),
+			Latitude:    48.2,
+			Longitude:   16.4,
+			Canceled:    true,
+		}},
+	}
+
+	buf := &bytes.Buffer{}
+
+	if _, err := c.WriteTo(buf); err != nil {
+		t.Fatalf("Calendar.WriteTo() failed: %v", err)
+	}
+
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Tournaments\r\n",
+		"DTSTART:20190601T090000Z\r\n",
+		"DTEND:20190601T170000Z\r\n",
+		"SUMMARY:Amateur Tour\\, Wien\r\n",
+		"GEO:48.2;16.4\r\n",
+		"STATUS:CANCELLED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Calendar.WriteTo(), want %q in:\n%s", want, out)
+		}
+	}
+
+	for _, l := range strings.Split(out, "\r\n") {
+		if len(l) > maxLineLength {
+			t.Errorf("Calendar.WriteTo(), line is longer than %d octets: %q", maxLineLength, l)
+		}
+	}
+
+	if !strings.Contains(out, "DESCRIPTION:"+strings.Repeat("ä", 31)+"\r\n "+strings.Repeat("ä", 19)+"\r\n") {
+		t.Errorf("Calendar.WriteTo(), want the description to be folded between characters:\n%s", out)
+	}
+}
+
+func TestWriteToAllDay(t *testing.T) {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+
+	if err != nil {
+		t.Skipf("loading the time zone failed: %v", err)
+	}
+
+	start := time.Date(2019, 6, 1, 0, 0, 0, 0, vienna)
+
+	tests := []struct {
+		name  string
+		event *Event
+		want  []string
+	}{
+		{
+			"single day",
+			&Event{UID: "tournament-1@scores", Stamp: start, Start: start, AllDay: true},
+			[]string{"DTSTART;VALUE=DATE:20190601\r\n", "DTEND;VALUE=DATE:20190602\r\n"},
+		},
+		{
+			"several days",
+			&Event{UID: "tournament-2@scores", Stamp: start, Start: start, End: start.AddDate(0, 0, 1), AllDay: true},
+			[]string{"DTSTART;VALUE=DATE:20190601\r\n", "DTEND;VALUE=DATE:20190603\r\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+
+		if _, err := (&Calendar{Events: []*Event{tt.event}}).WriteTo(buf); err != nil {
+			t.Fatalf("Calendar.WriteTo() failed: %v", err)
+		}
+
+		for _, want := range tt.want {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("Calendar.WriteTo() %s, want %q in:\n%s", tt.name, want, buf.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package job
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/raphi011/scores/events"
+)
+
+const (
+	// ErroredEventType is published when a job enters the errored state.
+	ErroredEventType = "job/errored"
+	// RecoveredEventType is published when an errored job succeeds again.
+	RecoveredEventType = "job/recovered"
+)
+
+// defaultMaxBackoff limits the retry delay if `MaxBackoff` is not set.
+const defaultMaxBackoff = time.Hour
+
+// Event is the body of the events published when a job
+// enters or leaves the errored state.
+type Event struct {
+	JobName   string    `json:"jobName"`
+	Failures  int       `json:"failures"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// retryDelay returns the delay before the next attempt after `failures`
+// consecutive failures. The delay doubles with every failure up to
+// `MaxBackoff`, half of it is random so that failing jobs of multiple
+// instances don't retry in lockstep.
+func (j *Job) retryDelay(failures int) time.Duration {
+	max := j.MaxBackoff
+
+	if max <= 0 {
+		max = defaultMaxBackoff
+	}
+
+	delay := j.Backoff
+
+	for i := 1; i < failures && delay < max; i++ {
+		delay *= 2
+	}
+
+	if delay > max {
+		delay = max
+	}
+
+	half := delay / 2
+
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// cooledDown returns the time an errored job is retried, false is
+// returned if errored jobs are not retried automatically.
+func (j *Job) cooledDown() (time.Time, bool) {
+	if j.Execution.State != StateErrored || j.Cooldown <= 0 {
+		return time.Time{}, false
+	}
+
+	return j.Execution.LastRun.Add(j.Cooldown), true
+}
+
+// event returns the body of a job event.
+func (s *Manager) event(job *Job, err error) Event {
+	body := Event{
+		JobName:   job.Name,
+		Failures:  int(job.Execution.Failures),
+		Timestamp: time.Now(),
+	}
+
+	if err != nil {
+		body.Error = err.Error()
+	}
+
+	return body
+}
+
+// publish sends a job event if a publisher has been set.
+func (s *Manager) publish(eventType string, body Event) {
+	if s.Events == nil {
+		return
+	}
+
+	s.Events.Publish(events.Event{Name: eventType, Body: body})
+}
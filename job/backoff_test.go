@@ -0,0 +1,104 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores/events"
+	"github.com/raphi011/scores/test"
+)
+
+func TestRetryDelay(t *testing.T) {
+	j := &Job{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	tests := []struct {
+		failures int
+		min, max time.Duration
+	}{
+		{1, 500 * time.Millisecond, time.Second},
+		{2, time.Second, 2 * time.Second},
+		{3, 2 * time.Second, 4 * time.Second},
+		{4, 2500 * time.Millisecond, 5 * time.Second},
+		{10, 2500 * time.Millisecond, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			delay := j.retryDelay(tt.failures)
+
+			test.Assert(t, "retryDelay(%d) = %s, want between %s and %s",
+				delay >= tt.min && delay <= tt.max, tt.failures, delay, tt.min, tt.max)
+		}
+	}
+}
+
+type publisherMock struct {
+	lock   sync.Mutex
+	events []events.Event
+}
+
+func (p *publisherMock) Publish(event events.Event) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.events = append(p.events, event)
+}
+
+func (p *publisherMock) names() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	names := []string{}
+
+	for _, e := range p.events {
+		names = append(names, e.Name)
+	}
+
+	return names
+}
+
+func TestManagerCooldown(t *testing.T) {
+	publisher := &publisherMock{}
+	manager := NewManager(logrus.New())
+	manager.Events = publisher
+
+	calls := 0
+
+	err := manager.Start(
+		Job{
+			Name: "Test",
+
+			Interval:    time.Hour,
+			MaxFailures: 2,
+			Backoff:     10 * time.Millisecond,
+			Cooldown:    50 * time.Millisecond,
+
+			Do: func(ctx context.Context) error {
+				calls++
+
+				if calls <= 2 {
+					return errors.New("failed")
+				}
+
+				return nil
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed", err)
+
+	// the second attempt is a retry after the backoff instead of the interval
+	j := waitForRuns(t, manager, "Test", 2)
+	test.Assert(t, "expected job to be errored, got %s", j.Execution.State == StateErrored, j.Execution.State)
+
+	j = waitForRuns(t, manager, "Test", 3)
+	test.Assert(t, "expected job to be waiting after the cooldown, got %s", j.Execution.State == StateWaiting, j.Execution.State)
+	test.Assert(t, "expected errors to be cleared", len(j.Execution.Errors) == 0)
+
+	test.Compare(t, "unexpected events:\n%s", []string{ErroredEventType, RecoveredEventType}, publisher.names())
+}
@@ -0,0 +1,222 @@
+package job
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression which calculates the next run of a job.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// if either day of month or day of week is unrestricted (e.g. `*` or
+	// `*/1`) both have to match, otherwise one of them
+	domStar, dowStar bool
+
+	location *time.Location
+}
+
+type cronField struct {
+	name     string
+	min, max uint
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7}, // 0 and 7 are sunday
+}
+
+var cronDescriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+// maxSearchYears limits the search for the next run of expressions
+// that (almost) never match, e.g. the 31st of february.
+const maxSearchYears = 5
+
+// ParseSchedule parses a cron expression with the five fields `minute hour
+// day-of-month month day-of-week`, e.g. `*/5 7-22 * * *` runs every 5 minutes
+// between 07:00 and 23:00. Fields support lists (`1,15`), ranges (`1-5`) and
+// steps (`*/10`, `0-30/10`). The expression can be prefixed with a time zone
+// (`CRON_TZ=Europe/Vienna 0 3 * * *`), otherwise the local time zone is used.
+// The descriptors @hourly, @daily, @weekly, @monthly and @yearly are supported too.
+func ParseSchedule(expression string) (*Schedule, error) {
+	s := &Schedule{location: time.Local}
+	fields := strings.Fields(expression)
+
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "CRON_TZ=") {
+		location, err := time.LoadLocation(strings.TrimPrefix(fields[0], "CRON_TZ="))
+
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %v", expression, err)
+		}
+
+		s.location = location
+		fields = fields[1:]
+	}
+
+	if len(fields) == 1 {
+		if descriptor, ok := cronDescriptors[fields[0]]; ok {
+			fields = strings.Fields(descriptor)
+		}
+	}
+
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q: expected %d fields, got %d", expression, len(cronFields), len(fields))
+	}
+
+	bitsets := make([]uint64, len(cronFields))
+
+	for i, field := range cronFields {
+		var err error
+		bitsets[i], err = parseCronField(fields[i], field)
+
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %v", expression, err)
+		}
+	}
+
+	s.minute, s.hour, s.dom, s.month, s.dow = bitsets[0], bitsets[1], bitsets[2], bitsets[3], bitsets[4]
+
+	// sunday can be 0 or 7
+	if s.dow&(1<<7) > 0 {
+		s.dow |= 1
+	}
+
+	s.domStar = covers(s.dom, 1, 31)
+	s.dowStar = covers(s.dow, 0, 6)
+
+	return s, nil
+}
+
+// covers returns true if all values from min to max are in the set.
+func covers(set uint64, min, max uint) bool {
+	for v := min; v <= max; v++ {
+		if set&(1<<v) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseCronField(value string, field cronField) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(value, ",") {
+		step := uint(1)
+		rangePart := part
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.ParseUint(part[i+1:], 10, 8)
+
+			if err != nil || s == 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", field.name, part)
+			}
+
+			step = uint(s)
+			rangePart = part[:i]
+		}
+
+		start, end := field.min, field.max
+
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+
+			var err error
+			start, err = parseCronValue(bounds[0], field)
+
+			if err != nil {
+				return 0, err
+			}
+
+			if len(bounds) == 2 {
+				end, err = parseCronValue(bounds[1], field)
+
+				if err != nil {
+					return 0, err
+				}
+			} else if step == 1 {
+				// a single value, `5/10` means `5-max/10`
+				end = start
+			}
+
+			if start > end {
+				return 0, fmt.Errorf("invalid range in %s field %q", field.name, part)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			set |= 1 << v
+		}
+	}
+
+	return set, nil
+}
+
+func parseCronValue(value string, field cronField) (uint, error) {
+	v, err := strconv.ParseUint(value, 10, 8)
+
+	if err != nil || uint(v) < field.min || uint(v) > field.max {
+		return 0, fmt.Errorf("%s field must be between %d and %d, got %q", field.name, field.min, field.max, value)
+	}
+
+	return uint(v), nil
+}
+
+// Next returns the time of the first run after `t` or the zero time
+// if the schedule never matches.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	until := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(until) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) > 0
+}
@@ -0,0 +1,64 @@
+package job
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	tests := []struct {
+		expression string
+		now        time.Time
+		want       time.Time
+	}{
+		{"CRON_TZ=UTC */5 * * * *", time.Date(2019, 6, 1, 12, 2, 30, 0, time.UTC), time.Date(2019, 6, 1, 12, 5, 0, 0, time.UTC)},
+		{"CRON_TZ=UTC */5 7-22 * * *", time.Date(2019, 6, 1, 22, 55, 0, 0, time.UTC), time.Date(2019, 6, 2, 7, 0, 0, 0, time.UTC)},
+		{"CRON_TZ=UTC @daily", time.Date(2019, 12, 31, 10, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"CRON_TZ=UTC 0 3 * * *", time.Date(2019, 6, 1, 3, 0, 0, 0, time.UTC), time.Date(2019, 6, 2, 3, 0, 0, 0, time.UTC)},
+		{"CRON_TZ=UTC 0 12 * * 1-5", time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 6, 3, 12, 0, 0, 0, time.UTC)}, // saturday -> monday
+		{"CRON_TZ=UTC 0 0 13 * 5", time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 9, 6, 0, 0, 0, 0, time.UTC)},    // 13th or friday
+		{"CRON_TZ=UTC 0 0 */1 * 1", time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 6, 3, 0, 0, 0, 0, time.UTC)},   // `*/1` is unrestricted, saturday -> monday
+		{"CRON_TZ=UTC 0 0 1-31 * 1", time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{"CRON_TZ=UTC 0 0 13 * 0-7", time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 9, 13, 0, 0, 0, 0, time.UTC)}, // `0-7` is unrestricted, only the 13th
+		{"CRON_TZ=UTC 0 0 29 2 *", time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"CRON_TZ=UTC 0 0 30 2 *", time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+		{"CRON_TZ=Europe/Vienna 0 3 * * *", time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC), time.Date(2019, 6, 2, 3, 0, 0, 0, vienna)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expression)
+
+			if err != nil {
+				t.Fatalf("ParseSchedule() failed: %v", err)
+			}
+
+			if next := schedule.Next(tt.now); !next.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", next, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	expressions := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 5-1 * * *",
+		"*/0 * * * *",
+		"CRON_TZ=Nowhere/Atlantis * * * * *",
+	}
+
+	for _, expression := range expressions {
+		if _, err := ParseSchedule(expression); err == nil {
+			t.Errorf("ParseSchedule(%q) should fail", expression)
+		}
+	}
+}
@@ -1,31 +1,51 @@
 package job
 
 import (
-	"sync"
+	"context"
 	"time"
 )
 
-const (
-	// SignalStop signals a job to stop
-	SignalStop = 0
-	// SignalStart signals a job to wake up and run
-	SignalStart = 1
-)
+// maxErrors is the max. # of errors that are kept of consecutive failed runs.
+const maxErrors = 10
 
 // Execution represents a running job
 type Execution struct {
 	LastRun      time.Time     `json:"lastRun"`
 	LastDuration time.Duration `json:"lastDuration"`
+	NextRun      time.Time     `json:"nextRun"`
+
+	Errors   []error `json:"errors"`   // the errors of the last (at most `maxErrors`) consecutive failed runs
+	Failures uint    `json:"failures"` // # of consecutive failed runs
+	Runs     uint    `json:"runs"`
+	Skipped  uint    `json:"skipped"` // # of runs that were skipped because of a dependency or because another instance holds the lease
+	State    State   `json:"state"`
+	Leader   bool    `json:"leader"` // true if this instance holds the lease of the job
+
+	BlockedBy string `json:"blockedBy"` // why the last run was skipped because of a dependency
+
+	// cancel cancels the current run
+	cancel context.CancelFunc
 
-	Errors []error `json:"errors"`
-	Runs   uint    `json:"runs"`
-	State  State   `json:"state"`
+	// trigger is set if a run was requested manually
+	trigger bool
 
-	start time.Time
-	end   time.Time
-	sleep time.Duration
+	// wake signals the scheduler to reevaluate the state of the job
+	wake chan struct{}
 
-	lock sync.Mutex
+	// removed is closed if the job has been removed by a reload
+	removed chan struct{}
+}
+
+// Record is a finished run of a job.
+type Record struct {
+	JobName string
+	Start   time.Time
+	End     time.Time
+	Error   error
+	Summary string
+}
 
-	signal chan int
+// History persists the records of all runs.
+type History interface {
+	Record(record Record) error
 }
@@ -1,6 +1,9 @@
 package job
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Job is the definition of a job which is run the Manager in defined intervals.
 type Job struct {
@@ -9,42 +12,136 @@ type Job struct {
 	MaxFailures uint          `json:"maxFailures"` // max # of consecutive failures, retries endlessly if 0
 	Interval    time.Duration `json:"interval"`    // attempts to call the job every interval, if the job takes longer than the interval it will be restarted immediately after finishing
 	Delay       time.Duration `json:"delay"`       // delays first job start
+	Cron        string        `json:"cron"`        // cron expression (see `ParseSchedule`), if set `Interval` and `Delay` are ignored
+	Timeout     time.Duration `json:"timeout"`     // cancels the context of a run after the timeout, no timeout if 0
+	Local       bool          `json:"local"`       // local jobs run on every instance and don't need a lease
+	DependsOn   []string      `json:"dependsOn"`   // names of jobs whose last run must have succeeded before this job can run
+	Triggers    []string      `json:"triggers"`    // names of jobs that are run after this job has succeeded
+	Backoff     time.Duration `json:"backoff"`     // delay before retrying a failed run, doubles with every consecutive failure, retries on schedule if 0
+	MaxBackoff  time.Duration `json:"maxBackoff"`  // upper limit of the retry delay, defaults to 1h
+	Cooldown    time.Duration `json:"cooldown"`    // errored jobs are retried after the cooldown, they stay errored if 0
 
 	Execution Execution `json:"execution"`
 
-	Do func() error `json:"-"` // when started the job calls the do function
+	Do      func(ctx context.Context) error `json:"-"` // when started the job calls the do function, it must return when `ctx` is done
+	Summary func() string                   `json:"-"` // optional, summarizes the last run (e.g. a sync report) for the history
+
+	schedule *Schedule
 }
 
 func (j *Job) hasFailed() bool {
-	errors := len(j.Execution.Errors)
-	maxFailures := int(j.MaxFailures)
+	failures := j.Execution.Failures
 
-	return errors > 0 && maxFailures > 0 && errors >= maxFailures
+	return failures > 0 && j.MaxFailures > 0 && failures >= j.MaxFailures
 }
 
-func (j *Job) canStart() bool {
-	state := j.Execution.State
+// next returns the time of the next run, false is returned if
+// the job's schedule will never run again.
+func (j *Job) next(now time.Time) (time.Time, bool) {
+	failures := int(j.Execution.Failures)
 
-	return state == StateStopped || state == StateWaiting || state == StateErrored
-}
+	if failures > 0 && j.Backoff > 0 {
+		retry := now.Add(j.retryDelay(failures))
+
+		if j.schedule != nil {
+			// retry early but don't skip a scheduled run
+			if next := j.schedule.Next(now); !next.IsZero() && next.Before(retry) {
+				return next, true
+			}
+		}
+
+		return retry, true
+	}
+
+	if j.schedule != nil {
+		next := j.schedule.Next(now)
 
-func (j *Job) start() {
-	j.Execution.signal <- SignalStart
+		return next, !next.IsZero()
+	}
+
+	if j.Execution.Runs == 0 && j.Execution.Skipped == 0 {
+		return now.Add(j.Delay), true
+	}
+
+	return now.Add(j.Interval), true
 }
 
 func (j *Job) canRun() bool {
+	return j.Execution.State != StateRunning && !j.Execution.trigger
+}
+
+func (j *Job) canPause() bool {
 	return j.Execution.State == StateWaiting
 }
 
-func (j *Job) isActive() bool {
-	return j.Execution.State == StateWaiting || j.Execution.State == StateRunning
+func (j *Job) canResume() bool {
+	return j.Execution.State == StatePaused
+}
 
+func (j *Job) canStop() bool {
+	return j.Execution.State == StateWaiting || j.Execution.State == StatePaused
+}
+
+func (j *Job) canReset() bool {
+	return j.Execution.State == StateErrored || j.Execution.State == StateStopped
 }
 
 func (j *Job) shouldStop() bool {
 	return j.MaxRuns > 0 && j.Execution.Runs >= j.MaxRuns
 }
 
+// stop stops all further scheduled runs.
 func (j *Job) stop() {
+	j.Execution.State = StateStopped
+	j.Execution.NextRun = time.Time{}
+}
+
+// reset clears all runs and errors and reschedules the job
+// as if it has just been started.
+func (j *Job) reset() {
+	j.Execution.Errors = nil
+	j.Execution.Failures = 0
+	j.Execution.Runs = 0
+	j.Execution.Skipped = 0
+	j.Execution.State = StateWaiting
+	j.Execution.NextRun = time.Time{}
+}
+
+// update replaces the definition of the job with `definition`
+// but keeps its name and execution, true is returned if the
+// schedule of the job has changed.
+func (j *Job) update(definition *Job) bool {
+	rescheduled := j.Interval != definition.Interval ||
+		j.Delay != definition.Delay ||
+		j.Cron != definition.Cron ||
+		j.Backoff != definition.Backoff ||
+		j.MaxBackoff != definition.MaxBackoff ||
+		j.Cooldown != definition.Cooldown
+
+	j.MaxRuns = definition.MaxRuns
+	j.MaxFailures = definition.MaxFailures
+	j.Interval = definition.Interval
+	j.Delay = definition.Delay
+	j.Cron = definition.Cron
+	j.Timeout = definition.Timeout
+	j.Local = definition.Local
+	j.DependsOn = definition.DependsOn
+	j.Triggers = definition.Triggers
+	j.Backoff = definition.Backoff
+	j.MaxBackoff = definition.MaxBackoff
+	j.Cooldown = definition.Cooldown
+	j.Do = definition.Do
+	j.Summary = definition.Summary
+	j.schedule = definition.schedule
+
+	return rescheduled
+}
 
+// signal wakes up the scheduler of the job, if it has already
+// been woken up this does nothing.
+func (j *Job) signal() {
+	select {
+	case j.Execution.wake <- struct{}{}:
+	default:
+	}
 }
@@ -0,0 +1,141 @@
+package job
+
+import (
+	"time"
+)
+
+// defaultLeaseTTL is used if the manager's `LeaseTTL` is not set.
+const defaultLeaseTTL = 1 * time.Minute
+
+// Locker grants leases on jobs, if it's set the Manager only runs jobs it
+// holds the lease of. This makes sure that a job only runs on one instance
+// at a time if several instances share the same Locker (e.g. a database).
+type Locker interface {
+	// Acquire creates or renews the lease of the job for `ttl`,
+	// false is returned if another instance holds it.
+	Acquire(jobName string, ttl time.Duration) (bool, error)
+	// Release gives up the lease so another instance can take over immediately.
+	Release(jobName string) error
+}
+
+func (s *Manager) leaseTTL() time.Duration {
+	if s.LeaseTTL > 0 {
+		return s.LeaseTTL
+	}
+
+	return defaultLeaseTTL
+}
+
+// lease acquires the lease of the job before a run, this always
+// succeeds if there's no Locker or the job is local.
+func (s *Manager) lease(job *Job) bool {
+	s.lock.Lock()
+	local := job.Local
+	s.lock.Unlock()
+
+	if s.Locker == nil || local {
+		return true
+	}
+
+	acquired, err := s.Locker.Acquire(job.Name, s.leaseTTL())
+
+	if err != nil {
+		s.log.Warnf("acquiring the lease of job %q failed: %v", job.Name, err)
+		acquired = false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	job.Execution.Leader = acquired
+
+	if !acquired {
+		job.Execution.Skipped++
+		s.log.Debugf("job %q skipped, another instance holds the lease", job.Name)
+	}
+
+	return acquired
+}
+
+// renewLeases renews the leases of all jobs this instance leads until the
+// manager shuts down, a running job is canceled if its lease is lost.
+// Must be run in a go routine.
+func (s *Manager) renewLeases() {
+	ticker := time.NewTicker(s.leaseTTL() / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, job := range s.leaders() {
+			acquired, err := s.Locker.Acquire(job.Name, s.leaseTTL())
+
+			if err != nil {
+				// without the lease another instance could take over, so
+				// it's safer to assume that it's lost
+				s.log.Warnf("renewing the lease of job %q failed: %v", job.Name, err)
+			}
+
+			if acquired && err == nil {
+				continue
+			}
+
+			s.lock.Lock()
+			job.Execution.Leader = false
+
+			if job.Execution.cancel != nil {
+				s.log.Warnf("job %q lost its lease, canceling it", job.Name)
+				job.Execution.cancel()
+			}
+
+			s.lock.Unlock()
+		}
+	}
+}
+
+// leaders returns all jobs this instance holds the lease of.
+func (s *Manager) leaders() []*Job {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	jobs := []*Job{}
+
+	for _, job := range s.jobs {
+		if job.Execution.Leader && !job.Local {
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs
+}
+
+// releaseLeases releases all leases so other instances can take over.
+func (s *Manager) releaseLeases() {
+	if s.Locker == nil {
+		return
+	}
+
+	for _, job := range s.leaders() {
+		s.release(job)
+	}
+}
+
+// release releases the lease of a job if this instance holds it.
+func (s *Manager) release(job *Job) {
+	s.lock.Lock()
+	leader := job.Execution.Leader && !job.Local
+	job.Execution.Leader = false
+	s.lock.Unlock()
+
+	if s.Locker == nil || !leader {
+		return
+	}
+
+	if err := s.Locker.Release(job.Name); err != nil {
+		s.log.Warnf("releasing the lease of job %q failed: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,93 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores/test"
+)
+
+// lockerMock is an in memory Locker that is shared by several managers.
+type lockerMock struct {
+	lock   sync.Mutex
+	owners map[string]*Manager
+}
+
+type managerLocker struct {
+	*lockerMock
+	manager *Manager
+}
+
+func (l *managerLocker) Acquire(jobName string, ttl time.Duration) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if owner, ok := l.owners[jobName]; ok && owner != l.manager {
+		return false, nil
+	}
+
+	l.owners[jobName] = l.manager
+
+	return true, nil
+}
+
+func (l *managerLocker) Release(jobName string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.owners[jobName] == l.manager {
+		delete(l.owners, jobName)
+	}
+
+	return nil
+}
+
+func TestManagerLease(t *testing.T) {
+	locker := &lockerMock{owners: map[string]*Manager{}}
+	managers := []*Manager{NewManager(logrus.New()), NewManager(logrus.New())}
+
+	for _, m := range managers {
+		m.Locker = &managerLocker{lockerMock: locker, manager: m}
+
+		err := m.Start(Job{
+			Name:     "Test",
+			Interval: 10 * time.Millisecond,
+
+			Do: func(ctx context.Context) error {
+				return nil
+			},
+		})
+
+		test.Check(t, "manager.Start() failed: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	first, _ := managers[0].Job("Test")
+	second, _ := managers[1].Job("Test")
+
+	leader, follower := managers[0], managers[1]
+
+	if second.Execution.Runs > 0 {
+		first, second = second, first
+		leader, follower = follower, leader
+	}
+
+	test.Assert(t, "want the leader to run the job", first.Execution.Runs > 0 && first.Execution.Leader)
+	test.Assert(t, "want the follower to skip all runs, got %d runs", second.Execution.Runs == 0 && second.Execution.Skipped > 0, second.Execution.Runs)
+
+	err := leader.Shutdown(context.Background())
+	test.Check(t, "manager.Shutdown() failed: %v", err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	second, _ = follower.Job("Test")
+	test.Assert(t, "want the follower to take over after the leader released the lease", second.Execution.Runs > 0 && second.Execution.Leader)
+
+	err = follower.Shutdown(context.Background())
+	test.Check(t, "manager.Shutdown() failed: %v", err)
+}
@@ -1,21 +1,49 @@
 package job
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores/events"
+)
+
+var (
+	// ErrJobNotFound is returned if a job with the passed name does not exist.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrInvalidState is returned if a job's state does not allow an action,
+	// e.g. a stopped job cannot be paused.
+	ErrInvalidState = errors.New("invalid job state")
 )
 
 // Manager runs jobs in defined intervals
 type Manager struct {
+	History  History          // optional, records every run
+	Locker   Locker           // optional, if set jobs only run if this instance holds their lease
+	LeaseTTL time.Duration    // how long a lease is valid, it's renewed every third of it
+	Events   events.Publisher // optional, publishes an event when a job enters or leaves the errored state
+
 	log logrus.FieldLogger
 
 	waitGroup sync.WaitGroup
 
-	jobs map[string]*Job
+	// ctx is the parent context of all runs, it's canceled if
+	// running jobs don't finish in time during shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// quit is closed on shutdown and stops all schedules
+	quit chan struct{}
+
+	// lock guards the jobs and their executions
+	lock  sync.Mutex
+	jobs  map[string]*Job
+	order []string // dependencies first
 }
 
 // NewManager constructs a new Manager.
@@ -27,103 +55,269 @@ func NewManager(log logrus.FieldLogger) *Manager {
 
 // run starts an execution and sets the appropriate state.
 func (s *Manager) run(job *Job) {
-	job.Execution.lock.Lock()
+	if s.blocked(job) || !s.lease(job) {
+		return
+	}
+
+	s.lock.Lock()
+	// the definition can change during the run if the jobs are reloaded
+	do, summary := job.Do, job.Summary
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	if job.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	previousState := job.Execution.State
 	job.Execution.State = StateRunning
-	job.Execution.lock.Unlock()
+	job.Execution.NextRun = time.Time{}
+	job.Execution.cancel = cancel
+	s.lock.Unlock()
 	s.log.Debugf("job %q running", job.Name)
 
 	start := time.Now()
-	err := job.Do()
+	err := do(ctx)
 	end := time.Now()
 
-	job.Execution.lock.Lock()
-	defer job.Execution.lock.Unlock()
+	s.record(job.Name, summary, start, end, err)
+
+	s.lock.Lock()
 
+	// a retry after the cooldown still has the errors of the previous runs
+	wasErrored := job.hasFailed()
+
+	job.Execution.cancel = nil
 	job.Execution.LastDuration = end.Sub(start)
 	job.Execution.LastRun = time.Now()
 	job.Execution.Runs++
 
 	if err != nil {
 		s.log.Warnf("job %q failed: %v", job.Name, err)
+		job.Execution.Failures++
 		job.Execution.Errors = append(job.Execution.Errors, err)
+
+		if len(job.Execution.Errors) > maxErrors {
+			job.Execution.Errors = job.Execution.Errors[len(job.Execution.Errors)-maxErrors:]
+		}
 	} else {
 		s.log.Debugf("job %q finished", job.Name)
+		job.Execution.Failures = 0
 		job.Execution.Errors = nil
 	}
 
-	if job.hasFailed() {
+	if previousState == StatePaused || previousState == StateStopped {
+		// manually triggered runs don't resume the job
+		job.Execution.State = previousState
+	} else if job.hasFailed() {
 		s.log.Warnf("job %q failed %d times, stopping", job.Name, job.MaxFailures)
 		job.Execution.State = StateErrored
 	} else if job.shouldStop() {
 		s.log.Infof("job %q ran %d times, stopping", job.Name, job.Execution.Runs)
-		job.Execution.State = StateStopped
+		job.stop()
 	} else {
 		job.Execution.State = StateWaiting
 	}
+
+	if err == nil {
+		s.triggerNext(job)
+	}
+
+	eventType := ""
+
+	if !wasErrored && job.Execution.State == StateErrored {
+		eventType = ErroredEventType
+	} else if wasErrored && err == nil {
+		s.log.Infof("job %q recovered", job.Name)
+		eventType = RecoveredEventType
+	}
+
+	event := s.event(job, err)
+	s.lock.Unlock()
+
+	// events are published without holding the lock so
+	// that subscribers are free to call the manager
+	if eventType != "" {
+		s.publish(eventType, event)
+	}
+}
+
+// blocked returns true if a dependency prevents the job from running.
+func (s *Manager) blocked(job *Job) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	job.Execution.BlockedBy = s.blockedBy(job)
+
+	if job.Execution.BlockedBy != "" {
+		job.Execution.Skipped++
+		s.log.Infof("job %q skipped, %s", job.Name, job.Execution.BlockedBy)
+		return true
+	}
+
+	return false
+}
+
+// record adds the run to the history.
+func (s *Manager) record(jobName string, summary func() string, start, end time.Time, err error) {
+	if s.History == nil {
+		return
+	}
+
+	record := Record{
+		JobName: jobName,
+		Start:   start,
+		End:     end,
+		Error:   err,
+	}
+
+	if summary != nil {
+		record.Summary = summary()
+	}
+
+	if err := s.History.Record(record); err != nil {
+		s.log.Warnf("recording run of job %q failed: %v", jobName, err)
+	}
 }
 
-// schedule adds an execution to the run queue during the next interval
-// if the execution's state allows it (has not errored, isn't stopped).
-// Must be run in a go routine.
+// schedule runs the job when it's due or has been triggered manually and
+// otherwise waits for state changes. Must be run in a go routine.
 func (s *Manager) schedule(job *Job) {
-	s.waitGroup.Add(1)
+	defer s.waitGroup.Done()
 
 	for {
-		sleep := time.Duration(0)
+		select {
+		case <-s.quit:
+			s.log.Debugf("job %q shut down", job.Name)
+			return
+		case <-job.Execution.removed:
+			s.log.Debugf("job %q removed", job.Name)
+			return
+		default:
+		}
+
+		now := time.Now()
+		runNow := false
+		sleep := time.Duration(-1)
+
+		s.lock.Lock()
+
+		if job.Execution.trigger {
+			job.Execution.trigger = false
+			runNow = true
+		} else if job.Execution.State == StateWaiting {
+			// the next run is only computed after a run or a change of the
+			// job's schedule, waking up the job doesn't postpone it
+			if job.Execution.NextRun.IsZero() {
+				if next, ok := job.next(now); ok {
+					job.Execution.NextRun = next
+				} else {
+					s.log.Warnf("job %q has no next run, stopping", job.Name)
+					job.stop()
+				}
+			}
+
+			if job.Execution.State == StateWaiting {
+				sleep = job.Execution.NextRun.Sub(now)
+
+				if sleep <= 0 {
+					sleep = 0
+					job.Execution.NextRun = time.Time{}
+				}
+			}
+		} else if retry, ok := job.cooledDown(); ok {
+			job.Execution.NextRun = retry
+			sleep = retry.Sub(now)
+
+			if sleep < 0 {
+				sleep = 0
+			}
+
+			if sleep == 0 {
+				s.log.Infof("job %q cooled down, retrying", job.Name)
+				job.Execution.State = StateWaiting
+				job.Execution.NextRun = time.Time{}
+			}
+		}
+
+		s.lock.Unlock()
 
-		if job.Execution.Runs == 0 {
-			sleep = job.Delay
-		} else {
-			sleep = job.Interval
+		if runNow || sleep == 0 {
+			s.run(job)
+			continue
 		}
 
+		var timer *time.Timer
+		var due <-chan time.Time
+
 		if sleep > 0 {
 			s.log.Debugf("job %q going to sleep for: %s", job.Name, formatDuration(sleep))
+			timer = time.NewTimer(sleep)
+			due = timer.C
+		}
 
-			sleepTimer := time.AfterFunc(sleep, func() {
-				job.Execution.signal <- SignalStart
-			})
+		select {
+		case <-due:
+			s.lock.Lock()
+			stillDue := job.Execution.State == StateWaiting
 
-			if signal := <-job.Execution.signal; signal == SignalStop {
-				sleepTimer.Stop()
+			if _, ok := job.cooledDown(); ok {
+				s.log.Infof("job %q cooled down, retrying", job.Name)
+				job.Execution.State = StateWaiting
+				stillDue = true
+			}
 
-				job.Execution.lock.Lock()
-				job.Execution.State = StateStopped
-				job.Execution.lock.Unlock()
-				s.log.Debugf("job %q stopped", job.Name)
-				break
+			if stillDue {
+				// the run might be skipped, compute the next one afterwards
+				job.Execution.NextRun = time.Time{}
 			}
 
+			s.lock.Unlock()
+
+			if stillDue {
+				s.run(job)
+			}
+		case <-job.Execution.wake:
 			s.log.Debugf("job %q woken up", job.Name)
+		case <-job.Execution.removed:
+		case <-s.quit:
 		}
 
-		s.run(job)
-
-		if job.Execution.State != StateWaiting {
-			break
+		if timer != nil {
+			timer.Stop()
 		}
 	}
-
-	s.waitGroup.Done()
 }
 
 func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
 }
 
-// Jobs returns all running jobs.
+// Jobs returns all jobs sorted by name.
 func (s *Manager) Jobs() []Job {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	jobs := []Job{}
 
 	for _, job := range s.jobs {
 		jobs = append(jobs, *job)
 	}
 
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+
 	return jobs
 }
 
 // HasJob returns true if a job with the name `jobName` exists.
 func (s *Manager) HasJob(jobName string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	_, ok := s.jobs[jobName]
 
 	return ok
@@ -131,41 +325,265 @@ func (s *Manager) HasJob(jobName string) bool {
 
 // Job retrieves a job.
 func (s *Manager) Job(jobName string) (Job, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	j, ok := s.jobs[jobName]
 
 	if ok {
-		j.Execution.lock.Lock()
-		job := *j
-		j.Execution.lock.Unlock()
-
-		return job, true
+		return *j, true
 	}
 
 	return Job{}, false
 }
 
+// Run triggers an immediate run of a job that is not running yet, this
+// does not resume paused or stopped jobs.
+func (s *Manager) Run(jobName string) error {
+	return s.control(jobName, "run", (*Job).canRun, func(j *Job) {
+		j.Execution.trigger = true
+	})
+}
+
+// Pause suspends the scheduled runs of a job until it's resumed.
+func (s *Manager) Pause(jobName string) error {
+	return s.control(jobName, "pause", (*Job).canPause, func(j *Job) {
+		j.Execution.State = StatePaused
+		j.Execution.NextRun = time.Time{}
+	})
+}
+
+// Resume continues the scheduled runs of a paused job.
+func (s *Manager) Resume(jobName string) error {
+	return s.control(jobName, "resume", (*Job).canResume, func(j *Job) {
+		j.Execution.State = StateWaiting
+	})
+}
+
+// StopJob stops a job, it can be started again with `Reset`.
+func (s *Manager) StopJob(jobName string) error {
+	return s.control(jobName, "stop", (*Job).canStop, (*Job).stop)
+}
+
+// Reset clears the runs and errors of an errored or stopped job
+// and schedules it again.
+func (s *Manager) Reset(jobName string) error {
+	return s.control(jobName, "reset", (*Job).canReset, (*Job).reset)
+}
+
+// control applies `action` to the job if `allowed` and wakes up its scheduler.
+func (s *Manager) control(jobName, name string, allowed func(*Job) bool, action func(*Job)) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	job, ok := s.jobs[jobName]
+
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	if !allowed(job) {
+		return fmt.Errorf("cannot %s job %q while it's %s: %w", name, jobName, job.Execution.State, ErrInvalidState)
+	}
+
+	action(job)
+	job.signal()
+
+	s.log.Infof("job %q: %s", jobName, name)
+
+	return nil
+}
+
 // Start runs the Manager and queues the `jobs`
 func (s *Manager) Start(jobs ...Job) error {
 	if len(jobs) == 0 {
 		return nil
 	}
 
-	s.jobs = make(map[string]*Job)
+	byName, order, err := prepare(jobs)
 
-	for _, job := range jobs {
-		if job.Do == nil {
-			return errors.New("job has no 'Do' function")
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.quit = make(chan struct{})
+	s.jobs = byName
+	s.order = order
+
+	// dependencies are scheduled first, this way jobs that are
+	// due at the same time usually run in the right order
+	for _, name := range order {
+		s.add(s.jobs[name])
+	}
+
+	if s.Locker != nil {
+		go s.renewLeases()
+	}
+
+	return nil
+}
+
+// Reload replaces the jobs of a started manager without interrupting the
+// jobs that are still defined. Jobs that keep their name keep their
+// execution (runs, errors, state and next run), only jobs whose schedule
+// has changed are rescheduled. Jobs that are no longer defined are removed
+// and their running executions are canceled.
+func (s *Manager) Reload(jobs ...Job) error {
+	byName, order, err := prepare(jobs)
+
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+
+	if s.quit == nil {
+		s.lock.Unlock()
+		return errors.New("manager has not been started")
+	}
+
+	select {
+	case <-s.quit:
+		s.lock.Unlock()
+		return errors.New("manager has been shut down")
+	default:
+	}
+
+	removed := []*Job{}
+
+	for name, job := range s.jobs {
+		if _, ok := byName[name]; !ok {
+			close(job.Execution.removed)
+
+			if job.Execution.cancel != nil {
+				job.Execution.cancel()
+			}
+
+			removed = append(removed, job)
+		}
+	}
+
+	for _, name := range order {
+		current, ok := s.jobs[name]
+
+		if !ok {
+			s.add(byName[name])
+			continue
 		}
+
+		if current.update(byName[name]) {
+			current.Execution.NextRun = time.Time{}
+			current.signal()
+		}
+
+		byName[name] = current
+	}
+
+	s.jobs = byName
+	s.order = order
+	s.lock.Unlock()
+
+	s.log.Infof("reloaded jobs: %d defined, %d removed", len(order), len(removed))
+
+	for _, job := range removed {
+		s.release(job)
 	}
 
+	return nil
+}
+
+// prepare validates the jobs and returns them by name and in
+// the order they are scheduled in.
+func prepare(jobs []Job) (map[string]*Job, []string, error) {
+	byName := make(map[string]*Job)
+
 	for i := range jobs {
 		job := &jobs[i]
 
-		job.Execution.signal = make(chan int)
-		s.jobs[job.Name] = job
+		if job.Do == nil {
+			return nil, nil, errors.New("job has no 'Do' function")
+		}
+
+		if _, ok := byName[job.Name]; ok {
+			return nil, nil, fmt.Errorf("job %q is defined twice", job.Name)
+		}
+
+		byName[job.Name] = job
+
+		if job.Cron != "" {
+			schedule, err := ParseSchedule(job.Cron)
+
+			if err != nil {
+				return nil, nil, fmt.Errorf("job %q: %v", job.Name, err)
+			}
+
+			job.schedule = schedule
+		}
+	}
+
+	order, err := resolveOrder(byName)
 
-		go s.schedule(job)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return nil
+	return byName, order, nil
+}
+
+// add schedules a new job, the lock must be held.
+func (s *Manager) add(job *Job) {
+	job.Execution = Execution{
+		State:   StateWaiting,
+		wake:    make(chan struct{}, 1),
+		removed: make(chan struct{}),
+	}
+
+	s.waitGroup.Add(1)
+	go s.schedule(job)
+}
+
+// Shutdown stops all schedules and waits until running jobs have finished.
+// If `ctx` is done before, the contexts of the running jobs are canceled
+// and the error of `ctx` is returned.
+func (s *Manager) Shutdown(ctx context.Context) error {
+	s.lock.Lock()
+
+	if s.quit == nil {
+		// never started
+		s.lock.Unlock()
+		return nil
+	}
+
+	select {
+	case <-s.quit:
+	default:
+		close(s.quit)
+	}
+
+	s.lock.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.waitGroup.Wait()
+		close(done)
+	}()
+
+	var err error
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.log.Warn("jobs did not finish in time, canceling them")
+		err = ctx.Err()
+	}
+
+	s.cancel()
+	s.releaseLeases()
+
+	return err
 }
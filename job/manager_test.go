@@ -1,6 +1,9 @@
 package job
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -19,7 +22,7 @@ func TestManager(t *testing.T) {
 			Interval: 1 * time.Second,
 			MaxRuns:  3,
 
-			Do: func() error {
+			Do: func(ctx context.Context) error {
 				return nil
 			},
 		},
@@ -34,3 +37,303 @@ func TestManager(t *testing.T) {
 	test.Assert(t, "manager.Job() can't retrieve a job", ok)
 	test.Assert(t, "expected job to execute 3 times, got %d", j.Execution.Runs == 3, j.Execution.Runs)
 }
+
+func waitForRuns(t *testing.T, manager *Manager, jobName string, runs uint) Job {
+	t.Helper()
+
+	for i := 0; i < 100; i++ {
+		j, _ := manager.Job(jobName)
+
+		if j.Execution.Runs == runs && j.Execution.State != StateRunning {
+			return j
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("job %q did not run %d times", jobName, runs)
+
+	return Job{}
+}
+
+func TestManagerControl(t *testing.T) {
+	manager := NewManager(logrus.New())
+
+	err := manager.Start(
+		Job{
+			Name: "Test",
+
+			Interval: 1 * time.Hour,
+			Delay:    1 * time.Hour,
+
+			Do: func(ctx context.Context) error {
+				return nil
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed", err)
+
+	j, _ := manager.Job("Test")
+
+	for i := 0; i < 100 && j.Execution.NextRun.IsZero(); i++ {
+		time.Sleep(10 * time.Millisecond)
+		j, _ = manager.Job("Test")
+	}
+
+	test.Assert(t, "expected next run to be set", !j.Execution.NextRun.IsZero())
+
+	err = manager.Run("Test")
+	test.Check(t, "manager.Run() failed: %v", err)
+	j = waitForRuns(t, manager, "Test", 1)
+	test.Assert(t, "expected job to wait after a manual run, got %s", j.Execution.State == StateWaiting, j.Execution.State)
+
+	err = manager.Pause("Test")
+	test.Check(t, "manager.Pause() failed: %v", err)
+
+	err = manager.Run("Test")
+	test.Check(t, "manager.Run() failed: %v", err)
+	j = waitForRuns(t, manager, "Test", 2)
+	test.Assert(t, "expected job to stay paused, got %s", j.Execution.State == StatePaused, j.Execution.State)
+
+	err = manager.Resume("Test")
+	test.Check(t, "manager.Resume() failed: %v", err)
+
+	err = manager.StopJob("Test")
+	test.Check(t, "manager.StopJob() failed: %v", err)
+
+	err = manager.Pause("Test")
+	test.Assert(t, "expected ErrInvalidState when pausing a stopped job, got %v", errors.Is(err, ErrInvalidState), err)
+
+	err = manager.Reset("Test")
+	test.Check(t, "manager.Reset() failed: %v", err)
+
+	j, _ = manager.Job("Test")
+	test.Assert(t, "expected reset job to wait, got %s", j.Execution.State == StateWaiting, j.Execution.State)
+	test.Assert(t, "expected runs to be reset, got %d", j.Execution.Runs == 0, j.Execution.Runs)
+
+	err = manager.Run("Unknown")
+	test.Assert(t, "expected ErrJobNotFound, got %v", err == ErrJobNotFound, err)
+}
+
+type historyMock struct {
+	records chan Record
+}
+
+func (h *historyMock) Record(record Record) error {
+	h.records <- record
+
+	return nil
+}
+
+func TestManagerHistory(t *testing.T) {
+	manager := NewManager(logrus.New())
+	history := &historyMock{records: make(chan Record, 1)}
+	manager.History = history
+
+	err := manager.Start(
+		Job{
+			Name:    "Test",
+			MaxRuns: 1,
+
+			Do: func(ctx context.Context) error {
+				return errors.New("scrape failed")
+			},
+			Summary: func() string {
+				return "tournaments: 1 new"
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed", err)
+
+	select {
+	case record := <-history.records:
+		test.Assert(t, "want record of job %q, got %q", record.JobName == "Test", "Test", record.JobName)
+		test.Assert(t, "want error to be recorded, got %v", record.Error != nil && record.Error.Error() == "scrape failed", record.Error)
+		test.Assert(t, "want summary to be recorded, got %q", record.Summary == "tournaments: 1 new", record.Summary)
+	case <-time.After(time.Second):
+		t.Fatal("job run was not recorded")
+	}
+}
+
+func TestManagerTimeout(t *testing.T) {
+	manager := NewManager(logrus.New())
+	history := &historyMock{records: make(chan Record, 1)}
+	manager.History = history
+
+	err := manager.Start(
+		Job{
+			Name:    "Test",
+			MaxRuns: 1,
+			Timeout: 10 * time.Millisecond,
+
+			Do: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed", err)
+
+	select {
+	case record := <-history.records:
+		test.Assert(t, "want deadline exceeded, got %v", record.Error == context.DeadlineExceeded, record.Error)
+	case <-time.After(time.Second):
+		t.Fatal("job did not time out")
+	}
+}
+
+func TestManagerShutdown(t *testing.T) {
+	manager := NewManager(logrus.New())
+	running := make(chan struct{})
+	finished := make(chan struct{})
+
+	err := manager.Start(
+		Job{
+			Name:     "Waiting",
+			Interval: time.Hour,
+			Delay:    time.Hour,
+
+			Do: func(ctx context.Context) error {
+				return nil
+			},
+		},
+		Job{
+			Name:    "Running",
+			MaxRuns: 1,
+
+			Do: func(ctx context.Context) error {
+				close(running)
+				<-ctx.Done()
+				close(finished)
+				return ctx.Err()
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed", err)
+
+	<-running
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = manager.Shutdown(ctx)
+	test.Assert(t, "want deadline exceeded, got %v", err == context.DeadlineExceeded, err)
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("running job was not canceled")
+	}
+
+	// all schedules have quit once the canceled job has returned
+	err = manager.Shutdown(context.Background())
+	test.Check(t, "manager.Shutdown() failed: %v", err)
+}
+
+func TestManagerReload(t *testing.T) {
+	manager := NewManager(logrus.New())
+	do := func(ctx context.Context) error { return nil }
+
+	err := manager.Start(
+		Job{Name: "Kept", Interval: time.Hour, Do: do},
+		Job{Name: "Removed", Interval: time.Hour, Do: do},
+	)
+
+	test.Check(t, "manager.Start() failed", err)
+
+	waitForRuns(t, manager, "Kept", 1)
+
+	err = manager.Reload(
+		Job{Name: "Kept", Interval: 2 * time.Hour, Do: do},
+		Job{Name: "Added", Interval: time.Hour, DependsOn: []string{"Kept"}, Do: do},
+	)
+
+	test.Check(t, "manager.Reload() failed", err)
+
+	test.Assert(t, "expected removed job to be gone", !manager.HasJob("Removed"))
+	waitForRuns(t, manager, "Added", 1)
+
+	kept, _ := manager.Job("Kept")
+	test.Assert(t, "expected the execution to be kept, got %d runs", kept.Execution.Runs == 1, kept.Execution.Runs)
+	test.Assert(t, "expected the new interval, got %s", kept.Interval == 2*time.Hour, kept.Interval)
+
+	err = manager.Reload(Job{Name: "Invalid", Interval: time.Hour, DependsOn: []string{"Unknown"}, Do: do})
+	test.Assert(t, "expected reload with unknown dependency to fail", err != nil)
+	test.Assert(t, "expected failed reload to keep the jobs", manager.HasJob("Kept"))
+
+	test.Check(t, "manager.Shutdown() failed", manager.Shutdown(context.Background()))
+}
+
+func TestManagerReloadKeepsNextRun(t *testing.T) {
+	manager := NewManager(logrus.New())
+	do := func(ctx context.Context) error { return nil }
+	waiting := Job{Name: "Waiting", Interval: time.Hour, Delay: time.Hour, Do: do}
+
+	err := manager.Start(waiting)
+	test.Check(t, "manager.Start() failed", err)
+
+	nextRun := func() time.Time {
+		j, _ := manager.Job("Waiting")
+		return j.Execution.NextRun
+	}
+
+	for i := 0; i < 100 && nextRun().IsZero(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	scheduled := nextRun()
+	test.Assert(t, "expected next run to be set", !scheduled.IsZero())
+
+	err = manager.Reload(waiting, Job{Name: "Other", Interval: time.Hour, Do: do})
+	test.Check(t, "manager.Reload() failed", err)
+
+	test.Check(t, "manager.Run() failed", manager.Run("Other"))
+	waitForRuns(t, manager, "Other", 1)
+
+	test.Assert(t, "expected the next run %s to be kept, got %s", nextRun().Equal(scheduled), scheduled, nextRun())
+
+	waiting.Delay = 2 * time.Hour
+	err = manager.Reload(waiting)
+	test.Check(t, "manager.Reload() failed", err)
+
+	for i := 0; i < 100 && !nextRun().After(scheduled); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	test.Assert(t, "expected the changed job to be rescheduled, got %s", nextRun().After(scheduled.Add(30*time.Minute)), nextRun())
+
+	test.Check(t, "manager.Shutdown() failed", manager.Shutdown(context.Background()))
+}
+
+func TestManagerKeepsLastErrors(t *testing.T) {
+	manager := NewManager(logrus.New())
+	runs := 0
+
+	err := manager.Start(
+		Job{
+			Name: "Test",
+
+			Interval:   1 * time.Hour,
+			Backoff:    1 * time.Millisecond,
+			MaxBackoff: 1 * time.Millisecond,
+			MaxRuns:    maxErrors + 2,
+
+			Do: func(ctx context.Context) error {
+				runs++
+				return fmt.Errorf("run %d", runs)
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed", err)
+
+	j := waitForRuns(t, manager, "Test", maxErrors+2)
+
+	test.Assert(t, "expected %d failures, got %d", j.Execution.Failures == maxErrors+2, maxErrors+2, j.Execution.Failures)
+	test.Assert(t, "expected the last %d errors, got %v",
+		len(j.Execution.Errors) == maxErrors && j.Execution.Errors[0].Error() == "run 3", maxErrors, j.Execution.Errors)
+}
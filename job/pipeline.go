@@ -0,0 +1,211 @@
+package job
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Pipeline is a group of jobs that depend on or trigger each other.
+type Pipeline struct {
+	Jobs   []string `json:"jobs"`   // in execution order
+	Status string   `json:"status"` // `running`, `failed`, `blocked` or `ok`
+}
+
+// pipeline states
+const (
+	PipelineRunning = "running"
+	PipelineFailed  = "failed"
+	PipelineBlocked = "blocked"
+	PipelineOK      = "ok"
+)
+
+// edges returns the jobs that have to run after `job`.
+func edges(jobs map[string]*Job, job *Job) []string {
+	next := append([]string{}, job.Triggers...)
+
+	for _, j := range jobs {
+		for _, dependency := range j.DependsOn {
+			if dependency == job.Name {
+				next = append(next, j.Name)
+			}
+		}
+	}
+
+	return next
+}
+
+// resolveOrder sorts the jobs topologically, dependencies and triggering
+// jobs first. An error is returned if a job references an unknown job or
+// if the jobs form a cycle.
+func resolveOrder(jobs map[string]*Job) ([]string, error) {
+	inDegree := map[string]int{}
+
+	for name, job := range jobs {
+		inDegree[name] += 0
+
+		for _, related := range append(append([]string{}, job.DependsOn...), job.Triggers...) {
+			if _, ok := jobs[related]; !ok {
+				return nil, fmt.Errorf("job %q references unknown job %q", name, related)
+			}
+
+			if related == name {
+				return nil, fmt.Errorf("job %q references itself", name)
+			}
+		}
+
+		for _, next := range edges(jobs, job) {
+			inDegree[next]++
+		}
+	}
+
+	order := []string{}
+	ready := []string{}
+
+	for name, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	for len(ready) > 0 {
+		// sorting keeps the order stable
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+
+		for _, next := range edges(jobs, jobs[name]) {
+			inDegree[next]--
+
+			if inDegree[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+
+	if len(order) != len(jobs) {
+		return nil, fmt.Errorf("the dependencies / triggers of the jobs form a cycle")
+	}
+
+	return order, nil
+}
+
+// blockedBy returns the reason why the job can't run because of its
+// dependencies or an empty string if it can run, must hold the lock.
+func (s *Manager) blockedBy(job *Job) string {
+	for _, name := range job.DependsOn {
+		dependency := s.jobs[name]
+
+		switch {
+		case dependency.Execution.State == StateRunning:
+			return fmt.Sprintf("dependency %q is running", name)
+		case dependency.Execution.Runs == 0:
+			return fmt.Sprintf("dependency %q has not run yet", name)
+		case dependency.Execution.Failures > 0:
+			return fmt.Sprintf("dependency %q has failed", name)
+		}
+	}
+
+	return ""
+}
+
+// triggerNext triggers all jobs that have to run after `job`
+// has succeeded, must hold the lock.
+func (s *Manager) triggerNext(job *Job) {
+	for _, name := range job.Triggers {
+		next := s.jobs[name]
+
+		// paused, stopped and errored jobs are not triggered
+		if next.Execution.State != StateWaiting || next.Execution.trigger {
+			continue
+		}
+
+		s.log.Debugf("job %q triggered by %q", name, job.Name)
+		next.Execution.trigger = true
+		next.signal()
+	}
+}
+
+// Pipelines returns all groups of jobs that depend on or trigger each other.
+func (s *Manager) Pipelines() []Pipeline {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// union find over all relations
+	group := map[string]string{}
+
+	var find func(name string) string
+	find = func(name string) string {
+		if group[name] == "" || group[name] == name {
+			return name
+		}
+
+		return find(group[name])
+	}
+
+	for _, job := range s.jobs {
+		for _, related := range append(append([]string{}, job.DependsOn...), job.Triggers...) {
+			group[find(related)] = find(job.Name)
+		}
+	}
+
+	pipelines := map[string]*Pipeline{}
+	order := []string{}
+
+	for _, name := range s.order {
+		job := s.jobs[name]
+
+		if len(job.DependsOn) == 0 && len(job.Triggers) == 0 && len(edges(s.jobs, job)) == 0 {
+			// not part of a pipeline
+			continue
+		}
+
+		root := find(name)
+		pipeline, ok := pipelines[root]
+
+		if !ok {
+			pipeline = &Pipeline{Status: PipelineOK}
+			pipelines[root] = pipeline
+			order = append(order, root)
+		}
+
+		pipeline.Jobs = append(pipeline.Jobs, name)
+		pipeline.Status = worseStatus(pipeline.Status, jobStatus(job))
+	}
+
+	result := []Pipeline{}
+
+	for _, root := range order {
+		result = append(result, *pipelines[root])
+	}
+
+	return result
+}
+
+func jobStatus(job *Job) string {
+	switch {
+	case job.Execution.State == StateRunning:
+		return PipelineRunning
+	case job.Execution.Failures > 0:
+		return PipelineFailed
+	case job.Execution.BlockedBy != "":
+		return PipelineBlocked
+	default:
+		return PipelineOK
+	}
+}
+
+var statusSeverity = map[string]int{
+	PipelineOK:      0,
+	PipelineBlocked: 1,
+	PipelineRunning: 2,
+	PipelineFailed:  3,
+}
+
+func worseStatus(a, b string) string {
+	if statusSeverity[b] > statusSeverity[a] {
+		return b
+	}
+
+	return a
+}
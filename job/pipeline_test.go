@@ -0,0 +1,100 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores/test"
+)
+
+func TestResolveOrder(t *testing.T) {
+	jobs := map[string]*Job{
+		"Enrichment":  {Name: "Enrichment", DependsOn: []string{"Tournaments"}},
+		"Tournaments": {Name: "Tournaments", DependsOn: []string{"Players"}},
+		"Players":     {Name: "Players", Triggers: []string{"Tournaments"}},
+		"Cleanup":     {Name: "Cleanup"},
+	}
+
+	order, err := resolveOrder(jobs)
+	test.Check(t, "resolveOrder() failed: %v", err)
+	test.Compare(t, "order differs:\n%s", []string{"Cleanup", "Players", "Tournaments", "Enrichment"}, order)
+
+	jobs["Players"].DependsOn = []string{"Enrichment"}
+
+	_, err = resolveOrder(jobs)
+	test.Assert(t, "resolveOrder() should detect the cycle", err != nil)
+
+	_, err = resolveOrder(map[string]*Job{"A": {Name: "A", DependsOn: []string{"B"}}})
+	test.Assert(t, "resolveOrder() should detect unknown jobs", err != nil)
+}
+
+func TestPipeline(t *testing.T) {
+	manager := NewManager(logrus.New())
+	fail := true
+	tournamentRuns := make(chan struct{}, 10)
+
+	err := manager.Start(
+		Job{
+			Name:     "Players",
+			Interval: time.Hour,
+			Triggers: []string{"Tournaments"},
+
+			Do: func(ctx context.Context) error {
+				if fail {
+					return errors.New("ladder unavailable")
+				}
+
+				return nil
+			},
+		},
+		Job{
+			Name:      "Tournaments",
+			Interval:  time.Hour,
+			DependsOn: []string{"Players"},
+
+			Do: func(ctx context.Context) error {
+				tournamentRuns <- struct{}{}
+				return nil
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed: %v", err)
+
+	waitForRuns(t, manager, "Players", 1)
+
+	for i := 0; i < 100; i++ {
+		if j, _ := manager.Job("Tournaments"); j.Execution.BlockedBy != "" {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	j, _ := manager.Job("Tournaments")
+	test.Assert(t, "want tournaments to be blocked by the failed players job", j.Execution.BlockedBy != "" && j.Execution.Runs == 0)
+	test.Compare(t, "pipelines differ:\n%s", []Pipeline{{Jobs: []string{"Players", "Tournaments"}, Status: PipelineFailed}}, manager.Pipelines())
+
+	manager.lock.Lock()
+	fail = false
+	manager.lock.Unlock()
+
+	err = manager.Run("Players")
+	test.Check(t, "manager.Run() failed: %v", err)
+
+	select {
+	case <-tournamentRuns:
+	case <-time.After(time.Second):
+		t.Fatal("tournaments were not triggered after players succeeded")
+	}
+
+	waitForRuns(t, manager, "Tournaments", 1)
+	test.Compare(t, "pipelines differ:\n%s", []Pipeline{{Jobs: []string{"Players", "Tournaments"}, Status: PipelineOK}}, manager.Pipelines())
+
+	err = manager.Shutdown(context.Background())
+	test.Check(t, "manager.Shutdown() failed: %v", err)
+}
@@ -4,7 +4,7 @@ package job
 type State int
 
 const (
-	// StateStopped is set if a job was explicitly stopped
+	// StateStopped is set if a job was explicitly stopped or has reached its max. runs
 	StateStopped State = iota
 	// StateWaiting is set if a job is in the queue waiting to be run
 	StateWaiting
@@ -12,6 +12,8 @@ const (
 	StateRunning
 	// StateErrored is set if a job is in an error state
 	StateErrored
+	// StatePaused is set if scheduled runs of a job are suspended until it is resumed
+	StatePaused
 )
 
 // String returns the State alias
@@ -25,7 +27,14 @@ func (s State) String() string {
 		return "running"
 	case StateErrored:
 		return "errored"
+	case StatePaused:
+		return "paused"
 	default:
 		return "unknown"
 	}
 }
+
+// MarshalText returns the State alias, this makes states readable in reports
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -0,0 +1,15 @@
+package scores
+
+import "time"
+
+// JobExecution is a record of a single run of a background job.
+type JobExecution struct {
+	M
+	Track
+	JobName string    `json:"jobName" db:"job_name"`
+	Start   time.Time `json:"start" db:"start_time"`
+	End     time.Time `json:"end" db:"end_time"`
+	Success bool      `json:"success"`
+	Error   string    `json:"error"`
+	Summary string    `json:"summary"` // e.g. the # of changes of a sync job
+}
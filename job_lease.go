@@ -0,0 +1,11 @@
+package scores
+
+import "time"
+
+// JobLease grants an instance (the owner) the exclusive right to run a
+// job until the lease expires, it has to be renewed before that.
+type JobLease struct {
+	JobName   string    `json:"jobName" db:"job_name"`
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt" db:"expires_at"`
+}
@@ -0,0 +1,197 @@
+// Package ladder reproduces volleynet's ladder points from stored results.
+//
+// A player's ladder points are the sum of the points of the best results
+// of the player within a validity window. The points of a placement depend
+// on the sub league of the tournament, they are learned from the points
+// that have been awarded in the stored results.
+package ladder
+
+import (
+	"sort"
+	"time"
+
+	"github.com/raphi011/scores/volleynet"
+)
+
+// Default parameters of the calculator.
+const (
+	DefaultBestResults = 6
+	DefaultWindow      = 365 * 24 * time.Hour
+)
+
+// Calculator calculates the ladder points of players.
+type Calculator struct {
+	// BestResults is the # of results that count, the others are ignored.
+	BestResults int
+	// Window is how long a result counts after the start of its tournament.
+	Window time.Duration
+
+	// table are the points per sub league and placement
+	table   map[string]map[int]int
+	results []*volleynet.TeamResult
+}
+
+// NewCalculator returns a calculator with the default parameters that
+// learns the points per placement from `results`.
+func NewCalculator(results []*volleynet.TeamResult) *Calculator {
+	return &Calculator{
+		BestResults: DefaultBestResults,
+		Window:      DefaultWindow,
+		table:       Table(results),
+		results:     results,
+	}
+}
+
+// Table returns the points per sub league and placement, if different
+// points have been awarded for a placement the most frequent points
+// (the highest on a tie) are used.
+func Table(results []*volleynet.TeamResult) map[string]map[int]int {
+	counts := map[string]map[int]map[int]int{}
+
+	for _, r := range results {
+		if counts[r.SubLeagueKey] == nil {
+			counts[r.SubLeagueKey] = map[int]map[int]int{}
+		}
+
+		if counts[r.SubLeagueKey][r.Result] == nil {
+			counts[r.SubLeagueKey][r.Result] = map[int]int{}
+		}
+
+		counts[r.SubLeagueKey][r.Result][r.WonPoints]++
+	}
+
+	table := map[string]map[int]int{}
+
+	for subLeague, placements := range counts {
+		table[subLeague] = map[int]int{}
+
+		for result, points := range placements {
+			best, frequency := 0, 0
+
+			for p, n := range points {
+				if n > frequency || (n == frequency && p > best) {
+					best, frequency = p, n
+				}
+			}
+
+			table[subLeague][result] = best
+		}
+	}
+
+	return table
+}
+
+// Points returns the points of a placement in a tournament of the sub league.
+// Placements are shared (e.g. two teams finish 5th), a placement that has
+// not been awarded yet gets the points of the next better known placement.
+// False is returned if no such placement is known.
+func (c *Calculator) Points(subLeague string, result int) (int, bool) {
+	placements := c.table[subLeague]
+	best := 0
+
+	for r := range placements {
+		if r <= result && r > best {
+			best = r
+		}
+	}
+
+	if best == 0 {
+		return 0, false
+	}
+
+	return placements[best], true
+}
+
+// Result is a result of a player that counts for the ladder.
+type Result struct {
+	TournamentID int       `json:"tournamentId"`
+	Start        time.Time `json:"start"`
+	Result       int       `json:"result"`
+	Points       int       `json:"points"`
+}
+
+// Ladder returns the ladder points of all players at `at`, additional
+// results that haven't been stored (yet) can be passed as `extra`.
+func (c *Calculator) Ladder(at time.Time, extra ...*volleynet.TeamResult) map[int]int {
+	ladder := map[int]int{}
+
+	for playerID, results := range c.playerResults(at, extra) {
+		ladder[playerID] = sum(c.best(results))
+	}
+
+	return ladder
+}
+
+// Counting returns the results of a player that count at `at`, the best first.
+func (c *Calculator) Counting(playerID int, at time.Time, extra ...*volleynet.TeamResult) []*Result {
+	return c.best(c.playerResults(at, extra)[playerID])
+}
+
+// playerResults returns the results per player that are valid at `at`.
+func (c *Calculator) playerResults(at time.Time, extra []*volleynet.TeamResult) map[int][]*Result {
+	players := map[int][]*Result{}
+	from := at.Add(-c.Window)
+
+	for _, results := range [][]*volleynet.TeamResult{c.results, extra} {
+		for _, r := range results {
+			if !r.TournamentStart.After(from) || r.TournamentStart.After(at) {
+				continue
+			}
+
+			result := &Result{
+				TournamentID: r.TournamentID,
+				Start:        r.TournamentStart,
+				Result:       r.Result,
+				Points:       r.WonPoints,
+			}
+
+			players[r.Player1ID] = append(players[r.Player1ID], result)
+
+			if r.Player2ID != r.Player1ID {
+				players[r.Player2ID] = append(players[r.Player2ID], result)
+			}
+		}
+	}
+
+	return players
+}
+
+// best returns the `BestResults` results with the most points.
+func (c *Calculator) best(results []*Result) []*Result {
+	best := make([]*Result, len(results))
+	copy(best, results)
+
+	sort.SliceStable(best, func(i, j int) bool {
+		return best[i].Points > best[j].Points
+	})
+
+	if len(best) > c.BestResults {
+		best = best[:c.BestResults]
+	}
+
+	return best
+}
+
+func sum(results []*Result) int {
+	points := 0
+
+	for _, r := range results {
+		points += r.Points
+	}
+
+	return points
+}
+
+// Rank returns the rank of `points` within the ladder points of `players`,
+// players with the same points share a rank.
+func Rank(points int, players []int) int {
+	rank := 1
+
+	for _, p := range players {
+		if p > points {
+			rank++
+		}
+	}
+
+	return rank
+}
@@ -0,0 +1,95 @@
+package ladder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores/volleynet"
+)
+
+func result(tournamentID int, start time.Time, subLeague string, player1ID, player2ID, result, points int) *volleynet.TeamResult {
+	return &volleynet.TeamResult{
+		TournamentID:    tournamentID,
+		TournamentStart: start,
+		SubLeagueKey:    subLeague,
+		Player1ID:       player1ID,
+		Player2ID:       player2ID,
+		Result:          result,
+		WonPoints:       points,
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTable(t *testing.T) {
+	c := NewCalculator([]*volleynet.TeamResult{
+		result(1, date(2019, 6, 1), "amateur-league", 1, 2, 1, 40),
+		result(1, date(2019, 6, 1), "amateur-league", 3, 4, 2, 36),
+		result(1, date(2019, 6, 1), "amateur-league", 5, 6, 5, 25),
+		result(2, date(2019, 6, 8), "amateur-league", 1, 3, 1, 40),
+		result(3, date(2019, 6, 8), "amateur-league", 1, 3, 1, 30), // e.g. a corrected table
+		result(4, date(2019, 6, 8), "pro-league", 1, 3, 1, 100),
+	})
+
+	tests := []struct {
+		subLeague string
+		result    int
+		points    int
+		ok        bool
+	}{
+		{"amateur-league", 1, 40, true},
+		{"amateur-league", 2, 36, true},
+		{"amateur-league", 7, 25, true}, // shares the 5th place
+		{"pro-league", 1, 100, true},
+		{"pro-league", 3, 100, true},
+		{"junior-league", 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		points, ok := c.Points(tt.subLeague, tt.result)
+
+		if points != tt.points || ok != tt.ok {
+			t.Errorf("Calculator.Points(%q, %d), want %d (%t), got %d (%t)",
+				tt.subLeague, tt.result, tt.points, tt.ok, points, ok)
+		}
+	}
+}
+
+func TestLadder(t *testing.T) {
+	c := NewCalculator([]*volleynet.TeamResult{
+		result(1, date(2018, 5, 1), "amateur-league", 1, 2, 1, 50), // expired
+		result(2, date(2019, 1, 1), "amateur-league", 1, 2, 1, 10),
+		result(3, date(2019, 2, 1), "amateur-league", 1, 3, 1, 20),
+		result(4, date(2019, 3, 1), "amateur-league", 1, 3, 1, 30),
+		result(5, date(2019, 7, 1), "amateur-league", 1, 3, 1, 40), // not played yet
+	})
+
+	c.BestResults = 2
+
+	ladder := c.Ladder(date(2019, 6, 1))
+
+	if ladder[1] != 50 || ladder[2] != 10 || ladder[3] != 50 {
+		t.Fatalf("Calculator.Ladder(), want the best 2 valid results, got %v", ladder)
+	}
+
+	extra := result(6, date(2019, 6, 1), "amateur-league", 2, 2, 1, 25)
+	ladder = c.Ladder(date(2019, 6, 1), extra)
+
+	if ladder[2] != 35 {
+		t.Fatalf("Calculator.Ladder() with an extra result, want 35 points, got %d", ladder[2])
+	}
+
+	counting := c.Counting(2, date(2019, 6, 1), extra)
+
+	if len(counting) != 2 || counting[0].TournamentID != 6 || counting[1].TournamentID != 2 {
+		t.Fatalf("Calculator.Counting(), want tournaments 6 and 2, got %+v", counting)
+	}
+}
+
+func TestRank(t *testing.T) {
+	if rank := Rank(30, []int{50, 30, 10, 40}); rank != 3 {
+		t.Fatalf("Rank(), want 3, got %d", rank)
+	}
+}
@@ -0,0 +1,14 @@
+package scores
+
+// NotificationLog is a record of a notification that was sent
+// (or failed to be sent) to a user.
+type NotificationLog struct {
+	M
+	Track
+	UserID        int    `json:"userId" db:"user_id"`
+	Channel       string `json:"channel"`
+	Recipient     string `json:"recipient"`
+	Subject       string `json:"subject"`
+	Notifications int    `json:"notifications"` // # of notifications that were bundled
+	Error         string `json:"error"`
+}
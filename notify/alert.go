@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"fmt"
+	"math"
+	"path"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+const earthRadius = 6371 // km
+
+// ruleMatches returns true if the event satisfies all criteria of the rule.
+func ruleMatches(rule *scores.AlertRule, eventName string, event *sync.TournamentEvent) bool {
+	t := event.Tournament
+
+	if rule.Event != "" && rule.Event != path.Base(eventName) {
+		return false
+	}
+
+	if rule.League != "" && rule.League != t.LeagueKey {
+		return false
+	}
+
+	if rule.Gender != "" && rule.Gender != t.Gender {
+		return false
+	}
+
+	if rule.PlayerID > 0 && !participates(rule.PlayerID, t, event.Team) {
+		return false
+	}
+
+	if rule.Radius > 0 {
+		// tournaments without a location are never close
+		if t.Latitude == 0 && t.Longitude == 0 {
+			return false
+		}
+
+		d := distance(rule.Latitude, rule.Longitude, t.Latitude, t.Longitude)
+
+		if d > float64(rule.Radius) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// participates returns true if the player is part of the team or, if the
+// event is not about a single team, of any team of the tournament.
+func participates(playerID int, tournament *volleynet.Tournament, team *volleynet.TournamentTeam) bool {
+	if team != nil {
+		return team.HasPlayer(playerID)
+	}
+
+	for _, t := range tournament.Teams {
+		if t.HasPlayer(playerID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eventKey identifies an event, rules are triggered only once per key.
+func eventKey(eventName string, event *sync.TournamentEvent) string {
+	key := fmt.Sprintf("%s/%d", path.Base(eventName), event.Tournament.ID)
+
+	if team := event.Team; team != nil && team.Player1 != nil && team.Player2 != nil {
+		key += fmt.Sprintf("/%d-%d", team.Player1.ID, team.Player2.ID)
+	}
+
+	return key
+}
+
+// distance calculates the great-circle distance in km between two
+// coordinates with the haversine formula.
+func distance(lat1, lon1, lat2, lon2 float32) float64 {
+	φ1 := radians(lat1)
+	φ2 := radians(lat2)
+	Δφ := radians(lat2 - lat1)
+	Δλ := radians(lon2 - lon1)
+
+	a := math.Sin(Δφ/2)*math.Sin(Δφ/2) +
+		math.Cos(φ1)*math.Cos(φ2)*math.Sin(Δλ/2)*math.Sin(Δλ/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+func radians(degrees float32) float64 {
+	return float64(degrees) * math.Pi / 180
+}
@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+func TestRuleMatches(t *testing.T) {
+	vienna := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{LeagueKey: "pro-tour", Gender: "M"},
+		Latitude:       48.21,
+		Longitude:      16.37,
+	}
+	graz := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{LeagueKey: "pro-tour", Gender: "M"},
+		Latitude:       47.07,
+		Longitude:      15.44,
+	}
+
+	nearVienna := &scores.AlertRule{
+		Event:     "registration-open",
+		League:    "pro-tour",
+		Latitude:  48.3,
+		Longitude: 16.3,
+		Radius:    50,
+	}
+
+	tests := []struct {
+		name       string
+		rule       *scores.AlertRule
+		event      string
+		tournament *volleynet.Tournament
+		want       bool
+	}{
+		{"within radius", nearVienna, sync.RegistrationOpenEventType, vienna, true},
+		{"outside radius", nearVienna, sync.RegistrationOpenEventType, graz, false},
+		{"other event", nearVienna, sync.ResultsEventType, vienna, false},
+		{"no location", nearVienna, sync.RegistrationOpenEventType, &volleynet.Tournament{TournamentInfo: vienna.TournamentInfo}, false},
+		{"other gender", &scores.AlertRule{Gender: "W"}, sync.ResultsEventType, vienna, false},
+		{"empty rule", &scores.AlertRule{}, sync.ResultsEventType, graz, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ruleMatches(tt.rule, tt.event, &sync.TournamentEvent{Tournament: tt.tournament})
+
+			if got != tt.want {
+				t.Errorf("ruleMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/events"
+	"github.com/raphi011/scores/services"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+// Dispatcher listens to tournament sync events, figures out which users
+// are interested in them (or have an alert rule that matches) and notifies
+// them via all channels. The outcome of a scheduled signup is only sent to
+// the user of the signup.
+type Dispatcher struct {
+	Log          logrus.FieldLogger
+	UserService  *services.User
+	AlertService *services.Alert // optional
+
+	Channels []Channel
+}
+
+// Listen subscribes to all tournament and signup events and dispatches
+// them until unsubscribed.
+func (d *Dispatcher) Listen(subscriber events.Subscriber) events.Unsubscribe {
+	unsubscribes := []events.Unsubscribe{}
+
+	for _, name := range []string{sync.TournamentEventsType, services.SignupEventsType} {
+		subscription, unsubscribe := subscriber.Subscribe(name)
+		unsubscribes = append(unsubscribes, unsubscribe)
+
+		go func() {
+			for event := range subscription {
+				err := d.Dispatch(context.Background(), event)
+
+				if err != nil {
+					d.Log.Warnf("dispatching event %q failed: %v", event.Name, err)
+				}
+			}
+		}()
+	}
+
+	return func() {
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}
+}
+
+// Dispatch notifies all users that are interested in the event.
+func (d *Dispatcher) Dispatch(ctx context.Context, event events.Event) error {
+	var recipients []*scores.User
+	var notification *Notification
+	var err error
+
+	switch body := event.Body.(type) {
+	case sync.TournamentEvent:
+		recipients, err = d.recipients(ctx, event.Name, &body)
+		notification = &Notification{
+			Type:       event.Name,
+			Tournament: body.Tournament,
+			Team:       body.Team,
+		}
+	case services.SignupEvent:
+		var user *scores.User
+		user, err = d.UserService.ByID(ctx, body.Signup.UserID)
+		recipients = []*scores.User{user}
+		notification = &Notification{
+			Type:       event.Name,
+			Tournament: body.Tournament,
+			Signup:     body.Signup,
+		}
+	default:
+		return errors.Errorf("unexpected event body %T", event.Body)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "loading recipients")
+	}
+
+	for _, user := range recipients {
+		for _, channel := range d.Channels {
+			err := channel.Notify(user, notification)
+
+			if err != nil {
+				d.Log.Warnf("notifying user %d via %s failed: %v", user.ID, channel.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) recipients(ctx context.Context, eventName string, event *sync.TournamentEvent) ([]*scores.User, error) {
+	users, err := d.UserService.All(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := []*scores.User{}
+	seen := map[int]bool{}
+	byID := map[int]*scores.User{}
+
+	for _, user := range users {
+		byID[user.ID] = user
+
+		if isInterested(user, eventName, event) {
+			recipients = append(recipients, user)
+			seen[user.ID] = true
+		}
+	}
+
+	alerted, err := d.alertRecipients(ctx, eventName, event)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// a user is notified only once, even if several rules match
+	for _, userID := range alerted {
+		user, ok := byID[userID]
+
+		if seen[userID] || !ok {
+			continue
+		}
+
+		recipients = append(recipients, user)
+		seen[userID] = true
+	}
+
+	return recipients, nil
+}
+
+// alertRecipients returns the ids of all users that have an alert rule which
+// matches the event and has not been triggered by it before.
+func (d *Dispatcher) alertRecipients(ctx context.Context, eventName string, event *sync.TournamentEvent) ([]int, error) {
+	if d.AlertService == nil {
+		return nil, nil
+	}
+
+	rules, err := d.AlertService.AllRules(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key := eventKey(eventName, event)
+	userIDs := []int{}
+
+	for _, rule := range rules {
+		if !ruleMatches(rule, eventName, event) {
+			continue
+		}
+
+		triggered, err := d.AlertService.Trigger(ctx, rule.ID, key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if triggered {
+			userIDs = append(userIDs, rule.UserID)
+		}
+	}
+
+	return userIDs, nil
+}
+
+func isInterested(user *scores.User, eventName string, event *sync.TournamentEvent) bool {
+	switch eventName {
+	case sync.RegistrationOpenEventType:
+		return followsTournaments(user, event.Tournament)
+	case sync.TeamRegisteredEventType, sync.TeamMainDrawEventType:
+		return isTeamMember(user, event.Team)
+	case sync.ResultsEventType:
+		for _, team := range event.Tournament.Teams {
+			if isTeamMember(user, team) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isTeamMember returns true if the user has linked a player of the team.
+func isTeamMember(user *scores.User, team *volleynet.TournamentTeam) bool {
+	if user.PlayerID == 0 || team == nil {
+		return false
+	}
+
+	return team.HasPlayer(user.PlayerID)
+}
+
+// followsTournaments returns true if the user's last used tournament filter
+// includes the league and gender of the tournament.
+func followsTournaments(user *scores.User, tournament *volleynet.Tournament) bool {
+	return settingContains(user.Settings, "tournament-filter-league", tournament.LeagueKey) &&
+		settingContains(user.Settings, "tournament-filter-gender", tournament.Gender)
+}
+
+func settingContains(settings scores.Settings, key, value string) bool {
+	values, ok := settings[key].([]string)
+
+	if !ok {
+		return false
+	}
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,164 @@
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/events"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql"
+	"github.com/raphi011/scores/services"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+type channelMock struct {
+	notified []int
+}
+
+func (c *channelMock) Name() string {
+	return "mock"
+}
+
+func (c *channelMock) Notify(user *scores.User, notification *Notification) error {
+	c.notified = append(c.notified, user.ID)
+
+	return nil
+}
+
+func dispatcherMock(t *testing.T) (*Dispatcher, *channelMock, *services.User) {
+	repos, db := sql.RepositoriesTest(t)
+
+	sql.CreatePlayers(t, db, sql.P{ID: 1}, sql.P{ID: 2}, sql.P{ID: 3})
+
+	channel := &channelMock{}
+	userService := &services.User{
+		Repo:        repos.UserRepo,
+		PlayerRepo:  repos.PlayerRepo,
+		SettingRepo: repos.SettingRepo,
+		Password:    &services.PBKDF2Password{SaltBytes: 16, Iterations: 1},
+	}
+
+	return &Dispatcher{
+		Log:          logrus.New(),
+		UserService:  userService,
+		AlertService: &services.Alert{Repo: repos.AlertRuleRepo},
+		Channels:     []Channel{channel},
+	}, channel, userService
+}
+
+func newUser(t *testing.T, userService *services.User, email string, playerID int) *scores.User {
+	user, err := userService.New(context.Background(), email, "test", "user")
+	test.Check(t, "userService.New() failed: %v", err)
+
+	err = userService.SetVolleynetLogin(context.Background(), user.ID, playerID, email)
+	test.Check(t, "userService.SetVolleynetLogin() failed: %v", err)
+
+	return user
+}
+
+func TestDispatchTeamRegistered(t *testing.T) {
+	dispatcher, channel, userService := dispatcherMock(t)
+
+	player1 := newUser(t, userService, "1@test.at", 1)
+	newUser(t, userService, "3@test.at", 3)
+
+	err := dispatcher.Dispatch(context.Background(), events.Event{
+		Name: sync.TeamRegisteredEventType,
+		Body: sync.TournamentEvent{
+			Tournament: &volleynet.Tournament{},
+			Team: &volleynet.TournamentTeam{
+				Player1: &volleynet.Player{ID: 1},
+				Player2: &volleynet.Player{ID: 2},
+			},
+		},
+	})
+
+	test.Check(t, "dispatcher.Dispatch() failed: %v", err)
+	test.Compare(t, "notified users differ:\n%s", []int{player1.ID}, channel.notified)
+}
+
+func TestDispatchRegistrationOpen(t *testing.T) {
+	dispatcher, channel, userService := dispatcherMock(t)
+
+	user := newUser(t, userService, "1@test.at", 1)
+	newUser(t, userService, "2@test.at", 2)
+
+	err := userService.UpdateTournamentFilter(context.Background(), user.ID, repo.TournamentFilter{
+		Seasons: []string{"2019"},
+		Leagues: []string{"amateur-tour"},
+		Genders: []string{"M"},
+	})
+	test.Check(t, "userService.UpdateTournamentFilter() failed: %v", err)
+
+	err = dispatcher.Dispatch(context.Background(), events.Event{
+		Name: sync.RegistrationOpenEventType,
+		Body: sync.TournamentEvent{
+			Tournament: &volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{
+				LeagueKey: "amateur-tour",
+				Gender:    "M",
+			}},
+		},
+	})
+
+	test.Check(t, "dispatcher.Dispatch() failed: %v", err)
+	test.Compare(t, "notified users differ:\n%s", []int{user.ID}, channel.notified)
+}
+
+func TestDispatchAlertRule(t *testing.T) {
+	dispatcher, channel, userService := dispatcherMock(t)
+
+	player1 := newUser(t, userService, "1@test.at", 1)
+	fan := newUser(t, userService, "fan@test.at", 3)
+
+	// both rules match, the fan is notified once
+	for _, rule := range []*scores.AlertRule{
+		{UserID: fan.ID, PlayerID: 1},
+		{UserID: fan.ID, Event: "team-registered"},
+	} {
+		_, err := dispatcher.AlertService.Create(context.Background(), rule)
+		test.Check(t, "alertService.Create() failed: %v", err)
+	}
+
+	event := events.Event{
+		Name: sync.TeamRegisteredEventType,
+		Body: sync.TournamentEvent{
+			Tournament: &volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{ID: 1}},
+			Team: &volleynet.TournamentTeam{
+				Player1: &volleynet.Player{ID: 1},
+				Player2: &volleynet.Player{ID: 2},
+			},
+		},
+	}
+
+	err := dispatcher.Dispatch(context.Background(), event)
+	test.Check(t, "dispatcher.Dispatch() failed: %v", err)
+	test.Compare(t, "notified users differ:\n%s", []int{player1.ID, fan.ID}, channel.notified)
+
+	// rules are only triggered once per event
+	err = dispatcher.Dispatch(context.Background(), event)
+	test.Check(t, "dispatcher.Dispatch() failed: %v", err)
+	test.Compare(t, "notified users differ:\n%s", []int{player1.ID, fan.ID, player1.ID}, channel.notified)
+}
+
+func TestDispatchSignup(t *testing.T) {
+	dispatcher, channel, userService := dispatcherMock(t)
+
+	newUser(t, userService, "1@test.at", 1)
+	user := newUser(t, userService, "2@test.at", 2)
+
+	err := dispatcher.Dispatch(context.Background(), events.Event{
+		Name: services.SignupSucceededEventType,
+		Body: services.SignupEvent{
+			Tournament: &volleynet.Tournament{},
+			Signup:     &volleynet.ScheduledSignup{UserID: user.ID, PartnerID: 1},
+		},
+	})
+
+	test.Check(t, "dispatcher.Dispatch() failed: %v", err)
+	test.Compare(t, "notified users differ:\n%s", []int{user.ID}, channel.notified)
+}
@@ -0,0 +1,25 @@
+package notify
+
+import (
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// Notification informs a user about a change of a tournament he/she
+// is interested in.
+type Notification struct {
+	Type       string                    `json:"type"` // name of the event that caused the notification
+	Tournament *volleynet.Tournament     `json:"tournament"`
+	Team       *volleynet.TournamentTeam `json:"team,omitempty"`
+	// Signup is the scheduled signup of the user for `signup/*` notifications.
+	Signup *volleynet.ScheduledSignup `json:"signup,omitempty"`
+}
+
+// Channel delivers notifications to users, e.g. via telegram.
+type Channel interface {
+	// Name of the channel, useful in logs
+	Name() string
+	// Notify sends the notification to the user, if the user has not
+	// enabled the channel it does nothing.
+	Notify(user *scores.User, notification *Notification) error
+}
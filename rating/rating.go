@@ -0,0 +1,181 @@
+// Package rating rates players by their final placements in tournaments.
+//
+// It's an Elo rating where a tournament is treated as a round robin of all
+// teams of its field: every team wins against all teams that finished behind
+// it, loses against all teams that finished ahead of it and draws with teams
+// that share its placement. A team's rating is the average rating of its
+// players and both players receive the change of the team's rating.
+package rating
+
+import (
+	"math"
+
+	"github.com/raphi011/scores/volleynet"
+)
+
+// Default parameters of the engine.
+const (
+	DefaultInitial                = 1500
+	DefaultK                      = 32
+	DefaultProvisionalK           = 64
+	DefaultProvisionalTournaments = 5
+)
+
+// Engine keeps the current ratings of all players and rates tournaments.
+type Engine struct {
+	Initial float64 // rating of a new player
+	// K is the max. change of a rating by one tournament, players that
+	// have played less than `ProvisionalTournaments` use `ProvisionalK`
+	// so their rating converges faster.
+	K                      float64
+	ProvisionalK           float64
+	ProvisionalTournaments int
+
+	ratings map[int]*volleynet.Rating
+}
+
+// NewEngine returns an engine with the default parameters that continues
+// with the `current` ratings.
+func NewEngine(current []*volleynet.Rating) *Engine {
+	e := &Engine{
+		Initial:                DefaultInitial,
+		K:                      DefaultK,
+		ProvisionalK:           DefaultProvisionalK,
+		ProvisionalTournaments: DefaultProvisionalTournaments,
+		ratings:                map[int]*volleynet.Rating{},
+	}
+
+	for _, r := range current {
+		e.ratings[r.PlayerID] = r
+	}
+
+	return e
+}
+
+// Rating returns the current rating of a player.
+func (e *Engine) Rating(playerID int) float64 {
+	if r, ok := e.ratings[playerID]; ok {
+		return r.Rating
+	}
+
+	return e.Initial
+}
+
+// tournaments returns the # of rated tournaments of a player.
+func (e *Engine) tournaments(playerID int) int {
+	if r, ok := e.ratings[playerID]; ok {
+		return r.Tournaments
+	}
+
+	return 0
+}
+
+func (e *Engine) k(playerID int) float64 {
+	if e.tournaments(playerID) < e.ProvisionalTournaments {
+		return e.ProvisionalK
+	}
+
+	return e.K
+}
+
+// Field returns the teams of a tournament's results that can be rated,
+// teams with a player that is part of another team are ignored.
+func Field(results []*volleynet.TeamResult) []*volleynet.TeamResult {
+	teams := []*volleynet.TeamResult{}
+	players := map[int]bool{}
+
+	for _, t := range results {
+		if players[t.Player1ID] || players[t.Player2ID] || t.Player1ID == t.Player2ID {
+			continue
+		}
+
+		players[t.Player1ID] = true
+		players[t.Player2ID] = true
+		teams = append(teams, t)
+	}
+
+	return teams
+}
+
+// Rate rates the results of one tournament and returns the new ratings
+// of its players, tournaments with a field of less than two teams can't
+// be rated.
+func (e *Engine) Rate(results []*volleynet.TeamResult) []*volleynet.Rating {
+	teams := Field(results)
+
+	if len(teams) < 2 {
+		return nil
+	}
+
+	teamRatings := make([]float64, len(teams))
+
+	for i, t := range teams {
+		teamRatings[i] = (e.Rating(t.Player1ID) + e.Rating(t.Player2ID)) / 2
+	}
+
+	ratings := []*volleynet.Rating{}
+	opponents := float64(len(teams) - 1)
+
+	for i, t := range teams {
+		score, expected := 0.0, 0.0
+
+		for j, opponent := range teams {
+			if i == j {
+				continue
+			}
+
+			score += outcome(t.Result, opponent.Result)
+			expected += Expected(teamRatings[i], teamRatings[j])
+		}
+
+		performance := (score - expected) / opponents
+
+		for _, playerID := range []int{t.Player1ID, t.Player2ID} {
+			delta := e.k(playerID) * performance
+
+			r := &volleynet.Rating{
+				PlayerID:        playerID,
+				TournamentID:    t.TournamentID,
+				TournamentStart: t.TournamentStart,
+				Rating:          round(e.Rating(playerID) + delta),
+				Delta:           round(delta),
+				Tournaments:     e.tournaments(playerID) + 1,
+			}
+
+			ratings = append(ratings, r)
+		}
+	}
+
+	// the ratings are updated after all teams have been rated so every
+	// team is rated against the ratings before the tournament
+	for _, r := range ratings {
+		e.ratings[r.PlayerID] = r
+	}
+
+	return ratings
+}
+
+// Expected returns the expected score (between 0 and 1) of a team with
+// rating `a` against a team with rating `b`.
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// outcome returns the score of a team with placement `result` against
+// a team with placement `opponent`, lower placements are better.
+func outcome(result, opponent int) float64 {
+	switch {
+	case result < opponent:
+		return 1
+	case result > opponent:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+// round rounds a rating to two decimals so it's stored
+// and compared the same way by all providers.
+func round(rating float64) float64 {
+	return math.Round(rating*100) / 100
+}
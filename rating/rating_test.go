@@ -0,0 +1,151 @@
+package rating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores/volleynet"
+)
+
+var start = time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func result(tournamentID, player1ID, player2ID, result int) *volleynet.TeamResult {
+	return &volleynet.TeamResult{
+		TournamentID:    tournamentID,
+		TournamentStart: start,
+		Player1ID:       player1ID,
+		Player2ID:       player2ID,
+		Result:          result,
+	}
+}
+
+func ratingOf(ratings []*volleynet.Rating, playerID int) *volleynet.Rating {
+	for _, r := range ratings {
+		if r.PlayerID == playerID {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func TestRate(t *testing.T) {
+	e := NewEngine(nil)
+
+	ratings := e.Rate([]*volleynet.TeamResult{
+		result(1, 1, 2, 1),
+		result(1, 3, 4, 2),
+		result(1, 5, 6, 3),
+	})
+
+	if len(ratings) != 6 {
+		t.Fatalf("Rate(), want 6 ratings, got %d", len(ratings))
+	}
+
+	winner, second, last := ratingOf(ratings, 1), ratingOf(ratings, 3), ratingOf(ratings, 5)
+
+	if winner.Delta != 32 || winner.Rating != 1532 {
+		t.Errorf("Rate(), want the winner to gain 32 points, got %+v", winner)
+	}
+
+	if second.Delta != 0 {
+		t.Errorf("Rate(), want the second team to keep its rating, got %+v", second)
+	}
+
+	if last.Delta != -32 {
+		t.Errorf("Rate(), want the last team to lose 32 points, got %+v", last)
+	}
+
+	if ratingOf(ratings, 2).Rating != winner.Rating {
+		t.Errorf("Rate(), want both players of a team to get the same change")
+	}
+
+	if winner.Tournaments != 1 || winner.TournamentID != 1 || !winner.TournamentStart.Equal(start) {
+		t.Errorf("Rate(), want the rating of tournament 1, got %+v", winner)
+	}
+
+	if e.Rating(1) != 1532 {
+		t.Errorf("Rating(), want the engine to keep the new rating 1532, got %v", e.Rating(1))
+	}
+}
+
+func TestRateExperiencedPlayers(t *testing.T) {
+	current := []*volleynet.Rating{}
+
+	for playerID := 1; playerID <= 4; playerID++ {
+		current = append(current, &volleynet.Rating{PlayerID: playerID, Rating: 1500, Tournaments: DefaultProvisionalTournaments})
+	}
+
+	e := NewEngine(current)
+
+	ratings := e.Rate([]*volleynet.TeamResult{
+		result(1, 1, 2, 1),
+		result(1, 3, 4, 2),
+	})
+
+	if r := ratingOf(ratings, 1); r.Delta != 16 || r.Tournaments != DefaultProvisionalTournaments+1 {
+		t.Errorf("Rate(), want experienced players to gain K/2 = 16 points, got %+v", r)
+	}
+}
+
+func TestRateUpset(t *testing.T) {
+	e := NewEngine([]*volleynet.Rating{
+		{PlayerID: 1, Rating: 1400, Tournaments: 10},
+		{PlayerID: 2, Rating: 1400, Tournaments: 10},
+		{PlayerID: 3, Rating: 1600, Tournaments: 10},
+		{PlayerID: 4, Rating: 1600, Tournaments: 10},
+	})
+
+	ratings := e.Rate([]*volleynet.TeamResult{
+		result(1, 1, 2, 1),
+		result(1, 3, 4, 2),
+	})
+
+	underdog, favorite := ratingOf(ratings, 1), ratingOf(ratings, 3)
+
+	if underdog.Delta <= 16 {
+		t.Errorf("Rate(), want an underdog to gain more than K/2 points, got %+v", underdog)
+	}
+
+	if underdog.Delta != -favorite.Delta {
+		t.Errorf("Rate(), want the changes of both teams to cancel out, got %v and %v", underdog.Delta, favorite.Delta)
+	}
+}
+
+func TestRateTie(t *testing.T) {
+	e := NewEngine(nil)
+
+	ratings := e.Rate([]*volleynet.TeamResult{
+		result(1, 1, 2, 5),
+		result(1, 3, 4, 5),
+	})
+
+	for _, r := range ratings {
+		if r.Delta != 0 {
+			t.Errorf("Rate(), want equally rated teams with the same result to keep their rating, got %+v", r)
+		}
+	}
+}
+
+func TestRateInvalidField(t *testing.T) {
+	e := NewEngine(nil)
+
+	if ratings := e.Rate([]*volleynet.TeamResult{result(1, 1, 2, 1)}); ratings != nil {
+		t.Errorf("Rate(), want no ratings for a single team, got %v", ratings)
+	}
+
+	// the second team contains a player of the first team
+	if ratings := e.Rate([]*volleynet.TeamResult{result(1, 1, 2, 1), result(1, 2, 3, 2)}); ratings != nil {
+		t.Errorf("Rate(), want no ratings for an invalid field, got %v", ratings)
+	}
+}
+
+func TestExpected(t *testing.T) {
+	if e := Expected(1500, 1500); e != 0.5 {
+		t.Errorf("Expected(1500, 1500), want 0.5, got %v", e)
+	}
+
+	if a, b := Expected(1700, 1500), Expected(1500, 1700); a+b != 1 || a < 0.75 {
+		t.Errorf("Expected(), want the favorite to be expected to win, got %v and %v", a, b)
+	}
+}
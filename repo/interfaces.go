@@ -1,6 +1,9 @@
 package repo
 
 import (
+	"context"
+	"time"
+
 	"github.com/raphi011/scores"
 	"github.com/raphi011/scores/volleynet"
 )
@@ -12,25 +15,36 @@ type PlayerFilter struct {
 	Gender    string `db:"gender"`
 }
 
+// PlayerHistoryFilter restricts a player's tournament history and
+// statistics to tournaments of the `Leagues`, empty matches all leagues.
+type PlayerHistoryFilter struct {
+	Leagues []string
+}
+
 // PlayerRepository exposes CRUD operations on players.
 type PlayerRepository interface {
-	Get(id int) (*volleynet.Player, error)
-	New(p *volleynet.Player) (*volleynet.Player, error)
-	Update(p *volleynet.Player) error
-	Ladder(gender string) ([]*volleynet.Player, error)
-	ByGender(gender string) ([]*volleynet.Player, error)
-	PreviousPartners(playerID int) ([]*volleynet.Player, error)
-	Search(filter PlayerFilter) ([]*volleynet.Player, error)
+	Get(ctx context.Context, id int) (*volleynet.Player, error)
+	New(ctx context.Context, p *volleynet.Player) (*volleynet.Player, error)
+	Update(ctx context.Context, p *volleynet.Player) error
+	Ladder(ctx context.Context, gender string) ([]*volleynet.Player, error)
+	ByGender(ctx context.Context, gender string) ([]*volleynet.Player, error)
+	// PreviousPartners returns all distinct partners of a player, the latest partner first.
+	PreviousPartners(ctx context.Context, playerID int) ([]*volleynet.Player, error)
+	Search(ctx context.Context, filter PlayerFilter) ([]*volleynet.Player, error)
+	// History returns all tournament entries of a player, the latest tournament first.
+	History(ctx context.Context, playerID int, filter PlayerHistoryFilter) ([]*volleynet.PlayerTournament, error)
+	// SeasonStats returns the statistics of a player per season, the latest season first.
+	SeasonStats(ctx context.Context, playerID int, filter PlayerHistoryFilter) ([]*volleynet.SeasonStats, error)
 }
 
 // TeamRepository exposes CRUD operations on teams.
 type TeamRepository interface {
-	ByTournament(tournamentID int) ([]*volleynet.TournamentTeam, error)
-	Delete(t *volleynet.TournamentTeam) error
-	New(t *volleynet.TournamentTeam) (*volleynet.TournamentTeam, error)
-	NewBatch(t ...*volleynet.TournamentTeam) error
-	Update(t *volleynet.TournamentTeam) error
-	UpdateBatch(t ...*volleynet.TournamentTeam) error
+	ByTournament(ctx context.Context, tournamentID int) ([]*volleynet.TournamentTeam, error)
+	Delete(ctx context.Context, t *volleynet.TournamentTeam) error
+	New(ctx context.Context, t *volleynet.TournamentTeam) (*volleynet.TournamentTeam, error)
+	NewBatch(ctx context.Context, t ...*volleynet.TournamentTeam) error
+	Update(ctx context.Context, t *volleynet.TournamentTeam) error
+	UpdateBatch(ctx context.Context, t ...*volleynet.TournamentTeam) error
 }
 
 // TournamentFilter contains all available Tournament filters.
@@ -42,33 +56,238 @@ type TournamentFilter struct {
 
 // TournamentRepository exposes CRUD operations on tournaments.
 type TournamentRepository interface {
-	Search(filter TournamentFilter) (
+	Search(ctx context.Context, filter TournamentFilter) (
 		[]*volleynet.Tournament, error)
-	Get(tournamentID int) (*volleynet.Tournament, error)
-	New(t *volleynet.Tournament) (*volleynet.Tournament, error)
-	NewBatch(t ...*volleynet.Tournament) error
-	Update(t *volleynet.Tournament) error
-	UpdateBatch(t ...*volleynet.Tournament) error
+	Get(ctx context.Context, tournamentID int) (*volleynet.Tournament, error)
+	New(ctx context.Context, t *volleynet.Tournament) (*volleynet.Tournament, error)
+	NewBatch(ctx context.Context, t ...*volleynet.Tournament) error
+	Update(ctx context.Context, t *volleynet.Tournament) error
+	UpdateBatch(ctx context.Context, t ...*volleynet.Tournament) error
 
-	Leagues() ([]string, error)
-	SubLeagues() ([]string, error)
-	Seasons() ([]string, error)
+	Leagues(ctx context.Context) ([]string, error)
+	SubLeagues(ctx context.Context) ([]string, error)
+	Seasons(ctx context.Context) ([]string, error)
 }
 
 // UserRepository exposes CRUD operations on users.
 type UserRepository interface {
-	All() ([]*scores.User, error)
-	ByEmail(email string) (*scores.User, error)
-	ByID(userID int) (*scores.User, error)
-	New(user *scores.User) (*scores.User, error)
-	Update(user *scores.User) error
+	All(ctx context.Context) ([]*scores.User, error)
+	ByEmail(ctx context.Context, email string) (*scores.User, error)
+	ByID(ctx context.Context, userID int) (*scores.User, error)
+	New(ctx context.Context, user *scores.User) (*scores.User, error)
+	Update(ctx context.Context, user *scores.User) error
 }
 
 // SettingRepository exposes CRUD operations on settings.
 type SettingRepository interface {
-	Create(setting *scores.Setting) (*scores.Setting, error)
-	Update(setting *scores.Setting) error
-	ByUserID(userID int) ([]*scores.Setting, error)
+	Create(ctx context.Context, setting *scores.Setting) (*scores.Setting, error)
+	Update(ctx context.Context, setting *scores.Setting) error
+	ByUserID(ctx context.Context, userID int) ([]*scores.Setting, error)
+	ByKey(ctx context.Context, key string) ([]*scores.Setting, error)
+	All(ctx context.Context) ([]*scores.Setting, error)
+}
+
+// NotificationLogRepository exposes CRUD operations on the notification log.
+type NotificationLogRepository interface {
+	New(ctx context.Context, log *scores.NotificationLog) (*scores.NotificationLog, error)
+	ByUserID(ctx context.Context, userID int) ([]*scores.NotificationLog, error)
+}
+
+// AlertRuleRepository exposes CRUD operations on alert rules.
+type AlertRuleRepository interface {
+	All(ctx context.Context) ([]*scores.AlertRule, error)
+	ByID(ctx context.Context, ruleID int) (*scores.AlertRule, error)
+	ByUserID(ctx context.Context, userID int) ([]*scores.AlertRule, error)
+	New(ctx context.Context, rule *scores.AlertRule) (*scores.AlertRule, error)
+	Update(ctx context.Context, rule *scores.AlertRule) error
+	Delete(ctx context.Context, rule *scores.AlertRule) error
+	// Trigger records that the rule has matched the event `key`, it returns
+	// false if the rule has already been triggered by this event before.
+	Trigger(ctx context.Context, ruleID int, key string) (bool, error)
+}
+
+// JobExecutionRepository exposes CRUD operations on the job history.
+type JobExecutionRepository interface {
+	New(ctx context.Context, execution *scores.JobExecution) (*scores.JobExecution, error)
+	// Page loads executions of the job (or of all jobs if `jobName` is empty), latest first.
+	Page(ctx context.Context, jobName string, offset, limit int) ([]*scores.JobExecution, error)
+	// DeleteBefore deletes all executions that have started before `t`.
+	DeleteBefore(ctx context.Context, t time.Time) (int, error)
+}
+
+// JobLeaseRepository exposes operations on job leases.
+type JobLeaseRepository interface {
+	// Acquire creates, renews or takes over (if expired at `now`) the lease,
+	// false is returned if another owner holds it.
+	Acquire(ctx context.Context, lease *scores.JobLease, now time.Time) (bool, error)
+	Release(ctx context.Context, jobName, owner string) error
+}
+
+// PollOffsetRepository stores how far pollers (e.g. the telegram bot)
+// have consumed their updates.
+type PollOffsetRepository interface {
+	// Get returns the stored offset of the poller, 0 if there is none.
+	Get(ctx context.Context, poller string) (int, error)
+	Set(ctx context.Context, poller string, offset int) error
+}
+
+// RatingRepository exposes operations on the rating history of players.
+type RatingRepository interface {
+	// Results returns the results of all teams in finished tournaments that
+	// have not deregistered, ordered by the tournaments' start and id.
+	Results(ctx context.Context) ([]*volleynet.TeamResult, error)
+	// RatedTournaments returns the ids of all tournaments that have been rated.
+	RatedTournaments(ctx context.Context) ([]int, error)
+	// Latest returns the current rating of all rated players.
+	Latest(ctx context.Context) ([]*volleynet.Rating, error)
+	// ByPlayer returns the rating history of a player, the oldest rating first.
+	ByPlayer(ctx context.Context, playerID int) ([]*volleynet.Rating, error)
+	// Leaderboard returns the `limit` players of the gender with the highest current rating.
+	Leaderboard(ctx context.Context, gender string, limit int) ([]*volleynet.RatedPlayer, error)
+	New(ctx context.Context, ratings ...*volleynet.Rating) error
+	// DeleteFrom deletes the ratings of all tournaments that started at or after `start`.
+	DeleteFrom(ctx context.Context, start time.Time) (int, error)
+	// RequestRecompute records that all ratings have to be recomputed.
+	RequestRecompute(ctx context.Context) error
+	// RecomputeRequest returns the id of the latest pending recompute
+	// request, 0 if there is none.
+	RecomputeRequest(ctx context.Context) (int, error)
+	// ClearRecomputeRequests deletes the recompute requests up to `requestID`.
+	ClearRecomputeRequests(ctx context.Context, requestID int) error
+}
+
+// PartnerListingFilter restricts open partner listings, zero values match all listings.
+type PartnerListingFilter struct {
+	// TournamentID matches listings for the tournament and listings for
+	// a date range, they are restricted further by `League`, `From` and `To`.
+	TournamentID int
+	League       string
+	Gender       string
+	MinPoints    int
+	MaxPoints    int
+	From         time.Time // excludes listings that end before
+	To           time.Time // excludes listings that start after
+}
+
+// PartnerRepository exposes CRUD operations on the listings and
+// requests of the partner board.
+type PartnerRepository interface {
+	Listing(ctx context.Context, listingID int) (*volleynet.PartnerListing, error)
+	// Listings returns the open listings that match the filter, ordered by their start.
+	Listings(ctx context.Context, filter PartnerListingFilter) ([]*volleynet.PartnerListing, error)
+	// ListingsByUser returns all listings of a user, the latest first.
+	ListingsByUser(ctx context.Context, userID int) ([]*volleynet.PartnerListing, error)
+	NewListing(ctx context.Context, listing *volleynet.PartnerListing) (*volleynet.PartnerListing, error)
+	// SetListingStatus changes the status of a listing from `current` to `status`,
+	// false is returned if the listing's status is not `current`.
+	SetListingStatus(ctx context.Context, listingID int, current, status string) (bool, error)
+
+	Request(ctx context.Context, requestID int) (*volleynet.PartnerRequest, error)
+	// RequestsByListing returns the requests for a listing, the oldest first.
+	RequestsByListing(ctx context.Context, listingID int) ([]*volleynet.PartnerRequest, error)
+	// RequestsByUser returns the requests a user has sent, the latest first.
+	RequestsByUser(ctx context.Context, userID int) ([]*volleynet.PartnerRequest, error)
+	// RequestsToUser returns the requests for the listings of a user, the latest first.
+	RequestsToUser(ctx context.Context, userID int) ([]*volleynet.PartnerRequest, error)
+	NewRequest(ctx context.Context, request *volleynet.PartnerRequest) (*volleynet.PartnerRequest, error)
+	// SetRequestStatus changes the status of a request from `current` to `status`,
+	// false is returned if the request's status is not `current`.
+	SetRequestStatus(ctx context.Context, requestID int, current, status string) (bool, error)
+}
+
+// WatchRepository exposes CRUD operations on the watchlists and
+// followed players of users.
+type WatchRepository interface {
+	// Tournaments returns the watched tournaments of a user, the latest first.
+	Tournaments(ctx context.Context, userID int) ([]*volleynet.WatchedTournament, error)
+	WatchTournament(ctx context.Context, w *volleynet.WatchedTournament) (*volleynet.WatchedTournament, error)
+	// UnwatchTournament removes a tournament from the watchlist of a user,
+	// `scores.ErrNotFound` is returned if the tournament isn't watched.
+	UnwatchTournament(ctx context.Context, userID, tournamentID int) error
+
+	// Players returns the followed players of a user, the latest first.
+	Players(ctx context.Context, userID int) ([]*volleynet.FollowedPlayer, error)
+	FollowPlayer(ctx context.Context, f *volleynet.FollowedPlayer) (*volleynet.FollowedPlayer, error)
+	// UnfollowPlayer removes a player from the followed players of a user,
+	// `scores.ErrNotFound` is returned if the player isn't followed.
+	UnfollowPlayer(ctx context.Context, userID, playerID int) error
+	// IsFollowed returns true if the player is followed by at least one user.
+	IsFollowed(ctx context.Context, playerID int) (bool, error)
+}
+
+// ActivityFilter restricts the activity feed to activities of the tournaments
+// or the players, activities that have been created before `Since` are excluded.
+type ActivityFilter struct {
+	TournamentIDs []int
+	PlayerIDs     []int
+	Since         time.Time
+	Limit         int
+}
+
+// ActivityRepository exposes CRUD operations on activities.
+type ActivityRepository interface {
+	New(ctx context.Context, activities ...*volleynet.Activity) error
+	// Feed returns the activities that match the filter, the latest first.
+	Feed(ctx context.Context, filter ActivityFilter) ([]*volleynet.Activity, error)
+	// DeleteBefore permanently deletes activities that have been created
+	// before `t` and returns the # of deleted activities.
+	DeleteBefore(ctx context.Context, t time.Time) (int, error)
+}
+
+// SignupRepository exposes CRUD operations on scheduled signups.
+type SignupRepository interface {
+	Get(ctx context.Context, signupID int) (*volleynet.ScheduledSignup, error)
+	// ByUser returns the signups of a user, the latest first.
+	ByUser(ctx context.Context, userID int) ([]*volleynet.ScheduledSignup, error)
+	// ByStatus returns the signups with the status, the oldest first.
+	ByStatus(ctx context.Context, status string) ([]*volleynet.ScheduledSignup, error)
+	New(ctx context.Context, signup *volleynet.ScheduledSignup) (*volleynet.ScheduledSignup, error)
+	// SetStatus changes the status of a signup from `current` to `status`,
+	// false is returned if the signup's status is not `current`.
+	SetStatus(ctx context.Context, signupID int, current, status string) (bool, error)
+	// Finish persists the status, message and attempt of a signup and deletes
+	// its credentials in one write, false is returned if the signup's status
+	// is not `current`.
+	Finish(ctx context.Context, signup *volleynet.ScheduledSignup, current string) (bool, error)
+}
+
+// TransferRepository loads and persists all entities unchanged (including
+// their ids, timestamps and soft deleted entities) to move data between
+// providers. Entities are loaded ordered by their primary key. Activities
+// are not transferred, they are only kept for a while anyway, neither are
+// scheduled signups because their credentials can only be decrypted with
+// the secret of the source instance.
+type TransferRepository interface {
+	Players(ctx context.Context) ([]*volleynet.Player, error)
+	Tournaments(ctx context.Context) ([]*volleynet.Tournament, error)
+	Teams(ctx context.Context) ([]*volleynet.TournamentTeam, error)
+	Users(ctx context.Context) ([]*scores.User, error)
+	Settings(ctx context.Context) ([]*scores.Setting, error)
+	NotificationLogs(ctx context.Context) ([]*scores.NotificationLog, error)
+	AlertRules(ctx context.Context) ([]*scores.AlertRule, error)
+	AlertTriggers(ctx context.Context) ([]*scores.AlertTrigger, error)
+	JobExecutions(ctx context.Context) ([]*scores.JobExecution, error)
+	PartnerListings(ctx context.Context) ([]*volleynet.PartnerListing, error)
+	PartnerRequests(ctx context.Context) ([]*volleynet.PartnerRequest, error)
+	WatchedTournaments(ctx context.Context) ([]*volleynet.WatchedTournament, error)
+	FollowedPlayers(ctx context.Context) ([]*volleynet.FollowedPlayer, error)
+	// ScheduledSignups returns the # of scheduled signups, they are not
+	// exported but the destination of an import must not contain any.
+	ScheduledSignups(ctx context.Context) (int, error)
+
+	ImportPlayers(ctx context.Context, players ...*volleynet.Player) error
+	ImportTournaments(ctx context.Context, tournaments ...*volleynet.Tournament) error
+	ImportTeams(ctx context.Context, teams ...*volleynet.TournamentTeam) error
+	ImportUsers(ctx context.Context, users ...*scores.User) error
+	ImportSettings(ctx context.Context, settings ...*scores.Setting) error
+	ImportNotificationLogs(ctx context.Context, logs ...*scores.NotificationLog) error
+	ImportAlertRules(ctx context.Context, rules ...*scores.AlertRule) error
+	ImportAlertTriggers(ctx context.Context, triggers ...*scores.AlertTrigger) error
+	ImportJobExecutions(ctx context.Context, executions ...*scores.JobExecution) error
+	ImportPartnerListings(ctx context.Context, listings ...*volleynet.PartnerListing) error
+	ImportPartnerRequests(ctx context.Context, requests ...*volleynet.PartnerRequest) error
+	ImportWatchedTournaments(ctx context.Context, watches ...*volleynet.WatchedTournament) error
+	ImportFollowedPlayers(ctx context.Context, follows ...*volleynet.FollowedPlayer) error
 }
 
 // Repositories is a collection of instances of all available repositories.
@@ -78,4 +297,16 @@ type Repositories struct {
 	TournamentRepo TournamentRepository
 	UserRepo       UserRepository
 	SettingRepo    SettingRepository
+
+	NotificationLogRepo NotificationLogRepository
+	AlertRuleRepo       AlertRuleRepository
+	JobExecutionRepo    JobExecutionRepository
+	JobLeaseRepo        JobLeaseRepository
+	PollOffsetRepo      PollOffsetRepository
+	RatingRepo          RatingRepository
+	PartnerRepo         PartnerRepository
+	WatchRepo           WatchRepository
+	ActivityRepo        ActivityRepository
+	SignupRepo          SignupRepository
+	TransferRepo        TransferRepository
 }
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.ActivityRepository = &activityRepository{}
+
+type activityRepository struct {
+	*store
+}
+
+// New persists activities and assigns new ids.
+func (s *activityRepository) New(ctx context.Context, activities ...*volleynet.Activity) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+
+	for _, activity := range activities {
+		activity.Create(now)
+		activity.SetID(s.nextID("activity"))
+
+		a := *activity
+		a.Players = nil
+		s.activities = append(s.activities, &a)
+	}
+
+	return nil
+}
+
+// Feed returns the activities that match the filter, the latest first.
+func (s *activityRepository) Feed(ctx context.Context, filter repo.ActivityFilter) ([]*volleynet.Activity, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	tournaments := ids(filter.TournamentIDs)
+	players := ids(filter.PlayerIDs)
+	activities := []*volleynet.Activity{}
+
+	// activities are appended in the order of their ids
+	for i := len(s.activities) - 1; i >= 0 && len(activities) < filter.Limit; i-- {
+		activity := s.activities[i]
+
+		if activity.DeletedAt != nil || activity.CreatedAt.Before(filter.Since) {
+			continue
+		}
+
+		if tournaments[activity.TournamentID] || players[activity.Player1ID] || players[activity.Player2ID] {
+			a := *activity
+			activities = append(activities, &a)
+		}
+	}
+
+	return activities, nil
+}
+
+// DeleteBefore permanently deletes activities that have been created before `t`.
+func (s *activityRepository) DeleteBefore(ctx context.Context, t time.Time) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	kept := []*volleynet.Activity{}
+
+	for _, activity := range s.activities {
+		if !activity.CreatedAt.Before(t) {
+			kept = append(kept, activity)
+		}
+	}
+
+	deleted := len(s.activities) - len(kept)
+	s.activities = kept
+
+	return deleted, nil
+}
+
+// ids returns a set of the ids.
+func ids(ids []int) map[int]bool {
+	set := map[int]bool{}
+
+	for _, id := range ids {
+		set[id] = true
+	}
+
+	return set
+}
@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+)
+
+var _ repo.AlertRuleRepository = &alertRuleRepository{}
+
+type alertRuleRepository struct {
+	*store
+}
+
+type alertTriggerKey struct {
+	ruleID int
+	key    string
+}
+
+// All loads all alert rules of all users.
+func (s *alertRuleRepository) All(ctx context.Context) ([]*scores.AlertRule, error) {
+	return s.filter(func(r *scores.AlertRule) bool { return true }), nil
+}
+
+// ByID loads an alert rule.
+func (s *alertRuleRepository) ByID(ctx context.Context, ruleID int) (*scores.AlertRule, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	rule, ok := s.alertRules[ruleID]
+
+	if !ok || rule.DeletedAt != nil {
+		return &scores.AlertRule{}, errors.Wrap(scores.ErrNotFound, "byID alert rule")
+	}
+
+	r := *rule
+
+	return &r, nil
+}
+
+// ByUserID loads all alert rules of a user.
+func (s *alertRuleRepository) ByUserID(ctx context.Context, userID int) ([]*scores.AlertRule, error) {
+	return s.filter(func(r *scores.AlertRule) bool { return r.UserID == userID }), nil
+}
+
+// New persists an alert rule and assigns a new id.
+func (s *alertRuleRepository) New(ctx context.Context, rule *scores.AlertRule) (*scores.AlertRule, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	rule.Create(time.Now())
+	rule.SetID(s.nextID("alert-rule"))
+
+	r := *rule
+	s.alertRules[rule.ID] = &r
+
+	return rule, nil
+}
+
+// Update updates an alert rule.
+func (s *alertRuleRepository) Update(ctx context.Context, rule *scores.AlertRule) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	persisted, ok := s.alertRules[rule.ID]
+
+	if !ok || persisted.DeletedAt != nil {
+		return errors.Wrap(scores.ErrNotFound, "update alert rule")
+	}
+
+	rule.Update(time.Now())
+
+	r := *rule
+	r.CreatedAt = persisted.CreatedAt
+	r.DeletedAt = nil
+	s.alertRules[rule.ID] = &r
+
+	return nil
+}
+
+// Delete deletes an alert rule.
+func (s *alertRuleRepository) Delete(ctx context.Context, rule *scores.AlertRule) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	persisted, ok := s.alertRules[rule.ID]
+
+	if !ok || persisted.DeletedAt != nil {
+		return errors.Wrap(scores.ErrNotFound, "delete alert rule")
+	}
+
+	rule.Delete(time.Now())
+	persisted.DeletedAt = rule.DeletedAt
+
+	return nil
+}
+
+// Trigger records that the rule has matched the event `key`.
+func (s *alertRuleRepository) Trigger(ctx context.Context, ruleID int, key string) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	triggerKey := alertTriggerKey{ruleID: ruleID, key: key}
+
+	if _, ok := s.triggers[triggerKey]; ok {
+		return false, nil
+	}
+
+	trigger := &scores.AlertTrigger{RuleID: ruleID, Key: key}
+	trigger.Create(time.Now())
+	trigger.SetID(s.nextID("alert-trigger"))
+
+	s.triggers[triggerKey] = trigger
+
+	return true, nil
+}
+
+// filter returns copies of all rules that are not deleted and match, ordered by id.
+func (s *alertRuleRepository) filter(match func(r *scores.AlertRule) bool) []*scores.AlertRule {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	rules := []*scores.AlertRule{}
+
+	for _, rule := range s.alertRules {
+		if rule.DeletedAt == nil && match(rule) {
+			r := *rule
+			rules = append(rules, &r)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules
+}
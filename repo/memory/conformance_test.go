@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) *repo.Repositories {
+		return Repositories()
+	})
+}
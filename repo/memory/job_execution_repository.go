@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+)
+
+var _ repo.JobExecutionRepository = &jobExecutionRepository{}
+
+type jobExecutionRepository struct {
+	*store
+}
+
+// New persists a job execution and assigns a new id.
+func (s *jobExecutionRepository) New(ctx context.Context, execution *scores.JobExecution) (*scores.JobExecution, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	execution.Create(time.Now())
+	execution.SetID(s.nextID("job-execution"))
+
+	e := *execution
+	s.jobExecutions = append(s.jobExecutions, &e)
+
+	return execution, nil
+}
+
+// Page loads executions of the job (or of all jobs if `jobName` is empty), latest first.
+func (s *jobExecutionRepository) Page(ctx context.Context, jobName string, offset, limit int) ([]*scores.JobExecution, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	executions := []*scores.JobExecution{}
+
+	for _, execution := range s.jobExecutions {
+		if jobName == "" || execution.JobName == jobName {
+			e := *execution
+			executions = append(executions, &e)
+		}
+	}
+
+	sort.Slice(executions, func(i, j int) bool {
+		if !executions[i].Start.Equal(executions[j].Start) {
+			return executions[i].Start.After(executions[j].Start)
+		}
+
+		return executions[i].ID > executions[j].ID
+	})
+
+	if offset >= len(executions) {
+		return []*scores.JobExecution{}, nil
+	}
+
+	executions = executions[offset:]
+
+	if limit < len(executions) {
+		executions = executions[:limit]
+	}
+
+	return executions, nil
+}
+
+// DeleteBefore deletes all executions that have started before `t`.
+func (s *jobExecutionRepository) DeleteBefore(ctx context.Context, t time.Time) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	kept := []*scores.JobExecution{}
+
+	for _, execution := range s.jobExecutions {
+		if !execution.Start.Before(t) {
+			kept = append(kept, execution)
+		}
+	}
+
+	deleted := len(s.jobExecutions) - len(kept)
+	s.jobExecutions = kept
+
+	return deleted, nil
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+)
+
+var _ repo.JobLeaseRepository = &jobLeaseRepository{}
+
+type jobLeaseRepository struct {
+	*store
+}
+
+// Acquire creates, renews or takes over (if expired at `now`) the lease.
+func (s *jobLeaseRepository) Acquire(ctx context.Context, lease *scores.JobLease, now time.Time) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	persisted, ok := s.jobLeases[lease.JobName]
+
+	if ok && persisted.Owner != lease.Owner && !persisted.ExpiresAt.Before(now) {
+		return false, nil
+	}
+
+	l := *lease
+	s.jobLeases[lease.JobName] = &l
+
+	return true, nil
+}
+
+// Release deletes the lease if it's held by `owner`.
+func (s *jobLeaseRepository) Release(ctx context.Context, jobName, owner string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if persisted, ok := s.jobLeases[jobName]; ok && persisted.Owner == owner {
+		delete(s.jobLeases, jobName)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+)
+
+var _ repo.NotificationLogRepository = &notificationLogRepository{}
+
+type notificationLogRepository struct {
+	*store
+}
+
+// New persists a notification log entry and assigns a new id.
+func (s *notificationLogRepository) New(ctx context.Context, log *scores.NotificationLog) (*scores.NotificationLog, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	log.Create(time.Now())
+	log.SetID(s.nextID("notification-log"))
+
+	l := *log
+	s.notificationLogs = append(s.notificationLogs, &l)
+
+	return log, nil
+}
+
+// ByUserID loads all notifications that were sent to a user, latest first.
+func (s *notificationLogRepository) ByUserID(ctx context.Context, userID int) ([]*scores.NotificationLog, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	logs := []*scores.NotificationLog{}
+
+	for _, log := range s.notificationLogs {
+		if log.UserID == userID {
+			l := *log
+			logs = append(logs, &l)
+		}
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		if !logs[i].CreatedAt.Equal(logs[j].CreatedAt) {
+			return logs[i].CreatedAt.After(logs[j].CreatedAt)
+		}
+
+		return logs[i].ID > logs[j].ID
+	})
+
+	return logs, nil
+}
@@ -0,0 +1,283 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.PartnerRepository = &partnerRepository{}
+
+type partnerRepository struct {
+	*store
+}
+
+// Listing loads a partner listing.
+func (s *partnerRepository) Listing(ctx context.Context, listingID int) (*volleynet.PartnerListing, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	listing, ok := s.partnerListings[listingID]
+
+	if !ok || listing.DeletedAt != nil {
+		return &volleynet.PartnerListing{}, errors.Wrap(scores.ErrNotFound, "listing")
+	}
+
+	l, ok := s.listing(listing)
+
+	if !ok {
+		return &volleynet.PartnerListing{}, errors.Wrap(scores.ErrNotFound, "listing")
+	}
+
+	return l, nil
+}
+
+// Listings returns the open listings that match the filter, ordered by their start.
+func (s *partnerRepository) Listings(ctx context.Context, filter repo.PartnerListingFilter) (
+	[]*volleynet.PartnerListing, error) {
+
+	listings := s.filterListings(func(l *volleynet.PartnerListing) bool {
+		p := l.Player
+
+		return l.Status == volleynet.ListingOpen &&
+			(filter.TournamentID == 0 || l.TournamentID == 0 || l.TournamentID == filter.TournamentID) &&
+			(filter.League == "" || l.League == filter.League) &&
+			(filter.Gender == "" || p.Gender == filter.Gender) &&
+			p.TotalPoints >= filter.MinPoints &&
+			(filter.MaxPoints == 0 || p.TotalPoints <= filter.MaxPoints) &&
+			!l.To.Before(filter.From) &&
+			(filter.To.IsZero() || !l.From.After(filter.To))
+	})
+
+	sort.Slice(listings, func(i, j int) bool {
+		if !listings[i].From.Equal(listings[j].From) {
+			return listings[i].From.Before(listings[j].From)
+		}
+
+		return listings[i].ID < listings[j].ID
+	})
+
+	return listings, nil
+}
+
+// ListingsByUser returns all listings of a user, the latest first.
+func (s *partnerRepository) ListingsByUser(ctx context.Context, userID int) ([]*volleynet.PartnerListing, error) {
+	listings := s.filterListings(func(l *volleynet.PartnerListing) bool {
+		return l.UserID == userID
+	})
+
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].ID > listings[j].ID
+	})
+
+	return listings, nil
+}
+
+// NewListing persists a partner listing and assigns a new id.
+func (s *partnerRepository) NewListing(ctx context.Context, listing *volleynet.PartnerListing) (
+	*volleynet.PartnerListing, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	listing.Create(time.Now())
+	listing.SetID(s.nextID("partner-listing"))
+
+	l := *listing
+	l.Player = &volleynet.Player{ID: listing.Player.ID}
+	s.partnerListings[l.ID] = &l
+
+	return listing, nil
+}
+
+// SetListingStatus changes the status of a listing from `current` to `status`.
+func (s *partnerRepository) SetListingStatus(ctx context.Context, listingID int, current, status string) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	listing, ok := s.partnerListings[listingID]
+
+	if !ok || listing.DeletedAt != nil || listing.Status != current {
+		return false, nil
+	}
+
+	listing.Update(time.Now())
+	listing.Status = status
+
+	return true, nil
+}
+
+// Request loads a partner request.
+func (s *partnerRepository) Request(ctx context.Context, requestID int) (*volleynet.PartnerRequest, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	request, ok := s.partnerRequests[requestID]
+
+	if !ok || request.DeletedAt != nil {
+		return &volleynet.PartnerRequest{}, errors.Wrap(scores.ErrNotFound, "request")
+	}
+
+	r, ok := s.request(request)
+
+	if !ok {
+		return &volleynet.PartnerRequest{}, errors.Wrap(scores.ErrNotFound, "request")
+	}
+
+	return r, nil
+}
+
+// RequestsByListing returns the requests for a listing, the oldest first.
+func (s *partnerRepository) RequestsByListing(ctx context.Context, listingID int) ([]*volleynet.PartnerRequest, error) {
+	requests := s.filterRequests(func(r *volleynet.PartnerRequest) bool {
+		return r.ListingID == listingID
+	})
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].ID < requests[j].ID
+	})
+
+	return requests, nil
+}
+
+// RequestsByUser returns the requests a user has sent, the latest first.
+func (s *partnerRepository) RequestsByUser(ctx context.Context, userID int) ([]*volleynet.PartnerRequest, error) {
+	requests := s.filterRequests(func(r *volleynet.PartnerRequest) bool {
+		return r.UserID == userID
+	})
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].ID > requests[j].ID
+	})
+
+	return requests, nil
+}
+
+// RequestsToUser returns the requests for the listings of a user, the latest first.
+func (s *partnerRepository) RequestsToUser(ctx context.Context, userID int) ([]*volleynet.PartnerRequest, error) {
+	requests := s.filterRequests(func(r *volleynet.PartnerRequest) bool {
+		listing, ok := s.partnerListings[r.ListingID]
+
+		return ok && listing.DeletedAt == nil && listing.UserID == userID
+	})
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].ID > requests[j].ID
+	})
+
+	return requests, nil
+}
+
+// NewRequest persists a partner request and assigns a new id.
+func (s *partnerRepository) NewRequest(ctx context.Context, request *volleynet.PartnerRequest) (
+	*volleynet.PartnerRequest, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.partnerListings[request.ListingID]; !ok {
+		return nil, errors.Errorf("new request: listing %d does not exist", request.ListingID)
+	}
+
+	request.Create(time.Now())
+	request.SetID(s.nextID("partner-request"))
+
+	r := *request
+	r.Player = &volleynet.Player{ID: request.Player.ID}
+	s.partnerRequests[r.ID] = &r
+
+	return request, nil
+}
+
+// SetRequestStatus changes the status of a request from `current` to `status`.
+func (s *partnerRepository) SetRequestStatus(ctx context.Context, requestID int, current, status string) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	request, ok := s.partnerRequests[requestID]
+
+	if !ok || request.DeletedAt != nil || request.Status != current {
+		return false, nil
+	}
+
+	request.Update(time.Now())
+	request.Status = status
+
+	return true, nil
+}
+
+// listing returns a copy of a stored listing with its player, false is
+// returned if the player doesn't exist. The lock must be held.
+func (s *partnerRepository) listing(listing *volleynet.PartnerListing) (*volleynet.PartnerListing, bool) {
+	player, ok := s.player(listing.Player.ID)
+
+	if !ok {
+		return nil, false
+	}
+
+	l := *listing
+	l.Player = player
+
+	return &l, true
+}
+
+// request returns a copy of a stored request with its player, false is
+// returned if the player doesn't exist. The lock must be held.
+func (s *partnerRepository) request(request *volleynet.PartnerRequest) (*volleynet.PartnerRequest, bool) {
+	player, ok := s.player(request.Player.ID)
+
+	if !ok {
+		return nil, false
+	}
+
+	r := *request
+	r.Player = player
+
+	return &r, true
+}
+
+// filterListings returns copies of all listings that are not deleted and match.
+func (s *partnerRepository) filterListings(match func(l *volleynet.PartnerListing) bool) []*volleynet.PartnerListing {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	listings := []*volleynet.PartnerListing{}
+
+	for _, listing := range s.partnerListings {
+		if listing.DeletedAt != nil {
+			continue
+		}
+
+		if l, ok := s.listing(listing); ok && match(l) {
+			listings = append(listings, l)
+		}
+	}
+
+	return listings
+}
+
+// filterRequests returns copies of all requests that are not deleted and match.
+func (s *partnerRepository) filterRequests(match func(r *volleynet.PartnerRequest) bool) []*volleynet.PartnerRequest {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	requests := []*volleynet.PartnerRequest{}
+
+	for _, request := range s.partnerRequests {
+		if request.DeletedAt != nil {
+			continue
+		}
+
+		if r, ok := s.request(request); ok && match(r) {
+			requests = append(requests, r)
+		}
+	}
+
+	return requests
+}
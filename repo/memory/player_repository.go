@@ -0,0 +1,291 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+type playerRepository struct {
+	*store
+}
+
+var _ repo.PlayerRepository = &playerRepository{}
+
+// ByGender gets all players of the passed gender.
+func (s *playerRepository) ByGender(ctx context.Context, gender string) ([]*volleynet.Player, error) {
+	return s.filter(func(p *volleynet.Player) bool {
+		return p.Gender == gender
+	}), nil
+}
+
+// Ladder gets all players of the passed gender that have a rank.
+func (s *playerRepository) Ladder(ctx context.Context, gender string) ([]*volleynet.Player, error) {
+	return s.filter(func(p *volleynet.Player) bool {
+		return p.Gender == gender && p.LadderRank > 0
+	}), nil
+}
+
+// Get loads a player.
+func (s *playerRepository) Get(ctx context.Context, id int) (*volleynet.Player, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	player, ok := s.players[id]
+
+	if !ok {
+		return &volleynet.Player{}, errors.Wrap(scores.ErrNotFound, "get player")
+	}
+
+	p := *player
+
+	return &p, nil
+}
+
+// New creates a new player.
+func (s *playerRepository) New(ctx context.Context, p *volleynet.Player) (*volleynet.Player, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.players[p.ID]; ok {
+		return p, errors.Wrap(errDuplicate("player", p.ID), "new player")
+	}
+
+	p.Create(time.Now())
+
+	player := *p
+	s.players[p.ID] = &player
+
+	return p, nil
+}
+
+// Update updates a player.
+func (s *playerRepository) Update(ctx context.Context, p *volleynet.Player) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	persisted, ok := s.players[p.ID]
+
+	if !ok {
+		return errors.Wrap(scores.ErrNotFound, "update player")
+	}
+
+	p.Update(time.Now())
+
+	player := *p
+	player.CreatedAt = persisted.CreatedAt
+	player.DeletedAt = persisted.DeletedAt
+	s.players[p.ID] = &player
+
+	return nil
+}
+
+// PreviousPartners returns a list of all partners a player has played
+// with before, the latest partner first.
+func (s *playerRepository) PreviousPartners(ctx context.Context, playerID int) ([]*volleynet.Player, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	lastPlayed := map[int]time.Time{}
+
+	for _, team := range s.teams {
+		tournament, ok := s.tournaments[team.TournamentID]
+
+		if !ok {
+			continue
+		}
+
+		partnerID := 0
+
+		if team.Player1.ID == playerID {
+			partnerID = team.Player2.ID
+		} else if team.Player2.ID == playerID {
+			partnerID = team.Player1.ID
+		} else {
+			continue
+		}
+
+		if played, ok := lastPlayed[partnerID]; !ok || tournament.Start.After(played) {
+			lastPlayed[partnerID] = tournament.Start
+		}
+	}
+
+	players := []*volleynet.Player{}
+
+	for partnerID := range lastPlayed {
+		if partner, ok := s.players[partnerID]; ok {
+			p := *partner
+			players = append(players, &p)
+		}
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		left, right := lastPlayed[players[i].ID], lastPlayed[players[j].ID]
+
+		if !left.Equal(right) {
+			return left.After(right)
+		}
+
+		return players[i].ID < players[j].ID
+	})
+
+	return players, nil
+}
+
+// History returns all tournament entries of a player, the latest tournament first.
+func (s *playerRepository) History(ctx context.Context, playerID int, filter repo.PlayerHistoryFilter) (
+	[]*volleynet.PlayerTournament, error) {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	history := []*volleynet.PlayerTournament{}
+
+	for _, team := range s.teams {
+		tournament, ok := s.tournaments[team.TournamentID]
+
+		if !ok || team.DeletedAt != nil || tournament.DeletedAt != nil ||
+			(len(filter.Leagues) > 0 && !contains(filter.Leagues, tournament.LeagueKey)) {
+			continue
+		}
+
+		partnerID := 0
+
+		if team.Player1.ID == playerID {
+			partnerID = team.Player2.ID
+		} else if team.Player2.ID == playerID {
+			partnerID = team.Player1.ID
+		} else {
+			continue
+		}
+
+		partner, ok := s.players[partnerID]
+
+		if !ok {
+			continue
+		}
+
+		p := *partner
+		p.Track = scores.Track{}
+
+		history = append(history, &volleynet.PlayerTournament{
+			Tournament:   tournament.TournamentInfo,
+			Partner:      &p,
+			Deregistered: team.Deregistered,
+			PrizeMoney:   team.PrizeMoney,
+			Result:       team.Result,
+			Seed:         team.Seed,
+			TotalPoints:  team.TotalPoints,
+			WonPoints:    team.WonPoints,
+		})
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		left, right := history[i].Tournament, history[j].Tournament
+
+		if !left.Start.Equal(right.Start) {
+			return left.Start.After(right.Start)
+		}
+
+		return left.ID > right.ID
+	})
+
+	return history, nil
+}
+
+// SeasonStats returns the statistics of a player per season, the latest season first.
+func (s *playerRepository) SeasonStats(ctx context.Context, playerID int, filter repo.PlayerHistoryFilter) (
+	[]*volleynet.SeasonStats, error) {
+
+	history, err := s.History(ctx, playerID, filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	seasons := map[string]*volleynet.SeasonStats{}
+	stats := []*volleynet.SeasonStats{}
+
+	for _, entry := range history {
+		if entry.Tournament.Status != volleynet.StatusDone || entry.Deregistered || entry.Result <= 0 {
+			continue
+		}
+
+		season, ok := seasons[entry.Tournament.Season]
+
+		if !ok {
+			season = &volleynet.SeasonStats{Season: entry.Tournament.Season, BestResult: entry.Result}
+			seasons[season.Season] = season
+			stats = append(stats, season)
+		}
+
+		// the sum of all results until the average is calculated below
+		season.AverageResult += float64(entry.Result)
+		season.Tournaments++
+		season.WonPoints += entry.WonPoints
+		season.PrizeMoney += entry.PrizeMoney
+
+		if entry.Result < season.BestResult {
+			season.BestResult = entry.Result
+		}
+		if entry.Result <= 3 {
+			season.Podiums++
+		}
+	}
+
+	for _, season := range stats {
+		season.AverageResult /= float64(season.Tournaments)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Season > stats[j].Season
+	})
+
+	return stats, nil
+}
+
+// Search searches for players that satisfy the passed filter, first and
+// last name match if they start with the (case insensitive) filter.
+func (s *playerRepository) Search(ctx context.Context, filter repo.PlayerFilter) ([]*volleynet.Player, error) {
+	return s.filter(func(p *volleynet.Player) bool {
+		return startsWith(p.FirstName, filter.FirstName) &&
+			startsWith(p.LastName, filter.LastName) &&
+			(filter.Gender == "" || p.Gender == filter.Gender)
+	}), nil
+}
+
+// filter returns copies of all players that match, ordered by their rank.
+func (s *playerRepository) filter(match func(p *volleynet.Player) bool) []*volleynet.Player {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	players := []*volleynet.Player{}
+
+	for _, player := range s.players {
+		if match(player) {
+			p := *player
+			players = append(players, &p)
+		}
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].LadderRank != players[j].LadderRank {
+			return players[i].LadderRank < players[j].LadderRank
+		}
+
+		return players[i].ID < players[j].ID
+	})
+
+	return players
+}
+
+func startsWith(value, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(value), strings.ToLower(prefix))
+}
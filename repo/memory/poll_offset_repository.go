@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/raphi011/scores/repo"
+)
+
+var _ repo.PollOffsetRepository = &pollOffsetRepository{}
+
+type pollOffsetRepository struct {
+	*store
+}
+
+// Get returns the stored offset of the poller, 0 if there is none.
+func (s *pollOffsetRepository) Get(ctx context.Context, poller string) (int, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.pollOffsets[poller], nil
+}
+
+// Set stores the offset of the poller.
+func (s *pollOffsetRepository) Set(ctx context.Context, poller string, offset int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.pollOffsets[poller] = offset
+
+	return nil
+}
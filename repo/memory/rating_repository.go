@@ -0,0 +1,248 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.RatingRepository = &ratingRepository{}
+
+type ratingRepository struct {
+	*store
+}
+
+// Results returns the results of all teams in finished tournaments that
+// have not deregistered, ordered by the tournaments' start and id.
+func (s *ratingRepository) Results(ctx context.Context) ([]*volleynet.TeamResult, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	results := []*volleynet.TeamResult{}
+
+	for _, team := range s.teams {
+		tournament, ok := s.tournaments[team.TournamentID]
+
+		if !ok || tournament.Status != volleynet.StatusDone || tournament.DeletedAt != nil ||
+			team.DeletedAt != nil || team.Deregistered || team.Result <= 0 {
+			continue
+		}
+
+		results = append(results, &volleynet.TeamResult{
+			TournamentID:    team.TournamentID,
+			TournamentStart: tournament.Start,
+			SubLeagueKey:    tournament.SubLeagueKey,
+			Player1ID:       team.Player1.ID,
+			Player2ID:       team.Player2.ID,
+			Result:          team.Result,
+			WonPoints:       team.WonPoints,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		left, right := results[i], results[j]
+
+		if !left.TournamentStart.Equal(right.TournamentStart) {
+			return left.TournamentStart.Before(right.TournamentStart)
+		}
+		if left.TournamentID != right.TournamentID {
+			return left.TournamentID < right.TournamentID
+		}
+
+		return left.Result < right.Result
+	})
+
+	return results, nil
+}
+
+// RatedTournaments returns the ids of all tournaments that have been rated.
+func (s *ratingRepository) RatedTournaments(ctx context.Context) ([]int, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	rated := map[int]bool{}
+	ids := []int{}
+
+	for _, rating := range s.ratings {
+		if !rated[rating.TournamentID] {
+			rated[rating.TournamentID] = true
+			ids = append(ids, rating.TournamentID)
+		}
+	}
+
+	sort.Ints(ids)
+
+	return ids, nil
+}
+
+// Latest returns the current rating of all rated players.
+func (s *ratingRepository) Latest(ctx context.Context) ([]*volleynet.Rating, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	latest := s.latest()
+	ratings := []*volleynet.Rating{}
+
+	for _, rating := range latest {
+		r := *rating
+		ratings = append(ratings, &r)
+	}
+
+	sort.Slice(ratings, func(i, j int) bool {
+		return ratings[i].PlayerID < ratings[j].PlayerID
+	})
+
+	return ratings, nil
+}
+
+// ByPlayer returns the rating history of a player, the oldest rating first.
+func (s *ratingRepository) ByPlayer(ctx context.Context, playerID int) ([]*volleynet.Rating, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ratings := []*volleynet.Rating{}
+
+	for _, rating := range s.ratings {
+		if rating.PlayerID == playerID {
+			r := *rating
+			ratings = append(ratings, &r)
+		}
+	}
+
+	sort.Slice(ratings, func(i, j int) bool {
+		return ratings[i].Tournaments < ratings[j].Tournaments
+	})
+
+	return ratings, nil
+}
+
+// Leaderboard returns the `limit` players of the gender with the highest current rating.
+func (s *ratingRepository) Leaderboard(ctx context.Context, gender string, limit int) ([]*volleynet.RatedPlayer, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	players := []*volleynet.RatedPlayer{}
+
+	for playerID, rating := range s.latest() {
+		player, ok := s.players[playerID]
+
+		if !ok || player.Gender != gender {
+			continue
+		}
+
+		p := *player
+		p.Track = scores.Track{}
+
+		players = append(players, &volleynet.RatedPlayer{
+			Player:      &p,
+			Rating:      rating.Rating,
+			Tournaments: rating.Tournaments,
+		})
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Rating != players[j].Rating {
+			return players[i].Rating > players[j].Rating
+		}
+
+		return players[i].Player.ID < players[j].Player.ID
+	})
+
+	if len(players) > limit {
+		players = players[:limit]
+	}
+
+	return players, nil
+}
+
+// New persists ratings.
+func (s *ratingRepository) New(ctx context.Context, ratings ...*volleynet.Rating) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+
+	for _, rating := range ratings {
+		for _, persisted := range s.ratings {
+			if persisted.PlayerID == rating.PlayerID && persisted.TournamentID == rating.TournamentID {
+				return errors.Wrap(errDuplicate("rating", rating.TournamentID), "insert ratings")
+			}
+		}
+
+		rating.Create(now)
+
+		r := *rating
+		s.ratings = append(s.ratings, &r)
+	}
+
+	return nil
+}
+
+// DeleteFrom deletes the ratings of all tournaments that started at or after `start`.
+func (s *ratingRepository) DeleteFrom(ctx context.Context, start time.Time) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	kept := []*volleynet.Rating{}
+
+	for _, rating := range s.ratings {
+		if rating.TournamentStart.Before(start) {
+			kept = append(kept, rating)
+		}
+	}
+
+	deleted := len(s.ratings) - len(kept)
+	s.ratings = kept
+
+	return deleted, nil
+}
+
+// RequestRecompute records that all ratings have to be recomputed.
+func (s *ratingRepository) RequestRecompute(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.recomputeRequest = s.nextID("rating-recompute-request")
+
+	return nil
+}
+
+// RecomputeRequest returns the id of the latest pending recompute
+// request, 0 if there is none.
+func (s *ratingRepository) RecomputeRequest(ctx context.Context) (int, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.recomputeRequest, nil
+}
+
+// ClearRecomputeRequests deletes the recompute requests up to `requestID`.
+func (s *ratingRepository) ClearRecomputeRequests(ctx context.Context, requestID int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.recomputeRequest <= requestID {
+		s.recomputeRequest = 0
+	}
+
+	return nil
+}
+
+// latest returns the current rating per player, the lock must be held.
+func (s *ratingRepository) latest() map[int]*volleynet.Rating {
+	latest := map[int]*volleynet.Rating{}
+
+	for _, rating := range s.ratings {
+		if current, ok := latest[rating.PlayerID]; !ok || rating.Tournaments > current.Tournaments {
+			latest[rating.PlayerID] = rating
+		}
+	}
+
+	return latest
+}
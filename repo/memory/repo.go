@@ -0,0 +1,128 @@
+// Package memory implements all repositories in memory, the data is lost
+// on restart. It's meant for unit tests and demo instances.
+package memory
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// Repositories returns a collection of all repositories that share the same
+// in memory store.
+func Repositories() *repo.Repositories {
+	s := &store{
+		players:     map[int]*volleynet.Player{},
+		tournaments: map[int]*volleynet.Tournament{},
+		users:       map[int]*scores.User{},
+		alertRules:  map[int]*scores.AlertRule{},
+		triggers:    map[alertTriggerKey]*scores.AlertTrigger{},
+		jobLeases:   map[string]*scores.JobLease{},
+		pollOffsets: map[string]int{},
+
+		partnerListings: map[int]*volleynet.PartnerListing{},
+		partnerRequests: map[int]*volleynet.PartnerRequest{},
+
+		watchedTournaments: map[int]*volleynet.WatchedTournament{},
+		followedPlayers:    map[int]*volleynet.FollowedPlayer{},
+
+		signups: map[int]*volleynet.ScheduledSignup{},
+	}
+
+	return &repo.Repositories{
+		UserRepo:       &userRepository{store: s},
+		PlayerRepo:     &playerRepository{store: s},
+		TournamentRepo: &tournamentRepository{store: s},
+		TeamRepo:       &teamRepository{store: s},
+		SettingRepo:    &settingRepository{store: s},
+
+		NotificationLogRepo: &notificationLogRepository{store: s},
+		AlertRuleRepo:       &alertRuleRepository{store: s},
+		JobExecutionRepo:    &jobExecutionRepository{store: s},
+		JobLeaseRepo:        &jobLeaseRepository{store: s},
+		PollOffsetRepo:      &pollOffsetRepository{store: s},
+		RatingRepo:          &ratingRepository{store: s},
+		PartnerRepo:         &partnerRepository{store: s},
+		WatchRepo:           &watchRepository{store: s},
+		ActivityRepo:        &activityRepository{store: s},
+		SignupRepo:          &signupRepository{store: s},
+		TransferRepo:        &transferRepository{store: s},
+	}
+}
+
+// store holds the data of all repositories, one lock guards all of it
+// because some queries (e.g. previous partners) span several entities.
+// Entities are copied in and out so callers can't modify stored data.
+type store struct {
+	lock sync.RWMutex
+
+	players     map[int]*volleynet.Player
+	tournaments map[int]*volleynet.Tournament
+	teams       []*volleynet.TournamentTeam
+	users       map[int]*scores.User
+	settings    []*scores.Setting
+
+	notificationLogs []*scores.NotificationLog
+	alertRules       map[int]*scores.AlertRule
+	triggers         map[alertTriggerKey]*scores.AlertTrigger
+	jobExecutions    []*scores.JobExecution
+	jobLeases        map[string]*scores.JobLease
+	pollOffsets      map[string]int
+	ratings          []*volleynet.Rating
+	recomputeRequest int // id of the latest pending recompute request
+	partnerListings  map[int]*volleynet.PartnerListing
+	partnerRequests  map[int]*volleynet.PartnerRequest
+
+	watchedTournaments map[int]*volleynet.WatchedTournament
+	followedPlayers    map[int]*volleynet.FollowedPlayer
+	activities         []*volleynet.Activity
+
+	signups map[int]*volleynet.ScheduledSignup
+
+	// lastID is the last assigned id of auto incremented entities
+	lastID map[string]int
+}
+
+// nextID returns the next auto incremented id of an entity, the lock must be held.
+func (s *store) nextID(entity string) int {
+	if s.lastID == nil {
+		s.lastID = map[string]int{}
+	}
+
+	s.lastID[entity]++
+
+	return s.lastID[entity]
+}
+
+// importedID makes sure that ids are assigned after an imported id, the lock must be held.
+func (s *store) importedID(entity string, id int) {
+	if s.lastID == nil {
+		s.lastID = map[string]int{}
+	}
+
+	if id > s.lastID[entity] {
+		s.lastID[entity] = id
+	}
+}
+
+// player returns a copy of a player without its timestamps, the lock must be held.
+func (s *store) player(playerID int) (*volleynet.Player, bool) {
+	player, ok := s.players[playerID]
+
+	if !ok {
+		return nil, false
+	}
+
+	p := *player
+	p.Track = scores.Track{}
+
+	return &p, true
+}
+
+// errDuplicate is returned if an entity with the same key already exists.
+func errDuplicate(entity string, key interface{}) error {
+	return fmt.Errorf("%s %v already exists", entity, key)
+}
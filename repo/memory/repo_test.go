@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+func TestPreviousPartners(t *testing.T) {
+	repos := Repositories()
+
+	for id := 1; id <= 4; id++ {
+		_, err := repos.PlayerRepo.New(context.Background(), &volleynet.Player{ID: id, Gender: "M"})
+		test.Check(t, "playerRepo.New() failed: %v", err)
+	}
+
+	start := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	err := repos.TournamentRepo.NewBatch(context.Background(),
+		&volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{ID: 1, Start: start}},
+		&volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{ID: 2, Start: start.AddDate(0, 1, 0)}},
+	)
+	test.Check(t, "tournamentRepo.NewBatch() failed: %v", err)
+
+	err = repos.TeamRepo.NewBatch(context.Background(),
+		&volleynet.TournamentTeam{TournamentID: 1, Player1: &volleynet.Player{ID: 1}, Player2: &volleynet.Player{ID: 2}},
+		&volleynet.TournamentTeam{TournamentID: 1, Player1: &volleynet.Player{ID: 3}, Player2: &volleynet.Player{ID: 4}},
+		&volleynet.TournamentTeam{TournamentID: 2, Player1: &volleynet.Player{ID: 3}, Player2: &volleynet.Player{ID: 1}},
+	)
+	test.Check(t, "teamRepo.NewBatch() failed: %v", err)
+
+	partners, err := repos.PlayerRepo.PreviousPartners(context.Background(), 1)
+
+	test.Check(t, "playerRepo.PreviousPartners() failed: %v", err)
+	test.Assert(t, "want 2 partners, got %d", len(partners) == 2, len(partners))
+	test.Assert(t, "want latest partner first, got %d", partners[0].ID == 3, partners[0].ID)
+}
+
+func TestTeams(t *testing.T) {
+	repos := Repositories()
+
+	repos.PlayerRepo.New(context.Background(), &volleynet.Player{ID: 1, FirstName: "Richard"})
+	repos.PlayerRepo.New(context.Background(), &volleynet.Player{ID: 2, FirstName: "Dominik"})
+
+	team := &volleynet.TournamentTeam{TournamentID: 1, Player1: &volleynet.Player{ID: 1}, Player2: &volleynet.Player{ID: 2}, Seed: 1}
+
+	_, err := repos.TeamRepo.New(context.Background(), team)
+	test.Check(t, "teamRepo.New() failed: %v", err)
+
+	_, err = repos.TeamRepo.New(context.Background(), team)
+	test.Assert(t, "expected an error when creating a team twice", err != nil)
+
+	team.Seed = 2
+	test.Check(t, "teamRepo.Update() failed: %v", repos.TeamRepo.Update(context.Background(), team))
+
+	teams, err := repos.TeamRepo.ByTournament(context.Background(), 1)
+
+	test.Check(t, "teamRepo.ByTournament() failed: %v", err)
+	test.Assert(t, "want 1 team, got %d", len(teams) == 1, len(teams))
+	test.Assert(t, "want seed 2, got %d", teams[0].Seed == 2, teams[0].Seed)
+	test.Assert(t, "want the player's name, got %q", teams[0].Player1.FirstName == "Richard", teams[0].Player1.FirstName)
+
+	test.Check(t, "teamRepo.Delete() failed: %v", repos.TeamRepo.Delete(context.Background(), team))
+
+	teams, _ = repos.TeamRepo.ByTournament(context.Background(), 1)
+	test.Assert(t, "want deleted team to be excluded, got %d teams", len(teams) == 0, len(teams))
+}
+
+func TestTournamentSearch(t *testing.T) {
+	repos := Repositories()
+
+	repos.TournamentRepo.NewBatch(context.Background(),
+		&volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{ID: 1, Season: "2019", LeagueKey: "amateur-tour", Gender: "M"}, HTMLNotes: "notes"},
+		&volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{ID: 2, Season: "2019", LeagueKey: "pro-tour", Gender: "M"}},
+		&volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{ID: 3, Season: "2018", LeagueKey: "amateur-tour", Gender: "W"}},
+	)
+
+	tournaments, err := repos.TournamentRepo.Search(context.Background(), repo.TournamentFilter{
+		Seasons: []string{"2019"},
+		Leagues: []string{"amateur-tour"},
+		Genders: []string{"M", "W"},
+	})
+
+	test.Check(t, "tournamentRepo.Search() failed: %v", err)
+	test.Assert(t, "want 1 tournament, got %d", len(tournaments) == 1, len(tournaments))
+	test.Assert(t, "want notes to be excluded", tournaments[0].HTMLNotes == "")
+
+	seasons, _ := repos.TournamentRepo.Seasons(context.Background())
+	test.Compare(t, "unexpected seasons:\n%s", []string{"2018", "2019"}, seasons)
+
+	tournament, err := repos.TournamentRepo.Get(context.Background(), 1)
+	test.Check(t, "tournamentRepo.Get() failed: %v", err)
+	test.Assert(t, "want notes, got %q", tournament.HTMLNotes == "notes", tournament.HTMLNotes)
+
+	_, err = repos.TournamentRepo.Get(context.Background(), 4)
+	test.Assert(t, "want ErrNotFound, got %v", errors.Cause(err) == scores.ErrNotFound, err)
+}
+
+func TestUsers(t *testing.T) {
+	repos := Repositories()
+
+	user, err := repos.UserRepo.New(context.Background(), &scores.User{Email: "test@test.com"})
+
+	test.Check(t, "userRepo.New() failed: %v", err)
+	test.Assert(t, "want id 1, got %d", user.ID == 1, user.ID)
+	test.Assert(t, "want created at to be set", !user.CreatedAt.IsZero())
+
+	_, err = repos.UserRepo.New(context.Background(), &scores.User{Email: "test@test.com"})
+	test.Assert(t, "expected an error for a duplicate email", err != nil)
+
+	user.PlayerID = 5
+	test.Check(t, "userRepo.Update() failed: %v", repos.UserRepo.Update(context.Background(), user))
+
+	persisted, err := repos.UserRepo.ByEmail(context.Background(), "test@test.com")
+
+	test.Check(t, "userRepo.ByEmail() failed: %v", err)
+	test.Assert(t, "want player id 5, got %d", persisted.PlayerID == 5, persisted.PlayerID)
+
+	_, err = repos.UserRepo.ByID(context.Background(), 2)
+	test.Assert(t, "want ErrNotFound, got %v", errors.Cause(err) == scores.ErrNotFound, err)
+}
+
+func TestJobLease(t *testing.T) {
+	repos := Repositories()
+	now := time.Now()
+
+	acquired, _ := repos.JobLeaseRepo.Acquire(context.Background(), &scores.JobLease{JobName: "a", Owner: "1", ExpiresAt: now.Add(time.Minute)}, now)
+	test.Assert(t, "expected the lease to be acquired", acquired)
+
+	acquired, _ = repos.JobLeaseRepo.Acquire(context.Background(), &scores.JobLease{JobName: "a", Owner: "2", ExpiresAt: now.Add(time.Minute)}, now)
+	test.Assert(t, "expected the lease to be held by another owner", !acquired)
+
+	acquired, _ = repos.JobLeaseRepo.Acquire(context.Background(), &scores.JobLease{JobName: "a", Owner: "2", ExpiresAt: now.Add(3 * time.Minute)}, now.Add(2*time.Minute))
+	test.Assert(t, "expected the expired lease to be taken over", acquired)
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+)
+
+var _ repo.SettingRepository = &settingRepository{}
+
+type settingRepository struct {
+	*store
+}
+
+func (s *settingRepository) Create(ctx context.Context, setting *scores.Setting) (*scores.Setting, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.setting(setting.UserID, setting.Key) != nil {
+		return setting, errors.Wrap(errDuplicate("setting", setting.Key), "insert setting")
+	}
+
+	setting.Create(time.Now())
+
+	st := *setting
+	s.settings = append(s.settings, &st)
+
+	return setting, nil
+}
+
+func (s *settingRepository) Update(ctx context.Context, setting *scores.Setting) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	persisted := s.setting(setting.UserID, setting.Key)
+
+	if persisted == nil {
+		return errors.Wrap(scores.ErrNotFound, "update setting")
+	}
+
+	setting.Update(time.Now())
+
+	persisted.UpdatedAt = setting.UpdatedAt
+	persisted.Value = setting.Value
+
+	return nil
+}
+
+func (s *settingRepository) ByUserID(ctx context.Context, userID int) ([]*scores.Setting, error) {
+	return s.filter(func(st *scores.Setting) bool { return st.UserID == userID }), nil
+}
+
+func (s *settingRepository) ByKey(ctx context.Context, key string) ([]*scores.Setting, error) {
+	return s.filter(func(st *scores.Setting) bool { return st.Key == key }), nil
+}
+
+func (s *settingRepository) All(ctx context.Context) ([]*scores.Setting, error) {
+	return s.filter(func(st *scores.Setting) bool { return true }), nil
+}
+
+func (s *settingRepository) filter(match func(st *scores.Setting) bool) []*scores.Setting {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	settings := []*scores.Setting{}
+
+	for _, setting := range s.settings {
+		if match(setting) {
+			st := *setting
+			settings = append(settings, &st)
+		}
+	}
+
+	return settings
+}
+
+// setting returns the stored setting of a user, the lock must be held.
+func (s *store) setting(userID int, key string) *scores.Setting {
+	for _, setting := range s.settings {
+		if setting.UserID == userID && setting.Key == key {
+			return setting
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.SignupRepository = &signupRepository{}
+
+type signupRepository struct {
+	*store
+}
+
+// Get loads a scheduled signup.
+func (s *signupRepository) Get(ctx context.Context, signupID int) (*volleynet.ScheduledSignup, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	signup, ok := s.signups[signupID]
+
+	if !ok || signup.DeletedAt != nil {
+		return &volleynet.ScheduledSignup{}, errors.Wrap(scores.ErrNotFound, "signup")
+	}
+
+	su := *signup
+
+	return &su, nil
+}
+
+// ByUser returns the signups of a user, the latest first.
+func (s *signupRepository) ByUser(ctx context.Context, userID int) ([]*volleynet.ScheduledSignup, error) {
+	signups := s.filter(func(su *volleynet.ScheduledSignup) bool {
+		return su.UserID == userID
+	})
+
+	sort.Slice(signups, func(i, j int) bool {
+		return signups[i].ID > signups[j].ID
+	})
+
+	return signups, nil
+}
+
+// ByStatus returns the signups with the status, the oldest first.
+func (s *signupRepository) ByStatus(ctx context.Context, status string) ([]*volleynet.ScheduledSignup, error) {
+	signups := s.filter(func(su *volleynet.ScheduledSignup) bool {
+		return su.Status == status
+	})
+
+	sort.Slice(signups, func(i, j int) bool {
+		return signups[i].ID < signups[j].ID
+	})
+
+	return signups, nil
+}
+
+// New persists a scheduled signup and assigns a new id.
+func (s *signupRepository) New(ctx context.Context, signup *volleynet.ScheduledSignup) (
+	*volleynet.ScheduledSignup, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	signup.Create(time.Now())
+	signup.SetID(s.nextID("scheduled-signup"))
+
+	su := *signup
+	s.signups[su.ID] = &su
+
+	return signup, nil
+}
+
+// SetStatus changes the status of a signup from `current` to `status`.
+func (s *signupRepository) SetStatus(ctx context.Context, signupID int, current, status string) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	signup, ok := s.signups[signupID]
+
+	if !ok || signup.DeletedAt != nil || signup.Status != current {
+		return false, nil
+	}
+
+	signup.Update(time.Now())
+	signup.Status = status
+
+	return true, nil
+}
+
+// Finish persists the status, message and attempt of a signup and deletes
+// its credentials if its status is `current`.
+func (s *signupRepository) Finish(ctx context.Context, signup *volleynet.ScheduledSignup, current string) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stored, ok := s.signups[signup.ID]
+
+	if !ok || stored.DeletedAt != nil || stored.Status != current {
+		return false, nil
+	}
+
+	signup.Update(time.Now())
+	signup.Credentials = ""
+
+	stored.UpdatedAt = signup.UpdatedAt
+	stored.Status = signup.Status
+	stored.Message = signup.Message
+	stored.Credentials = ""
+	stored.AttemptedAt = signup.AttemptedAt
+
+	return true, nil
+}
+
+// filter returns copies of all signups that are not deleted and match.
+func (s *signupRepository) filter(match func(su *volleynet.ScheduledSignup) bool) []*volleynet.ScheduledSignup {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	signups := []*volleynet.ScheduledSignup{}
+
+	for _, signup := range s.signups {
+		if signup.DeletedAt == nil && match(signup) {
+			su := *signup
+			signups = append(signups, &su)
+		}
+	}
+
+	return signups
+}
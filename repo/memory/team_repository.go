@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.TeamRepository = &teamRepository{}
+
+type teamRepository struct {
+	*store
+}
+
+// New creates a new team.
+func (s *teamRepository) New(ctx context.Context, t *volleynet.TournamentTeam) (*volleynet.TournamentTeam, error) {
+	err := s.NewBatch(ctx, t)
+
+	return t, errors.Wrap(err, "insert team")
+}
+
+// NewBatch creates new teams.
+func (s *teamRepository) NewBatch(ctx context.Context, teams ...*volleynet.TournamentTeam) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+
+	for _, t := range teams {
+		if s.team(t) != nil {
+			return errors.Wrap(errDuplicate("team", teamKey(t)), "batch insert team")
+		}
+
+		t.Create(now)
+
+		team := *t
+		team.Player1 = &volleynet.Player{ID: t.Player1.ID}
+		team.Player2 = &volleynet.Player{ID: t.Player2.ID}
+
+		s.teams = append(s.teams, &team)
+	}
+
+	return nil
+}
+
+// Update updates a tournament team.
+func (s *teamRepository) Update(ctx context.Context, t *volleynet.TournamentTeam) error {
+	err := s.UpdateBatch(ctx, t)
+
+	return errors.Wrap(err, "update team")
+}
+
+// UpdateBatch updates tournament teams.
+func (s *teamRepository) UpdateBatch(ctx context.Context, teams ...*volleynet.TournamentTeam) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+
+	for _, t := range teams {
+		persisted := s.team(t)
+
+		if persisted == nil {
+			return errors.Wrap(scores.ErrNotFound, "batch update team")
+		}
+
+		t.Update(now)
+
+		persisted.UpdatedAt = t.UpdatedAt
+		persisted.Result = t.Result
+		persisted.Seed = t.Seed
+		persisted.TotalPoints = t.TotalPoints
+		persisted.WonPoints = t.WonPoints
+		persisted.PrizeMoney = t.PrizeMoney
+		persisted.Deregistered = t.Deregistered
+	}
+
+	return nil
+}
+
+// Delete deletes a team.
+func (s *teamRepository) Delete(ctx context.Context, t *volleynet.TournamentTeam) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	persisted := s.team(t)
+
+	if persisted == nil {
+		return errors.Wrap(scores.ErrNotFound, "delete team")
+	}
+
+	t.Delete(time.Now())
+	persisted.DeletedAt = t.DeletedAt
+
+	return nil
+}
+
+// ByTournament loads all teams of a tournament, the players only
+// contain the fields that are relevant for a team.
+func (s *teamRepository) ByTournament(ctx context.Context, tournamentID int) (
+	[]*volleynet.TournamentTeam, error) {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	teams := []*volleynet.TournamentTeam{}
+
+	for _, t := range s.teams {
+		if t.TournamentID != tournamentID || t.DeletedAt != nil {
+			continue
+		}
+
+		player1, ok1 := s.players[t.Player1.ID]
+		player2, ok2 := s.players[t.Player2.ID]
+
+		if !ok1 || !ok2 {
+			continue
+		}
+
+		teams = append(teams, &volleynet.TournamentTeam{
+			TournamentID: t.TournamentID,
+			Player1:      teamPlayer(player1),
+			Player2:      teamPlayer(player2),
+			Deregistered: t.Deregistered,
+			PrizeMoney:   t.PrizeMoney,
+			Result:       t.Result,
+			Seed:         t.Seed,
+			TotalPoints:  t.TotalPoints,
+			WonPoints:    t.WonPoints,
+		})
+	}
+
+	return teams, nil
+}
+
+// team returns the stored team with the same tournament and players,
+// the lock must be held.
+func (s *store) team(t *volleynet.TournamentTeam) *volleynet.TournamentTeam {
+	for _, team := range s.teams {
+		if teamKey(team) == teamKey(t) {
+			return team
+		}
+	}
+
+	return nil
+}
+
+func teamKey(t *volleynet.TournamentTeam) [3]int {
+	return [3]int{t.TournamentID, t.Player1.ID, t.Player2.ID}
+}
+
+func teamPlayer(p *volleynet.Player) *volleynet.Player {
+	return &volleynet.Player{
+		ID:           p.ID,
+		FirstName:    p.FirstName,
+		LastName:     p.LastName,
+		TotalPoints:  p.TotalPoints,
+		CountryUnion: p.CountryUnion,
+		Birthday:     p.Birthday,
+		License:      p.License,
+		Gender:       p.Gender,
+	}
+}
@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+type tournamentRepository struct {
+	*store
+}
+
+var _ repo.TournamentRepository = &tournamentRepository{}
+
+// Get loads a tournament by its id.
+func (s *tournamentRepository) Get(ctx context.Context, tournamentID int) (*volleynet.Tournament, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	tournament, ok := s.tournaments[tournamentID]
+
+	if !ok {
+		return &volleynet.Tournament{}, errors.Wrap(scores.ErrNotFound, "get tournament")
+	}
+
+	t := *tournament
+	t.Teams = []*volleynet.TournamentTeam{}
+
+	return &t, nil
+}
+
+// New creates a new tournament.
+func (s *tournamentRepository) New(ctx context.Context, t *volleynet.Tournament) (*volleynet.Tournament, error) {
+	err := s.NewBatch(ctx, t)
+
+	return t, errors.Wrap(err, "insert tournament")
+}
+
+// NewBatch creates new tournaments.
+func (s *tournamentRepository) NewBatch(ctx context.Context, tournaments ...*volleynet.Tournament) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+
+	for _, t := range tournaments {
+		if _, ok := s.tournaments[t.ID]; ok {
+			return errors.Wrap(errDuplicate("tournament", t.ID), "insert tournament")
+		}
+
+		t.Create(now)
+
+		tournament := *t
+		tournament.Teams = nil
+		s.tournaments[t.ID] = &tournament
+	}
+
+	return nil
+}
+
+// Update updates a tournament.
+func (s *tournamentRepository) Update(ctx context.Context, t *volleynet.Tournament) error {
+	err := s.UpdateBatch(ctx, t)
+
+	return errors.Wrap(err, "update tournament")
+}
+
+// UpdateBatch updates tournaments.
+func (s *tournamentRepository) UpdateBatch(ctx context.Context, tournaments ...*volleynet.Tournament) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+
+	for _, t := range tournaments {
+		persisted, ok := s.tournaments[t.ID]
+
+		if !ok {
+			return errors.Wrap(scores.ErrNotFound, "update tournament")
+		}
+
+		t.Update(now)
+
+		tournament := *t
+		tournament.Teams = nil
+		tournament.CreatedAt = persisted.CreatedAt
+		tournament.DeletedAt = persisted.DeletedAt
+		s.tournaments[t.ID] = &tournament
+	}
+
+	return nil
+}
+
+// Search loads all tournaments by season, league and gender.
+func (s *tournamentRepository) Search(ctx context.Context, filter repo.TournamentFilter) (
+	[]*volleynet.Tournament, error) {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	tournaments := []*volleynet.Tournament{}
+
+	for _, tournament := range s.tournaments {
+		if !contains(filter.Seasons, tournament.Season) ||
+			!contains(filter.Leagues, tournament.LeagueKey) ||
+			!contains(filter.Genders, tournament.Gender) {
+			continue
+		}
+
+		t := *tournament
+		t.HTMLNotes = "" // the notes are only loaded with `Get`
+		tournaments = append(tournaments, &t)
+	}
+
+	sort.Slice(tournaments, func(i, j int) bool {
+		if !tournaments[i].Start.Equal(tournaments[j].Start) {
+			return tournaments[i].Start.Before(tournaments[j].Start)
+		}
+
+		return tournaments[i].ID < tournaments[j].ID
+	})
+
+	return tournaments, nil
+}
+
+// Seasons returns all available seasons
+func (s *tournamentRepository) Seasons(ctx context.Context) ([]string, error) {
+	return s.distinct(func(t *volleynet.Tournament) string { return t.Season }), nil
+}
+
+// Leagues returns all available leagues
+func (s *tournamentRepository) Leagues(ctx context.Context) ([]string, error) {
+	return s.distinct(func(t *volleynet.Tournament) string { return t.LeagueKey }), nil
+}
+
+// SubLeagues returns all available sub-leagues
+func (s *tournamentRepository) SubLeagues(ctx context.Context) ([]string, error) {
+	return s.distinct(func(t *volleynet.Tournament) string { return t.SubLeagueKey }), nil
+}
+
+// distinct returns the sorted distinct values of a tournament field.
+func (s *tournamentRepository) distinct(field func(t *volleynet.Tournament) string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	seen := map[string]bool{}
+	values := []string{}
+
+	for _, t := range s.tournaments {
+		value := field(t)
+
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
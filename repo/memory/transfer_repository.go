@@ -0,0 +1,521 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.TransferRepository = &transferRepository{}
+
+type transferRepository struct {
+	*store
+}
+
+// Players loads all players.
+func (s *transferRepository) Players(ctx context.Context) ([]*volleynet.Player, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	players := []*volleynet.Player{}
+
+	for _, player := range s.players {
+		p := *player
+		players = append(players, &p)
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].ID < players[j].ID
+	})
+
+	return players, nil
+}
+
+// Tournaments loads all tournaments without their teams.
+func (s *transferRepository) Tournaments(ctx context.Context) ([]*volleynet.Tournament, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	tournaments := []*volleynet.Tournament{}
+
+	for _, tournament := range s.tournaments {
+		t := *tournament
+		tournaments = append(tournaments, &t)
+	}
+
+	sort.Slice(tournaments, func(i, j int) bool {
+		return tournaments[i].ID < tournaments[j].ID
+	})
+
+	return tournaments, nil
+}
+
+// Teams loads all teams, only the ids of their players are set.
+func (s *transferRepository) Teams(ctx context.Context) ([]*volleynet.TournamentTeam, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	teams := []*volleynet.TournamentTeam{}
+
+	for _, team := range s.teams {
+		t := *team
+		t.Player1 = &volleynet.Player{ID: team.Player1.ID}
+		t.Player2 = &volleynet.Player{ID: team.Player2.ID}
+		teams = append(teams, &t)
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		a, b := teams[i], teams[j]
+
+		if a.TournamentID != b.TournamentID {
+			return a.TournamentID < b.TournamentID
+		}
+		if a.Player1.ID != b.Player1.ID {
+			return a.Player1.ID < b.Player1.ID
+		}
+
+		return a.Player2.ID < b.Player2.ID
+	})
+
+	return teams, nil
+}
+
+// Users loads all users without their settings.
+func (s *transferRepository) Users(ctx context.Context) ([]*scores.User, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	users := []*scores.User{}
+
+	for _, user := range s.users {
+		users = append(users, storedUser(user))
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+
+	return users, nil
+}
+
+// Settings loads the settings of all users.
+func (s *transferRepository) Settings(ctx context.Context) ([]*scores.Setting, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	settings := []*scores.Setting{}
+
+	for _, setting := range s.settings {
+		st := *setting
+		settings = append(settings, &st)
+	}
+
+	sort.Slice(settings, func(i, j int) bool {
+		a, b := settings[i], settings[j]
+
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+
+		return a.Key < b.Key
+	})
+
+	return settings, nil
+}
+
+// NotificationLogs loads the notification log of all users.
+func (s *transferRepository) NotificationLogs(ctx context.Context) ([]*scores.NotificationLog, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	logs := []*scores.NotificationLog{}
+
+	for _, log := range s.notificationLogs {
+		l := *log
+		logs = append(logs, &l)
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		return logs[i].ID < logs[j].ID
+	})
+
+	return logs, nil
+}
+
+// AlertRules loads the alert rules of all users.
+func (s *transferRepository) AlertRules(ctx context.Context) ([]*scores.AlertRule, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	rules := []*scores.AlertRule{}
+
+	for _, rule := range s.alertRules {
+		r := *rule
+		rules = append(rules, &r)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules, nil
+}
+
+// AlertTriggers loads the triggers of all alert rules.
+func (s *transferRepository) AlertTriggers(ctx context.Context) ([]*scores.AlertTrigger, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	triggers := []*scores.AlertTrigger{}
+
+	for _, trigger := range s.triggers {
+		t := *trigger
+		triggers = append(triggers, &t)
+	}
+
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].ID < triggers[j].ID
+	})
+
+	return triggers, nil
+}
+
+// JobExecutions loads the whole job history.
+func (s *transferRepository) JobExecutions(ctx context.Context) ([]*scores.JobExecution, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	executions := []*scores.JobExecution{}
+
+	for _, execution := range s.jobExecutions {
+		e := *execution
+		executions = append(executions, &e)
+	}
+
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].ID < executions[j].ID
+	})
+
+	return executions, nil
+}
+
+// PartnerListings loads all listings of the partner board, only the ids of their players are set.
+func (s *transferRepository) PartnerListings(ctx context.Context) ([]*volleynet.PartnerListing, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	listings := []*volleynet.PartnerListing{}
+
+	for _, listing := range s.partnerListings {
+		l := *listing
+		l.Player = &volleynet.Player{ID: listing.Player.ID}
+		listings = append(listings, &l)
+	}
+
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].ID < listings[j].ID
+	})
+
+	return listings, nil
+}
+
+// PartnerRequests loads all requests of the partner board, only the ids of their players are set.
+func (s *transferRepository) PartnerRequests(ctx context.Context) ([]*volleynet.PartnerRequest, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	requests := []*volleynet.PartnerRequest{}
+
+	for _, request := range s.partnerRequests {
+		r := *request
+		r.Player = &volleynet.Player{ID: request.Player.ID}
+		requests = append(requests, &r)
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].ID < requests[j].ID
+	})
+
+	return requests, nil
+}
+
+// WatchedTournaments loads all watched tournaments.
+func (s *transferRepository) WatchedTournaments(ctx context.Context) ([]*volleynet.WatchedTournament, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	watches := []*volleynet.WatchedTournament{}
+
+	for _, watch := range s.watchedTournaments {
+		w := *watch
+		watches = append(watches, &w)
+	}
+
+	sort.Slice(watches, func(i, j int) bool {
+		return watches[i].ID < watches[j].ID
+	})
+
+	return watches, nil
+}
+
+// FollowedPlayers loads all followed players, only the ids of the players are set.
+func (s *transferRepository) FollowedPlayers(ctx context.Context) ([]*volleynet.FollowedPlayer, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	follows := []*volleynet.FollowedPlayer{}
+
+	for _, follow := range s.followedPlayers {
+		f := *follow
+		f.Player = &volleynet.Player{ID: follow.Player.ID}
+		follows = append(follows, &f)
+	}
+
+	sort.Slice(follows, func(i, j int) bool {
+		return follows[i].ID < follows[j].ID
+	})
+
+	return follows, nil
+}
+
+// ScheduledSignups returns the # of scheduled signups.
+func (s *transferRepository) ScheduledSignups(ctx context.Context) (int, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.signups), nil
+}
+
+// ImportPlayers persists players unchanged.
+func (s *transferRepository) ImportPlayers(ctx context.Context, players ...*volleynet.Player) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, player := range players {
+		if _, ok := s.players[player.ID]; ok {
+			return errors.Wrap(errDuplicate("player", player.ID), "import players")
+		}
+
+		p := *player
+		s.players[p.ID] = &p
+	}
+
+	return nil
+}
+
+// ImportTournaments persists tournaments unchanged, their teams are ignored.
+func (s *transferRepository) ImportTournaments(ctx context.Context, tournaments ...*volleynet.Tournament) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, tournament := range tournaments {
+		if _, ok := s.tournaments[tournament.ID]; ok {
+			return errors.Wrap(errDuplicate("tournament", tournament.ID), "import tournaments")
+		}
+
+		t := *tournament
+		t.Teams = nil
+		s.tournaments[t.ID] = &t
+	}
+
+	return nil
+}
+
+// ImportTeams persists teams unchanged.
+func (s *transferRepository) ImportTeams(ctx context.Context, teams ...*volleynet.TournamentTeam) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, team := range teams {
+		if s.team(team) != nil {
+			return errors.Wrap(errDuplicate("team", teamKey(team)), "import teams")
+		}
+
+		t := *team
+		t.Player1 = &volleynet.Player{ID: team.Player1.ID}
+		t.Player2 = &volleynet.Player{ID: team.Player2.ID}
+		s.teams = append(s.teams, &t)
+	}
+
+	return nil
+}
+
+// ImportUsers persists users unchanged, their settings are ignored.
+func (s *transferRepository) ImportUsers(ctx context.Context, users ...*scores.User) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, user := range users {
+		if _, ok := s.users[user.ID]; ok {
+			return errors.Wrap(errDuplicate("user", user.ID), "import users")
+		}
+
+		s.users[user.ID] = storedUser(user)
+		s.importedID("user", user.ID)
+	}
+
+	return nil
+}
+
+// ImportSettings persists settings unchanged.
+func (s *transferRepository) ImportSettings(ctx context.Context, settings ...*scores.Setting) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, setting := range settings {
+		if s.setting(setting.UserID, setting.Key) != nil {
+			return errors.Wrap(errDuplicate("setting", setting.Key), "import settings")
+		}
+
+		st := *setting
+		s.settings = append(s.settings, &st)
+	}
+
+	return nil
+}
+
+// ImportNotificationLogs persists notification log entries unchanged.
+func (s *transferRepository) ImportNotificationLogs(ctx context.Context, logs ...*scores.NotificationLog) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, log := range logs {
+		l := *log
+		s.notificationLogs = append(s.notificationLogs, &l)
+		s.importedID("notification-log", l.ID)
+	}
+
+	return nil
+}
+
+// ImportAlertRules persists alert rules unchanged.
+func (s *transferRepository) ImportAlertRules(ctx context.Context, rules ...*scores.AlertRule) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, rule := range rules {
+		if _, ok := s.alertRules[rule.ID]; ok {
+			return errors.Wrap(errDuplicate("alert rule", rule.ID), "import alert rules")
+		}
+
+		r := *rule
+		s.alertRules[r.ID] = &r
+		s.importedID("alert-rule", r.ID)
+	}
+
+	return nil
+}
+
+// ImportAlertTriggers persists alert triggers unchanged.
+func (s *transferRepository) ImportAlertTriggers(ctx context.Context, triggers ...*scores.AlertTrigger) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, trigger := range triggers {
+		key := alertTriggerKey{ruleID: trigger.RuleID, key: trigger.Key}
+
+		if _, ok := s.triggers[key]; ok {
+			return errors.Wrap(errDuplicate("alert trigger", trigger.Key), "import alert triggers")
+		}
+
+		t := *trigger
+		s.triggers[key] = &t
+		s.importedID("alert-trigger", t.ID)
+	}
+
+	return nil
+}
+
+// ImportJobExecutions persists job executions unchanged.
+func (s *transferRepository) ImportJobExecutions(ctx context.Context, executions ...*scores.JobExecution) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, execution := range executions {
+		e := *execution
+		s.jobExecutions = append(s.jobExecutions, &e)
+		s.importedID("job-execution", e.ID)
+	}
+
+	return nil
+}
+
+// ImportPartnerListings persists partner listings unchanged.
+func (s *transferRepository) ImportPartnerListings(ctx context.Context, listings ...*volleynet.PartnerListing) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, listing := range listings {
+		if _, ok := s.partnerListings[listing.ID]; ok {
+			return errors.Wrap(errDuplicate("partner listing", listing.ID), "import partner listings")
+		}
+
+		l := *listing
+		l.Player = &volleynet.Player{ID: listing.Player.ID}
+		s.partnerListings[l.ID] = &l
+		s.importedID("partner-listing", l.ID)
+	}
+
+	return nil
+}
+
+// ImportPartnerRequests persists partner requests unchanged.
+func (s *transferRepository) ImportPartnerRequests(ctx context.Context, requests ...*volleynet.PartnerRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, request := range requests {
+		if _, ok := s.partnerRequests[request.ID]; ok {
+			return errors.Wrap(errDuplicate("partner request", request.ID), "import partner requests")
+		}
+
+		r := *request
+		r.Player = &volleynet.Player{ID: request.Player.ID}
+		s.partnerRequests[r.ID] = &r
+		s.importedID("partner-request", r.ID)
+	}
+
+	return nil
+}
+
+// ImportWatchedTournaments persists watched tournaments unchanged.
+func (s *transferRepository) ImportWatchedTournaments(ctx context.Context, watches ...*volleynet.WatchedTournament) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, watch := range watches {
+		if _, ok := s.watchedTournaments[watch.ID]; ok {
+			return errors.Wrap(errDuplicate("watched tournament", watch.ID), "import watched tournaments")
+		}
+
+		w := *watch
+		s.watchedTournaments[w.ID] = &w
+		s.importedID("watched-tournament", w.ID)
+	}
+
+	return nil
+}
+
+// ImportFollowedPlayers persists followed players unchanged.
+func (s *transferRepository) ImportFollowedPlayers(ctx context.Context, follows ...*volleynet.FollowedPlayer) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, follow := range follows {
+		if _, ok := s.followedPlayers[follow.ID]; ok {
+			return errors.Wrap(errDuplicate("followed player", follow.ID), "import followed players")
+		}
+
+		f := *follow
+		f.Player = &volleynet.Player{ID: follow.Player.ID}
+		s.followedPlayers[f.ID] = &f
+		s.importedID("followed-player", f.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+)
+
+var _ repo.UserRepository = &userRepository{}
+
+type userRepository struct {
+	*store
+}
+
+// New persists a user and assigns a new id.
+func (s *userRepository) New(ctx context.Context, user *scores.User) (*scores.User, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, u := range s.users {
+		if u.Email == user.Email {
+			return user, errors.Wrap(errDuplicate("user", user.Email), "new user")
+		}
+	}
+
+	user.Create(time.Now())
+	user.SetID(s.nextID("user"))
+
+	s.users[user.ID] = storedUser(user)
+
+	return user, nil
+}
+
+// Update updates a user, the timestamps are not changed.
+func (s *userRepository) Update(ctx context.Context, user *scores.User) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	persisted, ok := s.users[user.ID]
+
+	if !ok {
+		return errors.Wrap(scores.ErrNotFound, "update user")
+	}
+
+	for _, u := range s.users {
+		if u.ID != user.ID && u.Email == user.Email {
+			return errors.Wrap(errDuplicate("user", user.Email), "update user")
+		}
+	}
+
+	u := storedUser(user)
+	u.Track = persisted.Track
+	s.users[user.ID] = u
+
+	return nil
+}
+
+// All returns all user's, this is used mainly for testing.
+func (s *userRepository) All(ctx context.Context) ([]*scores.User, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	users := []*scores.User{}
+
+	for _, user := range s.users {
+		u := *user
+		users = append(users, &u)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+
+	return users, nil
+}
+
+// ByID retrieves a user by his/her ID.
+func (s *userRepository) ByID(ctx context.Context, userID int) (*scores.User, error) {
+	user, err := s.find(func(u *scores.User) bool { return u.ID == userID })
+
+	return user, errors.Wrap(err, "byID user")
+}
+
+// ByEmail retrieves a user by his/her email.
+func (s *userRepository) ByEmail(ctx context.Context, email string) (*scores.User, error) {
+	user, err := s.find(func(u *scores.User) bool { return u.Email == email })
+
+	return user, errors.Wrap(err, "byEmail user")
+}
+
+// find returns a copy of the first user that is not deleted and matches.
+func (s *userRepository) find(match func(u *scores.User) bool) (*scores.User, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, user := range s.users {
+		if user.DeletedAt == nil && match(user) {
+			u := *user
+			return &u, nil
+		}
+	}
+
+	return &scores.User{}, scores.ErrNotFound
+}
+
+// storedUser copies the persisted fields of a user, settings
+// are stored by the setting repository.
+func storedUser(user *scores.User) *scores.User {
+	u := *user
+	u.Settings = nil
+
+	return &u
+}
@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.WatchRepository = &watchRepository{}
+
+type watchRepository struct {
+	*store
+}
+
+// Tournaments returns the watched tournaments of a user, the latest first.
+func (s *watchRepository) Tournaments(ctx context.Context, userID int) ([]*volleynet.WatchedTournament, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	watches := []*volleynet.WatchedTournament{}
+
+	for _, watch := range s.watchedTournaments {
+		if watch.UserID == userID && watch.DeletedAt == nil {
+			w := *watch
+			watches = append(watches, &w)
+		}
+	}
+
+	sort.Slice(watches, func(i, j int) bool {
+		return watches[i].ID > watches[j].ID
+	})
+
+	return watches, nil
+}
+
+// WatchTournament persists a watched tournament and assigns a new id.
+func (s *watchRepository) WatchTournament(ctx context.Context, w *volleynet.WatchedTournament) (
+	*volleynet.WatchedTournament, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, watch := range s.watchedTournaments {
+		if watch.UserID == w.UserID && watch.TournamentID == w.TournamentID {
+			return nil, errors.Wrap(errDuplicate("watched tournament", w.TournamentID), "watch tournament")
+		}
+	}
+
+	w.Create(time.Now())
+	w.SetID(s.nextID("watched-tournament"))
+
+	watch := *w
+	s.watchedTournaments[watch.ID] = &watch
+
+	return w, nil
+}
+
+// UnwatchTournament removes a tournament from the watchlist of a user.
+func (s *watchRepository) UnwatchTournament(ctx context.Context, userID, tournamentID int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for id, watch := range s.watchedTournaments {
+		if watch.UserID == userID && watch.TournamentID == tournamentID {
+			delete(s.watchedTournaments, id)
+			return nil
+		}
+	}
+
+	return errors.Wrap(scores.ErrNotFound, "unwatch tournament")
+}
+
+// Players returns the followed players of a user, the latest first.
+func (s *watchRepository) Players(ctx context.Context, userID int) ([]*volleynet.FollowedPlayer, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	follows := []*volleynet.FollowedPlayer{}
+
+	for _, follow := range s.followedPlayers {
+		if follow.UserID != userID || follow.DeletedAt != nil {
+			continue
+		}
+
+		player, ok := s.player(follow.Player.ID)
+
+		if !ok {
+			continue
+		}
+
+		f := *follow
+		f.Player = player
+		follows = append(follows, &f)
+	}
+
+	sort.Slice(follows, func(i, j int) bool {
+		return follows[i].ID > follows[j].ID
+	})
+
+	return follows, nil
+}
+
+// FollowPlayer persists a followed player and assigns a new id.
+func (s *watchRepository) FollowPlayer(ctx context.Context, f *volleynet.FollowedPlayer) (
+	*volleynet.FollowedPlayer, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, follow := range s.followedPlayers {
+		if follow.UserID == f.UserID && follow.Player.ID == f.Player.ID {
+			return nil, errors.Wrap(errDuplicate("followed player", f.Player.ID), "follow player")
+		}
+	}
+
+	f.Create(time.Now())
+	f.SetID(s.nextID("followed-player"))
+
+	follow := *f
+	follow.Player = &volleynet.Player{ID: f.Player.ID}
+	s.followedPlayers[follow.ID] = &follow
+
+	return f, nil
+}
+
+// UnfollowPlayer removes a player from the followed players of a user.
+func (s *watchRepository) UnfollowPlayer(ctx context.Context, userID, playerID int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for id, follow := range s.followedPlayers {
+		if follow.UserID == userID && follow.Player.ID == playerID {
+			delete(s.followedPlayers, id)
+			return nil
+		}
+	}
+
+	return errors.Wrap(scores.ErrNotFound, "unfollow player")
+}
+
+// IsFollowed returns true if the player is followed by at least one user.
+func (s *watchRepository) IsFollowed(ctx context.Context, playerID int) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, follow := range s.followedPlayers {
+		if follow.DeletedAt == nil && follow.Player.ID == playerID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
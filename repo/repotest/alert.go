@@ -0,0 +1,113 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+)
+
+var alertRuleTests = []conformanceTest{
+	{"AlertRule/Create", testAlertRuleCreate},
+	{"AlertRule/UpdateDelete", testAlertRuleUpdateDelete},
+	{"AlertRule/Trigger", testAlertRuleTrigger},
+}
+
+func testAlertRuleCreate(t *testing.T, repos *repo.Repositories) {
+	users := newUsers(t, repos, 2)
+
+	rule := &scores.AlertRule{
+		UserID:    users[0].ID,
+		Name:      "Pro tour near vienna",
+		Event:     "registration-open",
+		League:    "pro-tour",
+		Gender:    "M",
+		PlayerID:  12,
+		Latitude:  48.2,
+		Longitude: 16.37,
+		Radius:    50,
+	}
+
+	_, err := repos.AlertRuleRepo.New(context.Background(), rule)
+
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+	test.Assert(t, "alertRuleRepo.New() should assign an id", rule.ID > 0)
+	test.Assert(t, "alertRuleRepo.New() should set CreatedAt", !rule.CreatedAt.IsZero())
+
+	persisted, err := repos.AlertRuleRepo.ByID(context.Background(), rule.ID)
+
+	test.Check(t, "alertRuleRepo.ByID() failed: %v", err)
+	test.Compare(t, "persisted rule differs:\n%s", rule, persisted)
+
+	other := &scores.AlertRule{UserID: users[1].ID}
+	_, err = repos.AlertRuleRepo.New(context.Background(), other)
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+
+	rules, err := repos.AlertRuleRepo.ByUserID(context.Background(), users[0].ID)
+
+	test.Check(t, "alertRuleRepo.ByUserID() failed: %v", err)
+	test.Assert(t, "want 1 rule, got %d", len(rules) == 1, len(rules))
+
+	rules, err = repos.AlertRuleRepo.All(context.Background())
+
+	test.Check(t, "alertRuleRepo.All() failed: %v", err)
+	test.Assert(t, "want all rules ordered by id, got %v", len(rules) == 2 && rules[0].ID == rule.ID && rules[1].ID == other.ID, rules)
+}
+
+func testAlertRuleUpdateDelete(t *testing.T, repos *repo.Repositories) {
+	user := newUsers(t, repos, 1)[0]
+	rule := &scores.AlertRule{UserID: user.ID, Event: "results"}
+
+	_, err := repos.AlertRuleRepo.New(context.Background(), rule)
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+
+	rule.Radius = 20
+
+	err = repos.AlertRuleRepo.Update(context.Background(), rule)
+	test.Check(t, "alertRuleRepo.Update() failed: %v", err)
+
+	persisted, _ := repos.AlertRuleRepo.ByID(context.Background(), rule.ID)
+	test.Assert(t, "want radius 20, got %d", persisted.Radius == 20, persisted.Radius)
+	test.Assert(t, "want UpdatedAt to be set", persisted.UpdatedAt != nil)
+
+	err = repos.AlertRuleRepo.Delete(context.Background(), rule)
+	test.Check(t, "alertRuleRepo.Delete() failed: %v", err)
+
+	_, err = repos.AlertRuleRepo.ByID(context.Background(), rule.ID)
+	checkNotFound(t, "alertRuleRepo.ByID() of a deleted rule", err)
+
+	err = repos.AlertRuleRepo.Update(context.Background(), rule)
+	checkNotFound(t, "alertRuleRepo.Update() of a deleted rule", err)
+
+	err = repos.AlertRuleRepo.Delete(context.Background(), rule)
+	checkNotFound(t, "alertRuleRepo.Delete() of a deleted rule", err)
+
+	rules, _ := repos.AlertRuleRepo.All(context.Background())
+	test.Assert(t, "want deleted rules to be excluded, got %d", len(rules) == 0, len(rules))
+}
+
+func testAlertRuleTrigger(t *testing.T, repos *repo.Repositories) {
+	user := newUsers(t, repos, 1)[0]
+	rule := &scores.AlertRule{UserID: user.ID}
+
+	_, err := repos.AlertRuleRepo.New(context.Background(), rule)
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+
+	triggers := []struct {
+		key  string
+		want bool
+	}{
+		{"results/1", true},
+		{"results/1", false},
+		{"results/2", true},
+	}
+
+	for _, trigger := range triggers {
+		triggered, err := repos.AlertRuleRepo.Trigger(context.Background(), rule.ID, trigger.key)
+
+		test.Check(t, "alertRuleRepo.Trigger() failed: %v", err)
+		test.Assert(t, "Trigger(%q): want %t", triggered == trigger.want, trigger.key, trigger.want)
+	}
+}
@@ -0,0 +1,124 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+)
+
+var jobExecutionTests = []conformanceTest{
+	{"JobExecution/Page", testJobExecutionPage},
+	{"JobExecution/DeleteBefore", testJobExecutionDeleteBefore},
+}
+
+var jobLeaseTests = []conformanceTest{
+	{"JobLease/Acquire", testJobLeaseAcquire},
+	{"JobLease/Release", testJobLeaseRelease},
+}
+
+func newJobExecutions(t *testing.T, repos *repo.Repositories, executions ...*scores.JobExecution) {
+	t.Helper()
+
+	for _, execution := range executions {
+		_, err := repos.JobExecutionRepo.New(context.Background(), execution)
+
+		test.Check(t, "jobExecutionRepo.New() failed: %v", err)
+		test.Assert(t, "jobExecutionRepo.New() should assign an id", execution.ID > 0)
+	}
+}
+
+func summaries(executions []*scores.JobExecution) []string {
+	s := []string{}
+
+	for _, e := range executions {
+		s = append(s, e.Summary)
+	}
+
+	return s
+}
+
+func testJobExecutionPage(t *testing.T, repos *repo.Repositories) {
+	start := date(2019, 6, 1)
+
+	newJobExecutions(t, repos,
+		&scores.JobExecution{JobName: "a", Start: start, End: start.Add(time.Minute), Success: true, Summary: "a1"},
+		&scores.JobExecution{JobName: "b", Start: start.Add(time.Hour), End: start.Add(time.Hour), Summary: "b1", Error: "failed"},
+		&scores.JobExecution{JobName: "a", Start: start.Add(2 * time.Hour), End: start.Add(2 * time.Hour), Success: true, Summary: "a2"},
+	)
+
+	executions, err := repos.JobExecutionRepo.Page(context.Background(), "", 0, 10)
+
+	test.Check(t, "jobExecutionRepo.Page() failed: %v", err)
+	test.Compare(t, "want all executions, latest first:\n%s", []string{"a2", "b1", "a1"}, summaries(executions))
+
+	executions, err = repos.JobExecutionRepo.Page(context.Background(), "a", 1, 1)
+
+	test.Check(t, "jobExecutionRepo.Page() failed: %v", err)
+	test.Compare(t, "want the second page of job a:\n%s", []string{"a1"}, summaries(executions))
+	test.Assert(t, "want the execution to be successful", executions[0].Success)
+
+	executions, err = repos.JobExecutionRepo.Page(context.Background(), "a", 2, 1)
+
+	test.Check(t, "jobExecutionRepo.Page() failed: %v", err)
+	test.Assert(t, "want an empty page, got %v", executions != nil && len(executions) == 0, executions)
+}
+
+func testJobExecutionDeleteBefore(t *testing.T, repos *repo.Repositories) {
+	start := date(2019, 6, 1)
+
+	newJobExecutions(t, repos,
+		&scores.JobExecution{JobName: "a", Start: start, End: start, Summary: "1"},
+		&scores.JobExecution{JobName: "a", Start: start.Add(time.Hour), End: start.Add(time.Hour), Summary: "2"},
+		&scores.JobExecution{JobName: "a", Start: start.Add(2 * time.Hour), End: start.Add(2 * time.Hour), Summary: "3"},
+	)
+
+	deleted, err := repos.JobExecutionRepo.DeleteBefore(context.Background(), start.Add(time.Hour))
+
+	test.Check(t, "jobExecutionRepo.DeleteBefore() failed: %v", err)
+	test.Assert(t, "want 1 deleted execution, got %d", deleted == 1, deleted)
+
+	executions, _ := repos.JobExecutionRepo.Page(context.Background(), "", 0, 10)
+	test.Compare(t, "want the executions that started later:\n%s", []string{"3", "2"}, summaries(executions))
+}
+
+func acquire(t *testing.T, repos *repo.Repositories, owner string, now time.Time) bool {
+	t.Helper()
+
+	acquired, err := repos.JobLeaseRepo.Acquire(context.Background(), &scores.JobLease{
+		JobName:   "job",
+		Owner:     owner,
+		ExpiresAt: now.Add(time.Minute),
+	}, now)
+
+	test.Check(t, "jobLeaseRepo.Acquire() failed: %v", err)
+
+	return acquired
+}
+
+func testJobLeaseAcquire(t *testing.T, repos *repo.Repositories) {
+	now := date(2019, 6, 1)
+
+	test.Assert(t, "want a new lease to be acquired", acquire(t, repos, "a", now))
+	test.Assert(t, "want the lease to be renewed by its owner", acquire(t, repos, "a", now.Add(30*time.Second)))
+	test.Assert(t, "want a held lease to be refused", !acquire(t, repos, "b", now.Add(time.Minute)))
+	test.Assert(t, "want an expired lease to be taken over", acquire(t, repos, "b", now.Add(2*time.Minute)))
+	test.Assert(t, "want the previous owner to be refused", !acquire(t, repos, "a", now.Add(2*time.Minute)))
+}
+
+func testJobLeaseRelease(t *testing.T, repos *repo.Repositories) {
+	now := date(2019, 6, 1)
+
+	test.Assert(t, "want a new lease to be acquired", acquire(t, repos, "a", now))
+
+	err := repos.JobLeaseRepo.Release(context.Background(), "job", "b")
+	test.Check(t, "jobLeaseRepo.Release() failed: %v", err)
+	test.Assert(t, "want a lease to be released by its owner only", !acquire(t, repos, "b", now))
+
+	err = repos.JobLeaseRepo.Release(context.Background(), "job", "a")
+	test.Check(t, "jobLeaseRepo.Release() failed: %v", err)
+	test.Assert(t, "want a released lease to be acquired", acquire(t, repos, "b", now))
+}
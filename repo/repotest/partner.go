@@ -0,0 +1,220 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var partnerTests = []conformanceTest{
+	{"Partner/NotFound", testPartnerNotFound},
+	{"Partner/NewListing", testPartnerNewListing},
+	{"Partner/Listings", testPartnerListings},
+	{"Partner/ListingStatus", testPartnerListingStatus},
+	{"Partner/Requests", testPartnerRequests},
+}
+
+func listing(userID, playerID, tournamentID int, from, to time.Time) *volleynet.PartnerListing {
+	return &volleynet.PartnerListing{
+		UserID:       userID,
+		Player:       &volleynet.Player{ID: playerID},
+		TournamentID: tournamentID,
+		League:       "amateur-tour",
+		From:         from,
+		To:           to,
+		Status:       volleynet.ListingOpen,
+	}
+}
+
+func newListings(t *testing.T, repos *repo.Repositories, listings ...*volleynet.PartnerListing) []*volleynet.PartnerListing {
+	t.Helper()
+
+	for _, l := range listings {
+		_, err := repos.PartnerRepo.NewListing(context.Background(), l)
+		test.Check(t, "partnerRepo.NewListing() failed: %v", err)
+	}
+
+	return listings
+}
+
+func listingIDs(listings []*volleynet.PartnerListing) []int {
+	ids := []int{}
+
+	for _, l := range listings {
+		ids = append(ids, l.ID)
+	}
+
+	return ids
+}
+
+func requestIDs(requests []*volleynet.PartnerRequest) []int {
+	ids := []int{}
+
+	for _, r := range requests {
+		ids = append(ids, r.ID)
+	}
+
+	return ids
+}
+
+func testPartnerNotFound(t *testing.T, repos *repo.Repositories) {
+	_, err := repos.PartnerRepo.Listing(context.Background(), 1)
+	checkNotFound(t, "partnerRepo.Listing()", err)
+
+	_, err = repos.PartnerRepo.Request(context.Background(), 1)
+	checkNotFound(t, "partnerRepo.Request()", err)
+
+	changed, err := repos.PartnerRepo.SetListingStatus(context.Background(), 1, volleynet.ListingOpen, volleynet.ListingClosed)
+	test.Check(t, "partnerRepo.SetListingStatus() failed: %v", err)
+	test.Assert(t, "partnerRepo.SetListingStatus() of a missing listing should return false", !changed)
+}
+
+func testPartnerNewListing(t *testing.T, repos *repo.Repositories) {
+	users := newUsers(t, repos, 1)
+	newPlayers(t, repos, &volleynet.Player{ID: 1, Gender: "M", FirstName: "Richard", TotalPoints: 120})
+
+	l := newListings(t, repos, listing(users[0].ID, 1, 0, date(2019, 6, 1), date(2019, 6, 30)))[0]
+
+	test.Assert(t, "partnerRepo.NewListing() should assign an id", l.ID > 0)
+	test.Assert(t, "partnerRepo.NewListing() should set CreatedAt", !l.CreatedAt.IsZero())
+
+	persisted, err := repos.PartnerRepo.Listing(context.Background(), l.ID)
+	test.Check(t, "partnerRepo.Listing() failed: %v", err)
+
+	test.Assert(t, "want the listing's player, got %+v", persisted.Player.FirstName == "Richard" && persisted.Player.TotalPoints == 120, persisted.Player)
+	test.Assert(t, "want the listing's dates, got %v - %v",
+		persisted.From.Equal(date(2019, 6, 1)) && persisted.To.Equal(date(2019, 6, 30)), persisted.From, persisted.To)
+	test.Assert(t, "want status %q, got %q", persisted.Status == volleynet.ListingOpen, volleynet.ListingOpen, persisted.Status)
+
+	listings, err := repos.PartnerRepo.ListingsByUser(context.Background(), users[0].ID)
+	test.Check(t, "partnerRepo.ListingsByUser() failed: %v", err)
+	test.Compare(t, "listings of the user differ:\n%s", []int{l.ID}, listingIDs(listings))
+}
+
+func testPartnerListings(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	users := newUsers(t, repos, 1)
+	userID := users[0].ID
+
+	newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M", TotalPoints: 100},
+		&volleynet.Player{ID: 2, Gender: "M", TotalPoints: 300},
+		&volleynet.Player{ID: 3, Gender: "W", TotalPoints: 100},
+	)
+
+	l := []*volleynet.PartnerListing{
+		listing(userID, 1, 5, date(2019, 6, 1), date(2019, 6, 1)),   // tournament 5
+		listing(userID, 2, 0, date(2019, 5, 1), date(2019, 6, 30)),  // range covering tournament 5
+		listing(userID, 3, 6, date(2019, 6, 1), date(2019, 6, 1)),   // other tournament
+		listing(userID, 1, 0, date(2019, 4, 1), date(2019, 4, 30)),  // other league
+		listing(userID, 1, 7, date(2019, 6, 15), date(2019, 6, 15)), // closed
+	}
+
+	l[3].League = "pro-tour"
+	newListings(t, repos, l...)
+
+	changed, err := repos.PartnerRepo.SetListingStatus(ctx, l[4].ID, volleynet.ListingOpen, volleynet.ListingClosed)
+	test.Check(t, "partnerRepo.SetListingStatus() failed: %v", err)
+	test.Assert(t, "partnerRepo.SetListingStatus() should change an open listing", changed)
+
+	tests := []struct {
+		name   string
+		filter repo.PartnerListingFilter
+		want   []int
+	}{
+		{"all", repo.PartnerListingFilter{From: date(2019, 1, 1)}, []int{l[3].ID, l[1].ID, l[0].ID, l[2].ID}},
+		{"from", repo.PartnerListingFilter{From: date(2019, 5, 15)}, []int{l[1].ID, l[0].ID, l[2].ID}},
+		{"to", repo.PartnerListingFilter{From: date(2019, 1, 1), To: date(2019, 5, 31)}, []int{l[3].ID, l[1].ID}},
+		{"tournament", repo.PartnerListingFilter{TournamentID: 5, From: date(2019, 6, 1), To: date(2019, 6, 1)}, []int{l[1].ID, l[0].ID}},
+		{"gender", repo.PartnerListingFilter{Gender: "W", From: date(2019, 1, 1)}, []int{l[2].ID}},
+		{"points", repo.PartnerListingFilter{MinPoints: 150, MaxPoints: 300, From: date(2019, 1, 1)}, []int{l[1].ID}},
+		{"max points", repo.PartnerListingFilter{MaxPoints: 150, Gender: "M", From: date(2019, 1, 1)}, []int{l[3].ID, l[0].ID}},
+		{"league", repo.PartnerListingFilter{League: "pro-tour", From: date(2019, 1, 1)}, []int{l[3].ID}},
+	}
+
+	for _, tt := range tests {
+		listings, err := repos.PartnerRepo.Listings(ctx, tt.filter)
+
+		test.Check(t, "partnerRepo.Listings() failed: %v", err)
+		test.Compare(t, tt.name+": listings differ:\n%s", tt.want, listingIDs(listings))
+	}
+}
+
+func testPartnerListingStatus(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	users := newUsers(t, repos, 1)
+	newPlayers(t, repos, &volleynet.Player{ID: 1, Gender: "M"})
+
+	l := newListings(t, repos, listing(users[0].ID, 1, 5, date(2019, 6, 1), date(2019, 6, 1)))[0]
+
+	changed, err := repos.PartnerRepo.SetListingStatus(ctx, l.ID, volleynet.ListingOpen, volleynet.ListingMatched)
+	test.Check(t, "partnerRepo.SetListingStatus() failed: %v", err)
+	test.Assert(t, "partnerRepo.SetListingStatus() should change an open listing", changed)
+
+	changed, err = repos.PartnerRepo.SetListingStatus(ctx, l.ID, volleynet.ListingOpen, volleynet.ListingMatched)
+	test.Check(t, "partnerRepo.SetListingStatus() failed: %v", err)
+	test.Assert(t, "partnerRepo.SetListingStatus() should not change a listing that is not open", !changed)
+
+	persisted, err := repos.PartnerRepo.Listing(ctx, l.ID)
+	test.Check(t, "partnerRepo.Listing() failed: %v", err)
+	test.Assert(t, "want status %q, got %q", persisted.Status == volleynet.ListingMatched, volleynet.ListingMatched, persisted.Status)
+	test.Assert(t, "want UpdatedAt to be set", persisted.UpdatedAt != nil)
+}
+
+func testPartnerRequests(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	users := newUsers(t, repos, 3)
+
+	newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M"},
+		&volleynet.Player{ID: 2, Gender: "M", FirstName: "Dominik"},
+		&volleynet.Player{ID: 3, Gender: "M"},
+	)
+
+	l := newListings(t, repos,
+		listing(users[0].ID, 1, 5, date(2019, 6, 1), date(2019, 6, 1)),
+		listing(users[1].ID, 2, 5, date(2019, 6, 1), date(2019, 6, 1)),
+	)
+
+	requests := []*volleynet.PartnerRequest{
+		{ListingID: l[0].ID, UserID: users[1].ID, Player: &volleynet.Player{ID: 2}, TournamentID: 5, Message: "Hi"},
+		{ListingID: l[0].ID, UserID: users[2].ID, Player: &volleynet.Player{ID: 3}, TournamentID: 5},
+		{ListingID: l[1].ID, UserID: users[2].ID, Player: &volleynet.Player{ID: 3}, TournamentID: 5},
+	}
+
+	for _, r := range requests {
+		r.Status = volleynet.RequestPending
+
+		_, err := repos.PartnerRepo.NewRequest(ctx, r)
+		test.Check(t, "partnerRepo.NewRequest() failed: %v", err)
+	}
+
+	persisted, err := repos.PartnerRepo.Request(ctx, requests[0].ID)
+	test.Check(t, "partnerRepo.Request() failed: %v", err)
+	test.Assert(t, "want the request's player and message, got %+v",
+		persisted.Player.FirstName == "Dominik" && persisted.Message == "Hi" && persisted.ListingID == l[0].ID, persisted)
+
+	byListing, err := repos.PartnerRepo.RequestsByListing(ctx, l[0].ID)
+	test.Check(t, "partnerRepo.RequestsByListing() failed: %v", err)
+	test.Compare(t, "requests of the listing differ, want the oldest first:\n%s", []int{requests[0].ID, requests[1].ID}, requestIDs(byListing))
+
+	byUser, err := repos.PartnerRepo.RequestsByUser(ctx, users[2].ID)
+	test.Check(t, "partnerRepo.RequestsByUser() failed: %v", err)
+	test.Compare(t, "requests of the user differ, want the latest first:\n%s", []int{requests[2].ID, requests[1].ID}, requestIDs(byUser))
+
+	toUser, err := repos.PartnerRepo.RequestsToUser(ctx, users[0].ID)
+	test.Check(t, "partnerRepo.RequestsToUser() failed: %v", err)
+	test.Compare(t, "requests to the user differ, want the latest first:\n%s", []int{requests[1].ID, requests[0].ID}, requestIDs(toUser))
+
+	changed, err := repos.PartnerRepo.SetRequestStatus(ctx, requests[0].ID, volleynet.RequestPending, volleynet.RequestAccepted)
+	test.Check(t, "partnerRepo.SetRequestStatus() failed: %v", err)
+	test.Assert(t, "partnerRepo.SetRequestStatus() should change a pending request", changed)
+
+	changed, err = repos.PartnerRepo.SetRequestStatus(ctx, requests[0].ID, volleynet.RequestPending, volleynet.RequestDeclined)
+	test.Check(t, "partnerRepo.SetRequestStatus() failed: %v", err)
+	test.Assert(t, "partnerRepo.SetRequestStatus() should not change an accepted request", !changed)
+}
@@ -0,0 +1,284 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var playerTests = []conformanceTest{
+	{"Player/NotFound", testPlayerNotFound},
+	{"Player/Create", testPlayerCreate},
+	{"Player/Update", testPlayerUpdate},
+	{"Player/Ladder", testPlayerLadder},
+	{"Player/ByGender", testPlayerByGender},
+	{"Player/Search", testPlayerSearch},
+	{"Player/PreviousPartners", testPreviousPartners},
+	{"Player/History", testPlayerHistory},
+	{"Player/SeasonStats", testPlayerSeasonStats},
+}
+
+// newPlayers creates players with the passed ids and genders.
+func newPlayers(t *testing.T, repos *repo.Repositories, players ...*volleynet.Player) []*volleynet.Player {
+	t.Helper()
+
+	for _, p := range players {
+		_, err := repos.PlayerRepo.New(context.Background(), p)
+		test.Check(t, "playerRepo.New() failed: %v", err)
+	}
+
+	return players
+}
+
+func testPlayerNotFound(t *testing.T, repos *repo.Repositories) {
+	_, err := repos.PlayerRepo.Get(context.Background(), 1)
+	checkNotFound(t, "playerRepo.Get()", err)
+
+	err = repos.PlayerRepo.Update(context.Background(), &volleynet.Player{ID: 1})
+	checkNotFound(t, "playerRepo.Update()", err)
+}
+
+func testPlayerCreate(t *testing.T, repos *repo.Repositories) {
+	birthday := date(1990, 5, 17)
+
+	player, err := repos.PlayerRepo.New(context.Background(), &volleynet.Player{
+		ID:           1,
+		FirstName:    "Richard",
+		LastName:     "Bosse",
+		Gender:       "M",
+		Birthday:     &birthday,
+		Club:         "Beach Club",
+		CountryUnion: "W",
+		License:      "ABC",
+		LadderRank:   3,
+		TotalPoints:  200,
+	})
+
+	test.Check(t, "playerRepo.New() failed: %v", err)
+	test.Assert(t, "playerRepo.New() should set CreatedAt", !player.CreatedAt.IsZero())
+
+	persisted, err := repos.PlayerRepo.Get(context.Background(), 1)
+
+	test.Check(t, "playerRepo.Get() failed: %v", err)
+	test.Assert(t, "want no UpdatedAt, got %v", persisted.UpdatedAt == nil, persisted.UpdatedAt)
+	test.Assert(t, "want birthday %v, got %v", persisted.Birthday != nil && persisted.Birthday.Equal(birthday), birthday, persisted.Birthday)
+
+	test.Compare(t, "persisted player differs:\n%s", player, persisted)
+}
+
+func testPlayerUpdate(t *testing.T, repos *repo.Repositories) {
+	player := newPlayers(t, repos, &volleynet.Player{ID: 1, Gender: "M", TotalPoints: 10})[0]
+
+	player.TotalPoints = 20
+	player.LadderRank = 5
+
+	err := repos.PlayerRepo.Update(context.Background(), player)
+	test.Check(t, "playerRepo.Update() failed: %v", err)
+
+	persisted, err := repos.PlayerRepo.Get(context.Background(), 1)
+
+	test.Check(t, "playerRepo.Get() failed: %v", err)
+	test.Assert(t, "want UpdatedAt to be set", persisted.UpdatedAt != nil)
+	test.Assert(t, "want total points 20, got %d", persisted.TotalPoints == 20, persisted.TotalPoints)
+	test.Assert(t, "want ladder rank 5, got %d", persisted.LadderRank == 5, persisted.LadderRank)
+}
+
+func playerIDs(players []*volleynet.Player) []int {
+	ids := []int{}
+
+	for _, p := range players {
+		ids = append(ids, p.ID)
+	}
+
+	return ids
+}
+
+func testPlayerLadder(t *testing.T, repos *repo.Repositories) {
+	newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M", LadderRank: 2},
+		&volleynet.Player{ID: 2, Gender: "M", LadderRank: 1},
+		&volleynet.Player{ID: 3, Gender: "M", LadderRank: 0},
+		&volleynet.Player{ID: 4, Gender: "W", LadderRank: 1},
+	)
+
+	ladder, err := repos.PlayerRepo.Ladder(context.Background(), "M")
+
+	test.Check(t, "playerRepo.Ladder() failed: %v", err)
+	test.Compare(t, "ladder should contain ranked players ordered by rank:\n%s", []int{2, 1}, playerIDs(ladder))
+}
+
+func testPlayerByGender(t *testing.T, repos *repo.Repositories) {
+	newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M", LadderRank: 2},
+		&volleynet.Player{ID: 2, Gender: "M", LadderRank: 1},
+		&volleynet.Player{ID: 3, Gender: "W", LadderRank: 1},
+	)
+
+	players, err := repos.PlayerRepo.ByGender(context.Background(), "M")
+
+	test.Check(t, "playerRepo.ByGender() failed: %v", err)
+	test.Compare(t, "players should be ordered by rank:\n%s", []int{2, 1}, playerIDs(players))
+
+	players, err = repos.PlayerRepo.ByGender(context.Background(), "X")
+
+	test.Check(t, "playerRepo.ByGender() failed: %v", err)
+	test.Assert(t, "want an empty list, got %v", players != nil && len(players) == 0, players)
+}
+
+func testPlayerSearch(t *testing.T, repos *repo.Repositories) {
+	newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M", FirstName: "Richard", LastName: "Bosse"},
+		&volleynet.Player{ID: 2, Gender: "M", FirstName: "Dominik", LastName: "Rieder"},
+		&volleynet.Player{ID: 3, Gender: "W", FirstName: "Romana", LastName: "Musterfrau"},
+		&volleynet.Player{ID: 4, Gender: "M", FirstName: "Roman", LastName: "Gutleber"},
+	)
+
+	tests := []struct {
+		filter repo.PlayerFilter
+		want   int
+	}{
+		{repo.PlayerFilter{FirstName: "Rom"}, 2},
+		{repo.PlayerFilter{FirstName: "Rom", Gender: "M"}, 1},
+		{repo.PlayerFilter{LastName: "Rieder"}, 1},
+		{repo.PlayerFilter{FirstName: "ichard"}, 0}, // only prefixes match
+		{repo.PlayerFilter{}, 4},
+	}
+
+	for _, tt := range tests {
+		players, err := repos.PlayerRepo.Search(context.Background(), tt.filter)
+
+		test.Check(t, "playerRepo.Search() failed: %v", err)
+		test.Assert(t, "Search(%+v): want %d players, got %d", len(players) == tt.want, tt.filter, tt.want, len(players))
+	}
+}
+
+func testPreviousPartners(t *testing.T, repos *repo.Repositories) {
+	p := newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M"},
+		&volleynet.Player{ID: 2, Gender: "M"},
+		&volleynet.Player{ID: 3, Gender: "M"},
+		&volleynet.Player{ID: 4, Gender: "M"},
+		&volleynet.Player{ID: 5, Gender: "M"},
+	)
+
+	newTournaments(t, repos,
+		tournament(1, date(2019, 6, 1)),
+		tournament(2, date(2019, 7, 1)),
+		tournament(3, date(2019, 8, 1)),
+	)
+
+	newTeams(t, repos,
+		team(1, p[0], p[1]),
+		team(1, p[2], p[3]),
+		team(2, p[2], p[0]),
+		team(3, p[0], p[1]),
+		team(3, p[3], p[4]),
+	)
+
+	partners, err := repos.PlayerRepo.PreviousPartners(context.Background(), 1)
+
+	test.Check(t, "playerRepo.PreviousPartners() failed: %v", err)
+	test.Compare(t, "partners should be distinct, the latest partner first:\n%s", []int{2, 3}, playerIDs(partners))
+
+	partners, err = repos.PlayerRepo.PreviousPartners(context.Background(), 6)
+
+	test.Check(t, "playerRepo.PreviousPartners() failed: %v", err)
+	test.Assert(t, "want no partners, got %v", len(partners) == 0, partners)
+}
+
+// newHistory creates tournaments of two seasons and leagues that player 1 played in.
+func newHistory(t *testing.T, repos *repo.Repositories) []*volleynet.Player {
+	p := newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M", FirstName: "Richard"},
+		&volleynet.Player{ID: 2, Gender: "M", FirstName: "Dominik"},
+		&volleynet.Player{ID: 3, Gender: "M", FirstName: "Roman"},
+	)
+
+	tournaments := []*volleynet.Tournament{
+		tournament(1, date(2018, 6, 1)),
+		tournament(2, date(2019, 6, 1)),
+		tournament(3, date(2019, 7, 1)),
+		tournament(4, date(2019, 8, 1)),
+		tournament(5, date(2019, 9, 1)),
+	}
+
+	tournaments[0].Season = "2018"
+	tournaments[3].LeagueKey = "pro-tour"
+
+	for _, t := range tournaments[:4] {
+		t.Status = volleynet.StatusDone
+	}
+
+	newTournaments(t, repos, tournaments...)
+
+	teams := []*volleynet.TournamentTeam{
+		team(1, p[0], p[1]),
+		team(2, p[0], p[1]),
+		team(3, p[2], p[0]),
+		team(4, p[0], p[2]),
+		team(5, p[0], p[1]),
+	}
+
+	teams[0].Result, teams[0].WonPoints, teams[0].PrizeMoney = 5, 20, 0
+	teams[1].Result, teams[1].WonPoints, teams[1].PrizeMoney = 2, 60, 150
+	teams[2].Result, teams[2].WonPoints, teams[2].PrizeMoney = 7, 10, 0
+	teams[3].Result, teams[3].WonPoints, teams[3].PrizeMoney = 3, 40, 100
+	teams[4].Seed = 4
+
+	newTeams(t, repos, teams...)
+
+	return p
+}
+
+func testPlayerHistory(t *testing.T, repos *repo.Repositories) {
+	newHistory(t, repos)
+
+	history, err := repos.PlayerRepo.History(context.Background(), 1, repo.PlayerHistoryFilter{})
+	test.Check(t, "playerRepo.History() failed: %v", err)
+
+	tournamentIDs := []int{}
+	partnerIDs := []int{}
+
+	for _, entry := range history {
+		tournamentIDs = append(tournamentIDs, entry.Tournament.ID)
+		partnerIDs = append(partnerIDs, entry.Partner.ID)
+	}
+
+	test.Compare(t, "history should contain all tournaments, the latest first:\n%s", []int{5, 4, 3, 2, 1}, tournamentIDs)
+	test.Compare(t, "history contains the wrong partners:\n%s", []int{2, 3, 3, 2, 2}, partnerIDs)
+	test.Assert(t, "want the partner's name, got %q", history[1].Partner.FirstName == "Roman", history[1].Partner.FirstName)
+	test.Assert(t, "want result 3 and 40 won points, got %d and %d",
+		history[1].Result == 3 && history[1].WonPoints == 40, history[1].Result, history[1].WonPoints)
+	test.Assert(t, "want seed 4, got %d", history[0].Seed == 4, history[0].Seed)
+
+	history, err = repos.PlayerRepo.History(context.Background(), 1, repo.PlayerHistoryFilter{Leagues: []string{"pro-tour"}})
+	test.Check(t, "playerRepo.History() failed: %v", err)
+	test.Assert(t, "want only the pro tour tournament, got %v", len(history) == 1 && history[0].Tournament.ID == 4, history)
+
+	history, err = repos.PlayerRepo.History(context.Background(), 4, repo.PlayerHistoryFilter{})
+	test.Check(t, "playerRepo.History() failed: %v", err)
+	test.Assert(t, "want an empty history, got %v", history != nil && len(history) == 0, history)
+}
+
+func testPlayerSeasonStats(t *testing.T, repos *repo.Repositories) {
+	newHistory(t, repos)
+
+	stats, err := repos.PlayerRepo.SeasonStats(context.Background(), 1, repo.PlayerHistoryFilter{})
+	test.Check(t, "playerRepo.SeasonStats() failed: %v", err)
+
+	// the upcoming tournament 5 is not counted
+	test.Compare(t, "season stats differ:\n%s", []*volleynet.SeasonStats{
+		{Season: "2019", Tournaments: 3, AverageResult: 4, BestResult: 2, WonPoints: 110, PrizeMoney: 250, Podiums: 2},
+		{Season: "2018", Tournaments: 1, AverageResult: 5, BestResult: 5, WonPoints: 20, PrizeMoney: 0, Podiums: 0},
+	}, stats)
+
+	stats, err = repos.PlayerRepo.SeasonStats(context.Background(), 1, repo.PlayerHistoryFilter{Leagues: []string{"amateur-tour"}})
+	test.Check(t, "playerRepo.SeasonStats() failed: %v", err)
+	test.Compare(t, "season stats differ:\n%s", []*volleynet.SeasonStats{
+		{Season: "2019", Tournaments: 2, AverageResult: 4.5, BestResult: 2, WonPoints: 70, PrizeMoney: 150, Podiums: 1},
+		{Season: "2018", Tournaments: 1, AverageResult: 5, BestResult: 5, WonPoints: 20, PrizeMoney: 0, Podiums: 0},
+	}, stats)
+}
@@ -0,0 +1,37 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+)
+
+var pollOffsetTests = []conformanceTest{
+	{"PollOffset/Set", testPollOffsetSet},
+}
+
+func pollOffset(t *testing.T, repos *repo.Repositories, poller string) int {
+	t.Helper()
+
+	offset, err := repos.PollOffsetRepo.Get(context.Background(), poller)
+	test.Check(t, "pollOffsetRepo.Get() failed: %v", err)
+
+	return offset
+}
+
+func testPollOffsetSet(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	test.Equal(t, "want offset %d of an unknown poller, got %d", 0, pollOffset(t, repos, "telegram"))
+
+	for _, offset := range []int{10, 10, 11} {
+		err := repos.PollOffsetRepo.Set(ctx, "telegram", offset)
+		test.Check(t, "pollOffsetRepo.Set() failed: %v", err)
+
+		test.Equal(t, "want offset %d, got %d", offset, pollOffset(t, repos, "telegram"))
+	}
+
+	test.Equal(t, "want offset %d of another poller, got %d", 0, pollOffset(t, repos, "other"))
+}
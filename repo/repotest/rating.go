@@ -0,0 +1,220 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var ratingTests = []conformanceTest{
+	{"Rating/Results", testRatingResults},
+	{"Rating/History", testRatingHistory},
+	{"Rating/Leaderboard", testRatingLeaderboard},
+	{"Rating/DeleteFrom", testRatingDeleteFrom},
+	{"Rating/Duplicate", testRatingDuplicate},
+	{"Rating/RecomputeRequest", testRatingRecomputeRequest},
+}
+
+func rating(playerID, tournamentID int, start time.Time, value float64, tournaments int) *volleynet.Rating {
+	return &volleynet.Rating{
+		PlayerID:        playerID,
+		TournamentID:    tournamentID,
+		TournamentStart: start,
+		Rating:          value,
+		Delta:           value - 1500,
+		Tournaments:     tournaments,
+	}
+}
+
+func newRatings(t *testing.T, repos *repo.Repositories, ratings ...*volleynet.Rating) {
+	t.Helper()
+
+	err := repos.RatingRepo.New(context.Background(), ratings...)
+	test.Check(t, "ratingRepo.New() failed: %v", err)
+}
+
+func testRatingResults(t *testing.T, repos *repo.Repositories) {
+	p := newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M"},
+		&volleynet.Player{ID: 2, Gender: "M"},
+		&volleynet.Player{ID: 3, Gender: "M"},
+		&volleynet.Player{ID: 4, Gender: "M"},
+	)
+
+	tournaments := []*volleynet.Tournament{
+		tournament(1, date(2019, 7, 1)),
+		tournament(2, date(2019, 6, 1)),
+		tournament(3, date(2019, 8, 1)),
+	}
+
+	tournaments[0].Status = volleynet.StatusDone
+	tournaments[1].Status = volleynet.StatusDone
+
+	newTournaments(t, repos, tournaments...)
+
+	teams := []*volleynet.TournamentTeam{
+		team(1, p[0], p[1]),
+		team(1, p[2], p[3]),
+		team(2, p[0], p[2]),
+		team(2, p[1], p[3]),
+		team(3, p[0], p[1]),
+	}
+
+	teams[0].Result = 2
+	teams[1].Result = 1
+	teams[2].Result = 1
+	teams[2].WonPoints = 40
+	teams[3].Deregistered = true
+	teams[4].Result = 1
+
+	newTeams(t, repos, teams...)
+
+	results, err := repos.RatingRepo.Results(context.Background())
+	test.Check(t, "ratingRepo.Results() failed: %v", err)
+
+	test.Assert(t, "want 3 results of finished tournaments, got %d", len(results) == 3, len(results))
+
+	want := []struct{ tournamentID, player1ID, result int }{
+		{2, 1, 1},
+		{1, 3, 1},
+		{1, 1, 2},
+	}
+
+	for i, w := range want {
+		if i >= len(results) {
+			break
+		}
+
+		r := results[i]
+
+		test.Assert(t, "result %d: want tournament %d, player %d and result %d, got %+v",
+			r.TournamentID == w.tournamentID && r.Player1ID == w.player1ID && r.Result == w.result,
+			i, w.tournamentID, w.player1ID, w.result, r)
+	}
+
+	if len(results) > 0 {
+		test.Assert(t, "want the start of the tournament, got %v", results[0].TournamentStart.Equal(date(2019, 6, 1)), results[0].TournamentStart)
+		test.Assert(t, "want the sub league and won points, got %+v", results[0].SubLeagueKey == "amateur-league" && results[0].WonPoints == 40, results[0])
+	}
+}
+
+func testRatingHistory(t *testing.T, repos *repo.Repositories) {
+	newRatings(t, repos,
+		rating(1, 1, date(2019, 6, 1), 1510, 1),
+		rating(2, 1, date(2019, 6, 1), 1490, 1),
+		rating(1, 2, date(2019, 7, 1), 1525.5, 2),
+	)
+
+	ids, err := repos.RatingRepo.RatedTournaments(context.Background())
+	test.Check(t, "ratingRepo.RatedTournaments() failed: %v", err)
+	test.Compare(t, "rated tournaments differ:\n%s", []int{1, 2}, ids)
+
+	history, err := repos.RatingRepo.ByPlayer(context.Background(), 1)
+	test.Check(t, "ratingRepo.ByPlayer() failed: %v", err)
+
+	test.Assert(t, "want 2 ratings, got %d", len(history) == 2, len(history))
+	test.Assert(t, "want the oldest rating first, got %+v", history[0].TournamentID == 1, history[0])
+	test.Assert(t, "want rating 1525.5, got %v", history[1].Rating == 1525.5, history[1].Rating)
+	test.Assert(t, "want the start of the tournament, got %v", history[1].TournamentStart.Equal(date(2019, 7, 1)), history[1].TournamentStart)
+	test.Assert(t, "ratingRepo.New() should set CreatedAt", !history[0].CreatedAt.IsZero())
+
+	latest, err := repos.RatingRepo.Latest(context.Background())
+	test.Check(t, "ratingRepo.Latest() failed: %v", err)
+
+	test.Assert(t, "want the latest rating of 2 players, got %d", len(latest) == 2, len(latest))
+	test.Assert(t, "want rating 1525.5 of player 1, got %+v", latest[0].PlayerID == 1 && latest[0].Rating == 1525.5, latest[0])
+	test.Assert(t, "want rating 1490 of player 2, got %+v", latest[1].PlayerID == 2 && latest[1].Rating == 1490, latest[1])
+}
+
+func testRatingLeaderboard(t *testing.T, repos *repo.Repositories) {
+	newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M"},
+		&volleynet.Player{ID: 2, Gender: "M"},
+		&volleynet.Player{ID: 3, Gender: "M"},
+		&volleynet.Player{ID: 4, Gender: "W"},
+	)
+
+	newRatings(t, repos,
+		rating(1, 1, date(2019, 6, 1), 1600, 1),
+		rating(2, 1, date(2019, 6, 1), 1550, 1),
+		rating(1, 2, date(2019, 7, 1), 1450, 2),
+		rating(3, 2, date(2019, 7, 1), 1500, 1),
+		rating(4, 3, date(2019, 7, 1), 1700, 1),
+	)
+
+	leaderboard, err := repos.RatingRepo.Leaderboard(context.Background(), "M", 2)
+	test.Check(t, "ratingRepo.Leaderboard() failed: %v", err)
+
+	ids := []int{}
+
+	for _, p := range leaderboard {
+		ids = append(ids, p.Player.ID)
+	}
+
+	test.Compare(t, "leaderboard should contain the best rated players of the gender:\n%s", []int{2, 3}, ids)
+	test.Assert(t, "want rating 1550 and 1 tournament, got %+v", leaderboard[0].Rating == 1550 && leaderboard[0].Tournaments == 1, leaderboard[0])
+
+	leaderboard, err = repos.RatingRepo.Leaderboard(context.Background(), "M", 10)
+	test.Check(t, "ratingRepo.Leaderboard() failed: %v", err)
+	test.Assert(t, "want the current rating of player 1, got %+v", leaderboard[2].Player.ID == 1 && leaderboard[2].Rating == 1450, leaderboard[2])
+}
+
+func testRatingDeleteFrom(t *testing.T, repos *repo.Repositories) {
+	newRatings(t, repos,
+		rating(1, 1, date(2019, 6, 1), 1510, 1),
+		rating(1, 2, date(2019, 7, 1), 1520, 2),
+		rating(2, 2, date(2019, 7, 1), 1480, 1),
+		rating(1, 3, date(2019, 8, 1), 1530, 3),
+	)
+
+	deleted, err := repos.RatingRepo.DeleteFrom(context.Background(), date(2019, 7, 1))
+	test.Check(t, "ratingRepo.DeleteFrom() failed: %v", err)
+	test.Equal(t, "want %d deleted ratings, got %d", 3, deleted)
+
+	ids, err := repos.RatingRepo.RatedTournaments(context.Background())
+	test.Check(t, "ratingRepo.RatedTournaments() failed: %v", err)
+	test.Compare(t, "rated tournaments differ:\n%s", []int{1}, ids)
+
+	deleted, err = repos.RatingRepo.DeleteFrom(context.Background(), time.Time{})
+	test.Check(t, "ratingRepo.DeleteFrom() failed: %v", err)
+	test.Equal(t, "want %d deleted ratings, got %d", 1, deleted)
+}
+
+func testRatingDuplicate(t *testing.T, repos *repo.Repositories) {
+	newRatings(t, repos, rating(1, 1, date(2019, 6, 1), 1510, 1))
+
+	err := repos.RatingRepo.New(context.Background(), rating(1, 1, date(2019, 6, 1), 1520, 1))
+	test.Assert(t, "ratingRepo.New() of an existing rating should fail", err != nil)
+}
+
+func testRatingRecomputeRequest(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	requestID, err := repos.RatingRepo.RecomputeRequest(ctx)
+	test.Check(t, "ratingRepo.RecomputeRequest() failed: %v", err)
+	test.Equal(t, "want no pending request %d, got %d", 0, requestID)
+
+	test.Check(t, "ratingRepo.RequestRecompute() failed: %v", repos.RatingRepo.RequestRecompute(ctx))
+
+	first, err := repos.RatingRepo.RecomputeRequest(ctx)
+	test.Check(t, "ratingRepo.RecomputeRequest() failed: %v", err)
+	test.Assert(t, "want a pending request, got %d", first > 0, first)
+
+	// a request during a recompute must not be cleared by it
+	test.Check(t, "ratingRepo.RequestRecompute() failed: %v", repos.RatingRepo.RequestRecompute(ctx))
+	test.Check(t, "ratingRepo.ClearRecomputeRequests() failed: %v", repos.RatingRepo.ClearRecomputeRequests(ctx, first))
+
+	second, err := repos.RatingRepo.RecomputeRequest(ctx)
+	test.Check(t, "ratingRepo.RecomputeRequest() failed: %v", err)
+	test.Assert(t, "want the later request to be pending, got %d", second > first, second)
+
+	test.Check(t, "ratingRepo.ClearRecomputeRequests() failed: %v", repos.RatingRepo.ClearRecomputeRequests(ctx, second))
+
+	requestID, err = repos.RatingRepo.RecomputeRequest(ctx)
+	test.Check(t, "ratingRepo.RecomputeRequest() failed: %v", err)
+	test.Equal(t, "want no pending request %d, got %d", 0, requestID)
+}
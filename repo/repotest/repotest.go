@@ -0,0 +1,72 @@
+// Package repotest contains a conformance test suite that verifies that an
+// implementation of the repositories fulfills the contracts of the interfaces
+// in `repo`. Every backend should run it, e.g.:
+//
+//	func TestConformance(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) *repo.Repositories {
+//			return Repositories()
+//		})
+//	}
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+)
+
+// Factory returns repositories without any data.
+type Factory func(t *testing.T) *repo.Repositories
+
+type conformanceTest struct {
+	name string
+	run  func(t *testing.T, repos *repo.Repositories)
+}
+
+// Run runs the conformance tests of all repositories, every
+// test gets new repositories from `newRepos`.
+func Run(t *testing.T, newRepos Factory) {
+	tests := []conformanceTest{}
+	tests = append(tests, playerTests...)
+	tests = append(tests, teamTests...)
+	tests = append(tests, tournamentTests...)
+	tests = append(tests, userTests...)
+	tests = append(tests, settingTests...)
+	tests = append(tests, notificationLogTests...)
+	tests = append(tests, alertRuleTests...)
+	tests = append(tests, jobExecutionTests...)
+	tests = append(tests, jobLeaseTests...)
+	tests = append(tests, pollOffsetTests...)
+	tests = append(tests, ratingTests...)
+	tests = append(tests, partnerTests...)
+	tests = append(tests, watchTests...)
+	tests = append(tests, signupTests...)
+	tests = append(tests, transferTests...)
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepos(t))
+		})
+	}
+}
+
+// checkNotFound fails the test if the cause of `err` is not `scores.ErrNotFound`.
+func checkNotFound(t *testing.T, action string, err error) {
+	t.Helper()
+
+	if errors.Cause(err) != scores.ErrNotFound {
+		t.Errorf("%s: want ErrNotFound, got: %v", action, err)
+	}
+}
+
+// date returns a UTC time with second precision which
+// survives the round trip through all databases.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
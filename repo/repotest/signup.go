@@ -0,0 +1,121 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var signupTests = []conformanceTest{
+	{"Signup/Queue", testSignupQueue},
+	{"Signup/Finish", testSignupFinish},
+}
+
+func newSignups(t *testing.T, repos *repo.Repositories, signups ...*volleynet.ScheduledSignup) {
+	t.Helper()
+
+	for _, signup := range signups {
+		_, err := repos.SignupRepo.New(context.Background(), signup)
+		test.Check(t, "signupRepo.New() failed: %v", err)
+		test.Assert(t, "signupRepo.New() should assign an id", signup.ID > 0)
+	}
+}
+
+func testSignupQueue(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	users := newUsers(t, repos, 2)
+	expires := date(2030, time.June, 1)
+
+	signups := []*volleynet.ScheduledSignup{
+		{UserID: users[0].ID, TournamentID: 1, PartnerID: 2, PartnerName: "Richard", Status: volleynet.SignupScheduled, Credentials: "secret", ExpiresAt: expires},
+		{UserID: users[1].ID, TournamentID: 1, PartnerID: 3, Status: volleynet.SignupScheduled, ExpiresAt: expires},
+		{UserID: users[0].ID, TournamentID: 2, PartnerID: 2, Status: volleynet.SignupCanceled, ExpiresAt: expires},
+	}
+
+	newSignups(t, repos, signups...)
+
+	signup, err := repos.SignupRepo.Get(ctx, signups[0].ID)
+	test.Check(t, "signupRepo.Get() failed: %v", err)
+	test.Assert(t, "want the persisted signup, got %+v",
+		signup.PartnerName == "Richard" && signup.Credentials == "secret" &&
+			signup.ExpiresAt.Equal(expires) && signup.AttemptedAt == nil, signup)
+
+	_, err = repos.SignupRepo.Get(ctx, 1000)
+	checkNotFound(t, "signupRepo.Get() of a missing signup", err)
+
+	byUser, err := repos.SignupRepo.ByUser(ctx, users[0].ID)
+	test.Check(t, "signupRepo.ByUser() failed: %v", err)
+	test.Compare(t, "signups differ:\n%s", []int{signups[2].ID, signups[0].ID}, signupIDs(byUser))
+
+	scheduled, err := repos.SignupRepo.ByStatus(ctx, volleynet.SignupScheduled)
+	test.Check(t, "signupRepo.ByStatus() failed: %v", err)
+	test.Compare(t, "signups differ:\n%s", []int{signups[0].ID, signups[1].ID}, signupIDs(scheduled))
+
+	changed, err := repos.SignupRepo.SetStatus(ctx, signups[0].ID, volleynet.SignupScheduled, volleynet.SignupRunning)
+	test.Check(t, "signupRepo.SetStatus() failed: %v", err)
+	test.Assert(t, "signupRepo.SetStatus() of a scheduled signup should change it", changed)
+
+	changed, err = repos.SignupRepo.SetStatus(ctx, signups[0].ID, volleynet.SignupScheduled, volleynet.SignupRunning)
+	test.Check(t, "signupRepo.SetStatus() failed: %v", err)
+	test.Assert(t, "signupRepo.SetStatus() of a running signup should not change it", !changed)
+
+	scheduled, err = repos.SignupRepo.ByStatus(ctx, volleynet.SignupScheduled)
+	test.Check(t, "signupRepo.ByStatus() failed: %v", err)
+	test.Compare(t, "signups differ:\n%s", []int{signups[1].ID}, signupIDs(scheduled))
+}
+
+func testSignupFinish(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	users := newUsers(t, repos, 1)
+
+	signup := &volleynet.ScheduledSignup{
+		UserID:       users[0].ID,
+		TournamentID: 1,
+		PartnerID:    2,
+		Status:       volleynet.SignupRunning,
+		Credentials:  "secret",
+		ExpiresAt:    date(2030, time.June, 1),
+	}
+
+	newSignups(t, repos, signup)
+
+	attempted := date(2030, time.May, 1)
+	signup.Status = volleynet.SignupFailed
+	signup.Message = "the tournament is full"
+	signup.AttemptedAt = &attempted
+
+	changed, err := repos.SignupRepo.Finish(ctx, signup, volleynet.SignupRunning)
+	test.Check(t, "signupRepo.Finish() failed: %v", err)
+	test.Assert(t, "signupRepo.Finish() of a running signup should change it", changed)
+
+	updated, err := repos.SignupRepo.Get(ctx, signup.ID)
+	test.Check(t, "signupRepo.Get() failed: %v", err)
+	test.Assert(t, "want the finished signup without credentials, got %+v",
+		updated.Status == volleynet.SignupFailed && updated.Message == "the tournament is full" &&
+			updated.Credentials == "" && updated.AttemptedAt != nil && updated.AttemptedAt.Equal(attempted) &&
+			updated.UpdatedAt != nil, updated)
+
+	signup.Status = volleynet.SignupSucceeded
+
+	changed, err = repos.SignupRepo.Finish(ctx, signup, volleynet.SignupRunning)
+	test.Check(t, "signupRepo.Finish() failed: %v", err)
+	test.Assert(t, "signupRepo.Finish() of a failed signup should not change it", !changed)
+
+	changed, err = repos.SignupRepo.Finish(ctx, &volleynet.ScheduledSignup{}, volleynet.SignupRunning)
+	test.Check(t, "signupRepo.Finish() failed: %v", err)
+	test.Assert(t, "signupRepo.Finish() of a missing signup should not change it", !changed)
+}
+
+func signupIDs(signups []*volleynet.ScheduledSignup) []int {
+	ids := []int{}
+
+	for _, s := range signups {
+		ids = append(ids, s.ID)
+	}
+
+	return ids
+}
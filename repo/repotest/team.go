@@ -0,0 +1,155 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var teamTests = []conformanceTest{
+	{"Team/NotFound", testTeamNotFound},
+	{"Team/Create", testTeamCreate},
+	{"Team/Batch", testTeamBatch},
+	{"Team/Delete", testTeamDelete},
+}
+
+func team(tournamentID int, player1, player2 *volleynet.Player) *volleynet.TournamentTeam {
+	return &volleynet.TournamentTeam{
+		TournamentID: tournamentID,
+		Player1:      player1,
+		Player2:      player2,
+	}
+}
+
+func newTeams(t *testing.T, repos *repo.Repositories, teams ...*volleynet.TournamentTeam) {
+	t.Helper()
+
+	err := repos.TeamRepo.NewBatch(context.Background(), teams...)
+	test.Check(t, "teamRepo.NewBatch() failed: %v", err)
+}
+
+// findTeam returns the team of the players or nil.
+func findTeam(teams []*volleynet.TournamentTeam, player1, player2 *volleynet.Player) *volleynet.TournamentTeam {
+	for _, t := range teams {
+		if t.Player1.ID == player1.ID && t.Player2.ID == player2.ID {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func testTeamNotFound(t *testing.T, repos *repo.Repositories) {
+	p := newPlayers(t, repos, &volleynet.Player{ID: 1}, &volleynet.Player{ID: 2})
+
+	err := repos.TeamRepo.Update(context.Background(), team(1, p[0], p[1]))
+	checkNotFound(t, "teamRepo.Update()", err)
+
+	err = repos.TeamRepo.Delete(context.Background(), team(1, p[0], p[1]))
+	checkNotFound(t, "teamRepo.Delete()", err)
+
+	teams, err := repos.TeamRepo.ByTournament(context.Background(), 1)
+	test.Check(t, "teamRepo.ByTournament() failed: %v", err)
+	test.Assert(t, "want an empty list, got %v", teams != nil && len(teams) == 0, teams)
+}
+
+func testTeamCreate(t *testing.T, repos *repo.Repositories) {
+	p := newPlayers(t, repos,
+		&volleynet.Player{ID: 1, FirstName: "Richard", LastName: "Bosse", Gender: "M", TotalPoints: 100},
+		&volleynet.Player{ID: 2, FirstName: "Dominik", LastName: "Rieder", Gender: "M", TotalPoints: 90},
+	)
+
+	newTournaments(t, repos, tournament(1, date(2019, 6, 1)))
+
+	tt := team(1, p[0], p[1])
+	tt.Seed = 3
+	tt.Result = 1
+	tt.TotalPoints = 190
+	tt.WonPoints = 25
+	tt.PrizeMoney = 150
+
+	_, err := repos.TeamRepo.New(context.Background(), tt)
+
+	test.Check(t, "teamRepo.New() failed: %v", err)
+	test.Assert(t, "teamRepo.New() should set CreatedAt", !tt.CreatedAt.IsZero())
+
+	teams, err := repos.TeamRepo.ByTournament(context.Background(), 1)
+
+	test.Check(t, "teamRepo.ByTournament() failed: %v", err)
+	test.Assert(t, "want 1 team, got %d", len(teams) == 1, len(teams))
+
+	persisted := teams[0]
+
+	test.Assert(t, "want seed 3, got %d", persisted.Seed == 3, persisted.Seed)
+	test.Assert(t, "want result 1, got %d", persisted.Result == 1, persisted.Result)
+	test.Assert(t, "want won points 25, got %d", persisted.WonPoints == 25, persisted.WonPoints)
+	test.Assert(t, "want prize money 150, got %f", persisted.PrizeMoney == 150, persisted.PrizeMoney)
+	test.Assert(t, "want the players' names, got %+v", persisted.Player1.FirstName == "Richard" && persisted.Player2.LastName == "Rieder", persisted)
+	test.Assert(t, "want the players' points, got %d", persisted.Player1.TotalPoints == 100, persisted.Player1.TotalPoints)
+}
+
+func testTeamBatch(t *testing.T, repos *repo.Repositories) {
+	p := newPlayers(t, repos,
+		&volleynet.Player{ID: 1},
+		&volleynet.Player{ID: 2},
+		&volleynet.Player{ID: 3},
+		&volleynet.Player{ID: 4},
+	)
+
+	newTournaments(t, repos,
+		tournament(1, date(2019, 6, 1)),
+		tournament(2, date(2019, 7, 1)),
+	)
+
+	t1 := team(1, p[0], p[1])
+	t2 := team(1, p[2], p[3])
+	t3 := team(2, p[0], p[2])
+
+	newTeams(t, repos, t1, t2, t3)
+
+	t1.Seed = 2
+	t2.Seed = 1
+	t2.Deregistered = true
+
+	err := repos.TeamRepo.UpdateBatch(context.Background(), t1, t2)
+	test.Check(t, "teamRepo.UpdateBatch() failed: %v", err)
+
+	teams, err := repos.TeamRepo.ByTournament(context.Background(), 1)
+
+	test.Check(t, "teamRepo.ByTournament() failed: %v", err)
+	test.Assert(t, "want the 2 teams of the tournament, got %d", len(teams) == 2, len(teams))
+
+	persisted := findTeam(teams, p[0], p[1])
+	test.Assert(t, "team 1 is missing or has the wrong seed: %+v", persisted != nil && persisted.Seed == 2, persisted)
+
+	persisted = findTeam(teams, p[2], p[3])
+	test.Assert(t, "team 2 is missing or was not updated: %+v", persisted != nil && persisted.Seed == 1 && persisted.Deregistered, persisted)
+}
+
+func testTeamDelete(t *testing.T, repos *repo.Repositories) {
+	p := newPlayers(t, repos,
+		&volleynet.Player{ID: 1},
+		&volleynet.Player{ID: 2},
+		&volleynet.Player{ID: 3},
+	)
+
+	newTournaments(t, repos, tournament(1, date(2019, 6, 1)))
+
+	t1 := team(1, p[0], p[1])
+	t2 := team(1, p[0], p[2])
+
+	newTeams(t, repos, t1, t2)
+
+	err := repos.TeamRepo.Delete(context.Background(), t1)
+
+	test.Check(t, "teamRepo.Delete() failed: %v", err)
+	test.Assert(t, "teamRepo.Delete() should set DeletedAt", t1.DeletedAt != nil)
+
+	teams, err := repos.TeamRepo.ByTournament(context.Background(), 1)
+
+	test.Check(t, "teamRepo.ByTournament() failed: %v", err)
+	test.Assert(t, "want deleted teams to be excluded, got %d teams", len(teams) == 1 && findTeam(teams, p[0], p[2]) != nil, len(teams))
+}
@@ -0,0 +1,162 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var tournamentTests = []conformanceTest{
+	{"Tournament/NotFound", testTournamentNotFound},
+	{"Tournament/Create", testTournamentCreate},
+	{"Tournament/UpdateBatch", testTournamentUpdateBatch},
+	{"Tournament/Search", testTournamentSearch},
+	{"Tournament/FilterOptions", testTournamentFilterOptions},
+}
+
+// tournament returns a tournament of the amateur tour that starts at `start`.
+func tournament(id int, start time.Time) *volleynet.Tournament {
+	return &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{
+			ID:           id,
+			Season:       "2019",
+			Start:        start,
+			End:          start.Add(8 * time.Hour),
+			Name:         "Tournament",
+			League:       "AMATEUR TOUR",
+			LeagueKey:    "amateur-tour",
+			SubLeague:    "AMATEUR LEAGUE",
+			SubLeagueKey: "amateur-league",
+			Status:       volleynet.StatusUpcoming,
+			Gender:       "M",
+		},
+	}
+}
+
+func newTournaments(t *testing.T, repos *repo.Repositories, tournaments ...*volleynet.Tournament) {
+	t.Helper()
+
+	err := repos.TournamentRepo.NewBatch(context.Background(), tournaments...)
+	test.Check(t, "tournamentRepo.NewBatch() failed: %v", err)
+}
+
+func tournamentIDs(tournaments []*volleynet.Tournament) []int {
+	ids := []int{}
+
+	for _, t := range tournaments {
+		ids = append(ids, t.ID)
+	}
+
+	return ids
+}
+
+func testTournamentNotFound(t *testing.T, repos *repo.Repositories) {
+	_, err := repos.TournamentRepo.Get(context.Background(), 1)
+	checkNotFound(t, "tournamentRepo.Get()", err)
+
+	err = repos.TournamentRepo.Update(context.Background(), tournament(1, date(2019, 6, 1)))
+	checkNotFound(t, "tournamentRepo.Update()", err)
+}
+
+func testTournamentCreate(t *testing.T, repos *repo.Repositories) {
+	endRegistration := date(2019, 5, 25)
+
+	tour := tournament(1, date(2019, 6, 1))
+	tour.EndRegistration = &endRegistration
+	tour.HTMLNotes = "<p>notes</p>"
+	tour.Location = "Vienna"
+	tour.MaxTeams = 16
+	tour.Latitude = 48.2
+	tour.Longitude = 16.37
+
+	_, err := repos.TournamentRepo.New(context.Background(), tour)
+
+	test.Check(t, "tournamentRepo.New() failed: %v", err)
+	test.Assert(t, "tournamentRepo.New() should set CreatedAt", !tour.CreatedAt.IsZero())
+
+	persisted, err := repos.TournamentRepo.Get(context.Background(), 1)
+
+	test.Check(t, "tournamentRepo.Get() failed: %v", err)
+	test.Assert(t, "want an empty list of teams, got %v", persisted.Teams != nil && len(persisted.Teams) == 0, persisted.Teams)
+
+	tour.Teams = persisted.Teams
+	test.Compare(t, "persisted tournament differs:\n%s", tour, persisted)
+}
+
+func testTournamentUpdateBatch(t *testing.T, repos *repo.Repositories) {
+	t1 := tournament(1, date(2019, 6, 1))
+	t2 := tournament(2, date(2019, 7, 1))
+
+	newTournaments(t, repos, t1, t2)
+
+	t1.Status = volleynet.StatusDone
+	t2.RegistrationOpen = true
+
+	err := repos.TournamentRepo.UpdateBatch(context.Background(), t1, t2)
+	test.Check(t, "tournamentRepo.UpdateBatch() failed: %v", err)
+
+	persisted, _ := repos.TournamentRepo.Get(context.Background(), 1)
+	test.Assert(t, "want status done, got %s", persisted.Status == volleynet.StatusDone, persisted.Status)
+	test.Assert(t, "want UpdatedAt to be set", persisted.UpdatedAt != nil)
+
+	persisted, _ = repos.TournamentRepo.Get(context.Background(), 2)
+	test.Assert(t, "want registration to be open", persisted.RegistrationOpen)
+}
+
+func testTournamentSearch(t *testing.T, repos *repo.Repositories) {
+	t1 := tournament(1, date(2019, 7, 1))
+	t2 := tournament(2, date(2019, 6, 1))
+	t3 := tournament(3, date(2019, 6, 15))
+	t3.Gender = "W"
+	t4 := tournament(4, date(2019, 6, 20))
+	t4.LeagueKey = "pro-tour"
+	t5 := tournament(5, date(2018, 6, 1))
+	t5.Season = "2018"
+
+	newTournaments(t, repos, t1, t2, t3, t4, t5)
+
+	tournaments, err := repos.TournamentRepo.Search(context.Background(), repo.TournamentFilter{
+		Seasons: []string{"2019"},
+		Leagues: []string{"amateur-tour"},
+		Genders: []string{"M"},
+	})
+
+	test.Check(t, "tournamentRepo.Search() failed: %v", err)
+	test.Compare(t, "tournaments should match all filters, ordered by start:\n%s", []int{2, 1}, tournamentIDs(tournaments))
+
+	tournaments, err = repos.TournamentRepo.Search(context.Background(), repo.TournamentFilter{
+		Seasons: []string{"2018", "2019"},
+		Leagues: []string{"amateur-tour", "pro-tour"},
+		Genders: []string{"M", "W"},
+	})
+
+	test.Check(t, "tournamentRepo.Search() failed: %v", err)
+	test.Compare(t, "tournaments should match any of the filter values:\n%s", []int{5, 2, 3, 4, 1}, tournamentIDs(tournaments))
+}
+
+func testTournamentFilterOptions(t *testing.T, repos *repo.Repositories) {
+	t1 := tournament(1, date(2019, 6, 1))
+	t2 := tournament(2, date(2019, 6, 1))
+	t2.LeagueKey = "pro-tour"
+	t2.SubLeagueKey = "pro-league"
+	t3 := tournament(3, date(2018, 6, 1))
+	t3.Season = "2018"
+
+	newTournaments(t, repos, t1, t2, t3)
+
+	seasons, err := repos.TournamentRepo.Seasons(context.Background())
+	test.Check(t, "tournamentRepo.Seasons() failed: %v", err)
+	test.Compare(t, "seasons should be distinct and sorted:\n%s", []string{"2018", "2019"}, seasons)
+
+	leagues, err := repos.TournamentRepo.Leagues(context.Background())
+	test.Check(t, "tournamentRepo.Leagues() failed: %v", err)
+	test.Compare(t, "leagues should be distinct and sorted:\n%s", []string{"amateur-tour", "pro-tour"}, leagues)
+
+	subLeagues, err := repos.TournamentRepo.SubLeagues(context.Background())
+	test.Check(t, "tournamentRepo.SubLeagues() failed: %v", err)
+	test.Compare(t, "sub leagues should be distinct and sorted:\n%s", []string{"amateur-league", "pro-league"}, subLeagues)
+}
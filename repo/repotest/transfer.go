@@ -0,0 +1,229 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var transferTests = []conformanceTest{
+	{"Transfer/ImportUnchanged", testTransferImportUnchanged},
+	{"Transfer/IDsAfterImport", testTransferIDsAfterImport},
+}
+
+// transferTrack returns timestamps in the past, `deleted` sets DeletedAt.
+func transferTrack(deleted bool) scores.Track {
+	created := time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC)
+	updated := created.Add(48 * time.Hour)
+
+	track := scores.Track{CreatedAt: created, UpdatedAt: &updated}
+
+	if deleted {
+		track.DeletedAt = &updated
+	}
+
+	return track
+}
+
+func testTransferImportUnchanged(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	r := repos.TransferRepo
+
+	players := []*volleynet.Player{
+		{ID: 11, Track: transferTrack(false), FirstName: "Richard", Gender: "M", TotalPoints: 120},
+		{ID: 12, Track: transferTrack(false), FirstName: "Dominik", Gender: "M", LadderRank: 3},
+	}
+	tournaments := []*volleynet.Tournament{
+		{
+			Track: transferTrack(false),
+			TournamentInfo: volleynet.TournamentInfo{
+				ID:     21,
+				Season: "2019",
+				Start:  time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2019, 6, 2, 0, 0, 0, 0, time.UTC),
+				Name:   "Vienna Open",
+				League: "Pro Tour",
+				Gender: "M",
+				Status: volleynet.StatusDone,
+			},
+			MaxTeams: 16,
+		},
+	}
+	teams := []*volleynet.TournamentTeam{
+		{
+			Track:        transferTrack(false),
+			TournamentID: 21,
+			Player1:      &volleynet.Player{ID: 11},
+			Player2:      &volleynet.Player{ID: 12},
+			Result:       1,
+			Seed:         2,
+		},
+	}
+	users := []*scores.User{
+		{
+			M:            scores.M{ID: 5},
+			Track:        transferTrack(false),
+			Email:        "user@test.com",
+			Role:         "user",
+			PlayerID:     11,
+			PasswordInfo: scores.PasswordInfo{Salt: []byte("salt"), Hash: []byte("hash"), Iterations: 10000},
+		},
+		{M: scores.M{ID: 8}, Track: transferTrack(true), Email: "deleted@test.com", Role: "user"},
+	}
+	settings := []*scores.Setting{
+		{Track: transferTrack(false), UserID: 5, Key: "language", Value: "de", Type: "string"},
+	}
+	logs := []*scores.NotificationLog{
+		{M: scores.M{ID: 31}, Track: transferTrack(false), UserID: 5, Channel: "email", Notifications: 2},
+	}
+	rules := []*scores.AlertRule{
+		{M: scores.M{ID: 41}, Track: transferTrack(false), UserID: 5, Name: "Vienna", Radius: 50},
+		{M: scores.M{ID: 42}, Track: transferTrack(true), UserID: 5, Name: "Deleted"},
+	}
+	triggers := []*scores.AlertTrigger{
+		{M: scores.M{ID: 51}, Track: transferTrack(false), RuleID: 41, Key: "registration-open/21"},
+	}
+	executions := []*scores.JobExecution{
+		{
+			M:       scores.M{ID: 61},
+			Track:   transferTrack(false),
+			JobName: "ladder",
+			Start:   time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC),
+			End:     time.Date(2019, 4, 1, 10, 1, 0, 0, time.UTC),
+			Success: true,
+		},
+	}
+	listings := []*volleynet.PartnerListing{
+		{
+			M:            scores.M{ID: 71},
+			Track:        transferTrack(false),
+			UserID:       5,
+			Player:       &volleynet.Player{ID: 11},
+			TournamentID: 21,
+			League:       "pro-tour",
+			From:         time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+			To:           time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+			Note:         "Looking for a blocker",
+			Status:       volleynet.ListingMatched,
+		},
+	}
+	requests := []*volleynet.PartnerRequest{
+		{
+			M:            scores.M{ID: 81},
+			Track:        transferTrack(false),
+			ListingID:    71,
+			UserID:       5,
+			Player:       &volleynet.Player{ID: 12},
+			TournamentID: 21,
+			Message:      "Let's play",
+			Status:       volleynet.RequestAccepted,
+		},
+	}
+	watches := []*volleynet.WatchedTournament{
+		{
+			M:            scores.M{ID: 91},
+			Track:        transferTrack(false),
+			UserID:       5,
+			TournamentID: 21,
+		},
+	}
+	follows := []*volleynet.FollowedPlayer{
+		{
+			M:      scores.M{ID: 101},
+			Track:  transferTrack(false),
+			UserID: 5,
+			Player: &volleynet.Player{ID: 12},
+		},
+	}
+
+	test.Check(t, "transferRepo.ImportPlayers() failed: %v", r.ImportPlayers(ctx, players...))
+	test.Check(t, "transferRepo.ImportTournaments() failed: %v", r.ImportTournaments(ctx, tournaments...))
+	test.Check(t, "transferRepo.ImportTeams() failed: %v", r.ImportTeams(ctx, teams...))
+	test.Check(t, "transferRepo.ImportUsers() failed: %v", r.ImportUsers(ctx, users...))
+	test.Check(t, "transferRepo.ImportSettings() failed: %v", r.ImportSettings(ctx, settings...))
+	test.Check(t, "transferRepo.ImportNotificationLogs() failed: %v", r.ImportNotificationLogs(ctx, logs...))
+	test.Check(t, "transferRepo.ImportAlertRules() failed: %v", r.ImportAlertRules(ctx, rules...))
+	test.Check(t, "transferRepo.ImportAlertTriggers() failed: %v", r.ImportAlertTriggers(ctx, triggers...))
+	test.Check(t, "transferRepo.ImportJobExecutions() failed: %v", r.ImportJobExecutions(ctx, executions...))
+	test.Check(t, "transferRepo.ImportPartnerListings() failed: %v", r.ImportPartnerListings(ctx, listings...))
+	test.Check(t, "transferRepo.ImportPartnerRequests() failed: %v", r.ImportPartnerRequests(ctx, requests...))
+	test.Check(t, "transferRepo.ImportWatchedTournaments() failed: %v", r.ImportWatchedTournaments(ctx, watches...))
+	test.Check(t, "transferRepo.ImportFollowedPlayers() failed: %v", r.ImportFollowedPlayers(ctx, follows...))
+
+	loadedPlayers, err := r.Players(ctx)
+	test.Check(t, "transferRepo.Players() failed: %v", err)
+	test.Compare(t, "exported players differ:\n%s", players, loadedPlayers)
+
+	loadedTournaments, err := r.Tournaments(ctx)
+	test.Check(t, "transferRepo.Tournaments() failed: %v", err)
+	test.Compare(t, "exported tournaments differ:\n%s", tournaments, loadedTournaments)
+
+	loadedTeams, err := r.Teams(ctx)
+	test.Check(t, "transferRepo.Teams() failed: %v", err)
+	test.Compare(t, "exported teams differ:\n%s", teams, loadedTeams)
+
+	loadedUsers, err := r.Users(ctx)
+	test.Check(t, "transferRepo.Users() failed: %v", err)
+	test.Compare(t, "exported users differ:\n%s", users, loadedUsers)
+
+	loadedSettings, err := r.Settings(ctx)
+	test.Check(t, "transferRepo.Settings() failed: %v", err)
+	test.Compare(t, "exported settings differ:\n%s", settings, loadedSettings)
+
+	loadedLogs, err := r.NotificationLogs(ctx)
+	test.Check(t, "transferRepo.NotificationLogs() failed: %v", err)
+	test.Compare(t, "exported notification logs differ:\n%s", logs, loadedLogs)
+
+	loadedRules, err := r.AlertRules(ctx)
+	test.Check(t, "transferRepo.AlertRules() failed: %v", err)
+	test.Compare(t, "exported alert rules differ:\n%s", rules, loadedRules)
+
+	loadedTriggers, err := r.AlertTriggers(ctx)
+	test.Check(t, "transferRepo.AlertTriggers() failed: %v", err)
+	test.Compare(t, "exported alert triggers differ:\n%s", triggers, loadedTriggers)
+
+	loadedExecutions, err := r.JobExecutions(ctx)
+	test.Check(t, "transferRepo.JobExecutions() failed: %v", err)
+	test.Compare(t, "exported job executions differ:\n%s", executions, loadedExecutions)
+
+	loadedListings, err := r.PartnerListings(ctx)
+	test.Check(t, "transferRepo.PartnerListings() failed: %v", err)
+	test.Compare(t, "exported partner listings differ:\n%s", listings, loadedListings)
+
+	loadedRequests, err := r.PartnerRequests(ctx)
+	test.Check(t, "transferRepo.PartnerRequests() failed: %v", err)
+	test.Compare(t, "exported partner requests differ:\n%s", requests, loadedRequests)
+
+	loadedWatches, err := r.WatchedTournaments(ctx)
+	test.Check(t, "transferRepo.WatchedTournaments() failed: %v", err)
+	test.Compare(t, "exported watched tournaments differ:\n%s", watches, loadedWatches)
+
+	loadedFollows, err := r.FollowedPlayers(ctx)
+	test.Check(t, "transferRepo.FollowedPlayers() failed: %v", err)
+	test.Compare(t, "exported followed players differ:\n%s", follows, loadedFollows)
+
+	_, err = repos.UserRepo.ByID(ctx, 8)
+	checkNotFound(t, "userRepo.ByID() of a deleted user", err)
+}
+
+func testTransferIDsAfterImport(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	err := repos.TransferRepo.ImportUsers(ctx, &scores.User{
+		M:     scores.M{ID: 7},
+		Track: transferTrack(false),
+		Email: "imported@test.com",
+		Role:  "user",
+	})
+
+	test.Check(t, "transferRepo.ImportUsers() failed: %v", err)
+
+	users := newUsers(t, repos, 1)
+
+	test.Assert(t, "userRepo.New() should assign an id after the imported ones, got %d", users[0].ID > 7, users[0].ID)
+}
@@ -0,0 +1,174 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+)
+
+var userTests = []conformanceTest{
+	{"User/NotFound", testUserNotFound},
+	{"User/Create", testUserCreate},
+	{"User/Update", testUserUpdate},
+	{"User/DuplicateEmail", testUserDuplicateEmail},
+}
+
+var settingTests = []conformanceTest{
+	{"Setting/CreateUpdate", testSettingCreateUpdate},
+	{"Setting/NotFound", testSettingNotFound},
+}
+
+var notificationLogTests = []conformanceTest{
+	{"NotificationLog/ByUserID", testNotificationLogByUserID},
+}
+
+// newUsers creates `count` users with distinct emails.
+func newUsers(t *testing.T, repos *repo.Repositories, count int) []*scores.User {
+	t.Helper()
+
+	users := []*scores.User{}
+
+	for i := 0; i < count; i++ {
+		user, err := repos.UserRepo.New(context.Background(), &scores.User{
+			Email: fmt.Sprintf("user%d@test.com", i),
+			Role:  "user",
+		})
+
+		test.Check(t, "userRepo.New() failed: %v", err)
+
+		users = append(users, user)
+	}
+
+	return users
+}
+
+func testUserNotFound(t *testing.T, repos *repo.Repositories) {
+	_, err := repos.UserRepo.ByID(context.Background(), 1)
+	checkNotFound(t, "userRepo.ByID()", err)
+
+	_, err = repos.UserRepo.ByEmail(context.Background(), "test@test.com")
+	checkNotFound(t, "userRepo.ByEmail()", err)
+}
+
+func testUserCreate(t *testing.T, repos *repo.Repositories) {
+	users := newUsers(t, repos, 2)
+
+	test.Assert(t, "userRepo.New() should assign distinct ids, got %d and %d",
+		users[0].ID > 0 && users[1].ID > 0 && users[0].ID != users[1].ID, users[0].ID, users[1].ID)
+	test.Assert(t, "userRepo.New() should set CreatedAt", !users[0].CreatedAt.IsZero())
+
+	persisted, err := repos.UserRepo.ByID(context.Background(), users[0].ID)
+
+	test.Check(t, "userRepo.ByID() failed: %v", err)
+	test.Compare(t, "persisted user differs:\n%s", users[0], persisted)
+
+	persisted, err = repos.UserRepo.ByEmail(context.Background(), users[1].Email)
+
+	test.Check(t, "userRepo.ByEmail() failed: %v", err)
+	test.Assert(t, "userRepo.ByEmail() returned the wrong user %d", persisted.ID == users[1].ID, persisted.ID)
+
+	all, err := repos.UserRepo.All(context.Background())
+
+	test.Check(t, "userRepo.All() failed: %v", err)
+	test.Assert(t, "want 2 users, got %d", len(all) == 2, len(all))
+}
+
+func testUserUpdate(t *testing.T, repos *repo.Repositories) {
+	user := newUsers(t, repos, 1)[0]
+
+	user.Role = "admin"
+	user.ProfileImageURL = "https://image"
+	user.PasswordInfo = scores.PasswordInfo{Salt: []byte("salt"), Hash: []byte("hash"), Iterations: 10}
+
+	err := repos.UserRepo.Update(context.Background(), user)
+	test.Check(t, "userRepo.Update() failed: %v", err)
+
+	persisted, err := repos.UserRepo.ByID(context.Background(), user.ID)
+
+	test.Check(t, "userRepo.ByID() failed: %v", err)
+	test.Assert(t, "want role admin, got %s", persisted.Role == "admin", persisted.Role)
+	test.Assert(t, "want the profile image, got %q", persisted.ProfileImageURL == "https://image", persisted.ProfileImageURL)
+	test.Compare(t, "password info differs:\n%s", user.PasswordInfo, persisted.PasswordInfo)
+}
+
+func testUserDuplicateEmail(t *testing.T, repos *repo.Repositories) {
+	newUsers(t, repos, 1)
+
+	_, err := repos.UserRepo.New(context.Background(), &scores.User{Email: "user0@test.com"})
+	test.Assert(t, "userRepo.New() should fail for an existing email", err != nil)
+}
+
+func testSettingCreateUpdate(t *testing.T, repos *repo.Repositories) {
+	users := newUsers(t, repos, 2)
+
+	for _, user := range users {
+		_, err := repos.SettingRepo.Create(context.Background(), &scores.Setting{UserID: user.ID, Key: "language", Value: "de", Type: "string"})
+		test.Check(t, "settingRepo.Create() failed: %v", err)
+	}
+
+	setting := &scores.Setting{UserID: users[0].ID, Key: "leagues", Value: "pro-tour", Type: "strings"}
+
+	_, err := repos.SettingRepo.Create(context.Background(), setting)
+	test.Check(t, "settingRepo.Create() failed: %v", err)
+
+	setting.Value = "pro-tour,amateur-tour"
+
+	err = repos.SettingRepo.Update(context.Background(), setting)
+	test.Check(t, "settingRepo.Update() failed: %v", err)
+
+	settings, err := repos.SettingRepo.ByUserID(context.Background(), users[0].ID)
+
+	test.Check(t, "settingRepo.ByUserID() failed: %v", err)
+	test.Assert(t, "want 2 settings, got %d", len(settings) == 2, len(settings))
+
+	dictionary := scores.ToSettingsDictionary(settings)
+	test.Compare(t, "settings differ:\n%s", scores.Settings{
+		"language": "de",
+		"leagues":  []string{"pro-tour", "amateur-tour"},
+	}, dictionary)
+
+	settings, err = repos.SettingRepo.ByKey(context.Background(), "language")
+
+	test.Check(t, "settingRepo.ByKey() failed: %v", err)
+	test.Assert(t, "want the settings of both users, got %d", len(settings) == 2, len(settings))
+
+	settings, err = repos.SettingRepo.All(context.Background())
+
+	test.Check(t, "settingRepo.All() failed: %v", err)
+	test.Assert(t, "want all 3 settings, got %d", len(settings) == 3, len(settings))
+}
+
+func testSettingNotFound(t *testing.T, repos *repo.Repositories) {
+	user := newUsers(t, repos, 1)[0]
+
+	err := repos.SettingRepo.Update(context.Background(), &scores.Setting{UserID: user.ID, Key: "language", Value: "en"})
+	checkNotFound(t, "settingRepo.Update()", err)
+}
+
+func testNotificationLogByUserID(t *testing.T, repos *repo.Repositories) {
+	users := newUsers(t, repos, 2)
+
+	entries := []*scores.NotificationLog{
+		{UserID: users[0].ID, Channel: "email", Recipient: "user0@test.com", Subject: "first", Notifications: 1},
+		{UserID: users[1].ID, Channel: "telegram", Recipient: "1234", Subject: "other user"},
+		{UserID: users[0].ID, Channel: "email", Recipient: "user0@test.com", Subject: "second", Error: "failed"},
+	}
+
+	for _, entry := range entries {
+		_, err := repos.NotificationLogRepo.New(context.Background(), entry)
+
+		test.Check(t, "notificationLogRepo.New() failed: %v", err)
+		test.Assert(t, "notificationLogRepo.New() should assign an id", entry.ID > 0)
+	}
+
+	logs, err := repos.NotificationLogRepo.ByUserID(context.Background(), users[0].ID)
+
+	test.Check(t, "notificationLogRepo.ByUserID() failed: %v", err)
+	test.Assert(t, "want 2 entries, got %d", len(logs) == 2, len(logs))
+	test.Assert(t, "want the latest entry first, got %q", logs[0].Subject == "second", logs[0].Subject)
+	test.Assert(t, "want the error, got %q", logs[0].Error == "failed", logs[0].Error)
+}
@@ -0,0 +1,191 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var watchTests = []conformanceTest{
+	{"Watch/Tournaments", testWatchTournaments},
+	{"Watch/Players", testWatchPlayers},
+	{"Activity/Feed", testActivityFeed},
+	{"Activity/DeleteBefore", testActivityDeleteBefore},
+}
+
+func testWatchTournaments(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	users := newUsers(t, repos, 2)
+
+	for _, w := range []*volleynet.WatchedTournament{
+		{UserID: users[0].ID, TournamentID: 1},
+		{UserID: users[0].ID, TournamentID: 2},
+		{UserID: users[1].ID, TournamentID: 1},
+	} {
+		_, err := repos.WatchRepo.WatchTournament(ctx, w)
+		test.Check(t, "watchRepo.WatchTournament() failed: %v", err)
+		test.Assert(t, "watchRepo.WatchTournament() should assign an id", w.ID > 0)
+	}
+
+	_, err := repos.WatchRepo.WatchTournament(ctx, &volleynet.WatchedTournament{UserID: users[0].ID, TournamentID: 1})
+	test.Assert(t, "watchRepo.WatchTournament() of a watched tournament should fail", err != nil)
+
+	watches, err := repos.WatchRepo.Tournaments(ctx, users[0].ID)
+	test.Check(t, "watchRepo.Tournaments() failed: %v", err)
+	test.Compare(t, "watched tournaments differ:\n%s", []int{2, 1}, watchedIDs(watches))
+
+	test.Check(t, "watchRepo.UnwatchTournament() failed: %v", repos.WatchRepo.UnwatchTournament(ctx, users[0].ID, 2))
+
+	err = repos.WatchRepo.UnwatchTournament(ctx, users[0].ID, 2)
+	checkNotFound(t, "watchRepo.UnwatchTournament() of an unwatched tournament", err)
+
+	watches, err = repos.WatchRepo.Tournaments(ctx, users[0].ID)
+	test.Check(t, "watchRepo.Tournaments() failed: %v", err)
+	test.Compare(t, "watched tournaments differ:\n%s", []int{1}, watchedIDs(watches))
+
+	_, err = repos.WatchRepo.WatchTournament(ctx, &volleynet.WatchedTournament{UserID: users[0].ID, TournamentID: 2})
+	test.Check(t, "watchRepo.WatchTournament() of an unwatched tournament failed: %v", err)
+}
+
+func testWatchPlayers(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	users := newUsers(t, repos, 2)
+
+	newPlayers(t, repos,
+		&volleynet.Player{ID: 1, Gender: "M", FirstName: "Richard"},
+		&volleynet.Player{ID: 2, Gender: "M"},
+		&volleynet.Player{ID: 3, Gender: "M"},
+	)
+
+	for _, f := range []*volleynet.FollowedPlayer{
+		{UserID: users[0].ID, Player: &volleynet.Player{ID: 1}},
+		{UserID: users[0].ID, Player: &volleynet.Player{ID: 2}},
+		{UserID: users[1].ID, Player: &volleynet.Player{ID: 1}},
+	} {
+		_, err := repos.WatchRepo.FollowPlayer(ctx, f)
+		test.Check(t, "watchRepo.FollowPlayer() failed: %v", err)
+	}
+
+	_, err := repos.WatchRepo.FollowPlayer(ctx, &volleynet.FollowedPlayer{UserID: users[1].ID, Player: &volleynet.Player{ID: 1}})
+	test.Assert(t, "watchRepo.FollowPlayer() of a followed player should fail", err != nil)
+
+	follows, err := repos.WatchRepo.Players(ctx, users[0].ID)
+	test.Check(t, "watchRepo.Players() failed: %v", err)
+	test.Compare(t, "followed players differ:\n%s", []int{2, 1}, followedIDs(follows))
+	test.Assert(t, "want the followed player, got %+v", follows[1].Player.FirstName == "Richard", follows[1].Player)
+
+	followed, err := repos.WatchRepo.IsFollowed(ctx, 2)
+	test.Check(t, "watchRepo.IsFollowed() failed: %v", err)
+	test.Assert(t, "watchRepo.IsFollowed() of a followed player should be true", followed)
+
+	followed, err = repos.WatchRepo.IsFollowed(ctx, 3)
+	test.Check(t, "watchRepo.IsFollowed() failed: %v", err)
+	test.Assert(t, "watchRepo.IsFollowed() of a player that isn't followed should be false", !followed)
+
+	test.Check(t, "watchRepo.UnfollowPlayer() failed: %v", repos.WatchRepo.UnfollowPlayer(ctx, users[0].ID, 2))
+
+	err = repos.WatchRepo.UnfollowPlayer(ctx, users[0].ID, 3)
+	checkNotFound(t, "watchRepo.UnfollowPlayer() of a player that isn't followed", err)
+
+	followed, err = repos.WatchRepo.IsFollowed(ctx, 2)
+	test.Check(t, "watchRepo.IsFollowed() failed: %v", err)
+	test.Assert(t, "watchRepo.IsFollowed() of an unfollowed player should be false", !followed)
+
+	followed, err = repos.WatchRepo.IsFollowed(ctx, 1)
+	test.Check(t, "watchRepo.IsFollowed() failed: %v", err)
+	test.Assert(t, "watchRepo.IsFollowed() of a player followed by another user should be true", followed)
+}
+
+func testActivityFeed(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	activities := []*volleynet.Activity{
+		{Type: volleynet.ActivityRegistrationOpen, TournamentID: 1, TournamentName: "Vienna"},
+		{Type: volleynet.ActivityRegistration, TournamentID: 2, Player1ID: 1, Player2ID: 2},
+		{Type: volleynet.ActivityResult, TournamentID: 2, Player1ID: 3, Player2ID: 1, Result: 5},
+		{Type: volleynet.ActivityLadderRank, Player1ID: 4, LadderRank: 10, PreviousRank: 12},
+	}
+
+	test.Check(t, "activityRepo.New() failed: %v", repos.ActivityRepo.New(ctx, activities...))
+	test.Assert(t, "activityRepo.New() should assign ids", activities[3].ID > activities[0].ID)
+	test.Assert(t, "activityRepo.New() should set CreatedAt", !activities[0].CreatedAt.IsZero())
+
+	since := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		filter repo.ActivityFilter
+		want   []int
+	}{
+		{"nothing", repo.ActivityFilter{Since: since, Limit: 10}, []int{}},
+		{"tournament", repo.ActivityFilter{TournamentIDs: []int{1}, Since: since, Limit: 10}, []int{activities[0].ID}},
+		{"player", repo.ActivityFilter{PlayerIDs: []int{1}, Since: since, Limit: 10}, []int{activities[2].ID, activities[1].ID}},
+		{"both", repo.ActivityFilter{TournamentIDs: []int{1}, PlayerIDs: []int{4}, Since: since, Limit: 10}, []int{activities[3].ID, activities[0].ID}},
+		{"limit", repo.ActivityFilter{PlayerIDs: []int{1}, Since: since, Limit: 1}, []int{activities[2].ID}},
+		{"since", repo.ActivityFilter{TournamentIDs: []int{1, 2}, Since: time.Now().Add(time.Hour), Limit: 10}, []int{}},
+	}
+
+	for _, tt := range tests {
+		feed, err := repos.ActivityRepo.Feed(ctx, tt.filter)
+
+		test.Check(t, "activityRepo.Feed() failed: %v", err)
+		test.Compare(t, tt.name+": activities differ:\n%s", tt.want, activityIDs(feed))
+	}
+
+	feed, err := repos.ActivityRepo.Feed(ctx, repo.ActivityFilter{PlayerIDs: []int{4}, Since: since, Limit: 10})
+	test.Check(t, "activityRepo.Feed() failed: %v", err)
+	test.Assert(t, "want the ladder ranks of the activity, got %+v",
+		len(feed) == 1 && feed[0].LadderRank == 10 && feed[0].PreviousRank == 12, feed)
+}
+
+func testActivityDeleteBefore(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	err := repos.ActivityRepo.New(ctx,
+		&volleynet.Activity{Type: volleynet.ActivityRegistrationOpen, TournamentID: 1},
+		&volleynet.Activity{Type: volleynet.ActivityRegistrationOpen, TournamentID: 2},
+	)
+	test.Check(t, "activityRepo.New() failed: %v", err)
+
+	deleted, err := repos.ActivityRepo.DeleteBefore(ctx, time.Now().Add(-time.Hour))
+	test.Check(t, "activityRepo.DeleteBefore() failed: %v", err)
+	test.Equal(t, "want %d deleted activities, got %d", 0, deleted)
+
+	deleted, err = repos.ActivityRepo.DeleteBefore(ctx, time.Now().Add(time.Hour))
+	test.Check(t, "activityRepo.DeleteBefore() failed: %v", err)
+	test.Equal(t, "want %d deleted activities, got %d", 2, deleted)
+}
+
+func watchedIDs(watches []*volleynet.WatchedTournament) []int {
+	ids := []int{}
+
+	for _, w := range watches {
+		ids = append(ids, w.TournamentID)
+	}
+
+	return ids
+}
+
+func followedIDs(follows []*volleynet.FollowedPlayer) []int {
+	ids := []int{}
+
+	for _, f := range follows {
+		ids = append(ids, f.Player.ID)
+	}
+
+	return ids
+}
+
+func activityIDs(activities []*volleynet.Activity) []int {
+	ids := []int{}
+
+	for _, a := range activities {
+		ids = append(ids, a.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,58 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.ActivityRepository = &activityRepository{}
+
+type activityRepository struct {
+	DB *sqlx.DB
+}
+
+// New persists activities and assigns new ids.
+func (s *activityRepository) New(ctx context.Context, activities ...*volleynet.Activity) error {
+	as := make([]scores.Model, len(activities))
+
+	for i, a := range activities {
+		as[i] = a
+	}
+
+	err := crud.CreateSetID(ctx, s.DB, "activity/insert", as...)
+
+	return errors.Wrap(err, "new activities")
+}
+
+// Feed returns the activities that match the filter, the latest first.
+func (s *activityRepository) Feed(ctx context.Context, filter repo.ActivityFilter) ([]*volleynet.Activity, error) {
+	activities := []*volleynet.Activity{}
+
+	if filter.Limit <= 0 {
+		return activities, nil
+	}
+
+	// an `IN` clause can't be empty, -1 doesn't match any id
+	tournamentIDs := append([]int{-1}, filter.TournamentIDs...)
+	playerIDs := append([]int{-1}, filter.PlayerIDs...)
+
+	err := crud.ReadIn(ctx, s.DB, "activity/select-feed", &activities,
+		filter.Since, tournamentIDs, playerIDs, playerIDs, filter.Limit)
+
+	return activities, errors.Wrap(err, "activity feed")
+}
+
+// DeleteBefore permanently deletes activities that have been created before `t`.
+func (s *activityRepository) DeleteBefore(ctx context.Context, t time.Time) (int, error) {
+	deleted, err := crud.DeleteWhere(ctx, s.DB, "activity/delete-before", t)
+
+	return deleted, errors.Wrap(err, "delete activities")
+}
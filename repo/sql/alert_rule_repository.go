@@ -0,0 +1,78 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+)
+
+var _ repo.AlertRuleRepository = &alertRuleRepository{}
+
+type alertRuleRepository struct {
+	DB *sqlx.DB
+}
+
+// All loads all alert rules of all users.
+func (s *alertRuleRepository) All(ctx context.Context) ([]*scores.AlertRule, error) {
+	rules := []*scores.AlertRule{}
+	err := crud.Read(ctx, s.DB, "alert-rule/select-all", &rules)
+
+	return rules, errors.Wrap(err, "all alert rules")
+}
+
+// ByID loads an alert rule.
+func (s *alertRuleRepository) ByID(ctx context.Context, ruleID int) (*scores.AlertRule, error) {
+	rule := &scores.AlertRule{}
+	err := crud.ReadOne(ctx, s.DB, "alert-rule/select-by-id", rule, ruleID)
+
+	return rule, errors.Wrap(err, "byID alert rule")
+}
+
+// ByUserID loads all alert rules of a user.
+func (s *alertRuleRepository) ByUserID(ctx context.Context, userID int) ([]*scores.AlertRule, error) {
+	rules := []*scores.AlertRule{}
+	err := crud.Read(ctx, s.DB, "alert-rule/select-by-user-id", &rules, userID)
+
+	return rules, errors.Wrap(err, "byUserID alert rule")
+}
+
+// New persists an alert rule and assigns a new id.
+func (s *alertRuleRepository) New(ctx context.Context, rule *scores.AlertRule) (*scores.AlertRule, error) {
+	err := crud.CreateSetID(ctx, s.DB, "alert-rule/insert", rule)
+
+	return rule, errors.Wrap(err, "new alert rule")
+}
+
+// Update updates an alert rule.
+func (s *alertRuleRepository) Update(ctx context.Context, rule *scores.AlertRule) error {
+	err := crud.Update(ctx, s.DB, "alert-rule/update", rule)
+
+	return errors.Wrap(err, "update alert rule")
+}
+
+// Delete deletes an alert rule.
+func (s *alertRuleRepository) Delete(ctx context.Context, rule *scores.AlertRule) error {
+	err := crud.Delete(ctx, s.DB, "alert-rule/delete", rule)
+
+	return errors.Wrap(err, "delete alert rule")
+}
+
+// Trigger records that the rule has matched the event `key`.
+func (s *alertRuleRepository) Trigger(ctx context.Context, ruleID int, key string) (bool, error) {
+	err := crud.ReadOne(ctx, s.DB, "alert-rule/select-trigger", &scores.AlertTrigger{}, ruleID, key)
+
+	if err == nil {
+		return false, nil
+	} else if err != scores.ErrNotFound {
+		return false, errors.Wrap(err, "select alert trigger")
+	}
+
+	err = crud.Create(ctx, s.DB, "alert-rule/insert-trigger", &scores.AlertTrigger{RuleID: ruleID, Key: key})
+
+	return err == nil, errors.Wrap(err, "insert alert trigger")
+}
@@ -0,0 +1,76 @@
+//go:build repository
+// +build repository
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/test"
+)
+
+func TestCreateAlertRule(t *testing.T) {
+	db := SetupDB(t)
+	alertRuleRepo := &alertRuleRepository{DB: db}
+	users := CreateUsers(t, db, U{})
+
+	rule := &scores.AlertRule{
+		UserID:    users[0].ID,
+		Name:      "Pro tour near vienna",
+		Event:     "registration-open",
+		League:    "pro-tour",
+		Latitude:  48.2,
+		Longitude: 16.37,
+		Radius:    50,
+	}
+
+	_, err := alertRuleRepo.New(context.Background(), rule)
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+	test.Assert(t, "alertRuleRepo.New() should assign an id", rule.ID > 0)
+
+	_, err = alertRuleRepo.New(context.Background(), &scores.AlertRule{UserID: users[0].ID, PlayerID: 1234})
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+
+	rules, err := alertRuleRepo.ByUserID(context.Background(), users[0].ID)
+	test.Check(t, "alertRuleRepo.ByUserID() failed: %v", err)
+	test.Assert(t, "want 2 rules, got %d", len(rules) == 2, len(rules))
+	test.Compare(t, "rule is not equal:\n%s", rules[0], rule)
+
+	rules, err = alertRuleRepo.All(context.Background())
+	test.Check(t, "alertRuleRepo.All() failed: %v", err)
+	test.Assert(t, "want 2 rules, got %d", len(rules) == 2, len(rules))
+}
+
+func TestDeleteAlertRule(t *testing.T) {
+	db := SetupDB(t)
+	alertRuleRepo := &alertRuleRepository{DB: db}
+	users := CreateUsers(t, db, U{})
+
+	rule, err := alertRuleRepo.New(context.Background(), &scores.AlertRule{UserID: users[0].ID})
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+
+	err = alertRuleRepo.Delete(context.Background(), rule)
+	test.Check(t, "alertRuleRepo.Delete() failed: %v", err)
+
+	_, err = alertRuleRepo.ByID(context.Background(), rule.ID)
+	test.Assert(t, "want ErrNotFound, got %v", err != nil, err)
+}
+
+func TestTriggerAlertRule(t *testing.T) {
+	db := SetupDB(t)
+	alertRuleRepo := &alertRuleRepository{DB: db}
+	users := CreateUsers(t, db, U{})
+
+	rule, err := alertRuleRepo.New(context.Background(), &scores.AlertRule{UserID: users[0].ID})
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+
+	triggered, err := alertRuleRepo.Trigger(context.Background(), rule.ID, "registration-open/1")
+	test.Check(t, "alertRuleRepo.Trigger() failed: %v", err)
+	test.Assert(t, "first trigger should return true", triggered)
+
+	triggered, err = alertRuleRepo.Trigger(context.Background(), rule.ID, "registration-open/1")
+	test.Check(t, "alertRuleRepo.Trigger() failed: %v", err)
+	test.Assert(t, "second trigger should return false", !triggered)
+}
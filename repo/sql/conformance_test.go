@@ -0,0 +1,18 @@
+//go:build repository
+// +build repository
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) *repo.Repositories {
+		repos, _ := RepositoriesTest(t)
+		return repos
+	})
+}
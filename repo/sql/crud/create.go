@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -8,10 +9,10 @@ import (
 )
 
 // Create creates a new entity.
-func Create(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
+func Create(ctx context.Context, db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
 	var err error
 
-	stmt, err := db.PrepareNamed(namedQuery(db, queryName))
+	stmt, err := db.PrepareNamedContext(ctx, namedQuery(ctx, db, queryName))
 
 	if err != nil {
 		return mapError(err)
@@ -22,7 +23,7 @@ func Create(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
 	for _, entity := range entities {
 		setTrackedCreate(entity, now)
 
-		_, err = stmt.Exec(entity)
+		_, err = stmt.ExecContext(ctx, entity)
 
 		if err != nil {
 			return mapError(err)
@@ -33,22 +34,22 @@ func Create(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
 }
 
 // CreateSetID creates a new entity and sets the newly assigned primary key.
-func CreateSetID(db *sqlx.DB, queryName string, entities ...scores.Model) error {
+func CreateSetID(ctx context.Context, db *sqlx.DB, queryName string, entities ...scores.Model) error {
 	var err error
 
 	if db.DriverName() == "postgres" {
-		err = createQueryID(db, queryName, entities...)
+		err = createQueryID(ctx, db, queryName, entities...)
 	} else {
-		err = createResultID(db, queryName, entities...)
+		err = createResultID(ctx, db, queryName, entities...)
 	}
 
 	return mapError(err)
 }
 
-func createResultID(db *sqlx.DB, queryName string, entities ...scores.Model) error {
-	q := query(db, queryName)
+func createResultID(ctx context.Context, db *sqlx.DB, queryName string, entities ...scores.Model) error {
+	q := query(ctx, db, queryName)
 
-	stmt, err := db.PrepareNamed(q)
+	stmt, err := db.PrepareNamedContext(ctx, q)
 
 	if err != nil {
 		return err
@@ -58,7 +59,7 @@ func createResultID(db *sqlx.DB, queryName string, entities ...scores.Model) err
 
 	for _, entity := range entities {
 		setTrackedCreate(entity, now)
-		result, err := stmt.Exec(entity)
+		result, err := stmt.ExecContext(ctx, entity)
 
 		if err != nil {
 			return err
@@ -76,10 +77,10 @@ func createResultID(db *sqlx.DB, queryName string, entities ...scores.Model) err
 	return nil
 }
 
-func createQueryID(db *sqlx.DB, queryName string, entities ...scores.Model) error {
-	q := namedQuery(db, queryName)
+func createQueryID(ctx context.Context, db *sqlx.DB, queryName string, entities ...scores.Model) error {
+	q := namedQuery(ctx, db, queryName)
 
-	stmt, err := db.PrepareNamed(q)
+	stmt, err := db.PrepareNamedContext(ctx, q)
 
 	if err != nil {
 		return err
@@ -89,7 +90,7 @@ func createQueryID(db *sqlx.DB, queryName string, entities ...scores.Model) erro
 
 	for _, entity := range entities {
 		setTrackedCreate(entity, now)
-		rows, err := stmt.Query(entity)
+		rows, err := stmt.QueryContext(ctx, entity)
 
 		if err != nil {
 			return err
@@ -118,3 +119,21 @@ func setTrackedCreate(entity interface{}, now time.Time) {
 		tracked.Create(now)
 	}
 }
+
+// Import inserts entities unchanged, e.g. their primary keys and timestamps
+// are not assigned by the db.
+func Import(ctx context.Context, db *sqlx.DB, queryName string, entities ...interface{}) error {
+	stmt, err := db.PrepareNamedContext(ctx, namedQuery(ctx, db, queryName))
+
+	if err != nil {
+		return mapError(err)
+	}
+
+	for _, entity := range entities {
+		if _, err = stmt.ExecContext(ctx, entity); err != nil {
+			return mapError(err)
+		}
+	}
+
+	return nil
+}
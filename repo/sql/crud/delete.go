@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -8,8 +9,8 @@ import (
 )
 
 // Delete deletes an entity.
-func Delete(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
-	stmt, err := db.PrepareNamed(namedQuery(db, queryName))
+func Delete(ctx context.Context, db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
+	stmt, err := db.PrepareNamedContext(ctx, namedQuery(ctx, db, queryName))
 
 	if err != nil {
 		return mapError(err)
@@ -20,7 +21,7 @@ func Delete(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
 	for _, entity := range entities {
 		entity.Delete(now)
 
-		result, err := stmt.Exec(entity)
+		result, err := stmt.ExecContext(ctx, entity)
 
 		if err != nil {
 			return mapError(err)
@@ -39,3 +40,9 @@ func Delete(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
 
 	return nil
 }
+
+// DeleteWhere permanently deletes all rows that are matched by the query
+// and returns the # of deleted rows.
+func DeleteWhere(ctx context.Context, db *sqlx.DB, queryName string, args ...interface{}) (int, error) {
+	return Exec(ctx, db, queryName, args...)
+}
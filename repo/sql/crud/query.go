@@ -1,8 +1,9 @@
 package crud
 
 import (
-	"fmt"
+	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/raphi011/scores"
@@ -16,16 +17,32 @@ var (
 )
 
 // Execute executes a query.
-func Execute(db *sqlx.DB, queryName string) error {
-	_, err := db.Exec(loadQuery(db, queryName))
+func Execute(ctx context.Context, db *sqlx.DB, queryName string) error {
+	_, err := db.ExecContext(ctx, loadQuery(ctx, db, queryName))
 
 	return err
 }
 
-func loadQuery(db *sqlx.DB, name string) string {
+// Exec executes a query with positional arguments and returns
+// the # of affected rows.
+func Exec(ctx context.Context, db *sqlx.DB, queryName string, args ...interface{}) (int, error) {
+	result, err := db.ExecContext(ctx, query(ctx, db, queryName), args...)
+
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	return int(rowsAffected), mapError(err)
+}
+
+func loadQuery(ctx context.Context, db *sqlx.DB, name string) string {
 	var q string
 	var err error
 
+	logQuery(ctx, name)
+
 	dbSpecificName := fmt.Sprintf("%s.%s.sql", name, db.DriverName())
 	genericName := fmt.Sprintf("%s.sql", name)
 
@@ -40,12 +57,24 @@ func loadQuery(db *sqlx.DB, name string) string {
 	return ""
 }
 
-func namedQuery(db *sqlx.DB, name string) string {
-	return loadQuery(db, name)
+// logQuery logs the query at debug level, if it's executed as part
+// of a request the request id is added so they can be correlated.
+func logQuery(ctx context.Context, name string) {
+	entry := log.WithField("query", name)
+
+	if requestID := scores.RequestID(ctx); requestID != "" {
+		entry = entry.WithField("request-id", requestID)
+	}
+
+	entry.Debug("executing query")
 }
 
-func query(db *sqlx.DB, queryName string) string {
-	return db.Rebind(loadQuery(db, queryName))
+func namedQuery(ctx context.Context, db *sqlx.DB, name string) string {
+	return loadQuery(ctx, db, name)
+}
+
+func query(ctx context.Context, db *sqlx.DB, queryName string) string {
+	return db.Rebind(loadQuery(ctx, db, queryName))
 }
 
 func mapError(err error) error {
@@ -59,4 +88,3 @@ func mapError(err error) error {
 
 	return err
 }
-
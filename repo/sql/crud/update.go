@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -9,8 +10,8 @@ import (
 )
 
 // Update updates multiple entities and updates the `UpdatedAt` field.
-func Update(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
-	stmt, err := db.PrepareNamed(namedQuery(db, queryName))
+func Update(ctx context.Context, db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
+	stmt, err := db.PrepareNamedContext(ctx, namedQuery(ctx, db, queryName))
 
 	if err != nil {
 		return mapError(err)
@@ -21,7 +22,7 @@ func Update(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
 	for _, entity := range entities {
 		entity.Update(now)
 
-		result, err := stmt.Exec(entity)
+		result, err := stmt.ExecContext(ctx, entity)
 
 		if err != nil {
 			return mapError(err)
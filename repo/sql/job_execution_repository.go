@@ -0,0 +1,41 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+)
+
+var _ repo.JobExecutionRepository = &jobExecutionRepository{}
+
+type jobExecutionRepository struct {
+	DB *sqlx.DB
+}
+
+// New persists a job execution and assigns a new id.
+func (s *jobExecutionRepository) New(ctx context.Context, execution *scores.JobExecution) (*scores.JobExecution, error) {
+	err := crud.CreateSetID(ctx, s.DB, "job-execution/insert", execution)
+
+	return execution, errors.Wrap(err, "new job execution")
+}
+
+// Page loads executions of the job (or of all jobs if `jobName` is empty), latest first.
+func (s *jobExecutionRepository) Page(ctx context.Context, jobName string, offset, limit int) ([]*scores.JobExecution, error) {
+	executions := []*scores.JobExecution{}
+	err := crud.Read(ctx, s.DB, "job-execution/select-page", &executions, jobName, jobName, limit, offset)
+
+	return executions, errors.Wrap(err, "page job executions")
+}
+
+// DeleteBefore deletes all executions that have started before `t`.
+func (s *jobExecutionRepository) DeleteBefore(ctx context.Context, t time.Time) (int, error) {
+	deleted, err := crud.DeleteWhere(ctx, s.DB, "job-execution/delete-before", t)
+
+	return deleted, errors.Wrap(err, "delete job executions")
+}
@@ -0,0 +1,60 @@
+//go:build repository
+// +build repository
+
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/test"
+)
+
+func TestJobExecutionPage(t *testing.T) {
+	db := SetupDB(t)
+	jobExecutionRepo := &jobExecutionRepository{DB: db}
+
+	start := time.Date(2019, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	for i, name := range []string{"Tournaments", "Players", "Tournaments", "Tournaments"} {
+		_, err := jobExecutionRepo.New(context.Background(), &scores.JobExecution{
+			JobName: name,
+			Start:   start.Add(time.Duration(i) * time.Hour),
+			End:     start.Add(time.Duration(i)*time.Hour + time.Minute),
+			Success: i != 0,
+		})
+		test.Check(t, "jobExecutionRepo.New() failed: %v", err)
+	}
+
+	executions, err := jobExecutionRepo.Page(context.Background(), "Tournaments", 1, 5)
+	test.Check(t, "jobExecutionRepo.Page() failed: %v", err)
+	test.Assert(t, "want 2 executions, got %d", len(executions) == 2, len(executions))
+	test.Assert(t, "want executions ordered by start desc, got %v", executions[0].Start.Equal(start.Add(2*time.Hour)), executions[0].Start)
+	test.Assert(t, "want the oldest execution to have failed", !executions[1].Success)
+
+	executions, err = jobExecutionRepo.Page(context.Background(), "", 0, 10)
+	test.Check(t, "jobExecutionRepo.Page() failed: %v", err)
+	test.Assert(t, "want 4 executions, got %d", len(executions) == 4, len(executions))
+}
+
+func TestJobExecutionDeleteBefore(t *testing.T) {
+	db := SetupDB(t)
+	jobExecutionRepo := &jobExecutionRepository{DB: db}
+
+	now := time.Now()
+
+	for _, start := range []time.Time{now.AddDate(0, 0, -40), now.AddDate(0, 0, -31), now} {
+		_, err := jobExecutionRepo.New(context.Background(), &scores.JobExecution{JobName: "Players", Start: start, End: start})
+		test.Check(t, "jobExecutionRepo.New() failed: %v", err)
+	}
+
+	deleted, err := jobExecutionRepo.DeleteBefore(context.Background(), now.AddDate(0, 0, -30))
+	test.Check(t, "jobExecutionRepo.DeleteBefore() failed: %v", err)
+	test.Assert(t, "want 2 deleted executions, got %d", deleted == 2, deleted)
+
+	executions, err := jobExecutionRepo.Page(context.Background(), "", 0, 10)
+	test.Check(t, "jobExecutionRepo.Page() failed: %v", err)
+	test.Assert(t, "want 1 execution, got %d", len(executions) == 1, len(executions))
+}
@@ -0,0 +1,74 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+)
+
+var _ repo.JobLeaseRepository = &jobLeaseRepository{}
+
+type jobLeaseRepository struct {
+	DB *sqlx.DB
+}
+
+// Acquire creates, renews or takes over (if expired at `now`) the lease.
+func (s *jobLeaseRepository) Acquire(ctx context.Context, lease *scores.JobLease, now time.Time) (bool, error) {
+	// times are stored in UTC so they can be compared on all providers
+	now = now.UTC()
+	expiresAt := lease.ExpiresAt.UTC()
+
+	updated, err := crud.Exec(ctx, s.DB, "job-lease/update",
+		lease.Owner, expiresAt, lease.JobName, lease.Owner, now)
+
+	if err != nil {
+		return false, errors.Wrap(err, "update job lease")
+	}
+
+	if updated == 1 {
+		return true, nil
+	}
+
+	persisted, err := s.byJobName(ctx, lease.JobName)
+
+	if errors.Cause(err) == scores.ErrNotFound {
+		_, err = crud.Exec(ctx, s.DB, "job-lease/insert", lease.JobName, lease.Owner, expiresAt)
+
+		if err == nil {
+			return true, nil
+		}
+
+		// another instance might have been faster
+		persisted, err = s.byJobName(ctx, lease.JobName)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	// some providers don't report rows whose values have not changed
+	// as updated, e.g. mysql when renewing within the same millisecond
+	held := persisted.Owner == lease.Owner && !persisted.ExpiresAt.Before(now)
+
+	return held, nil
+}
+
+// Release deletes the lease if it's held by `owner`.
+func (s *jobLeaseRepository) Release(ctx context.Context, jobName, owner string) error {
+	_, err := crud.Exec(ctx, s.DB, "job-lease/delete", jobName, owner)
+
+	return errors.Wrap(err, "release job lease")
+}
+
+func (s *jobLeaseRepository) byJobName(ctx context.Context, jobName string) (*scores.JobLease, error) {
+	lease := &scores.JobLease{}
+	err := crud.ReadOne(ctx, s.DB, "job-lease/select-by-job-name", lease, jobName)
+
+	return lease, errors.Wrap(err, "byJobName job lease")
+}
@@ -0,0 +1,69 @@
+//go:build repository
+// +build repository
+
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/test"
+)
+
+func TestAcquireJobLease(t *testing.T) {
+	db := SetupDB(t)
+	jobLeaseRepo := &jobLeaseRepository{DB: db}
+
+	now := time.Now()
+	lease := func(owner string, expiresAt time.Time) *scores.JobLease {
+		return &scores.JobLease{JobName: "Tournaments", Owner: owner, ExpiresAt: expiresAt}
+	}
+
+	acquired, err := jobLeaseRepo.Acquire(context.Background(), lease("a", now.Add(time.Minute)), now)
+	test.Check(t, "jobLeaseRepo.Acquire() failed: %v", err)
+	test.Assert(t, "a should acquire the new lease", acquired)
+
+	acquired, err = jobLeaseRepo.Acquire(context.Background(), lease("b", now.Add(time.Minute)), now)
+	test.Check(t, "jobLeaseRepo.Acquire() failed: %v", err)
+	test.Assert(t, "b should not acquire a's lease", !acquired)
+
+	acquired, err = jobLeaseRepo.Acquire(context.Background(), lease("a", now.Add(2*time.Minute)), now)
+	test.Check(t, "jobLeaseRepo.Acquire() failed: %v", err)
+	test.Assert(t, "a should renew its lease", acquired)
+
+	later := now.Add(3 * time.Minute)
+
+	acquired, err = jobLeaseRepo.Acquire(context.Background(), lease("b", later.Add(time.Minute)), later)
+	test.Check(t, "jobLeaseRepo.Acquire() failed: %v", err)
+	test.Assert(t, "b should take over the expired lease", acquired)
+
+	acquired, err = jobLeaseRepo.Acquire(context.Background(), lease("a", later.Add(time.Minute)), later)
+	test.Check(t, "jobLeaseRepo.Acquire() failed: %v", err)
+	test.Assert(t, "a should have lost its lease", !acquired)
+}
+
+func TestReleaseJobLease(t *testing.T) {
+	db := SetupDB(t)
+	jobLeaseRepo := &jobLeaseRepository{DB: db}
+
+	now := time.Now()
+
+	_, err := jobLeaseRepo.Acquire(context.Background(), &scores.JobLease{JobName: "Players", Owner: "a", ExpiresAt: now.Add(time.Minute)}, now)
+	test.Check(t, "jobLeaseRepo.Acquire() failed: %v", err)
+
+	err = jobLeaseRepo.Release(context.Background(), "Players", "b")
+	test.Check(t, "jobLeaseRepo.Release() failed: %v", err)
+
+	acquired, err := jobLeaseRepo.Acquire(context.Background(), &scores.JobLease{JobName: "Players", Owner: "b", ExpiresAt: now.Add(time.Minute)}, now)
+	test.Check(t, "jobLeaseRepo.Acquire() failed: %v", err)
+	test.Assert(t, "only the owner can release a lease", !acquired)
+
+	err = jobLeaseRepo.Release(context.Background(), "Players", "a")
+	test.Check(t, "jobLeaseRepo.Release() failed: %v", err)
+
+	acquired, err = jobLeaseRepo.Acquire(context.Background(), &scores.JobLease{JobName: "Players", Owner: "b", ExpiresAt: now.Add(time.Minute)}, now)
+	test.Check(t, "jobLeaseRepo.Acquire() failed: %v", err)
+	test.Assert(t, "b should acquire the released lease", acquired)
+}
@@ -0,0 +1,157 @@
+package migrate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo/sql/crud"
+)
+
+const (
+	// lockTTL is how long the lock is valid without being renewed, it
+	// expires if the instance that holds it crashes.
+	lockTTL = 1 * time.Minute
+	// lockRetryInterval is the time between two attempts to acquire the lock.
+	lockRetryInterval = 1 * time.Second
+)
+
+var (
+	// ErrLocked is returned if another instance holds the
+	// migration lock for longer than the lock timeout.
+	ErrLocked = errors.New("the migration lock is held by another instance")
+	// ErrLockLost is returned if the migration lock could not be renewed
+	// and may have been taken over by another instance.
+	ErrLockLost = errors.New("the migration lock has been lost")
+)
+
+// lock acquires the migration lock, the lock is stored in its own table
+// because the locks of the migration drivers only work within a process
+// on some providers (e.g. sqlite3). While the lock is held it's renewed
+// until `release` is called, if a renewal fails the error is sent to `lost`.
+func (m *Migrator) lock(ctx context.Context) (lost <-chan error, release func() error, err error) {
+	if err := crud.Execute(ctx, m.db, "migration-lock/create-table"); err != nil {
+		return nil, nil, errors.Wrap(err, "create migration lock table")
+	}
+
+	owner := lockOwner()
+	timeout := time.After(m.lockTimeout())
+
+	for {
+		acquired, err := m.tryLock(ctx, owner)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if acquired {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-timeout:
+			return nil, nil, ErrLocked
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	done := make(chan struct{})
+	renewed := make(chan struct{})
+	renewErr := make(chan error, 1)
+
+	go func() {
+		defer close(renewed)
+
+		if err := m.renewLock(owner, done); err != nil {
+			renewErr <- err
+		}
+	}()
+
+	return renewErr, func() error {
+		close(done)
+		<-renewed
+
+		// the lock must be released even if `ctx` is done
+		_, err := crud.Exec(context.Background(), m.db, "migration-lock/delete", owner)
+
+		return errors.Wrap(err, "release migration lock")
+	}, nil
+}
+
+// tryLock takes over an expired lock and tries to acquire it, false
+// is returned if another instance holds it.
+func (m *Migrator) tryLock(ctx context.Context, owner string) (bool, error) {
+	// times are stored in UTC so they can be compared on all providers
+	now := time.Now().UTC()
+
+	if _, err := crud.Exec(ctx, m.db, "migration-lock/delete-expired", now); err != nil {
+		return false, errors.Wrap(err, "delete expired migration lock")
+	}
+
+	_, err := crud.Exec(ctx, m.db, "migration-lock/insert", owner, now.Add(lockTTL))
+
+	if err == nil {
+		return true, nil
+	}
+
+	// the insert fails if the lock is held by another instance
+	var holder string
+	selectErr := crud.ReadOne(ctx, m.db, "migration-lock/select-owner", &holder)
+
+	if selectErr == scores.ErrNotFound {
+		return false, errors.Wrap(err, "insert migration lock")
+	} else if selectErr != nil {
+		return false, errors.Wrap(selectErr, "select migration lock owner")
+	}
+
+	return false, nil
+}
+
+// renewLock extends the lock until `done` is closed, so long running
+// migrations do not lose it. An error is returned as soon as a renewal
+// fails, the lock could expire and be taken over by another instance.
+func (m *Migrator) renewLock(owner string, done <-chan struct{}) error {
+	ticker := time.NewTicker(lockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-ticker.C:
+		}
+
+		expiresAt := time.Now().UTC().Add(lockTTL)
+
+		renewed, err := crud.Exec(context.Background(), m.db, "migration-lock/update", expiresAt, owner)
+
+		if err != nil {
+			return errors.Wrapf(ErrLockLost, "renew migration lock: %v", err)
+		} else if renewed == 0 {
+			// the lock has expired and another instance has taken it over
+			return ErrLockLost
+		}
+	}
+}
+
+// lockOwner returns an identifier that is unique for every lock, e.g. `api-1234-1a2b3c4d`.
+func lockOwner() string {
+	host, err := os.Hostname()
+
+	if err != nil {
+		host = "unknown"
+	}
+
+	random := make([]byte, 4)
+	rand.Read(random)
+
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(random))
+}
@@ -1,7 +1,9 @@
 package migrate
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/jmoiron/sqlx"
@@ -20,15 +22,41 @@ var (
 	migrations = packr.New("migrations", "../migrations")
 )
 
-// All runs all available migrations on the db connection.
-func All(provider string, db *sqlx.DB) error {
+// defaultLockTimeout is used if the migrator's `LockTimeout` is not set.
+const defaultLockTimeout = 1 * time.Minute
+
+// Migrator migrates the schema of a database, all operations hold
+// the migration lock so concurrent instances do not race.
+type Migrator struct {
+	LockTimeout time.Duration // how long to wait for the lock if another instance holds it
+
+	db      *sqlx.DB
+	source  *packrDriver
+	migrate *migrate.Migrate
+}
+
+// Status is the current state of the schema.
+type Status struct {
+	Version uint   `json:"version"` // 0 if no migration has been applied
+	Dirty   bool   `json:"dirty"`   // a migration has failed, the version must be fixed with `Force`
+	Latest  uint   `json:"latest"`
+	Pending []uint `json:"pending"`
+}
+
+// UpToDate returns true if all migrations have been applied successfully.
+func (s *Status) UpToDate() bool {
+	return !s.Dirty && len(s.Pending) == 0
+}
+
+// New creates a migrator for the db connection.
+func New(provider string, db *sqlx.DB) (*Migrator, error) {
 	var dbDriver database.Driver
 	var err error
 
 	driver, err := (&packrDriver{}).Open(provider)
 
 	if err != nil {
-		return errors.Wrap(err, "load migration scripts")
+		return nil, errors.Wrap(err, "load migration scripts")
 	}
 
 	switch provider {
@@ -39,24 +67,140 @@ func All(provider string, db *sqlx.DB) error {
 	case "sqlite3":
 		dbDriver, err = sqlite3.WithInstance(db.DB, &sqlite3.Config{})
 	default:
-		return fmt.Errorf("invalid migration db provider: %s", provider)
+		return nil, fmt.Errorf("invalid migration db provider: %s", provider)
 	}
 
 	if err != nil {
-		return errors.Wrap(err, "create db migration driver")
+		return nil, errors.Wrap(err, "create db migration driver")
 	}
 
 	m, err := migrate.NewWithInstance("packr", driver, provider, dbDriver)
 
 	if err != nil {
-		return errors.Wrap(err, "initialize migration")
+		return nil, errors.Wrap(err, "initialize migration")
+	}
+
+	return &Migrator{
+		db:      db,
+		source:  driver.(*packrDriver),
+		migrate: m,
+	}, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	err := m.locked(ctx, m.migrate.Up)
+
+	return errors.Wrap(err, "migrate up")
+}
+
+// Down reverts the last `n` migrations.
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	if n < 1 {
+		return errors.New("migrate down: at least one migration must be reverted")
 	}
 
-	err = m.Up()
+	err := m.locked(ctx, func() error {
+		return m.migrate.Steps(-n)
+	})
+
+	return errors.Wrapf(err, "migrate down %d", n)
+}
+
+// Goto migrates up or down to `version`.
+func (m *Migrator) Goto(ctx context.Context, version uint) error {
+	err := m.locked(ctx, func() error {
+		return m.migrate.Migrate(version)
+	})
+
+	return errors.Wrapf(err, "migrate to version %d", version)
+}
+
+// Force sets the version without running any migrations and clears
+// the dirty flag, -1 means that no migration has been applied. This
+// is used to recover after a migration has failed and has been fixed
+// manually.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	err := m.locked(ctx, func() error {
+		return m.migrate.Force(version)
+	})
+
+	return errors.Wrapf(err, "force version %d", version)
+}
+
+// Status returns the current state of the schema.
+func (m *Migrator) Status() (*Status, error) {
+	version, dirty, err := m.migrate.Version()
+
+	if err != nil && err != migrate.ErrNilVersion {
+		return nil, errors.Wrap(err, "load schema version")
+	}
+
+	status := &Status{
+		Version: version,
+		Dirty:   dirty,
+		Pending: []uint{},
+	}
+
+	for _, v := range m.source.versions() {
+		status.Latest = v
+
+		if v > version {
+			status.Pending = append(status.Pending, v)
+		}
+	}
+
+	return status, nil
+}
+
+// locked runs `migration` while holding the migration lock, it's
+// not an error if there was nothing to migrate. If the lock can't be
+// renewed the migration is stopped after the current step.
+func (m *Migrator) locked(ctx context.Context, migration func() error) error {
+	lost, release, err := m.lock(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	migrated := make(chan error, 1)
+
+	go func() {
+		migrated <- migration()
+	}()
+
+	select {
+	case err = <-migrated:
+	case err = <-lost:
+		m.migrate.GracefulStop <- true
+		<-migrated
+	}
+
+	if releaseErr := release(); err == nil || err == migrate.ErrNoChange {
+		err = releaseErr
+	}
+
+	return err
+}
+
+func (m *Migrator) lockTimeout() time.Duration {
+	if m.LockTimeout > 0 {
+		return m.LockTimeout
+	}
+
+	return defaultLockTimeout
+}
+
+// versions returns all versions of the source, ascending.
+func (p *packrDriver) versions() []uint {
+	versions := []uint{}
+
+	version, err := p.First()
 
-	if err == migrate.ErrNoChange {
-		return nil
+	for err == nil {
+		versions = append(versions, version)
+		version, err = p.Next(version)
 	}
 
-	return errors.Wrap(err, "migrateAll")
+	return versions
 }
@@ -0,0 +1,83 @@
+//go:build repository
+// +build repository
+
+package migrate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores/repo/sql"
+	"github.com/raphi011/scores/repo/sql/crud"
+	"github.com/raphi011/scores/repo/sql/migrate"
+	"github.com/raphi011/scores/test"
+)
+
+func newMigrator(t *testing.T) *migrate.Migrator {
+	db := sql.SetupDB(t)
+	migrator, err := migrate.New(db.DriverName(), db)
+	test.Check(t, "migrate.New() failed: %v", err)
+
+	return migrator
+}
+
+func TestMigrateDownAndUp(t *testing.T) {
+	ctx := context.Background()
+	migrator := newMigrator(t)
+
+	status, err := migrator.Status()
+	test.Check(t, "migrator.Status() failed: %v", err)
+	test.Assert(t, "want an up to date schema, got: %+v", status.UpToDate(), status)
+
+	latest := status.Latest
+
+	err = migrator.Down(ctx, 1)
+	test.Check(t, "migrator.Down() failed: %v", err)
+
+	status, err = migrator.Status()
+	test.Check(t, "migrator.Status() failed: %v", err)
+	test.Assert(t, "want version %d and one pending migration, got: %+v",
+		status.Version == latest-1 && len(status.Pending) == 1, latest-1, status)
+
+	err = migrator.Up(ctx)
+	test.Check(t, "migrator.Up() failed: %v", err)
+
+	// nothing to migrate is not an error
+	err = migrator.Up(ctx)
+	test.Check(t, "migrator.Up() failed: %v", err)
+
+	status, err = migrator.Status()
+	test.Check(t, "migrator.Status() failed: %v", err)
+	test.Assert(t, "want an up to date schema, got: %+v", status.UpToDate(), status)
+}
+
+func TestMigrationLock(t *testing.T) {
+	ctx := context.Background()
+	db := sql.SetupDB(t)
+	migrator, err := migrate.New(db.DriverName(), db)
+	test.Check(t, "migrate.New() failed: %v", err)
+
+	migrator.LockTimeout = 100 * time.Millisecond
+
+	err = crud.Execute(ctx, db, "migration-lock/create-table")
+	test.Check(t, "creating the lock table failed: %v", err)
+
+	_, err = crud.Exec(ctx, db, "migration-lock/insert", "other-instance", time.Now().UTC().Add(time.Hour))
+	test.Check(t, "inserting the lock failed: %v", err)
+
+	err = migrator.Up(ctx)
+
+	if errors.Cause(err) != migrate.ErrLocked {
+		t.Fatalf("migrator.Up() want: %v, got: %v", migrate.ErrLocked, err)
+	}
+
+	// an expired lock is taken over
+	_, err = crud.Exec(ctx, db, "migration-lock/update", time.Now().UTC().Add(-time.Minute), "other-instance")
+	test.Check(t, "expiring the lock failed: %v", err)
+
+	err = migrator.Up(ctx)
+	test.Check(t, "migrator.Up() failed: %v", err)
+}
@@ -0,0 +1,21 @@
+CREATE TABLE scheduled_signups (
+	id integer AUTO_INCREMENT PRIMARY KEY,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	player_id integer NOT NULL,
+	tournament_id integer NOT NULL,
+	partner_id integer NOT NULL,
+	partner_name varchar(255) CHARSET utf8mb4 NOT NULL,
+	status varchar(32) NOT NULL,
+	message varchar(1024) CHARSET utf8mb4 NOT NULL,
+	credentials varchar(1024) NOT NULL,
+	expires_at datetime NOT NULL,
+	attempted_at datetime,
+
+	INDEX(status),
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
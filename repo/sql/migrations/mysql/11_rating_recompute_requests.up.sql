@@ -0,0 +1,4 @@
+CREATE TABLE rating_recompute_requests (
+	id integer AUTO_INCREMENT PRIMARY KEY,
+	created_at datetime NOT NULL
+);
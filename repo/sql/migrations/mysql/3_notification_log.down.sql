@@ -0,0 +1 @@
+DROP TABLE notification_log;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE job_executions (
+	id integer AUTO_INCREMENT PRIMARY KEY,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	job_name varchar(128) NOT NULL,
+	start_time datetime NOT NULL,
+	end_time datetime NOT NULL,
+	success integer NOT NULL,
+	error text CHARSET utf8mb4 NOT NULL,
+	summary text CHARSET utf8mb4 NOT NULL,
+
+	INDEX(start_time)
+);
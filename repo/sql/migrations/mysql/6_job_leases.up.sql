@@ -0,0 +1,5 @@
+CREATE TABLE job_leases (
+	job_name varchar(128) PRIMARY KEY,
+	owner varchar(255) NOT NULL,
+	expires_at datetime(3) NOT NULL
+);
@@ -0,0 +1,40 @@
+CREATE TABLE partner_listings (
+	id integer AUTO_INCREMENT PRIMARY KEY,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	player_id integer NOT NULL,
+	tournament_id integer NOT NULL,
+	league_key varchar(128) NOT NULL,
+	from_date datetime NOT NULL,
+	to_date datetime NOT NULL,
+	note text CHARSET utf8mb4 NOT NULL,
+	status varchar(16) NOT NULL,
+
+	INDEX(user_id),
+	INDEX(status, to_date),
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE partner_requests (
+	id integer AUTO_INCREMENT PRIMARY KEY,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	listing_id integer NOT NULL,
+	user_id integer NOT NULL,
+	player_id integer NOT NULL,
+	tournament_id integer NOT NULL,
+	message text CHARSET utf8mb4 NOT NULL,
+	status varchar(16) NOT NULL,
+
+	INDEX(listing_id),
+	INDEX(user_id),
+	FOREIGN KEY(listing_id) REFERENCES partner_listings(id) ON DELETE CASCADE,
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
@@ -0,0 +1,49 @@
+CREATE TABLE watched_tournaments (
+	id integer AUTO_INCREMENT PRIMARY KEY,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	tournament_id integer NOT NULL,
+
+	UNIQUE(user_id, tournament_id),
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE followed_players (
+	id integer AUTO_INCREMENT PRIMARY KEY,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	player_id integer NOT NULL,
+
+	UNIQUE(user_id, player_id),
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE activities (
+	id integer AUTO_INCREMENT PRIMARY KEY,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	type varchar(64) NOT NULL,
+	tournament_id integer NOT NULL,
+	tournament_name varchar(255) CHARSET utf8mb4 NOT NULL,
+	player_1_id integer NOT NULL,
+	player_2_id integer NOT NULL,
+	result integer NOT NULL,
+	ladder_rank integer NOT NULL,
+	previous_rank integer NOT NULL,
+
+	INDEX(created_at),
+	INDEX(tournament_id),
+	INDEX(player_1_id),
+	INDEX(player_2_id)
+);
@@ -0,0 +1,21 @@
+CREATE TABLE scheduled_signups (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	user_id         int         NOT NULL REFERENCES users(id),
+	player_id       int         NOT NULL,
+	tournament_id   int         NOT NULL,
+	partner_id      int         NOT NULL,
+	partner_name    text        NOT NULL,
+	status          text        NOT NULL,
+	message         text        NOT NULL,
+	credentials     text        NOT NULL,
+	expires_at      timestamptz NOT NULL,
+	attempted_at    timestamptz
+);
+
+CREATE INDEX scheduled_signups_user_id ON scheduled_signups (user_id);
+CREATE INDEX scheduled_signups_status ON scheduled_signups (status);
@@ -0,0 +1,4 @@
+CREATE TABLE rating_recompute_requests (
+	id          serial      PRIMARY KEY,
+	created_at  timestamptz NOT NULL
+);
@@ -0,0 +1,5 @@
+CREATE TABLE poll_offsets (
+	poller          text        PRIMARY KEY,
+	update_offset   bigint      NOT NULL,
+	updated_at      timestamptz NOT NULL
+);
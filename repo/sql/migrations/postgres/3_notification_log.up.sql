@@ -0,0 +1,16 @@
+CREATE TABLE notification_log (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	user_id         int         NOT NULL REFERENCES users(id),
+	channel         text        NOT NULL,
+	recipient       text        NOT NULL,
+	subject         text        NOT NULL,
+	notifications   int         NOT NULL,
+	error           text        NOT NULL
+);
+
+CREATE INDEX notification_log_user_id ON notification_log (user_id);
@@ -0,0 +1,2 @@
+DROP TABLE alert_triggers;
+DROP TABLE alert_rules;
@@ -0,0 +1,32 @@
+CREATE TABLE alert_rules (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	user_id         int         NOT NULL REFERENCES users(id),
+	name            text        NOT NULL,
+	event           text        NOT NULL,
+	league_key      text        NOT NULL,
+	gender          text        NOT NULL,
+	player_id       int         NOT NULL,
+	loc_lat         float8      NOT NULL,
+	loc_lon         float8      NOT NULL,
+	radius          int         NOT NULL
+);
+
+CREATE INDEX alert_rules_user_id ON alert_rules (user_id);
+
+CREATE TABLE alert_triggers (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	rule_id         int         NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+	event_key       text        NOT NULL,
+
+	UNIQUE(rule_id, event_key)
+);
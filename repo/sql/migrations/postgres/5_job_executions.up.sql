@@ -0,0 +1,16 @@
+CREATE TABLE job_executions (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	job_name        text        NOT NULL,
+	start_time      timestamptz NOT NULL,
+	end_time        timestamptz NOT NULL,
+	success         boolean     NOT NULL,
+	error           text        NOT NULL,
+	summary         text        NOT NULL
+);
+
+CREATE INDEX job_executions_start_time ON job_executions (start_time);
@@ -0,0 +1,5 @@
+CREATE TABLE job_leases (
+	job_name        text        PRIMARY KEY,
+	owner           text        NOT NULL,
+	expires_at      timestamptz NOT NULL
+);
@@ -0,0 +1,16 @@
+CREATE TABLE player_ratings (
+	created_at          timestamptz NOT NULL,
+	updated_at          timestamptz,
+	deleted_at          timestamptz,
+
+	player_id           integer     NOT NULL,
+	tournament_id       integer     NOT NULL,
+	tournament_start    timestamptz NOT NULL,
+	rating              float8      NOT NULL,
+	delta               float8      NOT NULL,
+	tournaments         integer     NOT NULL,
+
+	PRIMARY KEY (player_id, tournament_id)
+);
+
+CREATE INDEX player_ratings_tournament_start ON player_ratings (tournament_start);
@@ -0,0 +1,2 @@
+DROP TABLE partner_requests;
+DROP TABLE partner_listings;
@@ -0,0 +1,37 @@
+CREATE TABLE partner_listings (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	user_id         int         NOT NULL REFERENCES users(id),
+	player_id       int         NOT NULL,
+	tournament_id   int         NOT NULL,
+	league_key      text        NOT NULL,
+	from_date       timestamptz NOT NULL,
+	to_date         timestamptz NOT NULL,
+	note            text        NOT NULL,
+	status          text        NOT NULL
+);
+
+CREATE INDEX partner_listings_user_id ON partner_listings (user_id);
+CREATE INDEX partner_listings_to_date ON partner_listings (status, to_date);
+
+CREATE TABLE partner_requests (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	listing_id      int         NOT NULL REFERENCES partner_listings(id) ON DELETE CASCADE,
+	user_id         int         NOT NULL REFERENCES users(id),
+	player_id       int         NOT NULL,
+	tournament_id   int         NOT NULL,
+	message         text        NOT NULL,
+	status          text        NOT NULL
+);
+
+CREATE INDEX partner_requests_listing_id ON partner_requests (listing_id);
+CREATE INDEX partner_requests_user_id ON partner_requests (user_id);
@@ -0,0 +1,47 @@
+CREATE TABLE watched_tournaments (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	user_id         int         NOT NULL REFERENCES users(id),
+	tournament_id   int         NOT NULL,
+
+	UNIQUE(user_id, tournament_id)
+);
+
+CREATE TABLE followed_players (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	user_id         int         NOT NULL REFERENCES users(id),
+	player_id       int         NOT NULL,
+
+	UNIQUE(user_id, player_id)
+);
+
+CREATE TABLE activities (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	type            text        NOT NULL,
+	tournament_id   int         NOT NULL,
+	tournament_name text        NOT NULL,
+	player_1_id     int         NOT NULL,
+	player_2_id     int         NOT NULL,
+	result          int         NOT NULL,
+	ladder_rank     int         NOT NULL,
+	previous_rank   int         NOT NULL
+);
+
+CREATE INDEX activities_created_at ON activities (created_at);
+CREATE INDEX activities_tournament_id ON activities (tournament_id);
+CREATE INDEX activities_player_1_id ON activities (player_1_id);
+CREATE INDEX activities_player_2_id ON activities (player_2_id);
@@ -0,0 +1,23 @@
+CREATE TABLE scheduled_signups (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	player_id integer NOT NULL,
+	tournament_id integer NOT NULL,
+	partner_id integer NOT NULL,
+	partner_name varchar(255) NOT NULL,
+	status varchar(32) NOT NULL,
+	message varchar(1024) NOT NULL,
+	credentials varchar(1024) NOT NULL,
+	expires_at datetime NOT NULL,
+	attempted_at datetime,
+
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE INDEX scheduled_signups_user_id ON scheduled_signups (user_id);
+CREATE INDEX scheduled_signups_status ON scheduled_signups (status);
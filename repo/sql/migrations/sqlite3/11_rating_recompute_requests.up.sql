@@ -0,0 +1,4 @@
+CREATE TABLE rating_recompute_requests (
+	id integer PRIMARY KEY autoincrement,
+	created_at datetime NOT NULL
+);
@@ -0,0 +1,5 @@
+CREATE TABLE poll_offsets (
+	poller varchar(128) PRIMARY KEY,
+	update_offset bigint NOT NULL,
+	updated_at datetime NOT NULL
+);
@@ -0,0 +1,16 @@
+CREATE TABLE notification_log (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	channel varchar(32) NOT NULL,
+	recipient varchar(255) NOT NULL,
+	subject varchar(255) NOT NULL,
+	notifications integer NOT NULL,
+	error text NOT NULL,
+
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
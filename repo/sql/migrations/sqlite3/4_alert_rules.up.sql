@@ -0,0 +1,33 @@
+CREATE TABLE alert_rules (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	name varchar(255) NOT NULL,
+	event varchar(64) NOT NULL,
+	league_key varchar(128) NOT NULL,
+	gender varchar(1) NOT NULL,
+	player_id integer NOT NULL,
+	loc_lat double NOT NULL,
+	loc_lon double NOT NULL,
+	radius integer NOT NULL,
+
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE alert_triggers (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	rule_id integer NOT NULL,
+	event_key varchar(255) NOT NULL,
+
+	UNIQUE(rule_id, event_key),
+	FOREIGN KEY(rule_id) REFERENCES alert_rules(id) ON DELETE CASCADE
+);
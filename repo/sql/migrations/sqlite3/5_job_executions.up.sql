@@ -0,0 +1,16 @@
+CREATE TABLE job_executions (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	job_name varchar(128) NOT NULL,
+	start_time datetime NOT NULL,
+	end_time datetime NOT NULL,
+	success integer NOT NULL,
+	error text NOT NULL,
+	summary text NOT NULL
+);
+
+CREATE INDEX job_executions_start_time ON job_executions (start_time);
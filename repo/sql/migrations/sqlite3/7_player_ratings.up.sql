@@ -0,0 +1,16 @@
+CREATE TABLE player_ratings (
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	player_id integer NOT NULL,
+	tournament_id integer NOT NULL,
+	tournament_start datetime NOT NULL,
+	rating double NOT NULL,
+	delta double NOT NULL,
+	tournaments integer NOT NULL,
+
+	PRIMARY KEY (player_id, tournament_id)
+);
+
+CREATE INDEX player_ratings_tournament_start ON player_ratings (tournament_start);
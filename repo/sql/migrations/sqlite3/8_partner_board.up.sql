@@ -0,0 +1,42 @@
+CREATE TABLE partner_listings (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	player_id integer NOT NULL,
+	tournament_id integer NOT NULL,
+	league_key varchar(128) NOT NULL,
+	from_date datetime NOT NULL,
+	to_date datetime NOT NULL,
+	note text NOT NULL,
+	status varchar(16) NOT NULL,
+
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE INDEX partner_listings_user_id ON partner_listings (user_id);
+CREATE INDEX partner_listings_to_date ON partner_listings (status, to_date);
+
+CREATE TABLE partner_requests (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	listing_id integer NOT NULL,
+	user_id integer NOT NULL,
+	player_id integer NOT NULL,
+	tournament_id integer NOT NULL,
+	message text NOT NULL,
+	status varchar(16) NOT NULL,
+
+	FOREIGN KEY(listing_id) REFERENCES partner_listings(id) ON DELETE CASCADE,
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE INDEX partner_requests_listing_id ON partner_requests (listing_id);
+CREATE INDEX partner_requests_user_id ON partner_requests (user_id);
@@ -0,0 +1,3 @@
+DROP TABLE activities;
+DROP TABLE followed_players;
+DROP TABLE watched_tournaments;
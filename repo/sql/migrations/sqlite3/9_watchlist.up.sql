@@ -0,0 +1,49 @@
+CREATE TABLE watched_tournaments (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	tournament_id integer NOT NULL,
+
+	UNIQUE(user_id, tournament_id),
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE followed_players (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	user_id integer NOT NULL,
+	player_id integer NOT NULL,
+
+	UNIQUE(user_id, player_id),
+	FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE activities (
+	id integer PRIMARY KEY autoincrement,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	type varchar(64) NOT NULL,
+	tournament_id integer NOT NULL,
+	tournament_name varchar(255) NOT NULL,
+	player_1_id integer NOT NULL,
+	player_2_id integer NOT NULL,
+	result integer NOT NULL,
+	ladder_rank integer NOT NULL,
+	previous_rank integer NOT NULL
+);
+
+CREATE INDEX activities_created_at ON activities (created_at);
+CREATE INDEX activities_tournament_id ON activities (tournament_id);
+CREATE INDEX activities_player_1_id ON activities (player_1_id);
+CREATE INDEX activities_player_2_id ON activities (player_2_id);
@@ -0,0 +1,33 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+)
+
+var _ repo.NotificationLogRepository = &notificationLogRepository{}
+
+type notificationLogRepository struct {
+	DB *sqlx.DB
+}
+
+// New persists a notification log entry and assigns a new id.
+func (s *notificationLogRepository) New(ctx context.Context, log *scores.NotificationLog) (*scores.NotificationLog, error) {
+	err := crud.CreateSetID(ctx, s.DB, "notification-log/insert", log)
+
+	return log, errors.Wrap(err, "new notification log")
+}
+
+// ByUserID loads all notifications that were sent to a user, latest first.
+func (s *notificationLogRepository) ByUserID(ctx context.Context, userID int) ([]*scores.NotificationLog, error) {
+	logs := []*scores.NotificationLog{}
+	err := crud.Read(ctx, s.DB, "notification-log/select-by-user-id", &logs, userID)
+
+	return logs, errors.Wrap(err, "byUserID notification log")
+}
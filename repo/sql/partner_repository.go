@@ -0,0 +1,130 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.PartnerRepository = &partnerRepository{}
+
+type partnerRepository struct {
+	DB *sqlx.DB
+}
+
+// listingFilter are the parameters of the `partner/select-listings` query.
+type listingFilter struct {
+	TournamentID int       `db:"tournament_id"`
+	League       string    `db:"league_key"`
+	Gender       string    `db:"gender"`
+	MinPoints    int       `db:"min_points"`
+	MaxPoints    int       `db:"max_points"`
+	From         time.Time `db:"from_date"`
+	To           time.Time `db:"to_date"`
+	AnyTo        bool      `db:"any_to"`
+}
+
+// Listing loads a partner listing.
+func (s *partnerRepository) Listing(ctx context.Context, listingID int) (*volleynet.PartnerListing, error) {
+	listing := &volleynet.PartnerListing{}
+	err := crud.ReadOne(ctx, s.DB, "partner/select-listing-by-id", listing, listingID)
+
+	return listing, errors.Wrap(err, "listing")
+}
+
+// Listings returns the open listings that match the filter, ordered by their start.
+func (s *partnerRepository) Listings(ctx context.Context, filter repo.PartnerListingFilter) (
+	[]*volleynet.PartnerListing, error) {
+
+	listings := []*volleynet.PartnerListing{}
+	err := crud.ReadNamed(ctx, s.DB, "partner/select-listings", &listings, listingFilter{
+		TournamentID: filter.TournamentID,
+		League:       filter.League,
+		Gender:       filter.Gender,
+		MinPoints:    filter.MinPoints,
+		MaxPoints:    filter.MaxPoints,
+		From:         filter.From,
+		To:           filter.To,
+		AnyTo:        filter.To.IsZero(),
+	})
+
+	return listings, errors.Wrap(err, "listings")
+}
+
+// ListingsByUser returns all listings of a user, the latest first.
+func (s *partnerRepository) ListingsByUser(ctx context.Context, userID int) ([]*volleynet.PartnerListing, error) {
+	listings := []*volleynet.PartnerListing{}
+	err := crud.Read(ctx, s.DB, "partner/select-listings-by-user-id", &listings, userID)
+
+	return listings, errors.Wrap(err, "listings by user")
+}
+
+// NewListing persists a partner listing and assigns a new id.
+func (s *partnerRepository) NewListing(ctx context.Context, listing *volleynet.PartnerListing) (
+	*volleynet.PartnerListing, error) {
+
+	err := crud.CreateSetID(ctx, s.DB, "partner/insert-listing", listing)
+
+	return listing, errors.Wrap(err, "new listing")
+}
+
+// SetListingStatus changes the status of a listing from `current` to `status`.
+func (s *partnerRepository) SetListingStatus(ctx context.Context, listingID int, current, status string) (bool, error) {
+	changed, err := crud.Exec(ctx, s.DB, "partner/update-listing-status", time.Now(), status, listingID, current)
+
+	return changed == 1, errors.Wrap(err, "set listing status")
+}
+
+// Request loads a partner request.
+func (s *partnerRepository) Request(ctx context.Context, requestID int) (*volleynet.PartnerRequest, error) {
+	request := &volleynet.PartnerRequest{}
+	err := crud.ReadOne(ctx, s.DB, "partner/select-request-by-id", request, requestID)
+
+	return request, errors.Wrap(err, "request")
+}
+
+// RequestsByListing returns the requests for a listing, the oldest first.
+func (s *partnerRepository) RequestsByListing(ctx context.Context, listingID int) ([]*volleynet.PartnerRequest, error) {
+	requests := []*volleynet.PartnerRequest{}
+	err := crud.Read(ctx, s.DB, "partner/select-requests-by-listing-id", &requests, listingID)
+
+	return requests, errors.Wrap(err, "requests by listing")
+}
+
+// RequestsByUser returns the requests a user has sent, the latest first.
+func (s *partnerRepository) RequestsByUser(ctx context.Context, userID int) ([]*volleynet.PartnerRequest, error) {
+	requests := []*volleynet.PartnerRequest{}
+	err := crud.Read(ctx, s.DB, "partner/select-requests-by-user-id", &requests, userID)
+
+	return requests, errors.Wrap(err, "requests by user")
+}
+
+// RequestsToUser returns the requests for the listings of a user, the latest first.
+func (s *partnerRepository) RequestsToUser(ctx context.Context, userID int) ([]*volleynet.PartnerRequest, error) {
+	requests := []*volleynet.PartnerRequest{}
+	err := crud.Read(ctx, s.DB, "partner/select-requests-to-user-id", &requests, userID)
+
+	return requests, errors.Wrap(err, "requests to user")
+}
+
+// NewRequest persists a partner request and assigns a new id.
+func (s *partnerRepository) NewRequest(ctx context.Context, request *volleynet.PartnerRequest) (
+	*volleynet.PartnerRequest, error) {
+
+	err := crud.CreateSetID(ctx, s.DB, "partner/insert-request", request)
+
+	return request, errors.Wrap(err, "new request")
+}
+
+// SetRequestStatus changes the status of a request from `current` to `status`.
+func (s *partnerRepository) SetRequestStatus(ctx context.Context, requestID int, current, status string) (bool, error) {
+	changed, err := crud.Exec(ctx, s.DB, "partner/update-request-status", time.Now(), status, requestID, current)
+
+	return changed == 1, errors.Wrap(err, "set request status")
+}
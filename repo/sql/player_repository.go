@@ -1,6 +1,8 @@
 package sql
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
@@ -16,63 +18,99 @@ type playerRepository struct {
 var _ repo.PlayerRepository = &playerRepository{}
 
 // ByGender gets all players of the passed gender.
-func (s *playerRepository) ByGender(gender string) ([]*volleynet.Player, error) {
+func (s *playerRepository) ByGender(ctx context.Context, gender string) ([]*volleynet.Player, error) {
 	players := []*volleynet.Player{}
-	err := crud.Read(s.DB, "player/select-by-gender", &players, gender)
+	err := crud.Read(ctx, s.DB, "player/select-by-gender", &players, gender)
 
 	return players, errors.Wrap(err, "by gender")
 }
 
 // Ladder gets all players of the passed gender that have a rank.
-func (s *playerRepository) Ladder(gender string) ([]*volleynet.Player, error) {
+func (s *playerRepository) Ladder(ctx context.Context, gender string) ([]*volleynet.Player, error) {
 
 	players := []*volleynet.Player{}
-	err := crud.Read(s.DB, "player/select-ladder", &players, gender)
+	err := crud.Read(ctx, s.DB, "player/select-ladder", &players, gender)
 
 	return players, errors.Wrap(err, "ladder")
 }
 
 // Get loads a player.
-func (s *playerRepository) Get(id int) (*volleynet.Player, error) {
+func (s *playerRepository) Get(ctx context.Context, id int) (*volleynet.Player, error) {
 
 	player := &volleynet.Player{}
-	err := crud.ReadOne(s.DB, "player/select-by-id", player, id)
+	err := crud.ReadOne(ctx, s.DB, "player/select-by-id", player, id)
 
 	return player, errors.Wrap(err, "get player")
 }
 
 // New creates a new player.
-func (s *playerRepository) New(p *volleynet.Player) (*volleynet.Player, error) {
-	err := crud.Create(s.DB, "player/insert", p)
+func (s *playerRepository) New(ctx context.Context, p *volleynet.Player) (*volleynet.Player, error) {
+	err := crud.Create(ctx, s.DB, "player/insert", p)
 
 	return p, errors.Wrap(err, "new player")
 }
 
 // Update updates a player.
-func (s *playerRepository) Update(p *volleynet.Player) error {
-	err := crud.Update(s.DB, "player/update", p)
+func (s *playerRepository) Update(ctx context.Context, p *volleynet.Player) error {
+	err := crud.Update(ctx, s.DB, "player/update", p)
 
 	return errors.Wrap(err, "update player")
 }
 
 // PreviousPartners returns a list of all partners a player has played with before.
-func (s *playerRepository) PreviousPartners(playerID int) ([]*volleynet.Player, error) {
+func (s *playerRepository) PreviousPartners(ctx context.Context, playerID int) ([]*volleynet.Player, error) {
 	players := []*volleynet.Player{}
 
-	err := crud.ReadNamed(s.DB, "player/select-partners", &players,
+	err := crud.ReadNamed(ctx, s.DB, "player/select-partners", &players,
 		map[string]interface{}{"player_id": playerID})
 
 	return players, errors.Wrap(err, "previousPartners")
 }
 
+// History returns all tournament entries of a player, the latest tournament first.
+func (s *playerRepository) History(ctx context.Context, playerID int, filter repo.PlayerHistoryFilter) (
+	[]*volleynet.PlayerTournament, error) {
+
+	allLeagues, leagues := leagueFilter(filter.Leagues)
+	history := []*volleynet.PlayerTournament{}
+
+	err := crud.ReadIn(ctx, s.DB, "player/select-history", &history,
+		playerID, playerID, playerID, allLeagues, leagues)
+
+	return history, errors.Wrap(err, "history")
+}
+
+// SeasonStats returns the statistics of a player per season, the latest season first.
+func (s *playerRepository) SeasonStats(ctx context.Context, playerID int, filter repo.PlayerHistoryFilter) (
+	[]*volleynet.SeasonStats, error) {
+
+	allLeagues, leagues := leagueFilter(filter.Leagues)
+	stats := []*volleynet.SeasonStats{}
+
+	err := crud.ReadIn(ctx, s.DB, "player/select-season-stats", &stats,
+		playerID, playerID, allLeagues, leagues)
+
+	return stats, errors.Wrap(err, "season stats")
+}
+
+// leagueFilter returns the args of the `(? OR t.league_key IN (?))` filter,
+// an `IN` clause can't be empty so all leagues match if `leagues` is empty.
+func leagueFilter(leagues []string) (bool, []string) {
+	if len(leagues) == 0 {
+		return true, []string{""}
+	}
+
+	return false, leagues
+}
+
 // Search searches for players that satisfy the passed filter.
-func (s *playerRepository) Search(filter repo.PlayerFilter) ([]*volleynet.Player, error) {
+func (s *playerRepository) Search(ctx context.Context, filter repo.PlayerFilter) ([]*volleynet.Player, error) {
 	players := []*volleynet.Player{}
 
 	filter.FirstName = startsWith(filter.FirstName)
 	filter.LastName = startsWith(filter.LastName)
 
-	err := crud.ReadNamed(s.DB, "player/search", &players, filter)
+	err := crud.ReadNamed(ctx, s.DB, "player/search", &players, filter)
 
 	return players, errors.Wrap(err, "search")
 }
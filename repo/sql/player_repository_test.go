@@ -3,8 +3,11 @@
 package sql
 
 import (
+	"context"
 	"testing"
 
+	"github.com/pkg/errors"
+
 	"github.com/raphi011/scores/repo"
 	"github.com/raphi011/scores/test"
 	"github.com/raphi011/scores/volleynet"
@@ -16,10 +19,10 @@ func TestCreatePlayer(t *testing.T) {
 
 	player := &volleynet.Player{ID: 1}
 
-	_, err := playerRepo.New(player)
+	_, err := playerRepo.New(context.Background(), player)
 	test.Check(t, "playerRepository.New(), err: %v", err)
 
-	persistedPlayer, err := playerRepo.Get(1)
+	persistedPlayer, err := playerRepo.Get(context.Background(), 1)
 
 	test.Check(t, "playerRepo.Get() failed: %v", err)
 	test.Compare(t, "players are not equal:\n%s", persistedPlayer, player)
@@ -29,15 +32,15 @@ func TestUpdatePlayer(t *testing.T) {
 	db := SetupDB(t)
 	playerRepo := &playerRepository{DB: db}
 
-	player, err := playerRepo.New(&volleynet.Player{ID: 1})
+	player, err := playerRepo.New(context.Background(), &volleynet.Player{ID: 1})
 	test.Check(t, "couldn't persist player: %v", err)
 
 	player.FirstName = "test!"
 
-	err = playerRepo.Update(player)
+	err = playerRepo.Update(context.Background(), player)
 	test.Check(t, "couldnt update player: %v", err)
 
-	updatedPlayer, err := playerRepo.Get(player.ID)
+	updatedPlayer, err := playerRepo.Get(context.Background(), player.ID)
 
 	test.Check(t, "couldnt get player: %v", err)
 	test.Compare(t, "players are not equal:\n%s", player, updatedPlayer)
@@ -47,7 +50,7 @@ func TestLadder(t *testing.T) {
 	db := SetupDB(t)
 	playerRepo := &playerRepository{DB: db}
 
-	players, err := playerRepo.Ladder("m")
+	players, err := playerRepo.Ladder(context.Background(), "m")
 
 	test.Check(t, "playerRepo.Ladder() failed: %v", err)
 	test.Assert(t, "ladder should be empty", len(players) == 0)
@@ -59,7 +62,7 @@ func TestLadder(t *testing.T) {
 		P{Gender: "w", TotalPoints: 4, LadderRank: 1, ID: 4},
 	)
 
-	players, err = playerRepo.Ladder("m")
+	players, err = playerRepo.Ladder(context.Background(), "m")
 
 	test.Check(t, "playerRepo.Ladder() failed: %v", err)
 	test.Assert(t, "len(ladder) should be 2 but is: %d", len(players) == 2, len(players))
@@ -87,7 +90,7 @@ func TestPreviousPartners(t *testing.T) {
 		TT{TournamentID: 2, Player1: p[0], Player2: p[2]},
 	)
 
-	players, err := playerRepo.PreviousPartners(1)
+	players, err := playerRepo.PreviousPartners(context.Background(), 1)
 
 	test.Check(t, "playerRepo.PreviousPartners() failed: %v", err)
 	test.Assert(t, "len(PreviousPartners) should be 2", len(players) == 2)
@@ -104,7 +107,7 @@ func TestSearchPlayers(t *testing.T) {
 		P{Gender: "m", FirstName: "Roman", LastName: "Gutleber", ID: 4},
 	)
 
-	players, err := playerRepo.Search(repo.PlayerFilter{
+	players, err := playerRepo.Search(context.Background(), repo.PlayerFilter{
 		Gender:    "m",
 		FirstName: "R",
 	})
@@ -112,3 +115,17 @@ func TestSearchPlayers(t *testing.T) {
 	test.Check(t, "playerRepo.Search() failed: %v", err)
 	test.Assert(t, "len(Search) should be 2 but is %d", len(players) == 2, len(players))
 }
+
+func TestCanceledContext(t *testing.T) {
+	db := SetupDB(t)
+	playerRepo := &playerRepository{DB: db}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := playerRepo.Get(ctx, 1)
+
+	if errors.Cause(err) != context.Canceled {
+		t.Fatalf("playerRepo.Get() with a canceled context, want: %v, got: %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,57 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+)
+
+var _ repo.PollOffsetRepository = &pollOffsetRepository{}
+
+type pollOffsetRepository struct {
+	DB *sqlx.DB
+}
+
+// Get returns the stored offset of the poller, 0 if there is none.
+func (s *pollOffsetRepository) Get(ctx context.Context, poller string) (int, error) {
+	offset := 0
+	err := crud.ReadOne(ctx, s.DB, "poll-offset/select-by-poller", &offset, poller)
+
+	if errors.Cause(err) == scores.ErrNotFound {
+		return 0, nil
+	}
+
+	return offset, errors.Wrap(err, "get poll offset")
+}
+
+// Set stores the offset of the poller.
+func (s *pollOffsetRepository) Set(ctx context.Context, poller string, offset int) error {
+	now := time.Now().UTC()
+	updated, err := crud.Exec(ctx, s.DB, "poll-offset/update", offset, now, poller)
+
+	if err != nil {
+		return errors.Wrap(err, "update poll offset")
+	}
+
+	if updated == 1 {
+		return nil
+	}
+
+	// some providers don't report rows whose values have not changed as
+	// updated, only insert the offset if the poller has none yet
+	err = crud.ReadOne(ctx, s.DB, "poll-offset/select-by-poller", new(int), poller)
+
+	if errors.Cause(err) == scores.ErrNotFound {
+		_, err = crud.Exec(ctx, s.DB, "poll-offset/insert", poller, offset, now)
+
+		return errors.Wrap(err, "insert poll offset")
+	}
+
+	return errors.Wrap(err, "get poll offset")
+}
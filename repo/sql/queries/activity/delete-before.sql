@@ -0,0 +1,2 @@
+DELETE FROM activities
+WHERE created_at < ?
@@ -0,0 +1,24 @@
+INSERT INTO activities
+(
+	created_at,
+	type,
+	tournament_id,
+	tournament_name,
+	player_1_id,
+	player_2_id,
+	result,
+	ladder_rank,
+	previous_rank
+)
+VALUES
+(
+	:created_at,
+	:type,
+	:tournament_id,
+	:tournament_name,
+	:player_1_id,
+	:player_2_id,
+	:result,
+	:ladder_rank,
+	:previous_rank
+)
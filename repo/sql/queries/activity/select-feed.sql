@@ -0,0 +1,19 @@
+SELECT
+	a.id,
+	a.created_at,
+	a.updated_at,
+	a.type,
+	a.tournament_id,
+	a.tournament_name,
+	a.player_1_id,
+	a.player_2_id,
+	a.result,
+	a.ladder_rank,
+	a.previous_rank
+FROM activities a
+WHERE
+	a.deleted_at IS NULL AND
+	a.created_at >= ? AND
+	(a.tournament_id IN (?) OR a.player_1_id IN (?) OR a.player_2_id IN (?))
+ORDER BY a.id DESC
+LIMIT ?
@@ -0,0 +1,3 @@
+UPDATE alert_rules SET
+	deleted_at = :deleted_at
+WHERE id = :id AND deleted_at IS NULL
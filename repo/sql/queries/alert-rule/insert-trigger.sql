@@ -0,0 +1,12 @@
+INSERT INTO alert_triggers
+(
+	created_at,
+	rule_id,
+	event_key
+)
+VALUES
+(
+	:created_at,
+	:rule_id,
+	:event_key
+)
@@ -0,0 +1,26 @@
+INSERT INTO alert_rules
+(
+	created_at,
+	user_id,
+	name,
+	event,
+	league_key,
+	gender,
+	player_id,
+	loc_lat,
+	loc_lon,
+	radius
+)
+VALUES
+(
+	:created_at,
+	:user_id,
+	:name,
+	:event,
+	:league_key,
+	:gender,
+	:player_id,
+	:loc_lat,
+	:loc_lon,
+	:radius
+)
@@ -0,0 +1,15 @@
+SELECT
+	r.id,
+	r.created_at,
+	r.updated_at,
+	r.user_id,
+	r.name,
+	r.event,
+	r.league_key,
+	r.gender,
+	r.player_id,
+	r.loc_lat,
+	r.loc_lon,
+	r.radius
+FROM alert_rules r
+WHERE r.deleted_at IS NULL AND r.id = ?
\ No newline at end of file
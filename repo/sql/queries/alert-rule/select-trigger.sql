@@ -0,0 +1,7 @@
+SELECT
+	t.id,
+	t.created_at,
+	t.rule_id,
+	t.event_key
+FROM alert_triggers t
+WHERE t.rule_id = ? AND t.event_key = ?
@@ -0,0 +1,11 @@
+UPDATE alert_rules SET
+	updated_at = :updated_at,
+	name = :name,
+	event = :event,
+	league_key = :league_key,
+	gender = :gender,
+	player_id = :player_id,
+	loc_lat = :loc_lat,
+	loc_lon = :loc_lon,
+	radius = :radius
+WHERE id = :id AND deleted_at IS NULL
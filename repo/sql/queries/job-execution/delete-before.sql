@@ -0,0 +1,2 @@
+DELETE FROM job_executions
+WHERE start_time < ?
@@ -0,0 +1,21 @@
+INSERT INTO job_executions
+(
+	created_at,
+	job_name,
+	start_time,
+	end_time,
+	success,
+	error,
+	summary
+)
+VALUES
+(
+	:created_at,
+	:job_name,
+	:start_time,
+	:end_time,
+	:success,
+	:error,
+	:summary
+)
+RETURNING id
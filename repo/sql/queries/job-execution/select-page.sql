@@ -0,0 +1,13 @@
+SELECT
+	e.id,
+	e.created_at,
+	e.job_name,
+	e.start_time,
+	e.end_time,
+	e.success,
+	e.error,
+	e.summary
+FROM job_executions e
+WHERE ? = '' OR e.job_name = ?
+ORDER BY e.start_time DESC, e.id DESC
+LIMIT ? OFFSET ?
@@ -0,0 +1,2 @@
+DELETE FROM job_leases
+WHERE job_name = ? AND owner = ?
@@ -0,0 +1,12 @@
+INSERT INTO job_leases
+(
+	job_name,
+	owner,
+	expires_at
+)
+VALUES
+(
+	?,
+	?,
+	?
+)
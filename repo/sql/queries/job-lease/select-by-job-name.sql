@@ -0,0 +1,6 @@
+SELECT
+	l.job_name,
+	l.owner,
+	l.expires_at
+FROM job_leases l
+WHERE l.job_name = ?
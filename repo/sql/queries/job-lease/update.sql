@@ -0,0 +1,4 @@
+UPDATE job_leases SET
+	owner = ?,
+	expires_at = ?
+WHERE job_name = ? AND (owner = ? OR expires_at < ?)
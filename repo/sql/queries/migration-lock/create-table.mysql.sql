@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+	id int PRIMARY KEY,
+	owner varchar(255) NOT NULL,
+	expires_at datetime(3) NOT NULL
+)
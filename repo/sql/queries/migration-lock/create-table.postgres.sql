@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+	id              integer     PRIMARY KEY,
+	owner           text        NOT NULL,
+	expires_at      timestamptz NOT NULL
+)
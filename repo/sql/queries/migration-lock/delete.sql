@@ -0,0 +1,2 @@
+DELETE FROM schema_migrations_lock
+WHERE id = 1 AND owner = ?
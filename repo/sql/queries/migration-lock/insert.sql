@@ -0,0 +1,12 @@
+INSERT INTO schema_migrations_lock
+(
+	id,
+	owner,
+	expires_at
+)
+VALUES
+(
+	1,
+	?,
+	?
+)
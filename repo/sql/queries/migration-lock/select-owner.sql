@@ -0,0 +1,2 @@
+SELECT owner FROM schema_migrations_lock
+WHERE id = 1
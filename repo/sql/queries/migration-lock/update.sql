@@ -0,0 +1,3 @@
+UPDATE schema_migrations_lock SET
+	expires_at = ?
+WHERE id = 1 AND owner = ?
@@ -0,0 +1,21 @@
+INSERT INTO notification_log
+(
+	created_at,
+	user_id,
+	channel,
+	recipient,
+	subject,
+	notifications,
+	error
+)
+VALUES
+(
+	:created_at,
+	:user_id,
+	:channel,
+	:recipient,
+	:subject,
+	:notifications,
+	:error
+)
+RETURNING id
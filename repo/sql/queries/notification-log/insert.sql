@@ -0,0 +1,20 @@
+INSERT INTO notification_log
+(
+	created_at,
+	user_id,
+	channel,
+	recipient,
+	subject,
+	notifications,
+	error
+)
+VALUES
+(
+	:created_at,
+	:user_id,
+	:channel,
+	:recipient,
+	:subject,
+	:notifications,
+	:error
+)
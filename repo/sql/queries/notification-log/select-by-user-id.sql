@@ -0,0 +1,12 @@
+SELECT
+	n.id,
+	n.created_at,
+	n.user_id,
+	n.channel,
+	n.recipient,
+	n.subject,
+	n.notifications,
+	n.error
+FROM notification_log n
+WHERE n.user_id = ?
+ORDER BY n.created_at DESC, n.id DESC
@@ -0,0 +1,25 @@
+INSERT INTO partner_listings
+(
+	created_at,
+	user_id,
+	player_id,
+	tournament_id,
+	league_key,
+	from_date,
+	to_date,
+	note,
+	status
+)
+VALUES
+(
+	:created_at,
+	:user_id,
+	:player.id,
+	:tournament_id,
+	:league_key,
+	:from_date,
+	:to_date,
+	:note,
+	:status
+)
+RETURNING id
@@ -0,0 +1,21 @@
+INSERT INTO partner_requests
+(
+	created_at,
+	listing_id,
+	user_id,
+	player_id,
+	tournament_id,
+	message,
+	status
+)
+VALUES
+(
+	:created_at,
+	:listing_id,
+	:user_id,
+	:player.id,
+	:tournament_id,
+	:message,
+	:status
+)
+RETURNING id
@@ -0,0 +1,34 @@
+SELECT
+	l.id,
+	l.created_at,
+	l.updated_at,
+	l.user_id,
+	l.tournament_id,
+	l.league_key,
+	l.from_date,
+	l.to_date,
+	l.note,
+	l.status,
+	p.id AS "player.id",
+	p.first_name AS "player.first_name",
+	p.last_name AS "player.last_name",
+	p.birthday AS "player.birthday",
+	p.gender AS "player.gender",
+	p.total_points AS "player.total_points",
+	p.ladder_rank AS "player.ladder_rank",
+	p.club AS "player.club",
+	p.country_union AS "player.country_union",
+	p.license AS "player.license"
+FROM partner_listings l
+JOIN players p ON p.id = l.player_id
+WHERE
+	l.status = 'open' AND
+	l.deleted_at IS NULL AND
+	(:tournament_id = 0 OR l.tournament_id = 0 OR l.tournament_id = :tournament_id) AND
+	(:league_key = '' OR l.league_key = :league_key) AND
+	(:gender = '' OR p.gender = :gender) AND
+	p.total_points >= :min_points AND
+	(:max_points = 0 OR p.total_points <= :max_points) AND
+	l.to_date >= :from_date AND
+	(:any_to OR l.from_date <= :to_date)
+ORDER BY l.from_date, l.id
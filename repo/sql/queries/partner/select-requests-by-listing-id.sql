@@ -0,0 +1,23 @@
+SELECT
+	r.id,
+	r.created_at,
+	r.updated_at,
+	r.listing_id,
+	r.user_id,
+	r.tournament_id,
+	r.message,
+	r.status,
+	p.id AS "player.id",
+	p.first_name AS "player.first_name",
+	p.last_name AS "player.last_name",
+	p.birthday AS "player.birthday",
+	p.gender AS "player.gender",
+	p.total_points AS "player.total_points",
+	p.ladder_rank AS "player.ladder_rank",
+	p.club AS "player.club",
+	p.country_union AS "player.country_union",
+	p.license AS "player.license"
+FROM partner_requests r
+JOIN players p ON p.id = r.player_id
+WHERE r.listing_id = ? AND r.deleted_at IS NULL
+ORDER BY r.id
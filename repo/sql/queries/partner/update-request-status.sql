@@ -0,0 +1,4 @@
+UPDATE partner_requests SET
+	updated_at = ?,
+	status = ?
+WHERE id = ? AND status = ? AND deleted_at IS NULL
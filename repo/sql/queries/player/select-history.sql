@@ -0,0 +1,43 @@
+SELECT
+	t.id AS "tournament.id",
+	t.season AS "tournament.season",
+	t.start_date AS "tournament.start_date",
+	t.end_date AS "tournament.end_date",
+	t.name AS "tournament.name",
+	t.league AS "tournament.league",
+	t.league_key AS "tournament.league_key",
+	t.sub_league AS "tournament.sub_league",
+	t.sub_league_key AS "tournament.sub_league_key",
+	t.link AS "tournament.link",
+	t.entry_link AS "tournament.entry_link",
+	t.status AS "tournament.status",
+	t.gender AS "tournament.gender",
+	t.registration_open AS "tournament.registration_open",
+	p.id AS "partner.id",
+	p.first_name AS "partner.first_name",
+	p.last_name AS "partner.last_name",
+	p.birthday AS "partner.birthday",
+	p.gender AS "partner.gender",
+	p.total_points AS "partner.total_points",
+	p.ladder_rank AS "partner.ladder_rank",
+	p.club AS "partner.club",
+	p.country_union AS "partner.country_union",
+	p.license AS "partner.license",
+	tt.deregistered,
+	tt.prize_money,
+	tt.result,
+	tt.seed,
+	tt.total_points,
+	tt.won_points
+FROM tournament_teams tt
+JOIN tournaments t ON t.id = tt.tournament_id
+JOIN players p ON p.id = CASE
+	WHEN tt.player_1_id = ? THEN tt.player_2_id
+	ELSE tt.player_1_id
+END
+WHERE
+	(tt.player_1_id = ? OR tt.player_2_id = ?) AND
+	(? OR t.league_key IN (?)) AND
+	tt.deleted_at IS NULL AND
+	t.deleted_at IS NULL
+ORDER BY t.start_date DESC, t.id DESC
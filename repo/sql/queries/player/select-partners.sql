@@ -24,4 +24,5 @@ FROM
     WHERE tt.player_1_id = :player_id OR tt.player_2_id = :player_id
     ORDER BY t.start_date DESC) AS partners
 GROUP BY partners.player_id) AS distinct_partners
-JOIN players p on distinct_partners.player_id = p.id
\ No newline at end of file
+JOIN players p on distinct_partners.player_id = p.id
+ORDER BY distinct_partners.last_played DESC, p.id
\ No newline at end of file
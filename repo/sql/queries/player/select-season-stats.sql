@@ -0,0 +1,20 @@
+SELECT
+	t.season,
+	COUNT(*) AS tournaments,
+	AVG(tt.result) AS average_result,
+	MIN(tt.result) AS best_result,
+	SUM(tt.won_points) AS won_points,
+	SUM(tt.prize_money) AS prize_money,
+	SUM(CASE WHEN tt.result <= 3 THEN 1 ELSE 0 END) AS podiums
+FROM tournament_teams tt
+JOIN tournaments t ON t.id = tt.tournament_id
+WHERE
+	(tt.player_1_id = ? OR tt.player_2_id = ?) AND
+	(? OR t.league_key IN (?)) AND
+	t.status = 'done' AND
+	NOT tt.deregistered AND
+	tt.result > 0 AND
+	tt.deleted_at IS NULL AND
+	t.deleted_at IS NULL
+GROUP BY t.season
+ORDER BY t.season DESC
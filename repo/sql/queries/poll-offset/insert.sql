@@ -0,0 +1,12 @@
+INSERT INTO poll_offsets
+(
+	poller,
+	update_offset,
+	updated_at
+)
+VALUES
+(
+	?,
+	?,
+	?
+)
@@ -0,0 +1,3 @@
+SELECT update_offset
+FROM poll_offsets
+WHERE poller = ?
@@ -0,0 +1,4 @@
+UPDATE poll_offsets SET
+	update_offset = ?,
+	updated_at = ?
+WHERE poller = ?
@@ -0,0 +1,2 @@
+DELETE FROM player_ratings
+WHERE tournament_start >= ?
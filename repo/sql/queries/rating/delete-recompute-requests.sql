@@ -0,0 +1,2 @@
+DELETE FROM rating_recompute_requests
+WHERE id <= ?
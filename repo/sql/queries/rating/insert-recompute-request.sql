@@ -0,0 +1,2 @@
+INSERT INTO rating_recompute_requests (created_at)
+VALUES (?)
@@ -0,0 +1,20 @@
+INSERT INTO player_ratings
+(
+	created_at,
+	player_id,
+	tournament_id,
+	tournament_start,
+	rating,
+	delta,
+	tournaments
+)
+VALUES
+(
+	:created_at,
+	:player_id,
+	:tournament_id,
+	:tournament_start,
+	:rating,
+	:delta,
+	:tournaments
+)
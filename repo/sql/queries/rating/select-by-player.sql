@@ -0,0 +1,12 @@
+SELECT
+	r.created_at,
+	r.updated_at,
+	r.player_id,
+	r.tournament_id,
+	r.tournament_start,
+	r.rating,
+	r.delta,
+	r.tournaments
+FROM player_ratings r
+WHERE r.player_id = ?
+ORDER BY r.tournaments
@@ -0,0 +1,16 @@
+SELECT
+	r.created_at,
+	r.updated_at,
+	r.player_id,
+	r.tournament_id,
+	r.tournament_start,
+	r.rating,
+	r.delta,
+	r.tournaments
+FROM player_ratings r
+JOIN (
+	SELECT player_id, MAX(tournaments) AS tournaments
+	FROM player_ratings
+	GROUP BY player_id
+) latest ON latest.player_id = r.player_id AND latest.tournaments = r.tournaments
+ORDER BY r.player_id
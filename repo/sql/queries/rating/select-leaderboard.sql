@@ -0,0 +1,23 @@
+SELECT
+	p.id AS "player.id",
+	p.first_name AS "player.first_name",
+	p.last_name AS "player.last_name",
+	p.birthday AS "player.birthday",
+	p.gender AS "player.gender",
+	p.total_points AS "player.total_points",
+	p.ladder_rank AS "player.ladder_rank",
+	p.club AS "player.club",
+	p.country_union AS "player.country_union",
+	p.license AS "player.license",
+	r.rating,
+	r.tournaments
+FROM player_ratings r
+JOIN (
+	SELECT player_id, MAX(tournaments) AS tournaments
+	FROM player_ratings
+	GROUP BY player_id
+) latest ON latest.player_id = r.player_id AND latest.tournaments = r.tournaments
+JOIN players p ON p.id = r.player_id
+WHERE p.gender = ?
+ORDER BY r.rating DESC, p.id
+LIMIT ?
@@ -0,0 +1,3 @@
+SELECT DISTINCT r.tournament_id
+FROM player_ratings r
+ORDER BY r.tournament_id
@@ -0,0 +1,2 @@
+SELECT COALESCE(MAX(id), 0)
+FROM rating_recompute_requests
@@ -0,0 +1,17 @@
+SELECT
+	tt.tournament_id,
+	t.start_date AS tournament_start,
+	t.sub_league_key,
+	tt.player_1_id,
+	tt.player_2_id,
+	tt.result,
+	tt.won_points
+FROM tournament_teams tt
+JOIN tournaments t ON t.id = tt.tournament_id
+WHERE
+	t.status = 'done' AND
+	NOT tt.deregistered AND
+	tt.result > 0 AND
+	tt.deleted_at IS NULL AND
+	t.deleted_at IS NULL
+ORDER BY t.start_date, t.id, tt.result
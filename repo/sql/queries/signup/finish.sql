@@ -0,0 +1,7 @@
+UPDATE scheduled_signups SET
+	updated_at = ?,
+	status = ?,
+	message = ?,
+	credentials = '',
+	attempted_at = ?
+WHERE id = ? AND status = ? AND deleted_at IS NULL
@@ -0,0 +1,29 @@
+INSERT INTO scheduled_signups
+(
+	created_at,
+	user_id,
+	player_id,
+	tournament_id,
+	partner_id,
+	partner_name,
+	status,
+	message,
+	credentials,
+	expires_at,
+	attempted_at
+)
+VALUES
+(
+	:created_at,
+	:user_id,
+	:player_id,
+	:tournament_id,
+	:partner_id,
+	:partner_name,
+	:status,
+	:message,
+	:credentials,
+	:expires_at,
+	:attempted_at
+)
+RETURNING id
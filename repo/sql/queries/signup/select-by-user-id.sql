@@ -0,0 +1,17 @@
+SELECT
+	id,
+	created_at,
+	updated_at,
+	user_id,
+	player_id,
+	tournament_id,
+	partner_id,
+	partner_name,
+	status,
+	message,
+	credentials,
+	expires_at,
+	attempted_at
+FROM scheduled_signups
+WHERE user_id = ? AND deleted_at IS NULL
+ORDER BY id DESC
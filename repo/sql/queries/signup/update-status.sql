@@ -0,0 +1,4 @@
+UPDATE scheduled_signups SET
+	updated_at = ?,
+	status = ?
+WHERE id = ? AND status = ? AND deleted_at IS NULL
@@ -1,3 +1,17 @@
+DELETE FROM poll_offsets;
+DELETE FROM rating_recompute_requests;
+DELETE FROM scheduled_signups;
+DELETE FROM activities;
+DELETE FROM followed_players;
+DELETE FROM watched_tournaments;
+DELETE FROM partner_requests;
+DELETE FROM partner_listings;
+DELETE FROM player_ratings;
+DELETE FROM job_leases;
+DELETE FROM job_executions;
+DELETE FROM alert_triggers;
+DELETE FROM alert_rules;
+DELETE FROM notification_log;
 DELETE FROM settings;
 DELETE FROM tournament_teams;
 DELETE FROM users;
@@ -0,0 +1,32 @@
+INSERT INTO alert_rules
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	user_id,
+	name,
+	event,
+	league_key,
+	gender,
+	player_id,
+	loc_lat,
+	loc_lon,
+	radius
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:user_id,
+	:name,
+	:event,
+	:league_key,
+	:gender,
+	:player_id,
+	:loc_lat,
+	:loc_lon,
+	:radius
+)
@@ -0,0 +1,18 @@
+INSERT INTO alert_triggers
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	rule_id,
+	event_key
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:rule_id,
+	:event_key
+)
@@ -0,0 +1,18 @@
+INSERT INTO followed_players
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	user_id,
+	player_id
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:user_id,
+	:player.id
+)
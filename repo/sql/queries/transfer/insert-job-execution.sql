@@ -0,0 +1,26 @@
+INSERT INTO job_executions
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	job_name,
+	start_time,
+	end_time,
+	success,
+	error,
+	summary
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:job_name,
+	:start_time,
+	:end_time,
+	:success,
+	:error,
+	:summary
+)
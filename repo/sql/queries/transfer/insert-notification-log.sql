@@ -0,0 +1,26 @@
+INSERT INTO notification_log
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	user_id,
+	channel,
+	recipient,
+	subject,
+	notifications,
+	error
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:user_id,
+	:channel,
+	:recipient,
+	:subject,
+	:notifications,
+	:error
+)
@@ -0,0 +1,30 @@
+INSERT INTO partner_listings
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	user_id,
+	player_id,
+	tournament_id,
+	league_key,
+	from_date,
+	to_date,
+	note,
+	status
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:user_id,
+	:player.id,
+	:tournament_id,
+	:league_key,
+	:from_date,
+	:to_date,
+	:note,
+	:status
+)
@@ -0,0 +1,26 @@
+INSERT INTO partner_requests
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	listing_id,
+	user_id,
+	player_id,
+	tournament_id,
+	message,
+	status
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:listing_id,
+	:user_id,
+	:player.id,
+	:tournament_id,
+	:message,
+	:status
+)
@@ -0,0 +1,32 @@
+INSERT INTO players
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	first_name,
+	last_name,
+	birthday,
+	gender,
+	total_points,
+	ladder_rank,
+	club,
+	country_union,
+	license
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:first_name,
+	:last_name,
+	:birthday,
+	:gender,
+	:total_points,
+	:ladder_rank,
+	:club,
+	:country_union,
+	:license
+)
@@ -0,0 +1,20 @@
+INSERT INTO settings
+(
+	user_id,
+	s_key,
+	created_at,
+	updated_at,
+	deleted_at,
+	s_value,
+	s_type
+)
+VALUES
+(
+	:user_id,
+	:s_key,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:s_value,
+	:s_type
+)
@@ -0,0 +1,30 @@
+INSERT INTO tournament_teams
+(
+	tournament_id,
+	player_1_id,
+	player_2_id,
+	created_at,
+	updated_at,
+	deleted_at,
+	result,
+	seed,
+	total_points,
+	won_points,
+	prize_money,
+	deregistered
+)
+VALUES
+(
+	:tournament_id,
+	:player1.id,
+	:player2.id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:result,
+	:seed,
+	:total_points,
+	:won_points,
+	:prize_money,
+	:deregistered
+)
@@ -0,0 +1,72 @@
+INSERT INTO tournaments
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	gender,
+	start_date,
+	end_date,
+	name,
+	league,
+	league_key,
+	sub_league,
+	sub_league_key,
+	link,
+	entry_link,
+	status,
+	registration_open,
+	location,
+	html_notes,
+	mode,
+	max_points,
+	min_teams,
+	max_teams,
+	end_registration,
+	organiser,
+	phone,
+	email,
+	website,
+	current_points,
+	live_scoring_link,
+	loc_lat,
+	loc_lon,
+	season,
+	signedup_teams
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:gender,
+	:start_date,
+	:end_date,
+	:name,
+	:league,
+	:league_key,
+	:sub_league,
+	:sub_league_key,
+	:link,
+	:entry_link,
+	:status,
+	:registration_open,
+	:location,
+	:html_notes,
+	:mode,
+	:max_points,
+	:min_teams,
+	:max_teams,
+	:end_registration,
+	:organiser,
+	:phone,
+	:email,
+	:website,
+	:current_points,
+	:live_scoring_link,
+	:loc_lat,
+	:loc_lon,
+	:season,
+	:signedup_teams
+)
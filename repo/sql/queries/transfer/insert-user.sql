@@ -0,0 +1,30 @@
+INSERT INTO users
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	email,
+	profile_image_url,
+	role,
+	pw_iterations,
+	pw_hash,
+	pw_salt,
+	player_id,
+	player_login
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:email,
+	:profile_image_url,
+	:role,
+	:pw_iterations,
+	:pw_hash,
+	:pw_salt,
+	NULLIF(:player_id, 0),
+	:player_login
+)
@@ -0,0 +1,18 @@
+INSERT INTO watched_tournaments
+(
+	id,
+	created_at,
+	updated_at,
+	deleted_at,
+	user_id,
+	tournament_id
+)
+VALUES
+(
+	:id,
+	:created_at,
+	:updated_at,
+	:deleted_at,
+	:user_id,
+	:tournament_id
+)
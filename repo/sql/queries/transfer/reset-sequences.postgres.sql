@@ -0,0 +1,9 @@
+SELECT setval(pg_get_serial_sequence('users', 'id'), MAX(id)) FROM users;
+SELECT setval(pg_get_serial_sequence('notification_log', 'id'), MAX(id)) FROM notification_log;
+SELECT setval(pg_get_serial_sequence('alert_rules', 'id'), MAX(id)) FROM alert_rules;
+SELECT setval(pg_get_serial_sequence('alert_triggers', 'id'), MAX(id)) FROM alert_triggers;
+SELECT setval(pg_get_serial_sequence('job_executions', 'id'), MAX(id)) FROM job_executions;
+SELECT setval(pg_get_serial_sequence('partner_listings', 'id'), MAX(id)) FROM partner_listings;
+SELECT setval(pg_get_serial_sequence('partner_requests', 'id'), MAX(id)) FROM partner_requests;
+SELECT setval(pg_get_serial_sequence('watched_tournaments', 'id'), MAX(id)) FROM watched_tournaments;
+SELECT setval(pg_get_serial_sequence('followed_players', 'id'), MAX(id)) FROM followed_players;
@@ -0,0 +1,16 @@
+SELECT
+	r.id,
+	r.created_at,
+	r.updated_at,
+	r.deleted_at,
+	r.user_id,
+	r.name,
+	r.event,
+	r.league_key,
+	r.gender,
+	r.player_id,
+	r.loc_lat,
+	r.loc_lon,
+	r.radius
+FROM alert_rules r
+ORDER BY r.id
@@ -0,0 +1,9 @@
+SELECT
+	t.id,
+	t.created_at,
+	t.updated_at,
+	t.deleted_at,
+	t.rule_id,
+	t.event_key
+FROM alert_triggers t
+ORDER BY t.id
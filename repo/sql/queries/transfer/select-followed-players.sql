@@ -0,0 +1,9 @@
+SELECT
+	f.id,
+	f.created_at,
+	f.updated_at,
+	f.deleted_at,
+	f.user_id,
+	f.player_id AS "player.id"
+FROM followed_players f
+ORDER BY f.id
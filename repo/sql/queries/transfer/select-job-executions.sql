@@ -0,0 +1,13 @@
+SELECT
+	e.id,
+	e.created_at,
+	e.updated_at,
+	e.deleted_at,
+	e.job_name,
+	e.start_time,
+	e.end_time,
+	e.success,
+	e.error,
+	e.summary
+FROM job_executions e
+ORDER BY e.id
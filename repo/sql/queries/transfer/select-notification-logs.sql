@@ -0,0 +1,13 @@
+SELECT
+	n.id,
+	n.created_at,
+	n.updated_at,
+	n.deleted_at,
+	n.user_id,
+	n.channel,
+	n.recipient,
+	n.subject,
+	n.notifications,
+	n.error
+FROM notification_log n
+ORDER BY n.id
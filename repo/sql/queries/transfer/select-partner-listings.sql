@@ -0,0 +1,15 @@
+SELECT
+	l.id,
+	l.created_at,
+	l.updated_at,
+	l.deleted_at,
+	l.user_id,
+	l.player_id AS "player.id",
+	l.tournament_id,
+	l.league_key,
+	l.from_date,
+	l.to_date,
+	l.note,
+	l.status
+FROM partner_listings l
+ORDER BY l.id
@@ -0,0 +1,13 @@
+SELECT
+	r.id,
+	r.created_at,
+	r.updated_at,
+	r.deleted_at,
+	r.listing_id,
+	r.user_id,
+	r.player_id AS "player.id",
+	r.tournament_id,
+	r.message,
+	r.status
+FROM partner_requests r
+ORDER BY r.id
@@ -0,0 +1,16 @@
+SELECT
+	p.id,
+	p.created_at,
+	p.updated_at,
+	p.deleted_at,
+	p.first_name,
+	p.last_name,
+	p.birthday,
+	p.gender,
+	p.total_points,
+	p.ladder_rank,
+	p.club,
+	p.country_union,
+	p.license
+FROM players p
+ORDER BY p.id
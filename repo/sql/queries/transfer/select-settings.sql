@@ -0,0 +1,10 @@
+SELECT
+	s.user_id,
+	s.s_key,
+	s.created_at,
+	s.updated_at,
+	s.deleted_at,
+	COALESCE(s.s_value, '') as s_value,
+	s.s_type
+FROM settings s
+ORDER BY s.user_id, s.s_key
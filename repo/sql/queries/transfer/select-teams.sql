@@ -0,0 +1,15 @@
+SELECT
+	t.tournament_id,
+	t.player_1_id as "player1.id",
+	t.player_2_id as "player2.id",
+	t.created_at,
+	t.updated_at,
+	t.deleted_at,
+	t.result,
+	t.seed,
+	t.total_points,
+	t.won_points,
+	t.prize_money,
+	t.deregistered
+FROM tournament_teams t
+ORDER BY t.tournament_id, t.player_1_id, t.player_2_id
@@ -0,0 +1,15 @@
+SELECT
+	u.id,
+	u.created_at,
+	u.updated_at,
+	u.deleted_at,
+	u.email,
+	u.profile_image_url,
+	u.role,
+	COALESCE(u.pw_iterations, 0) as pw_iterations,
+	u.pw_hash,
+	u.pw_salt,
+	COALESCE(u.player_id, 0) as player_id,
+	COALESCE(u.player_login, '') as player_login
+FROM users u
+ORDER BY u.id
@@ -0,0 +1,9 @@
+SELECT
+	w.id,
+	w.created_at,
+	w.updated_at,
+	w.deleted_at,
+	w.user_id,
+	w.tournament_id
+FROM watched_tournaments w
+ORDER BY w.id
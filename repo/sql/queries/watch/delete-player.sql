@@ -0,0 +1,2 @@
+DELETE FROM followed_players
+WHERE user_id = ? AND player_id = ?
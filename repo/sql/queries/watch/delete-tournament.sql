@@ -0,0 +1,2 @@
+DELETE FROM watched_tournaments
+WHERE user_id = ? AND tournament_id = ?
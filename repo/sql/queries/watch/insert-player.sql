@@ -0,0 +1,12 @@
+INSERT INTO followed_players
+(
+	created_at,
+	user_id,
+	player_id
+)
+VALUES
+(
+	:created_at,
+	:user_id,
+	:player.id
+)
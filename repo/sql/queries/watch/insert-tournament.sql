@@ -0,0 +1,12 @@
+INSERT INTO watched_tournaments
+(
+	created_at,
+	user_id,
+	tournament_id
+)
+VALUES
+(
+	:created_at,
+	:user_id,
+	:tournament_id
+)
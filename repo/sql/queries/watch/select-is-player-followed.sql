@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+FROM followed_players
+WHERE player_id = ? AND deleted_at IS NULL
@@ -0,0 +1,19 @@
+SELECT
+	f.id,
+	f.created_at,
+	f.updated_at,
+	f.user_id,
+	p.id AS "player.id",
+	p.first_name AS "player.first_name",
+	p.last_name AS "player.last_name",
+	p.birthday AS "player.birthday",
+	p.gender AS "player.gender",
+	p.total_points AS "player.total_points",
+	p.ladder_rank AS "player.ladder_rank",
+	p.club AS "player.club",
+	p.country_union AS "player.country_union",
+	p.license AS "player.license"
+FROM followed_players f
+JOIN players p ON p.id = f.player_id
+WHERE f.user_id = ? AND f.deleted_at IS NULL
+ORDER BY f.id DESC
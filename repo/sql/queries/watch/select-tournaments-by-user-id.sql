@@ -0,0 +1,9 @@
+SELECT
+	w.id,
+	w.created_at,
+	w.updated_at,
+	w.user_id,
+	w.tournament_id
+FROM watched_tournaments w
+WHERE w.user_id = ? AND w.deleted_at IS NULL
+ORDER BY w.id DESC
@@ -0,0 +1,104 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.RatingRepository = &ratingRepository{}
+
+type ratingRepository struct {
+	DB *sqlx.DB
+}
+
+// Results returns the results of all teams in finished tournaments that
+// have not deregistered, ordered by the tournaments' start and id.
+func (s *ratingRepository) Results(ctx context.Context) ([]*volleynet.TeamResult, error) {
+	results := []*volleynet.TeamResult{}
+	err := crud.Read(ctx, s.DB, "rating/select-results", &results)
+
+	return results, errors.Wrap(err, "results")
+}
+
+// RatedTournaments returns the ids of all tournaments that have been rated.
+func (s *ratingRepository) RatedTournaments(ctx context.Context) ([]int, error) {
+	ids := []int{}
+	err := crud.Read(ctx, s.DB, "rating/select-rated-tournaments", &ids)
+
+	return ids, errors.Wrap(err, "rated tournaments")
+}
+
+// Latest returns the current rating of all rated players.
+func (s *ratingRepository) Latest(ctx context.Context) ([]*volleynet.Rating, error) {
+	ratings := []*volleynet.Rating{}
+	err := crud.Read(ctx, s.DB, "rating/select-latest", &ratings)
+
+	return ratings, errors.Wrap(err, "latest ratings")
+}
+
+// ByPlayer returns the rating history of a player, the oldest rating first.
+func (s *ratingRepository) ByPlayer(ctx context.Context, playerID int) ([]*volleynet.Rating, error) {
+	ratings := []*volleynet.Rating{}
+	err := crud.Read(ctx, s.DB, "rating/select-by-player", &ratings, playerID)
+
+	return ratings, errors.Wrap(err, "ratings by player")
+}
+
+// Leaderboard returns the `limit` players of the gender with the highest current rating.
+func (s *ratingRepository) Leaderboard(ctx context.Context, gender string, limit int) ([]*volleynet.RatedPlayer, error) {
+	players := []*volleynet.RatedPlayer{}
+	err := crud.Read(ctx, s.DB, "rating/select-leaderboard", &players, gender, limit)
+
+	return players, errors.Wrap(err, "rating leaderboard")
+}
+
+// New persists ratings.
+func (s *ratingRepository) New(ctx context.Context, ratings ...*volleynet.Rating) error {
+	rs := make([]scores.Tracked, len(ratings))
+
+	for i, r := range ratings {
+		rs[i] = r
+	}
+
+	err := crud.Create(ctx, s.DB, "rating/insert", rs...)
+
+	return errors.Wrap(err, "insert ratings")
+}
+
+// DeleteFrom deletes the ratings of all tournaments that started at or after `start`.
+func (s *ratingRepository) DeleteFrom(ctx context.Context, start time.Time) (int, error) {
+	deleted, err := crud.DeleteWhere(ctx, s.DB, "rating/delete-from", start)
+
+	return deleted, errors.Wrap(err, "delete ratings")
+}
+
+// RequestRecompute records that all ratings have to be recomputed.
+func (s *ratingRepository) RequestRecompute(ctx context.Context) error {
+	_, err := crud.Exec(ctx, s.DB, "rating/insert-recompute-request", time.Now().UTC())
+
+	return errors.Wrap(err, "insert recompute request")
+}
+
+// RecomputeRequest returns the id of the latest pending recompute
+// request, 0 if there is none.
+func (s *ratingRepository) RecomputeRequest(ctx context.Context) (int, error) {
+	requestID := 0
+	err := crud.ReadOne(ctx, s.DB, "rating/select-recompute-request", &requestID)
+
+	return requestID, errors.Wrap(err, "recompute request")
+}
+
+// ClearRecomputeRequests deletes the recompute requests up to `requestID`.
+func (s *ratingRepository) ClearRecomputeRequests(ctx context.Context, requestID int) error {
+	_, err := crud.Exec(ctx, s.DB, "rating/delete-recompute-requests", requestID)
+
+	return errors.Wrap(err, "delete recompute requests")
+}
@@ -1,6 +1,9 @@
 package sql
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
@@ -9,14 +12,30 @@ import (
 )
 
 // Repositories returns a collection of all repositories with an SQL backend.
-func Repositories(provider, connectionString string) (*repo.Repositories, error) {
+// If `autoMigrate` is set the schema is migrated to the latest version,
+// otherwise an error is returned if there are pending migrations.
+func Repositories(provider, connectionString string, autoMigrate bool) (*repo.Repositories, error) {
 	db, err := sqlx.Open(provider, connectionString)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "open db: %v")
+		return nil, errors.Wrap(err, "open db")
+	}
+
+	migrator, err := migrate.New(provider, db)
+
+	if err != nil {
+		return nil, err
 	}
 
-	err = migrate.All(provider, db)
+	if autoMigrate {
+		err = migrator.Up(context.Background())
+	} else {
+		err = checkSchema(migrator)
+	}
+
+	if err != nil {
+		return nil, err
+	}
 
 	return &repo.Repositories{
 		UserRepo:       &userRepository{DB: db},
@@ -24,5 +43,36 @@ func Repositories(provider, connectionString string) (*repo.Repositories, error)
 		TournamentRepo: &tournamentRepository{DB: db},
 		TeamRepo:       &teamRepository{DB: db},
 		SettingRepo:    &settingRepository{DB: db},
-	}, err
+
+		NotificationLogRepo: &notificationLogRepository{DB: db},
+		AlertRuleRepo:       &alertRuleRepository{DB: db},
+		JobExecutionRepo:    &jobExecutionRepository{DB: db},
+		JobLeaseRepo:        &jobLeaseRepository{DB: db},
+		PollOffsetRepo:      &pollOffsetRepository{DB: db},
+		RatingRepo:          &ratingRepository{DB: db},
+		PartnerRepo:         &partnerRepository{DB: db},
+		WatchRepo:           &watchRepository{DB: db},
+		ActivityRepo:        &activityRepository{DB: db},
+		SignupRepo:          &signupRepository{DB: db},
+		TransferRepo:        &transferRepository{DB: db},
+	}, nil
+}
+
+// checkSchema returns an error if the schema is not up to date.
+func checkSchema(migrator *migrate.Migrator) error {
+	status, err := migrator.Status()
+
+	if err != nil {
+		return err
+	}
+
+	if status.Dirty {
+		return fmt.Errorf("the schema version %d is dirty, fix it and run `migrate force`", status.Version)
+	}
+
+	if len(status.Pending) > 0 {
+		return fmt.Errorf("the schema version %d is outdated (latest is %d), run `migrate up`", status.Version, status.Latest)
+	}
+
+	return nil
 }
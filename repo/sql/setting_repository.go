@@ -1,6 +1,8 @@
 package sql
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
@@ -15,22 +17,35 @@ type settingRepository struct {
 	DB *sqlx.DB
 }
 
-func (s *settingRepository) Create(setting *scores.Setting) (*scores.Setting, error) {
-	err := crud.Create(s.DB, "setting/insert", setting)
+func (s *settingRepository) Create(ctx context.Context, setting *scores.Setting) (*scores.Setting, error) {
+	err := crud.Create(ctx, s.DB, "setting/insert", setting)
 
 	return setting, errors.Wrap(err, "insert setting")
 }
 
-func (s *settingRepository) Update(setting *scores.Setting) error {
-	err := crud.Update(s.DB, "setting/update", setting)
+func (s *settingRepository) Update(ctx context.Context, setting *scores.Setting) error {
+	err := crud.Update(ctx, s.DB, "setting/update", setting)
 
 	return errors.Wrap(err, "update setting")
 }
 
-func (s *settingRepository) ByUserID(userID int) ([]*scores.Setting, error) {
+func (s *settingRepository) ByUserID(ctx context.Context, userID int) ([]*scores.Setting, error) {
 	settings := []*scores.Setting{}
-	err := crud.Read(s.DB, "setting/select-by-user-id", &settings, userID)
+	err := crud.Read(ctx, s.DB, "setting/select-by-user-id", &settings, userID)
 
 	return settings, errors.Wrap(err, "byUserID setting")
+}
+
+func (s *settingRepository) ByKey(ctx context.Context, key string) ([]*scores.Setting, error) {
+	settings := []*scores.Setting{}
+	err := crud.Read(ctx, s.DB, "setting/select-by-key", &settings, key)
+
+	return settings, errors.Wrap(err, "byKey setting")
+}
+
+func (s *settingRepository) All(ctx context.Context) ([]*scores.Setting, error) {
+	settings := []*scores.Setting{}
+	err := crud.Read(ctx, s.DB, "setting/select-all", &settings)
 
+	return settings, errors.Wrap(err, "all settings")
 }
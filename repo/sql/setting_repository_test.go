@@ -3,6 +3,7 @@
 package sql
 
 import (
+	"context"
 	"testing"
 
 	"github.com/raphi011/scores"
@@ -16,10 +17,10 @@ func TestCreateSetting(t *testing.T) {
 
 	setting := &scores.Setting{Key: "FILTER_LEAGUE", UserID: users[0].ID}
 
-	_, err := settingRepo.Create(setting)
+	_, err := settingRepo.Create(context.Background(), setting)
 	test.Check(t, "settingRepository.New(), err: %v", err)
 
-	persistedSetting, err := settingRepo.ByUserID(users[0].ID)
+	persistedSetting, err := settingRepo.ByUserID(context.Background(), users[0].ID)
 
 	test.Check(t, "settingRepo.Get() failed: %v", err)
 	test.Compare(t, "setting is not equal:\n%s", persistedSetting[0], setting)
@@ -36,10 +37,10 @@ func TestUpdateSetting(t *testing.T) {
 		UserID: users[0].ID,
 	}
 
-	_, err := settingRepo.Create(setting)
+	_, err := settingRepo.Create(context.Background(), setting)
 	test.Check(t, "settingRepository.New(), err: %v", err)
 
-	err = settingRepo.Update(&scores.Setting{
+	err = settingRepo.Update(context.Background(), &scores.Setting{
 		Key:    "FILTER_LEAGUE",
 		Value:  "junior-league",
 		UserID: users[0].ID,
@@ -47,7 +48,7 @@ func TestUpdateSetting(t *testing.T) {
 
 	test.Check(t, "settingRepo.Update() failed: %v", err)
 
-	persistedSetting, err := settingRepo.ByUserID(users[0].ID)
+	persistedSetting, err := settingRepo.ByUserID(context.Background(), users[0].ID)
 
 	test.Check(t, "settingRepository.ByUserID(), err: %v", err)
 
@@ -0,0 +1,71 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.SignupRepository = &signupRepository{}
+
+type signupRepository struct {
+	DB *sqlx.DB
+}
+
+// Get loads a scheduled signup.
+func (s *signupRepository) Get(ctx context.Context, signupID int) (*volleynet.ScheduledSignup, error) {
+	signup := &volleynet.ScheduledSignup{}
+	err := crud.ReadOne(ctx, s.DB, "signup/select-by-id", signup, signupID)
+
+	return signup, errors.Wrap(err, "signup")
+}
+
+// ByUser returns the signups of a user, the latest first.
+func (s *signupRepository) ByUser(ctx context.Context, userID int) ([]*volleynet.ScheduledSignup, error) {
+	signups := []*volleynet.ScheduledSignup{}
+	err := crud.Read(ctx, s.DB, "signup/select-by-user-id", &signups, userID)
+
+	return signups, errors.Wrap(err, "signups by user")
+}
+
+// ByStatus returns the signups with the status, the oldest first.
+func (s *signupRepository) ByStatus(ctx context.Context, status string) ([]*volleynet.ScheduledSignup, error) {
+	signups := []*volleynet.ScheduledSignup{}
+	err := crud.Read(ctx, s.DB, "signup/select-by-status", &signups, status)
+
+	return signups, errors.Wrap(err, "signups by status")
+}
+
+// New persists a scheduled signup and assigns a new id.
+func (s *signupRepository) New(ctx context.Context, signup *volleynet.ScheduledSignup) (
+	*volleynet.ScheduledSignup, error) {
+
+	err := crud.CreateSetID(ctx, s.DB, "signup/insert", signup)
+
+	return signup, errors.Wrap(err, "new signup")
+}
+
+// SetStatus changes the status of a signup from `current` to `status`.
+func (s *signupRepository) SetStatus(ctx context.Context, signupID int, current, status string) (bool, error) {
+	changed, err := crud.Exec(ctx, s.DB, "signup/update-status", time.Now(), status, signupID, current)
+
+	return changed == 1, errors.Wrap(err, "set signup status")
+}
+
+// Finish persists the status, message and attempt of a signup and deletes
+// its credentials if its status is `current`.
+func (s *signupRepository) Finish(ctx context.Context, signup *volleynet.ScheduledSignup, current string) (bool, error) {
+	signup.Update(time.Now())
+	signup.Credentials = ""
+
+	changed, err := crud.Exec(ctx, s.DB, "signup/finish",
+		signup.UpdatedAt, signup.Status, signup.Message, signup.AttemptedAt, signup.ID, current)
+
+	return changed == 1, errors.Wrap(err, "finish signup")
+}
@@ -3,6 +3,7 @@
 package sql
 
 import (
+	"context"
 	"testing"
 
 	"github.com/raphi011/scores/test"
@@ -24,7 +25,7 @@ func TestCreateTeam(t *testing.T) {
 		T{ID: 1},
 	)
 
-	_, err := teamRepo.New(&volleynet.TournamentTeam{
+	_, err := teamRepo.New(context.Background(), &volleynet.TournamentTeam{
 		TournamentID: ts[0].ID,
 		Player1:      ps[0],
 		Player2:      ps[1],
@@ -54,17 +55,17 @@ func TestUpdateTeam(t *testing.T) {
 		TT{TournamentID: ts[0].ID, Player1: ps[0], Player2: ps[1], Seed: 1},
 	)
 
-	teams, _ := teamRepo.ByTournament(1)
+	teams, _ := teamRepo.ByTournament(context.Background(), 1)
 
 	test.Assert(t, "teamRepository.ByTournament(), want len(tournaments) == 1, got: %d", len(teams) == 1, len(teams))
 	test.Assert(t, "team seed should be %d", teams[0].Seed == 1, teams[0].Seed)
 
 	teams[0].Seed = 2
-	err := teamRepo.Update(teams[0])
+	err := teamRepo.Update(context.Background(), teams[0])
 
 	test.Check(t, "teamRepository.Update(), err: %v", err)
 
-	teams, _ = teamRepo.ByTournament(1)
+	teams, _ = teamRepo.ByTournament(context.Background(), 1)
 
 	test.Assert(t, "teamRepository.ByTournament(), want len(tournaments) == 1, got: %d", len(teams) == 1, len(teams))
 	test.Assert(t, "team seed should be %d", teams[0].Seed == 2, teams[0].Seed)
@@ -87,7 +88,7 @@ func TestDeleteTeam(t *testing.T) {
 		TT{TournamentID: ts[0].ID, Player1: ps[0], Player2: ps[1]},
 	)
 
-	err := teamRepo.Delete(teams[0])
+	err := teamRepo.Delete(context.Background(), teams[0])
 
 	test.Check(t, "teamRepository.Delete(), err: %v", err)
 
@@ -118,7 +119,7 @@ func TestTournamentTeams(t *testing.T) {
 		TT{TournamentID: ts[0].ID, Player1: ps[4], Player2: ps[5]},
 	)
 
-	tournamentTeams, err := teamRepo.ByTournament(ts[0].ID)
+	tournamentTeams, err := teamRepo.ByTournament(context.Background(), ts[0].ID)
 
 	test.Check(t, "teamRepo.ByTournament() failed: %v", err)
 	test.Assert(t, "teamRepository.ByTournament(), want len(tournaments) == 3, got: %d", len(tournamentTeams) == 3, len(tournamentTeams))
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
@@ -28,6 +29,18 @@ func RepositoriesTest(t testing.TB) (*repo.Repositories, *sqlx.DB) {
 		TournamentRepo: &tournamentRepository{DB: db},
 		TeamRepo:       &teamRepository{DB: db},
 		SettingRepo:    &settingRepository{DB: db},
+
+		NotificationLogRepo: &notificationLogRepository{DB: db},
+		AlertRuleRepo:       &alertRuleRepository{DB: db},
+		JobExecutionRepo:    &jobExecutionRepository{DB: db},
+		JobLeaseRepo:        &jobLeaseRepository{DB: db},
+		PollOffsetRepo:      &pollOffsetRepository{DB: db},
+		RatingRepo:          &ratingRepository{DB: db},
+		PartnerRepo:         &partnerRepository{DB: db},
+		WatchRepo:           &watchRepository{DB: db},
+		ActivityRepo:        &activityRepository{DB: db},
+		SignupRepo:          &signupRepository{DB: db},
+		TransferRepo:        &transferRepository{DB: db},
 	}, db
 }
 
@@ -53,10 +66,13 @@ func SetupDB(t testing.TB) *sqlx.DB {
 	err = db.Ping()
 	test.Check(t, "unable to connect to db: %v", err)
 
-	err = migrate.All(dbProvider, db)
+	migrator, err := migrate.New(dbProvider, db)
+	test.Check(t, "unable to create migrator: %v", err)
+
+	err = migrator.Up(context.Background())
 	test.Check(t, "migration failed: %v", err)
 
-	err = crud.Execute(db, "test/delete-all")
+	err = crud.Execute(context.Background(), db, "test/delete-all")
 	test.Check(t, "db cleanup failed: %v", err)
 
 	return db
@@ -75,7 +91,7 @@ func CreateUsers(t testing.TB, db *sqlx.DB, users ...U) []*scores.User {
 	userRepo := &userRepository{DB: db}
 
 	for _, u := range users {
-		persistedUser, err := userRepo.New(&scores.User{
+		persistedUser, err := userRepo.New(context.Background(), &scores.User{
 			M: scores.M{
 				ID: u.ID,
 			},
@@ -107,7 +123,7 @@ func CreatePlayers(t testing.TB, db *sqlx.DB, players ...P) []*volleynet.Player
 	playerRepo := &playerRepository{DB: db}
 
 	for _, p := range players {
-		persistedPlayer, err := playerRepo.New(&volleynet.Player{
+		persistedPlayer, err := playerRepo.New(context.Background(), &volleynet.Player{
 			ID:          p.ID,
 			FirstName:	 p.FirstName,
 			LastName: 	 p.LastName,
@@ -143,7 +159,7 @@ func CreateTournaments(t testing.TB, db *sqlx.DB, tournaments ...T) []*volleynet
 	tournamentRepo := &tournamentRepository{DB: db}
 
 	for _, tournament := range tournaments {
-		persistedTournament, err := tournamentRepo.New(&volleynet.Tournament{
+		persistedTournament, err := tournamentRepo.New(context.Background(), &volleynet.Tournament{
 			TournamentInfo: volleynet.TournamentInfo{
 				ID:           tournament.ID,
 				Season:       tournament.Season,
@@ -185,7 +201,7 @@ func CreateTeams(t testing.TB, db *sqlx.DB, teams ...TT) []*volleynet.Tournament
 	teamRepo := &teamRepository{DB: db}
 
 	for _, team := range teams {
-		persistedTeam, err := teamRepo.New(&volleynet.TournamentTeam{
+		persistedTeam, err := teamRepo.New(context.Background(), &volleynet.TournamentTeam{
 			TournamentID: team.TournamentID,
 			TotalPoints:  team.TotalPoints,
 			Seed:         team.Seed,
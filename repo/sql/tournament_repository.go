@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"context"
 	"sort"
 
 	"github.com/jmoiron/sqlx"
@@ -19,59 +20,59 @@ type tournamentRepository struct {
 var _ repo.TournamentRepository = &tournamentRepository{}
 
 // Get loads a tournament by its id.
-func (s *tournamentRepository) Get(tournamentID int) (*volleynet.Tournament, error) {
+func (s *tournamentRepository) Get(ctx context.Context, tournamentID int) (*volleynet.Tournament, error) {
 	tournament := &volleynet.Tournament{
 		Teams: []*volleynet.TournamentTeam{},
 	}
-	err := crud.ReadOne(s.DB, "tournament/select-by-id", tournament, tournamentID)
+	err := crud.ReadOne(ctx, s.DB, "tournament/select-by-id", tournament, tournamentID)
 
 	return tournament, errors.Wrap(err, "get tournament")
 }
 
 // New creates a new tournament.
-func (s *tournamentRepository) New(t *volleynet.Tournament) (*volleynet.Tournament, error) {
-	err := crud.Create(s.DB, "tournament/insert", t)
+func (s *tournamentRepository) New(ctx context.Context, t *volleynet.Tournament) (*volleynet.Tournament, error) {
+	err := crud.Create(ctx, s.DB, "tournament/insert", t)
 
 	return t, errors.Wrap(err, "insert tournament")
 }
 
 // NewBatch creates a new tournament.
-func (s *tournamentRepository) NewBatch(tournaments ...*volleynet.Tournament) error {
+func (s *tournamentRepository) NewBatch(ctx context.Context, tournaments ...*volleynet.Tournament) error {
 	ts := make([]scores.Tracked, len(tournaments))
 
 	for i, t := range tournaments {
 		ts[i] = t
 	}
-	err := crud.Create(s.DB, "tournament/insert", ts...)
+	err := crud.Create(ctx, s.DB, "tournament/insert", ts...)
 
 	return errors.Wrap(err, "insert tournament")
 }
 
 // Update updates a tournament.
-func (s *tournamentRepository) Update(t *volleynet.Tournament) error {
-	err := crud.Update(s.DB, "tournament/update", t)
+func (s *tournamentRepository) Update(ctx context.Context, t *volleynet.Tournament) error {
+	err := crud.Update(ctx, s.DB, "tournament/update", t)
 
 	return errors.Wrap(err, "update tournament")
 }
 
 // UpdateBatch updates a tournament.
-func (s *tournamentRepository) UpdateBatch(tournaments ...*volleynet.Tournament) error {
+func (s *tournamentRepository) UpdateBatch(ctx context.Context, tournaments ...*volleynet.Tournament) error {
 	ts := make([]scores.Tracked, len(tournaments))
 
 	for i, t := range tournaments {
 		ts[i] = t
 	}
-	err := crud.Update(s.DB, "tournament/update", ts...)
+	err := crud.Update(ctx, s.DB, "tournament/update", ts...)
 
 	return errors.Wrap(err, "update tournament")
 }
 
 // Filter loads all tournaments by season, league and gender.
-func (s *tournamentRepository) Search(filter repo.TournamentFilter) (
+func (s *tournamentRepository) Search(ctx context.Context, filter repo.TournamentFilter) (
 	[]*volleynet.Tournament, error) {
 
 	tournaments := []*volleynet.Tournament{}
-	err := crud.ReadIn(s.DB, "tournament/select-by-filter", &tournaments,
+	err := crud.ReadIn(ctx, s.DB, "tournament/select-by-filter", &tournaments,
 		filter.Seasons,
 		filter.Leagues,
 		filter.Genders,
@@ -81,9 +82,9 @@ func (s *tournamentRepository) Search(filter repo.TournamentFilter) (
 }
 
 // Seasons returns all available seasons
-func (s *tournamentRepository) Seasons() ([]string, error) {
+func (s *tournamentRepository) Seasons(ctx context.Context) ([]string, error) {
 	seasons := []string{}
-	err := crud.ReadIn(s.DB, "tournament/select-seasons", &seasons)
+	err := crud.ReadIn(ctx, s.DB, "tournament/select-seasons", &seasons)
 
 	sort.Strings(seasons)
 
@@ -91,10 +92,10 @@ func (s *tournamentRepository) Seasons() ([]string, error) {
 }
 
 // Leagues returns all available leagues
-func (s *tournamentRepository) Leagues() ([]string, error) {
+func (s *tournamentRepository) Leagues(ctx context.Context) ([]string, error) {
 	leagues := []string{}
 
-	err := crud.ReadIn(s.DB, "tournament/select-leagues", &leagues)
+	err := crud.ReadIn(ctx, s.DB, "tournament/select-leagues", &leagues)
 
 	sort.Strings(leagues)
 
@@ -102,9 +103,9 @@ func (s *tournamentRepository) Leagues() ([]string, error) {
 }
 
 // SubLeagues returns all available sub-leagues
-func (s *tournamentRepository) SubLeagues() ([]string, error) {
+func (s *tournamentRepository) SubLeagues(ctx context.Context) ([]string, error) {
 	subLeagues := []string{}
-	err := crud.ReadIn(s.DB, "tournament/select-sub-leagues", &subLeagues)
+	err := crud.ReadIn(ctx, s.DB, "tournament/select-sub-leagues", &subLeagues)
 
 	sort.Strings(subLeagues)
 
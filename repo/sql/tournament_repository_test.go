@@ -3,6 +3,7 @@
 package sql
 
 import (
+	"context"
 	"math/rand"
 	"testing"
 	"time"
@@ -26,7 +27,7 @@ func TestSeasons(t *testing.T) {
 		T{ID: 3, Season: "2018"},
 	)
 
-	actual, err := tournamentRepo.Seasons()
+	actual, err := tournamentRepo.Seasons(context.Background())
 
 	test.Check(t, "tournamentRepository.Seasons(), err: %v", err)
 	test.Compare(t, "Seasons are not equal:\n%s", expected, actual)
@@ -43,7 +44,7 @@ func TestSubLeagues(t *testing.T) {
 		T{ID: 4, SubLeague: "Pro Tour 80"},
 	)
 
-	actual, err := tournamentRepo.SubLeagues()
+	actual, err := tournamentRepo.SubLeagues(context.Background())
 
 	test.Check(t, "tournamentRepository.SubLeagues(), err: %v", err)
 	test.Assert(t, "SubLeagues() expected len() == 4, got: %d", len(actual) == 4, len(actual))
@@ -59,7 +60,7 @@ func TestLeagues(t *testing.T) {
 		T{ID: 4, League: "Amateur Tour"},
 	)
 
-	actual, err := tournamentRepo.Leagues()
+	actual, err := tournamentRepo.Leagues(context.Background())
 
 	test.Check(t, "tournamentRepository.Leagues(), err: %v", err)
 	test.Assert(t, "Leagues() expected len() == 2, got: %d", len(actual) == 2, len(actual))
@@ -69,7 +70,7 @@ func TestCreateTournament(t *testing.T) {
 	db := SetupDB(t)
 	tournamentRepo := &tournamentRepository{DB: db}
 
-	tournament, err := tournamentRepo.New(&volleynet.Tournament{
+	tournament, err := tournamentRepo.New(context.Background(), &volleynet.Tournament{
 		TournamentInfo: volleynet.TournamentInfo{
 			ID:    1,
 			Start: time.Now(),
@@ -82,7 +83,7 @@ func TestCreateTournament(t *testing.T) {
 		t.Fatalf("tournamentRepository.New(), err: %s", err)
 	}
 
-	persistedTournament, err := tournamentRepo.Get(tournament.ID)
+	persistedTournament, err := tournamentRepo.Get(context.Background(), tournament.ID)
 
 	test.Check(t, "tournamentRepository.Get(), err: %v", err)
 	test.Compare(t, "tournaments are not equal:\n%s", tournament, persistedTournament)
@@ -131,7 +132,7 @@ func TestFilterTournament(t *testing.T) {
 	}
 
 	for _, tournament := range tournaments {
-		_, err := tournamentRepo.New(&volleynet.Tournament{
+		_, err := tournamentRepo.New(context.Background(), &volleynet.Tournament{
 			TournamentInfo: volleynet.TournamentInfo{
 				ID:        tournament.ID,
 				Season:    tournament.Season,
@@ -146,7 +147,7 @@ func TestFilterTournament(t *testing.T) {
 		test.Check(t, "tournamentRepo.New() failed: %v", err)
 	}
 
-	got, err := tournamentRepo.Search(repo.TournamentFilter{
+	got, err := tournamentRepo.Search(context.Background(), repo.TournamentFilter{
 		Seasons: []string{"2018"},
 		Leagues: []string{"amateur-tour", "pro-tour"},
 		Genders: []string{"M"},
@@ -166,7 +167,7 @@ func BenchmarkCreateTournament(b *testing.B) {
 		tournaments := randomTournaments(1000, n)
 		b.StartTimer()
 
-		err := tournamentRepo.NewBatch(tournaments...)
+		err := tournamentRepo.NewBatch(context.Background(), tournaments...)
 		test.Check(b, "failed to create random tournaments: %v", err)
 	}
 }
@@ -177,12 +178,12 @@ func BenchmarkFilterTournament(b *testing.B) {
 	tournamentRepo := &tournamentRepository{DB: db}
 
 	tournaments := randomTournaments(1000, 0)
-	err := tournamentRepo.NewBatch(tournaments...)
+	err := tournamentRepo.NewBatch(context.Background(), tournaments...)
 	test.Check(b, "failed to create random tournaments: %v", err)
 	b.StartTimer()
 
 	for n := 0; n < b.N; n++ {
-		ts, err := tournamentRepo.Search(repo.TournamentFilter{
+		ts, err := tournamentRepo.Search(context.Background(), repo.TournamentFilter{
 			Seasons: []string{"2018"},
 			Leagues: []string{"amateur-tour"},
 			Genders: []string{"M"},
@@ -198,7 +199,7 @@ func TestUpdateTournament(t *testing.T) {
 	db := SetupDB(t)
 	tournamentRepo := &tournamentRepository{DB: db}
 
-	tournament, err := tournamentRepo.New(&volleynet.Tournament{
+	tournament, err := tournamentRepo.New(context.Background(), &volleynet.Tournament{
 		TournamentInfo: volleynet.TournamentInfo{
 			ID:    1,
 			Start: time.Now(),
@@ -210,10 +211,10 @@ func TestUpdateTournament(t *testing.T) {
 
 	tournament.Email = "test!"
 
-	err = tournamentRepo.Update(tournament)
+	err = tournamentRepo.Update(context.Background(), tournament)
 	test.Check(t, "couldnt update tournament: %v", err)
 
-	updatedTournament, err := tournamentRepo.Get(tournament.ID)
+	updatedTournament, err := tournamentRepo.Get(context.Background(), tournament.ID)
 	test.Check(t, "couldnt get tournament: %v", err)
 	test.Compare(t, "tournaments are not equal:\n%s", tournament, updatedTournament)
 }
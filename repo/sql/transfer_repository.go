@@ -0,0 +1,346 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.TransferRepository = &transferRepository{}
+
+type transferRepository struct {
+	DB *sqlx.DB
+}
+
+// Players loads all players.
+func (s *transferRepository) Players(ctx context.Context) ([]*volleynet.Player, error) {
+	players := []*volleynet.Player{}
+	err := crud.Read(ctx, s.DB, "transfer/select-players", &players)
+
+	return players, errors.Wrap(err, "export players")
+}
+
+// Tournaments loads all tournaments without their teams.
+func (s *transferRepository) Tournaments(ctx context.Context) ([]*volleynet.Tournament, error) {
+	tournaments := []*volleynet.Tournament{}
+	err := crud.Read(ctx, s.DB, "transfer/select-tournaments", &tournaments)
+
+	return tournaments, errors.Wrap(err, "export tournaments")
+}
+
+// Teams loads all teams, only the ids of their players are set.
+func (s *transferRepository) Teams(ctx context.Context) ([]*volleynet.TournamentTeam, error) {
+	teams := []*volleynet.TournamentTeam{}
+	err := crud.Read(ctx, s.DB, "transfer/select-teams", &teams)
+
+	return teams, errors.Wrap(err, "export teams")
+}
+
+// Users loads all users without their settings.
+func (s *transferRepository) Users(ctx context.Context) ([]*scores.User, error) {
+	users := []*scores.User{}
+	err := crud.Read(ctx, s.DB, "transfer/select-users", &users)
+
+	return users, errors.Wrap(err, "export users")
+}
+
+// Settings loads the settings of all users.
+func (s *transferRepository) Settings(ctx context.Context) ([]*scores.Setting, error) {
+	settings := []*scores.Setting{}
+	err := crud.Read(ctx, s.DB, "transfer/select-settings", &settings)
+
+	return settings, errors.Wrap(err, "export settings")
+}
+
+// NotificationLogs loads the notification log of all users.
+func (s *transferRepository) NotificationLogs(ctx context.Context) ([]*scores.NotificationLog, error) {
+	logs := []*scores.NotificationLog{}
+	err := crud.Read(ctx, s.DB, "transfer/select-notification-logs", &logs)
+
+	return logs, errors.Wrap(err, "export notification logs")
+}
+
+// AlertRules loads the alert rules of all users.
+func (s *transferRepository) AlertRules(ctx context.Context) ([]*scores.AlertRule, error) {
+	rules := []*scores.AlertRule{}
+	err := crud.Read(ctx, s.DB, "transfer/select-alert-rules", &rules)
+
+	return rules, errors.Wrap(err, "export alert rules")
+}
+
+// AlertTriggers loads the triggers of all alert rules.
+func (s *transferRepository) AlertTriggers(ctx context.Context) ([]*scores.AlertTrigger, error) {
+	triggers := []*scores.AlertTrigger{}
+	err := crud.Read(ctx, s.DB, "transfer/select-alert-triggers", &triggers)
+
+	return triggers, errors.Wrap(err, "export alert triggers")
+}
+
+// JobExecutions loads the whole job history.
+func (s *transferRepository) JobExecutions(ctx context.Context) ([]*scores.JobExecution, error) {
+	executions := []*scores.JobExecution{}
+	err := crud.Read(ctx, s.DB, "transfer/select-job-executions", &executions)
+
+	return executions, errors.Wrap(err, "export job executions")
+}
+
+// PartnerListings loads all listings of the partner board, only the ids of their players are set.
+func (s *transferRepository) PartnerListings(ctx context.Context) ([]*volleynet.PartnerListing, error) {
+	listings := []*volleynet.PartnerListing{}
+	err := crud.Read(ctx, s.DB, "transfer/select-partner-listings", &listings)
+
+	return listings, errors.Wrap(err, "export partner listings")
+}
+
+// PartnerRequests loads all requests of the partner board, only the ids of their players are set.
+func (s *transferRepository) PartnerRequests(ctx context.Context) ([]*volleynet.PartnerRequest, error) {
+	requests := []*volleynet.PartnerRequest{}
+	err := crud.Read(ctx, s.DB, "transfer/select-partner-requests", &requests)
+
+	return requests, errors.Wrap(err, "export partner requests")
+}
+
+// WatchedTournaments loads all watched tournaments.
+func (s *transferRepository) WatchedTournaments(ctx context.Context) ([]*volleynet.WatchedTournament, error) {
+	watches := []*volleynet.WatchedTournament{}
+	err := crud.Read(ctx, s.DB, "transfer/select-watched-tournaments", &watches)
+
+	return watches, errors.Wrap(err, "export watched tournaments")
+}
+
+// FollowedPlayers loads all followed players, only the ids of the players are set.
+func (s *transferRepository) FollowedPlayers(ctx context.Context) ([]*volleynet.FollowedPlayer, error) {
+	follows := []*volleynet.FollowedPlayer{}
+	err := crud.Read(ctx, s.DB, "transfer/select-followed-players", &follows)
+
+	return follows, errors.Wrap(err, "export followed players")
+}
+
+// ScheduledSignups returns the # of scheduled signups.
+func (s *transferRepository) ScheduledSignups(ctx context.Context) (int, error) {
+	count := 0
+	err := crud.ReadOne(ctx, s.DB, "transfer/count-scheduled-signups", &count)
+
+	return count, errors.Wrap(err, "count scheduled signups")
+}
+
+// ImportPlayers persists players unchanged.
+func (s *transferRepository) ImportPlayers(ctx context.Context, players ...*volleynet.Player) error {
+	entities := make([]interface{}, len(players))
+
+	for i, p := range players {
+		entities[i] = p
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-player", entities...)
+
+	return errors.Wrap(err, "import players")
+}
+
+// ImportTournaments persists tournaments unchanged, their teams are ignored.
+func (s *transferRepository) ImportTournaments(ctx context.Context, tournaments ...*volleynet.Tournament) error {
+	entities := make([]interface{}, len(tournaments))
+
+	for i, t := range tournaments {
+		entities[i] = t
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-tournament", entities...)
+
+	return errors.Wrap(err, "import tournaments")
+}
+
+// ImportTeams persists teams unchanged.
+func (s *transferRepository) ImportTeams(ctx context.Context, teams ...*volleynet.TournamentTeam) error {
+	entities := make([]interface{}, len(teams))
+
+	for i, t := range teams {
+		entities[i] = t
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-team", entities...)
+
+	return errors.Wrap(err, "import teams")
+}
+
+// ImportUsers persists users unchanged, their settings are ignored.
+func (s *transferRepository) ImportUsers(ctx context.Context, users ...*scores.User) error {
+	entities := make([]interface{}, len(users))
+
+	for i, u := range users {
+		entities[i] = u
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-user", entities...)
+
+	if err == nil {
+		err = s.resetSequences(ctx)
+	}
+
+	return errors.Wrap(err, "import users")
+}
+
+// ImportSettings persists settings unchanged.
+func (s *transferRepository) ImportSettings(ctx context.Context, settings ...*scores.Setting) error {
+	entities := make([]interface{}, len(settings))
+
+	for i, setting := range settings {
+		entities[i] = setting
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-setting", entities...)
+
+	return errors.Wrap(err, "import settings")
+}
+
+// ImportNotificationLogs persists notification log entries unchanged.
+func (s *transferRepository) ImportNotificationLogs(ctx context.Context, logs ...*scores.NotificationLog) error {
+	entities := make([]interface{}, len(logs))
+
+	for i, l := range logs {
+		entities[i] = l
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-notification-log", entities...)
+
+	if err == nil {
+		err = s.resetSequences(ctx)
+	}
+
+	return errors.Wrap(err, "import notification logs")
+}
+
+// ImportAlertRules persists alert rules unchanged.
+func (s *transferRepository) ImportAlertRules(ctx context.Context, rules ...*scores.AlertRule) error {
+	entities := make([]interface{}, len(rules))
+
+	for i, r := range rules {
+		entities[i] = r
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-alert-rule", entities...)
+
+	if err == nil {
+		err = s.resetSequences(ctx)
+	}
+
+	return errors.Wrap(err, "import alert rules")
+}
+
+// ImportAlertTriggers persists alert triggers unchanged.
+func (s *transferRepository) ImportAlertTriggers(ctx context.Context, triggers ...*scores.AlertTrigger) error {
+	entities := make([]interface{}, len(triggers))
+
+	for i, t := range triggers {
+		entities[i] = t
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-alert-trigger", entities...)
+
+	if err == nil {
+		err = s.resetSequences(ctx)
+	}
+
+	return errors.Wrap(err, "import alert triggers")
+}
+
+// ImportJobExecutions persists job executions unchanged.
+func (s *transferRepository) ImportJobExecutions(ctx context.Context, executions ...*scores.JobExecution) error {
+	entities := make([]interface{}, len(executions))
+
+	for i, e := range executions {
+		entities[i] = e
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-job-execution", entities...)
+
+	if err == nil {
+		err = s.resetSequences(ctx)
+	}
+
+	return errors.Wrap(err, "import job executions")
+}
+
+// ImportPartnerListings persists partner listings unchanged.
+func (s *transferRepository) ImportPartnerListings(ctx context.Context, listings ...*volleynet.PartnerListing) error {
+	entities := make([]interface{}, len(listings))
+
+	for i, l := range listings {
+		entities[i] = l
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-partner-listing", entities...)
+
+	if err == nil {
+		err = s.resetSequences(ctx)
+	}
+
+	return errors.Wrap(err, "import partner listings")
+}
+
+// ImportPartnerRequests persists partner requests unchanged.
+func (s *transferRepository) ImportPartnerRequests(ctx context.Context, requests ...*volleynet.PartnerRequest) error {
+	entities := make([]interface{}, len(requests))
+
+	for i, r := range requests {
+		entities[i] = r
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-partner-request", entities...)
+
+	if err == nil {
+		err = s.resetSequences(ctx)
+	}
+
+	return errors.Wrap(err, "import partner requests")
+}
+
+// ImportWatchedTournaments persists watched tournaments unchanged.
+func (s *transferRepository) ImportWatchedTournaments(ctx context.Context, watches ...*volleynet.WatchedTournament) error {
+	entities := make([]interface{}, len(watches))
+
+	for i, w := range watches {
+		entities[i] = w
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-watched-tournament", entities...)
+
+	if err == nil {
+		err = s.resetSequences(ctx)
+	}
+
+	return errors.Wrap(err, "import watched tournaments")
+}
+
+// ImportFollowedPlayers persists followed players unchanged.
+func (s *transferRepository) ImportFollowedPlayers(ctx context.Context, follows ...*volleynet.FollowedPlayer) error {
+	entities := make([]interface{}, len(follows))
+
+	for i, f := range follows {
+		entities[i] = f
+	}
+
+	err := crud.Import(ctx, s.DB, "transfer/insert-followed-player", entities...)
+
+	if err == nil {
+		err = s.resetSequences(ctx)
+	}
+
+	return errors.Wrap(err, "import followed players")
+}
+
+// resetSequences makes sure that postgres assigns ids after the imported
+// ones, the other providers continue after the highest id on their own.
+func (s *transferRepository) resetSequences(ctx context.Context) error {
+	if s.DB.DriverName() != "postgres" {
+		return nil
+	}
+
+	return crud.Execute(ctx, s.DB, "transfer/reset-sequences")
+}
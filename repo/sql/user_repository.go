@@ -1,6 +1,8 @@
 package sql
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
@@ -16,41 +18,41 @@ type userRepository struct {
 }
 
 // New persists a user and assigns a new id.
-func (s *userRepository) New(user *scores.User) (*scores.User, error) {
-	err := crud.CreateSetID(s.DB, "user/insert", user)
+func (s *userRepository) New(ctx context.Context, user *scores.User) (*scores.User, error) {
+	err := crud.CreateSetID(ctx, s.DB, "user/insert", user)
 
 	return user, errors.Wrap(err, "new user")
 }
 
 // Update updates a user.
-func (s *userRepository) Update(user *scores.User) error {
-	err := crud.Update(s.DB, "user/update", user)
+func (s *userRepository) Update(ctx context.Context, user *scores.User) error {
+	err := crud.Update(ctx, s.DB, "user/update", user)
 
 	return errors.Wrap(err, "update user")
 }
 
 // All returns all user's, this is used mainly for testing.
-func (s *userRepository) All() ([]*scores.User, error) {
+func (s *userRepository) All(ctx context.Context) ([]*scores.User, error) {
 
 	users := []*scores.User{}
-	err := crud.Read(s.DB, "user/select-all", &users)
+	err := crud.Read(ctx, s.DB, "user/select-all", &users)
 
 	return users, errors.Wrap(err, "all users")
 }
 
 // ByID retrieves a user by his/her ID.
-func (s *userRepository) ByID(userID int) (*scores.User, error) {
+func (s *userRepository) ByID(ctx context.Context, userID int) (*scores.User, error) {
 
 	user := &scores.User{}
-	err := crud.ReadOne(s.DB, "user/select-by-id", user, userID)
+	err := crud.ReadOne(ctx, s.DB, "user/select-by-id", user, userID)
 
 	return user, errors.Wrap(err, "byID user")
 }
 
 // ByEmail retrieves a user by his/her email.
-func (s *userRepository) ByEmail(email string) (*scores.User, error) {
+func (s *userRepository) ByEmail(ctx context.Context, email string) (*scores.User, error) {
 	user := &scores.User{}
-	err := crud.ReadOne(s.DB, "user/select-by-email", user, email)
+	err := crud.ReadOne(ctx, s.DB, "user/select-by-email", user, email)
 
 	return user, errors.Wrap(err, "byEmail user")
 }
@@ -3,6 +3,7 @@
 package sql
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -17,7 +18,7 @@ func TestCreateUser(t *testing.T) {
 
 	email := "test@test.com"
 
-	user, err := userRepo.New(&scores.User{
+	user, err := userRepo.New(context.Background(), &scores.User{
 		Email:           email,
 		ProfileImageURL: "image.url",
 	})
@@ -25,11 +26,11 @@ func TestCreateUser(t *testing.T) {
 	test.Check(t, "userRepository.New() err: %v", err)
 	test.Assert(t, "userRepository.New(): want ID != 0, got 0", user.ID != 0)
 
-	userByEmail, err := userRepo.ByEmail(email)
+	userByEmail, err := userRepo.ByEmail(context.Background(), email)
 
 	test.Check(t, "userRepository.ByEmail() err: %v", err)
 
-	userByID, err := userRepo.ByID(user.ID)
+	userByID, err := userRepo.ByID(context.Background(), user.ID)
 
 	test.Check(t, "userRepository.ByID() err: %v", err)
 	test.Compare(t, "user byID and byEmail is not equal\n%s", userByEmail, userByID)
@@ -39,7 +40,7 @@ func TestUserNotFound(t *testing.T) {
 	db := SetupDB(t)
 	userRepo := &userRepository{DB: db}
 
-	_, err := userRepo.ByID(1)
+	_, err := userRepo.ByID(context.Background(), 1)
 
 	if errors.Cause(err) != scores.ErrNotFound {
 		t.Errorf("userRepository.ByID(), want err = ErrNotFound, got: %v", err)
@@ -50,7 +51,7 @@ func TestUsers(t *testing.T) {
 	db := SetupDB(t)
 	userRepo := &userRepository{DB: db}
 
-	_, err := userRepo.New(&scores.User{
+	_, err := userRepo.New(context.Background(), &scores.User{
 		Email: "test@test.at",
 	})
 
@@ -58,7 +59,7 @@ func TestUsers(t *testing.T) {
 		t.Errorf("userRepository.New() err: %s", err)
 	}
 
-	_, err = userRepo.New(&scores.User{
+	_, err = userRepo.New(context.Background(), &scores.User{
 		Email:           "test2@test.at",
 		ProfileImageURL: "image.url",
 	})
@@ -67,7 +68,7 @@ func TestUsers(t *testing.T) {
 		t.Errorf("userRepository.New() err: %s", err)
 	}
 
-	users, err := userRepo.All()
+	users, err := userRepo.All(context.Background())
 
 	if err != nil {
 		t.Errorf("userRepository.Users() err: %s", err)
@@ -86,20 +87,20 @@ func TestUpdateUser(t *testing.T) {
 	email := "test@test.com"
 	newEmail := "test2@test.com"
 
-	user, _ := userRepo.New(&scores.User{
+	user, _ := userRepo.New(context.Background(), &scores.User{
 		Email:           email,
 		ProfileImageURL: "image.url",
 	})
 
 	user.Email = newEmail
 
-	err := userRepo.Update(user)
+	err := userRepo.Update(context.Background(), user)
 
 	if err != nil {
 		t.Errorf("userRepository.Update() err: %s", err)
 	}
 
-	user, err = userRepo.ByEmail(newEmail)
+	user, err = userRepo.ByEmail(context.Background(), newEmail)
 
 	if err != nil || user.Email != newEmail {
 		t.Error("userRepository.Update(), user not updated")
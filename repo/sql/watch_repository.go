@@ -0,0 +1,83 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/sql/crud"
+	"github.com/raphi011/scores/volleynet"
+)
+
+var _ repo.WatchRepository = &watchRepository{}
+
+type watchRepository struct {
+	DB *sqlx.DB
+}
+
+// Tournaments returns the watched tournaments of a user, the latest first.
+func (s *watchRepository) Tournaments(ctx context.Context, userID int) ([]*volleynet.WatchedTournament, error) {
+	watches := []*volleynet.WatchedTournament{}
+	err := crud.Read(ctx, s.DB, "watch/select-tournaments-by-user-id", &watches, userID)
+
+	return watches, errors.Wrap(err, "watched tournaments")
+}
+
+// WatchTournament persists a watched tournament and assigns a new id.
+func (s *watchRepository) WatchTournament(ctx context.Context, w *volleynet.WatchedTournament) (
+	*volleynet.WatchedTournament, error) {
+
+	err := crud.CreateSetID(ctx, s.DB, "watch/insert-tournament", w)
+
+	return w, errors.Wrap(err, "watch tournament")
+}
+
+// UnwatchTournament removes a tournament from the watchlist of a user.
+func (s *watchRepository) UnwatchTournament(ctx context.Context, userID, tournamentID int) error {
+	deleted, err := crud.DeleteWhere(ctx, s.DB, "watch/delete-tournament", userID, tournamentID)
+
+	if err == nil && deleted == 0 {
+		err = scores.ErrNotFound
+	}
+
+	return errors.Wrap(err, "unwatch tournament")
+}
+
+// Players returns the followed players of a user, the latest first.
+func (s *watchRepository) Players(ctx context.Context, userID int) ([]*volleynet.FollowedPlayer, error) {
+	follows := []*volleynet.FollowedPlayer{}
+	err := crud.Read(ctx, s.DB, "watch/select-players-by-user-id", &follows, userID)
+
+	return follows, errors.Wrap(err, "followed players")
+}
+
+// FollowPlayer persists a followed player and assigns a new id.
+func (s *watchRepository) FollowPlayer(ctx context.Context, f *volleynet.FollowedPlayer) (
+	*volleynet.FollowedPlayer, error) {
+
+	err := crud.CreateSetID(ctx, s.DB, "watch/insert-player", f)
+
+	return f, errors.Wrap(err, "follow player")
+}
+
+// UnfollowPlayer removes a player from the followed players of a user.
+func (s *watchRepository) UnfollowPlayer(ctx context.Context, userID, playerID int) error {
+	deleted, err := crud.DeleteWhere(ctx, s.DB, "watch/delete-player", userID, playerID)
+
+	if err == nil && deleted == 0 {
+		err = scores.ErrNotFound
+	}
+
+	return errors.Wrap(err, "unfollow player")
+}
+
+// IsFollowed returns true if the player is followed by at least one user.
+func (s *watchRepository) IsFollowed(ctx context.Context, playerID int) (bool, error) {
+	count := 0
+	err := crud.ReadOne(ctx, s.DB, "watch/select-is-player-followed", &count, playerID)
+
+	return count > 0, errors.Wrap(err, "is player followed")
+}
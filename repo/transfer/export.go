@@ -0,0 +1,59 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores/repo"
+)
+
+// Export writes all entities of `repos` to `w` and returns
+// the number of exported entities per type.
+func Export(ctx context.Context, repos *repo.Repositories, w io.Writer) (Counts, error) {
+	loaded := make([][]interface{}, len(entities))
+	counts := Counts{}
+
+	for i, e := range entities {
+		v, err := e.load(ctx, repos.TransferRepo)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "load %s", e.name)
+		}
+
+		loaded[i] = v
+		counts[e.name] = len(v)
+	}
+
+	encoder := json.NewEncoder(w)
+
+	err := encoder.Encode(&header{
+		Format:     Format,
+		Version:    Version,
+		ExportedAt: time.Now(),
+		Counts:     counts,
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "write header")
+	}
+
+	for i, e := range entities {
+		for _, v := range loaded[i] {
+			err = encoder.Encode(&record{
+				Entity:    e.name,
+				DeletedAt: e.track(v).DeletedAt,
+				Data:      v,
+			})
+
+			if err != nil {
+				return nil, errors.Wrapf(err, "write %s", e.name)
+			}
+		}
+	}
+
+	return counts, nil
+}
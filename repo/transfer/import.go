@@ -0,0 +1,135 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores/repo"
+)
+
+// rawRecord is a record whose data has not been decoded yet.
+type rawRecord struct {
+	Entity    string          `json:"entity"`
+	DeletedAt *time.Time      `json:"deletedAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Import reads an export from `r` and persists all entities in `repos`,
+// which must not contain any data yet. The export is validated completely
+// before anything is persisted, afterwards the entities in `repos` are
+// counted to verify that nothing got lost.
+func Import(ctx context.Context, repos *repo.Repositories, r io.Reader) (Counts, error) {
+	existing, err := count(ctx, repos.TransferRepo)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "count existing entities")
+	}
+
+	signups, err := repos.TransferRepo.ScheduledSignups(ctx)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "count existing entities")
+	}
+
+	if existing.Total() > 0 || signups > 0 {
+		return nil, fmt.Errorf("import: the destination is not empty (%s, scheduledSignups: %d)", existing, signups)
+	}
+
+	h, decoded, err := read(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := Counts{}
+
+	for i, e := range entities {
+		if len(decoded[i]) == 0 {
+			continue
+		}
+
+		if err = e.save(ctx, repos.TransferRepo, decoded[i]); err != nil {
+			return counts, errors.Wrapf(err, "import %s", e.name)
+		}
+
+		counts[e.name] = len(decoded[i])
+	}
+
+	imported, err := count(ctx, repos.TransferRepo)
+
+	if err != nil {
+		return counts, errors.Wrap(err, "count imported entities")
+	}
+
+	if !imported.Equal(h.Counts) {
+		return counts, fmt.Errorf("import: verification failed, expected %s but found %s", h.Counts, imported)
+	}
+
+	return counts, nil
+}
+
+// read decodes and validates an export, the entities are grouped by their type.
+func read(r io.Reader) (*header, [][]interface{}, error) {
+	decoder := json.NewDecoder(r)
+	h := &header{}
+
+	if err := decoder.Decode(h); err != nil {
+		return nil, nil, errors.Wrap(err, "read header")
+	}
+
+	if h.Format != Format {
+		return nil, nil, fmt.Errorf("read header: unknown format %q", h.Format)
+	}
+
+	if h.Version != Version {
+		return nil, nil, fmt.Errorf("read header: unsupported version %d, expected %d", h.Version, Version)
+	}
+
+	decoded := make([][]interface{}, len(entities))
+	current := 0
+
+	for line := 2; ; line++ {
+		raw := rawRecord{}
+		err := decoder.Decode(&raw)
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, errors.Wrapf(err, "read record %d", line)
+		}
+
+		i, ok := entityIndex(raw.Entity)
+
+		if !ok {
+			return nil, nil, fmt.Errorf("read record %d: unknown entity %q", line, raw.Entity)
+		}
+
+		if i < current {
+			return nil, nil, fmt.Errorf("read record %d: %s must be written before %s", line, raw.Entity, entities[current].name)
+		}
+
+		current = i
+		e := entities[i]
+		v := e.new()
+
+		if err = json.Unmarshal(raw.Data, v); err != nil {
+			return nil, nil, errors.Wrapf(err, "read record %d", line)
+		}
+
+		e.track(v).DeletedAt = raw.DeletedAt
+		decoded[i] = append(decoded[i], v)
+	}
+
+	for i, e := range entities {
+		if len(decoded[i]) != h.Counts[e.name] {
+			return nil, nil, fmt.Errorf("read: expected %d %s but found %d, the export is incomplete", h.Counts[e.name], e.name, len(decoded[i]))
+		}
+	}
+
+	return h, decoded, nil
+}
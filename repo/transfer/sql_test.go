@@ -0,0 +1,16 @@
+//go:build repository
+// +build repository
+
+package transfer
+
+import (
+	"testing"
+
+	"github.com/raphi011/scores/repo/sql"
+)
+
+func TestRoundTripSQL(t *testing.T) {
+	repos, _ := sql.RepositoriesTest(t)
+
+	roundTrip(t, newSource(t), repos)
+}
@@ -0,0 +1,423 @@
+// Package transfer exports all data of a `repo.Repositories` into a portable
+// format and imports it into the repositories of any other provider, e.g. to
+// move from sqlite3 to postgres.
+//
+// An export is a stream of JSON lines, the first line is a header with the
+// format, its version and the number of exported entities per type. Every
+// following line is a record of one entity, records are ordered so that
+// entities are written after the entities they reference:
+//
+//	{"format":"scores","version":1,"exportedAt":"...","counts":{"players":2,...}}
+//	{"entity":"players","data":{"id":1,...}}
+//	{"entity":"users","deletedAt":"...","data":{"id":3,...}}
+//
+// IDs and timestamps are preserved, job leases are not exported because
+// they are only valid while a job runs, ratings because they are
+// recomputed from the results by the ratings job, activities because
+// they are only kept for a while and scheduled signups because their
+// credentials are encrypted with the secret of the source. The destination
+// must not contain any of the exported entities or scheduled signups.
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+const (
+	// Format identifies an export.
+	Format = "scores"
+	// Version is the version of the format that is written, imports
+	// of other versions are refused.
+	Version = 1
+)
+
+// Counts is the number of entities per entity type.
+type Counts map[string]int
+
+// Equal returns true if both counts contain the same number of entities
+// for every entity type.
+func (c Counts) Equal(other Counts) bool {
+	for _, e := range entities {
+		if c[e.name] != other[e.name] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Total returns the number of all entities.
+func (c Counts) Total() int {
+	total := 0
+
+	for _, count := range c {
+		total += count
+	}
+
+	return total
+}
+
+func (c Counts) String() string {
+	s := ""
+
+	for i, e := range entities {
+		if i > 0 {
+			s += ", "
+		}
+
+		s += fmt.Sprintf("%s: %d", e.name, c[e.name])
+	}
+
+	return s
+}
+
+// header is the first line of an export.
+type header struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	Counts     Counts    `json:"counts"`
+}
+
+// record is a line of an export that contains one entity. `DeletedAt`
+// is stored separately because it's not part of the entities' json.
+type record struct {
+	Entity    string      `json:"entity"`
+	DeletedAt *time.Time  `json:"deletedAt,omitempty"`
+	Data      interface{} `json:"data"`
+}
+
+// setting contains all fields of a `scores.Setting`, most
+// of them are not part of its json representation.
+type setting struct {
+	scores.Track
+	UserID int    `json:"userId"`
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Type   string `json:"type"`
+}
+
+// entity describes how an entity type is exported and imported.
+type entity struct {
+	name string
+	// load loads all entities of this type.
+	load func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error)
+	// new returns a new entity to decode a record into.
+	new func() interface{}
+	// track returns the timestamps of an entity.
+	track func(v interface{}) *scores.Track
+	// save persists entities of this type unchanged.
+	save func(ctx context.Context, r repo.TransferRepository, v []interface{}) error
+}
+
+// entities are ordered by their dependencies, e.g. teams reference
+// players and tournaments and are therefore exported after them.
+var entities = []entity{
+	{
+		name: "players",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			players, err := r.Players(ctx)
+			v := make([]interface{}, len(players))
+			for i, p := range players {
+				v[i] = p
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &volleynet.Player{} },
+		track: func(v interface{}) *scores.Track { return &v.(*volleynet.Player).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			players := make([]*volleynet.Player, len(v))
+			for i, p := range v {
+				players[i] = p.(*volleynet.Player)
+			}
+			return r.ImportPlayers(ctx, players...)
+		},
+	},
+	{
+		name: "tournaments",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			tournaments, err := r.Tournaments(ctx)
+			v := make([]interface{}, len(tournaments))
+			for i, t := range tournaments {
+				v[i] = t
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &volleynet.Tournament{} },
+		track: func(v interface{}) *scores.Track { return &v.(*volleynet.Tournament).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			tournaments := make([]*volleynet.Tournament, len(v))
+			for i, t := range v {
+				tournaments[i] = t.(*volleynet.Tournament)
+			}
+			return r.ImportTournaments(ctx, tournaments...)
+		},
+	},
+	{
+		name: "teams",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			teams, err := r.Teams(ctx)
+			v := make([]interface{}, len(teams))
+			for i, t := range teams {
+				v[i] = t
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &volleynet.TournamentTeam{} },
+		track: func(v interface{}) *scores.Track { return &v.(*volleynet.TournamentTeam).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			teams := make([]*volleynet.TournamentTeam, len(v))
+			for i, t := range v {
+				teams[i] = t.(*volleynet.TournamentTeam)
+			}
+			return r.ImportTeams(ctx, teams...)
+		},
+	},
+	{
+		name: "users",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			users, err := r.Users(ctx)
+			v := make([]interface{}, len(users))
+			for i, u := range users {
+				v[i] = u
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &scores.User{} },
+		track: func(v interface{}) *scores.Track { return &v.(*scores.User).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			users := make([]*scores.User, len(v))
+			for i, u := range v {
+				users[i] = u.(*scores.User)
+			}
+			return r.ImportUsers(ctx, users...)
+		},
+	},
+	{
+		name: "settings",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			settings, err := r.Settings(ctx)
+			v := make([]interface{}, len(settings))
+			for i, s := range settings {
+				v[i] = &setting{
+					Track:  s.Track,
+					UserID: s.UserID,
+					Key:    s.Key,
+					Value:  s.Value,
+					Type:   s.Type,
+				}
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &setting{} },
+		track: func(v interface{}) *scores.Track { return &v.(*setting).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			settings := make([]*scores.Setting, len(v))
+			for i, s := range v {
+				s := s.(*setting)
+				settings[i] = &scores.Setting{
+					Track:  s.Track,
+					UserID: s.UserID,
+					Key:    s.Key,
+					Value:  s.Value,
+					Type:   s.Type,
+				}
+			}
+			return r.ImportSettings(ctx, settings...)
+		},
+	},
+	{
+		name: "notificationLogs",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			logs, err := r.NotificationLogs(ctx)
+			v := make([]interface{}, len(logs))
+			for i, l := range logs {
+				v[i] = l
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &scores.NotificationLog{} },
+		track: func(v interface{}) *scores.Track { return &v.(*scores.NotificationLog).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			logs := make([]*scores.NotificationLog, len(v))
+			for i, l := range v {
+				logs[i] = l.(*scores.NotificationLog)
+			}
+			return r.ImportNotificationLogs(ctx, logs...)
+		},
+	},
+	{
+		name: "alertRules",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			rules, err := r.AlertRules(ctx)
+			v := make([]interface{}, len(rules))
+			for i, rule := range rules {
+				v[i] = rule
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &scores.AlertRule{} },
+		track: func(v interface{}) *scores.Track { return &v.(*scores.AlertRule).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			rules := make([]*scores.AlertRule, len(v))
+			for i, rule := range v {
+				rules[i] = rule.(*scores.AlertRule)
+			}
+			return r.ImportAlertRules(ctx, rules...)
+		},
+	},
+	{
+		name: "alertTriggers",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			triggers, err := r.AlertTriggers(ctx)
+			v := make([]interface{}, len(triggers))
+			for i, t := range triggers {
+				v[i] = t
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &scores.AlertTrigger{} },
+		track: func(v interface{}) *scores.Track { return &v.(*scores.AlertTrigger).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			triggers := make([]*scores.AlertTrigger, len(v))
+			for i, t := range v {
+				triggers[i] = t.(*scores.AlertTrigger)
+			}
+			return r.ImportAlertTriggers(ctx, triggers...)
+		},
+	},
+	{
+		name: "jobExecutions",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			executions, err := r.JobExecutions(ctx)
+			v := make([]interface{}, len(executions))
+			for i, e := range executions {
+				v[i] = e
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &scores.JobExecution{} },
+		track: func(v interface{}) *scores.Track { return &v.(*scores.JobExecution).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			executions := make([]*scores.JobExecution, len(v))
+			for i, e := range v {
+				executions[i] = e.(*scores.JobExecution)
+			}
+			return r.ImportJobExecutions(ctx, executions...)
+		},
+	},
+	{
+		name: "partnerListings",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			listings, err := r.PartnerListings(ctx)
+			v := make([]interface{}, len(listings))
+			for i, l := range listings {
+				v[i] = l
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &volleynet.PartnerListing{} },
+		track: func(v interface{}) *scores.Track { return &v.(*volleynet.PartnerListing).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			listings := make([]*volleynet.PartnerListing, len(v))
+			for i, l := range v {
+				listings[i] = l.(*volleynet.PartnerListing)
+			}
+			return r.ImportPartnerListings(ctx, listings...)
+		},
+	},
+	{
+		name: "partnerRequests",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			requests, err := r.PartnerRequests(ctx)
+			v := make([]interface{}, len(requests))
+			for i, request := range requests {
+				v[i] = request
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &volleynet.PartnerRequest{} },
+		track: func(v interface{}) *scores.Track { return &v.(*volleynet.PartnerRequest).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			requests := make([]*volleynet.PartnerRequest, len(v))
+			for i, request := range v {
+				requests[i] = request.(*volleynet.PartnerRequest)
+			}
+			return r.ImportPartnerRequests(ctx, requests...)
+		},
+	},
+	{
+		name: "watchedTournaments",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			watches, err := r.WatchedTournaments(ctx)
+			v := make([]interface{}, len(watches))
+			for i, w := range watches {
+				v[i] = w
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &volleynet.WatchedTournament{} },
+		track: func(v interface{}) *scores.Track { return &v.(*volleynet.WatchedTournament).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			watches := make([]*volleynet.WatchedTournament, len(v))
+			for i, w := range v {
+				watches[i] = w.(*volleynet.WatchedTournament)
+			}
+			return r.ImportWatchedTournaments(ctx, watches...)
+		},
+	},
+	{
+		name: "followedPlayers",
+		load: func(ctx context.Context, r repo.TransferRepository) ([]interface{}, error) {
+			follows, err := r.FollowedPlayers(ctx)
+			v := make([]interface{}, len(follows))
+			for i, f := range follows {
+				v[i] = f
+			}
+			return v, err
+		},
+		new:   func() interface{} { return &volleynet.FollowedPlayer{} },
+		track: func(v interface{}) *scores.Track { return &v.(*volleynet.FollowedPlayer).Track },
+		save: func(ctx context.Context, r repo.TransferRepository, v []interface{}) error {
+			follows := make([]*volleynet.FollowedPlayer, len(v))
+			for i, f := range v {
+				follows[i] = f.(*volleynet.FollowedPlayer)
+			}
+			return r.ImportFollowedPlayers(ctx, follows...)
+		},
+	},
+}
+
+// entityIndex returns the position of the entity type `name` in `entities`.
+func entityIndex(name string) (int, bool) {
+	for i, e := range entities {
+		if e.name == name {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// count counts the entities of all types in `r`.
+func count(ctx context.Context, r repo.TransferRepository) (Counts, error) {
+	counts := Counts{}
+
+	for _, e := range entities {
+		v, err := e.load(ctx, r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		counts[e.name] = len(v)
+	}
+
+	return counts, nil
+}
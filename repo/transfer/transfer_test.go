@@ -0,0 +1,186 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/memory"
+	"github.com/raphi011/scores/test"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// newSource returns repositories with a few entities of every type.
+func newSource(t *testing.T) *repo.Repositories {
+	ctx := context.Background()
+	repos := memory.Repositories()
+
+	_, err := repos.PlayerRepo.New(ctx, &volleynet.Player{ID: 1, FirstName: "Richard", Gender: "M"})
+	test.Check(t, "playerRepo.New() failed: %v", err)
+	_, err = repos.PlayerRepo.New(ctx, &volleynet.Player{ID: 2, FirstName: "Dominik", Gender: "M"})
+	test.Check(t, "playerRepo.New() failed: %v", err)
+
+	_, err = repos.TournamentRepo.New(ctx, &volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{ID: 1, Name: "Vienna"}})
+	test.Check(t, "tournamentRepo.New() failed: %v", err)
+
+	_, err = repos.TeamRepo.New(ctx, &volleynet.TournamentTeam{
+		TournamentID: 1,
+		Player1:      &volleynet.Player{ID: 1},
+		Player2:      &volleynet.Player{ID: 2},
+	})
+	test.Check(t, "teamRepo.New() failed: %v", err)
+
+	user, err := repos.UserRepo.New(ctx, &scores.User{Email: "user@test.com", Role: "user", PlayerID: 1})
+	test.Check(t, "userRepo.New() failed: %v", err)
+
+	_, err = repos.SettingRepo.Create(ctx, &scores.Setting{UserID: user.ID, Key: "language", Value: "de", Type: "string"})
+	test.Check(t, "settingRepo.Create() failed: %v", err)
+
+	_, err = repos.NotificationLogRepo.New(ctx, &scores.NotificationLog{UserID: user.ID, Channel: "email"})
+	test.Check(t, "notificationLogRepo.New() failed: %v", err)
+
+	rule, err := repos.AlertRuleRepo.New(ctx, &scores.AlertRule{UserID: user.ID, Name: "Vienna"})
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+
+	deleted, err := repos.AlertRuleRepo.New(ctx, &scores.AlertRule{UserID: user.ID, Name: "Deleted"})
+	test.Check(t, "alertRuleRepo.New() failed: %v", err)
+	test.Check(t, "alertRuleRepo.Delete() failed: %v", repos.AlertRuleRepo.Delete(ctx, deleted))
+
+	_, err = repos.AlertRuleRepo.Trigger(ctx, rule.ID, "registration-open/1")
+	test.Check(t, "alertRuleRepo.Trigger() failed: %v", err)
+
+	_, err = repos.JobExecutionRepo.New(ctx, &scores.JobExecution{JobName: "ladder", Success: true})
+	test.Check(t, "jobExecutionRepo.New() failed: %v", err)
+
+	listing, err := repos.PartnerRepo.NewListing(ctx, &volleynet.PartnerListing{
+		UserID:       user.ID,
+		Player:       &volleynet.Player{ID: 1},
+		TournamentID: 1,
+		Status:       volleynet.ListingOpen,
+	})
+	test.Check(t, "partnerRepo.NewListing() failed: %v", err)
+
+	_, err = repos.PartnerRepo.NewRequest(ctx, &volleynet.PartnerRequest{
+		ListingID:    listing.ID,
+		UserID:       user.ID,
+		Player:       &volleynet.Player{ID: 2},
+		TournamentID: 1,
+		Status:       volleynet.RequestPending,
+	})
+	test.Check(t, "partnerRepo.NewRequest() failed: %v", err)
+
+	_, err = repos.WatchRepo.WatchTournament(ctx, &volleynet.WatchedTournament{UserID: user.ID, TournamentID: 1})
+	test.Check(t, "watchRepo.WatchTournament() failed: %v", err)
+
+	_, err = repos.WatchRepo.FollowPlayer(ctx, &volleynet.FollowedPlayer{UserID: user.ID, Player: &volleynet.Player{ID: 2}})
+	test.Check(t, "watchRepo.FollowPlayer() failed: %v", err)
+
+	// activities are not exported
+	err = repos.ActivityRepo.New(ctx, &volleynet.Activity{Type: volleynet.ActivityResult, TournamentID: 1, Player1ID: 1, Player2ID: 2})
+	test.Check(t, "activityRepo.New() failed: %v", err)
+
+	return repos
+}
+
+// roundTrip exports `source` and imports it into `destination`.
+func roundTrip(t *testing.T, source, destination *repo.Repositories) {
+	ctx := context.Background()
+	buf := &bytes.Buffer{}
+
+	exported, err := Export(ctx, source, buf)
+	test.Check(t, "Export() failed: %v", err)
+	test.Equal(t, "want %d exported entities, got %d", 15, exported.Total())
+
+	imported, err := Import(ctx, destination, buf)
+	test.Check(t, "Import() failed: %v", err)
+	test.Compare(t, "imported counts differ:\n%s", exported, imported)
+
+	sourceRules, err := source.TransferRepo.AlertRules(ctx)
+	test.Check(t, "transferRepo.AlertRules() failed: %v", err)
+	destinationRules, err := destination.TransferRepo.AlertRules(ctx)
+	test.Check(t, "transferRepo.AlertRules() failed: %v", err)
+	test.Compare(t, "imported alert rules differ:\n%s", sourceRules, destinationRules)
+
+	sourceUsers, err := source.TransferRepo.Users(ctx)
+	test.Check(t, "transferRepo.Users() failed: %v", err)
+	destinationUsers, err := destination.TransferRepo.Users(ctx)
+	test.Check(t, "transferRepo.Users() failed: %v", err)
+	test.Compare(t, "imported users differ:\n%s", sourceUsers, destinationUsers)
+
+	sourceSettings, err := source.TransferRepo.Settings(ctx)
+	test.Check(t, "transferRepo.Settings() failed: %v", err)
+	destinationSettings, err := destination.TransferRepo.Settings(ctx)
+	test.Check(t, "transferRepo.Settings() failed: %v", err)
+	test.Compare(t, "imported settings differ:\n%s", sourceSettings, destinationSettings)
+
+	sourceRequests, err := source.TransferRepo.PartnerRequests(ctx)
+	test.Check(t, "transferRepo.PartnerRequests() failed: %v", err)
+	destinationRequests, err := destination.TransferRepo.PartnerRequests(ctx)
+	test.Check(t, "transferRepo.PartnerRequests() failed: %v", err)
+	test.Compare(t, "imported partner requests differ:\n%s", sourceRequests, destinationRequests)
+}
+
+func TestRoundTrip(t *testing.T) {
+	roundTrip(t, newSource(t), memory.Repositories())
+}
+
+func TestImportNotEmpty(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	_, err := Export(context.Background(), newSource(t), buf)
+	test.Check(t, "Export() failed: %v", err)
+
+	_, err = Import(context.Background(), newSource(t), buf)
+	test.Assert(t, "Import() into repositories with data should fail", err != nil)
+}
+
+func TestImportScheduledSignups(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	_, err := Export(context.Background(), newSource(t), buf)
+	test.Check(t, "Export() failed: %v", err)
+
+	destination := memory.Repositories()
+	_, err = destination.SignupRepo.New(context.Background(), &volleynet.ScheduledSignup{
+		UserID:       1,
+		TournamentID: 1,
+		Status:       volleynet.SignupScheduled,
+	})
+	test.Check(t, "signupRepo.New() failed: %v", err)
+
+	_, err = Import(context.Background(), destination, buf)
+	test.Assert(t, "Import() into repositories with scheduled signups should fail", err != nil)
+}
+
+func TestImportInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		export string
+	}{
+		{"unknown format", `{"format":"other","version":1}`},
+		{"unsupported version", `{"format":"scores","version":2}`},
+		{"unknown entity", `{"format":"scores","version":1}
+{"entity":"cars","data":{}}`},
+		{"wrong order", `{"format":"scores","version":1,"counts":{"players":1,"users":1}}
+{"entity":"users","data":{"id":1}}
+{"entity":"players","data":{"id":1}}`},
+		{"incomplete", `{"format":"scores","version":1,"counts":{"players":2}}
+{"entity":"players","data":{"id":1}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := memory.Repositories()
+
+			_, err := Import(context.Background(), repos, strings.NewReader(tt.export))
+			test.Assert(t, "Import() should fail", err != nil)
+
+			players, err := repos.TransferRepo.Players(context.Background())
+			test.Check(t, "transferRepo.Players() failed: %v", err)
+			test.Assert(t, "an invalid export must not be imported", len(players) == 0)
+		})
+	}
+}
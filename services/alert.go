@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+// maxAlertRules is the max. amount of alert rules a user can create.
+const maxAlertRules = 20
+
+// AlertEvents are the events an alert rule can be restricted to.
+var AlertEvents = []string{
+	path.Base(sync.RegistrationOpenEventType),
+	path.Base(sync.TeamRegisteredEventType),
+	path.Base(sync.TeamMainDrawEventType),
+	path.Base(sync.ResultsEventType),
+}
+
+// Alert allows loading / mutation of alert rules
+type Alert struct {
+	Repo repo.AlertRuleRepository
+}
+
+// AllRules loads the alert rules of all users.
+func (s *Alert) AllRules(ctx context.Context) ([]*scores.AlertRule, error) {
+	return s.Repo.All(ctx)
+}
+
+// Rules loads the alert rules of a user.
+func (s *Alert) Rules(ctx context.Context, userID int) ([]*scores.AlertRule, error) {
+	return s.Repo.ByUserID(ctx, userID)
+}
+
+// Create validates and persists a new alert rule.
+func (s *Alert) Create(ctx context.Context, rule *scores.AlertRule) (*scores.AlertRule, error) {
+	if err := validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.Repo.ByUserID(ctx, rule.UserID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading alert rules")
+	}
+
+	if len(rules) >= maxAlertRules {
+		return nil, errors.Wrapf(scores.ErrorValidation, "a user can create at most %d alert rules", maxAlertRules)
+	}
+
+	return s.Repo.New(ctx, rule)
+}
+
+// Update validates and updates an alert rule of the user.
+func (s *Alert) Update(ctx context.Context, userID int, rule *scores.AlertRule) error {
+	if err := validateAlertRule(rule); err != nil {
+		return err
+	}
+
+	persisted, err := s.rule(ctx, userID, rule.ID)
+
+	if err != nil {
+		return err
+	}
+
+	rule.UserID = persisted.UserID
+	rule.CreatedAt = persisted.CreatedAt
+
+	return s.Repo.Update(ctx, rule)
+}
+
+// Delete deletes an alert rule of the user.
+func (s *Alert) Delete(ctx context.Context, userID, ruleID int) error {
+	rule, err := s.rule(ctx, userID, ruleID)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Repo.Delete(ctx, rule)
+}
+
+// Trigger records that a rule has matched an event and returns false
+// if it has matched it before.
+func (s *Alert) Trigger(ctx context.Context, ruleID int, key string) (bool, error) {
+	return s.Repo.Trigger(ctx, ruleID, key)
+}
+
+// rule loads a rule and makes sure that it belongs to the user, rules
+// of other users are reported as not found.
+func (s *Alert) rule(ctx context.Context, userID, ruleID int) (*scores.AlertRule, error) {
+	rule, err := s.Repo.ByID(ctx, ruleID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.UserID != userID {
+		return nil, scores.ErrNotFound
+	}
+
+	return rule, nil
+}
+
+func validateAlertRule(rule *scores.AlertRule) error {
+	if rule.Event != "" && !containsString(AlertEvents, rule.Event) {
+		return errors.Wrapf(scores.ErrorValidation, "unknown event %q", rule.Event)
+	}
+
+	if rule.Gender != "" && rule.Gender != "M" && rule.Gender != "W" {
+		return errors.Wrapf(scores.ErrorValidation, "unknown gender %q", rule.Gender)
+	}
+
+	if rule.Radius < 0 {
+		return errors.Wrap(scores.ErrorValidation, "radius must not be negative")
+	}
+
+	if rule.Radius > 0 && rule.Latitude == 0 && rule.Longitude == 0 {
+		return errors.Wrap(scores.ErrorValidation, "a radius requires a location")
+	}
+
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
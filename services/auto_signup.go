@@ -0,0 +1,526 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/events"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/client"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+const (
+	// SignupEventsType matches all events of scheduled signups.
+	SignupEventsType = "signup/*"
+	// SignupSucceededEventType is published when a scheduled signup has succeeded.
+	SignupSucceededEventType = "signup/signup-succeeded"
+	// SignupFailedEventType is published when a scheduled signup has failed or expired.
+	SignupFailedEventType = "signup/signup-failed"
+
+	// DefaultCredentialTTL is how long the credentials of a signup are kept if
+	// no TTL is set. Volleynet doesn't announce when the registration of a
+	// tournament opens so the TTL can't be tied to it, it's kept short and
+	// users schedule the signup during the last two days before it opens.
+	DefaultCredentialTTL = 48 * time.Hour
+
+	// maxScheduledSignups is the max. amount of scheduled signups of a user.
+	maxScheduledSignups = 10
+	// staleSignup is how long a signup may be running before it's considered interrupted.
+	staleSignup = 10 * time.Minute
+	// credentialsPurpose is the purpose the credentials are encrypted for.
+	credentialsPurpose = "signup-credentials"
+)
+
+// AutoSignup signs users up for tournaments on their behalf as soon as the
+// registration of a tournament opens. The volleynet credentials of a signup
+// are stored encrypted until the signup has been attempted or they expire.
+type AutoSignup struct {
+	Repo           repo.SignupRepository
+	TournamentRepo repo.TournamentRepository
+	TeamRepo       repo.TeamRepository
+	PlayerRepo     repo.PlayerRepository
+	Cipher         *Cipher
+	Events         events.Publisher // optional
+
+	// NewClient creates the volleynet client of a signup, `client.DefaultClient` if not set.
+	NewClient func() client.Client
+	// CredentialTTL is how long the credentials are kept at most, `DefaultCredentialTTL` if not set.
+	CredentialTTL time.Duration
+
+	now clock
+}
+
+// SignupRequest contains everything that is needed to schedule a signup.
+type SignupRequest struct {
+	TournamentID int
+	PartnerID    int
+	// PartnerName is the name of the partner as used by volleynet,
+	// the partner's first and last name if empty.
+	PartnerName string
+	Username    string
+	Password    string
+}
+
+// SignupEvent is the body of all `signup/*` events.
+type SignupEvent struct {
+	ID         string                     `json:"id"`
+	Timestamp  time.Time                  `json:"time"`
+	Signup     *volleynet.ScheduledSignup `json:"signup"`
+	Tournament *volleynet.Tournament      `json:"tournament"`
+}
+
+// credentials are the encrypted volleynet login of a signup.
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Signups returns all signups of a user, the latest first.
+func (s *AutoSignup) Signups(ctx context.Context, userID int) ([]*volleynet.ScheduledSignup, error) {
+	signups, err := s.Repo.ByUser(ctx, userID)
+
+	return signups, errors.Wrap(err, "loading signups")
+}
+
+// Schedule schedules the signup of a user and a partner for a tournament
+// whose registration has not opened yet. The credentials are verified by
+// logging in and expire after the TTL or when the tournament starts.
+func (s *AutoSignup) Schedule(ctx context.Context, userID int, request SignupRequest) (
+	*volleynet.ScheduledSignup, error) {
+
+	if request.Username == "" || request.Password == "" {
+		return nil, errors.Wrap(scores.ErrorValidation, "the volleynet credentials are missing")
+	}
+
+	tournament, err := s.tournament(ctx, request.TournamentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if tournament.RegistrationOpen {
+		return nil, errors.Wrap(scores.ErrorValidation, "the registration is already open, sign up directly")
+	}
+
+	now := s.now.time()
+
+	if !tournament.Start.After(now) {
+		return nil, errors.Wrap(scores.ErrorValidation, "the tournament has already started")
+	}
+
+	signups, err := s.Repo.ByUser(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading signups")
+	}
+
+	scheduled := 0
+
+	for _, su := range signups {
+		if su.Status != volleynet.SignupScheduled {
+			continue
+		}
+
+		scheduled++
+
+		if su.TournamentID == request.TournamentID {
+			return nil, errors.Wrap(scores.ErrorValidation, "there is already a signup for this tournament")
+		}
+	}
+
+	if scheduled >= maxScheduledSignups {
+		return nil, errors.Wrapf(scores.ErrorValidation, "a user can schedule at most %d signups", maxScheduledSignups)
+	}
+
+	loginData, err := s.client().Login(request.Username, request.Password)
+
+	if errors.Cause(err) == client.ErrInvalidCredentials {
+		return nil, errors.Wrap(scores.ErrorValidation, "the volleynet login failed")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "volleynet login")
+	}
+
+	player, err := s.PlayerRepo.Get(ctx, loginData.ID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading player")
+	}
+
+	partner, err := s.PlayerRepo.Get(ctx, request.PartnerID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading partner")
+	}
+
+	if partner.ID == player.ID {
+		return nil, errors.Wrap(scores.ErrorValidation, "a player can't be his/her own partner")
+	}
+
+	if err := canPlay(tournament, player, partner); err != nil {
+		return nil, err
+	}
+
+	partnerName := request.PartnerName
+
+	if partnerName == "" {
+		partnerName = signup(tournament.ID, partner).PartnerName
+	}
+
+	signup := &volleynet.ScheduledSignup{
+		UserID:       userID,
+		PlayerID:     player.ID,
+		TournamentID: tournament.ID,
+		PartnerID:    partner.ID,
+		PartnerName:  partnerName,
+		Status:       volleynet.SignupScheduled,
+		ExpiresAt:    now.Add(s.credentialTTL()),
+	}
+
+	if tournament.Start.Before(signup.ExpiresAt) {
+		signup.ExpiresAt = tournament.Start
+	}
+
+	signup.Credentials, err = s.encrypt(userID, &credentials{
+		Username: request.Username,
+		Password: request.Password,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	signup, err = s.Repo.New(ctx, signup)
+
+	return signup, errors.Wrap(err, "scheduling signup")
+}
+
+// Cancel cancels a scheduled signup of a user and deletes its credentials.
+func (s *AutoSignup) Cancel(ctx context.Context, userID, signupID int) (*volleynet.ScheduledSignup, error) {
+	signup, err := s.Repo.Get(ctx, signupID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading signup")
+	}
+
+	if signup.UserID != userID {
+		return nil, scores.ErrNotFound
+	}
+
+	if signup.Status != volleynet.SignupScheduled {
+		return nil, errors.Wrap(scores.ErrorValidation, "only scheduled signups can be canceled")
+	}
+
+	canceled, err := s.finish(ctx, signup, nil, volleynet.SignupCanceled, "")
+
+	if err == nil && !canceled {
+		err = errors.Wrap(scores.ErrorValidation, "the signup is already being processed")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return signup, nil
+}
+
+// Run attempts the scheduled signups of all tournaments whose registration
+// has opened, expires signups and fails signups that have been interrupted.
+func (s *AutoSignup) Run(ctx context.Context) error {
+	if err := s.failInterrupted(ctx); err != nil {
+		return err
+	}
+
+	signups, err := s.Repo.ByStatus(ctx, volleynet.SignupScheduled)
+
+	if err != nil {
+		return errors.Wrap(err, "loading scheduled signups")
+	}
+
+	return s.process(ctx, signups)
+}
+
+// SignupTournament attempts the scheduled signups of a tournament.
+func (s *AutoSignup) SignupTournament(ctx context.Context, tournamentID int) error {
+	scheduled, err := s.Repo.ByStatus(ctx, volleynet.SignupScheduled)
+
+	if err != nil {
+		return errors.Wrap(err, "loading scheduled signups")
+	}
+
+	signups := []*volleynet.ScheduledSignup{}
+
+	for _, signup := range scheduled {
+		if signup.TournamentID == tournamentID {
+			signups = append(signups, signup)
+		}
+	}
+
+	return s.process(ctx, signups)
+}
+
+// Listen attempts the scheduled signups of a tournament as soon as its
+// registration opens until unsubscribed, failures are passed to `onError`.
+func (s *AutoSignup) Listen(subscriber events.Subscriber, onError func(events.Event, error)) events.Unsubscribe {
+	subscription, unsubscribe := subscriber.Subscribe(sync.RegistrationOpenEventType)
+
+	go func() {
+		for event := range subscription {
+			body, ok := event.Body.(sync.TournamentEvent)
+
+			if !ok {
+				onError(event, errors.Errorf("unexpected event body %T", event.Body))
+				continue
+			}
+
+			// signing up takes a while, don't block the sync
+			go func(event events.Event, tournamentID int) {
+				if err := s.SignupTournament(context.Background(), tournamentID); err != nil {
+					onError(event, err)
+				}
+			}(event, body.Tournament.ID)
+		}
+	}()
+
+	return unsubscribe
+}
+
+// process attempts or expires the signups, a failed signup is not an error
+// but failing to load or persist it is.
+func (s *AutoSignup) process(ctx context.Context, signups []*volleynet.ScheduledSignup) error {
+	failed := 0
+	var lastErr error
+
+	for _, signup := range signups {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := s.attempt(ctx, signup); err != nil {
+			failed++
+			lastErr = err
+		}
+	}
+
+	if failed > 0 {
+		return errors.Wrapf(lastErr, "%d of %d signups could not be processed", failed, len(signups))
+	}
+
+	return nil
+}
+
+// attempt signs up the players of a scheduled signup if the registration
+// of its tournament is open.
+func (s *AutoSignup) attempt(ctx context.Context, signup *volleynet.ScheduledSignup) error {
+	tournament, err := s.TournamentRepo.Get(ctx, signup.TournamentID)
+
+	if err != nil {
+		return errors.Wrap(err, "loading tournament")
+	}
+
+	switch {
+	case !s.now.time().Before(signup.ExpiresAt):
+		_, err = s.finish(ctx, signup, tournament, volleynet.SignupExpired,
+			"the registration has not opened before the credentials expired")
+		return err
+	case tournament.Status != volleynet.StatusUpcoming:
+		_, err = s.finish(ctx, signup, tournament, volleynet.SignupExpired, "the tournament is not upcoming anymore")
+		return err
+	case !tournament.RegistrationOpen:
+		return nil
+	}
+
+	claimed, err := s.Repo.SetStatus(ctx, signup.ID, volleynet.SignupScheduled, volleynet.SignupRunning)
+
+	if err != nil || !claimed {
+		// it has been canceled or another instance is signing up
+		return errors.Wrap(err, "claiming signup")
+	}
+
+	signup.Status = volleynet.SignupRunning
+
+	if message := s.enter(signup); message != "" {
+		_, err = s.finish(ctx, signup, tournament, volleynet.SignupFailed, message)
+	} else {
+		_, err = s.finish(ctx, signup, tournament, volleynet.SignupSucceeded, "")
+	}
+
+	return err
+}
+
+// enter logs in with the credentials of the signup and enters the players
+// at the tournament, the reason is returned if the signup has failed.
+func (s *AutoSignup) enter(signup *volleynet.ScheduledSignup) string {
+	creds, err := s.decrypt(signup)
+
+	if err != nil {
+		return "the stored credentials could not be decrypted"
+	}
+
+	vnClient := s.client()
+
+	if _, err := vnClient.Login(creds.Username, creds.Password); err != nil {
+		return "the volleynet login failed: " + err.Error()
+	}
+
+	err = vnClient.EnterTournament(signup.PartnerName, signup.PartnerID, signup.TournamentID)
+
+	if err != nil {
+		return "the volleynet signup failed: " + err.Error()
+	}
+
+	return ""
+}
+
+// failInterrupted fails the signups that have been running for too long,
+// e.g. because the instance has been stopped during the signup.
+func (s *AutoSignup) failInterrupted(ctx context.Context) error {
+	running, err := s.Repo.ByStatus(ctx, volleynet.SignupRunning)
+
+	if err != nil {
+		return errors.Wrap(err, "loading running signups")
+	}
+
+	for _, signup := range running {
+		if signup.UpdatedAt != nil && s.now.time().Sub(*signup.UpdatedAt) < staleSignup {
+			continue
+		}
+
+		tournament, err := s.TournamentRepo.Get(ctx, signup.TournamentID)
+
+		if err != nil {
+			return errors.Wrap(err, "loading tournament")
+		}
+
+		_, err = s.finish(ctx, signup, tournament, volleynet.SignupFailed,
+			"the signup has been interrupted, check the registration on volleynet")
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// finish changes the status of a signup, deletes its credentials (in the
+// same write, so they never outlive a finished signup) and publishes its
+// outcome. False is returned if the signup's status has
+// been changed in the meantime.
+func (s *AutoSignup) finish(
+	ctx context.Context,
+	signup *volleynet.ScheduledSignup,
+	tournament *volleynet.Tournament,
+	status, message string) (bool, error) {
+
+	finished := *signup
+	finished.Status = status
+	finished.Message = message
+
+	if status == volleynet.SignupSucceeded || status == volleynet.SignupFailed {
+		now := s.now.time()
+		finished.AttemptedAt = &now
+	}
+
+	changed, err := s.Repo.Finish(ctx, &finished, signup.Status)
+
+	if err != nil || !changed {
+		return false, errors.Wrap(err, "finishing signup")
+	}
+
+	*signup = finished
+
+	switch status {
+	case volleynet.SignupSucceeded:
+		s.publish(SignupSucceededEventType, signup, tournament)
+	case volleynet.SignupFailed, volleynet.SignupExpired:
+		s.publish(SignupFailedEventType, signup, tournament)
+	}
+
+	return true, nil
+}
+
+func (s *AutoSignup) publish(name string, signup *volleynet.ScheduledSignup, tournament *volleynet.Tournament) {
+	if s.Events == nil {
+		return
+	}
+
+	su := *signup
+
+	s.Events.Publish(events.Event{
+		Name: name,
+		Body: SignupEvent{
+			ID:         uuid.New().String(),
+			Timestamp:  s.now.time(),
+			Signup:     &su,
+			Tournament: tournament,
+		},
+	})
+}
+
+// tournament loads an upcoming tournament with its teams.
+func (s *AutoSignup) tournament(ctx context.Context, tournamentID int) (*volleynet.Tournament, error) {
+	tournament, err := s.TournamentRepo.Get(ctx, tournamentID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading tournament")
+	}
+
+	if tournament.Status != volleynet.StatusUpcoming {
+		return nil, errors.Wrap(scores.ErrorValidation, "the tournament is not upcoming")
+	}
+
+	tournament.Teams, err = s.TeamRepo.ByTournament(ctx, tournamentID)
+
+	return tournament, errors.Wrap(err, "loading teams")
+}
+
+// encrypt encrypts the credentials for the user, they can't be
+// decrypted for a signup of another user.
+func (s *AutoSignup) encrypt(userID int, creds *credentials) (string, error) {
+	plaintext, err := json.Marshal(creds)
+
+	if err != nil {
+		return "", errors.Wrap(err, "encoding credentials")
+	}
+
+	ciphertext, err := s.Cipher.Encrypt(credentialsPurpose+":"+strconv.Itoa(userID), plaintext)
+
+	return ciphertext, errors.Wrap(err, "encrypting credentials")
+}
+
+func (s *AutoSignup) decrypt(signup *volleynet.ScheduledSignup) (*credentials, error) {
+	plaintext, err := s.Cipher.Decrypt(credentialsPurpose+":"+strconv.Itoa(signup.UserID), signup.Credentials)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting credentials")
+	}
+
+	creds := &credentials{}
+	err = json.Unmarshal(plaintext, creds)
+
+	return creds, errors.Wrap(err, "decoding credentials")
+}
+
+func (s *AutoSignup) client() client.Client {
+	if s.NewClient != nil {
+		return s.NewClient()
+	}
+
+	return client.DefaultClient()
+}
+
+func (s *AutoSignup) credentialTTL() time.Duration {
+	if s.CredentialTTL > 0 {
+		return s.CredentialTTL
+	}
+
+	return DefaultCredentialTTL
+}
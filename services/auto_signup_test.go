@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/events"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/client"
+	"github.com/raphi011/scores/volleynet/mocks"
+	"github.com/raphi011/scores/volleynet/scrape"
+)
+
+type publisherMock struct {
+	events []events.Event
+}
+
+func (p *publisherMock) Publish(event events.Event) {
+	p.events = append(p.events, event)
+}
+
+func newAutoSignup(t *testing.T) (*AutoSignup, *repo.Repositories, *mocks.ClientMock, *publisherMock) {
+	t.Helper()
+
+	start := time.Now().Add(24 * time.Hour)
+
+	repos := seed(t, fixture{
+		players: []*volleynet.Player{
+			{ID: 1, Gender: "M", FirstName: "Richard", LastName: "Roe"},
+			{ID: 2, Gender: "M", FirstName: "John", LastName: "Doe"},
+			{ID: 3, Gender: "W"},
+		},
+		tournaments: []*volleynet.Tournament{
+			{TournamentInfo: volleynet.TournamentInfo{ID: 1, Name: "Wien", Gender: "M", Start: start, Status: volleynet.StatusUpcoming}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 2, Name: "Graz", Gender: "M", Start: start, Status: volleynet.StatusUpcoming, RegistrationOpen: true}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 3, Name: "Linz", Gender: "M", Start: start, Status: volleynet.StatusUpcoming}},
+		},
+	})
+
+	clientMock := &mocks.ClientMock{}
+	publisher := &publisherMock{}
+
+	return &AutoSignup{
+		Repo:           repos.SignupRepo,
+		TournamentRepo: repos.TournamentRepo,
+		TeamRepo:       repos.TeamRepo,
+		PlayerRepo:     repos.PlayerRepo,
+		Cipher:         &Cipher{Secret: []byte("secret")},
+		Events:         publisher,
+		NewClient:      func() client.Client { return clientMock },
+	}, repos, clientMock, publisher
+}
+
+func signupRequest(tournamentID int) SignupRequest {
+	return SignupRequest{
+		TournamentID: tournamentID,
+		PartnerID:    2,
+		Username:     "richard",
+		Password:     "password",
+	}
+}
+
+func TestAutoSignupSchedule(t *testing.T) {
+	ctx := context.Background()
+	service, _, clientMock, _ := newAutoSignup(t)
+
+	clientMock.On("Login", "richard", "password").Return(&scrape.LoginData{PlayerInfo: scrape.PlayerInfo{ID: 1}}, nil)
+	clientMock.On("Login", "richard", "wrong").Return(&scrape.LoginData{}, client.ErrInvalidCredentials)
+	clientMock.On("Login", "richard", "offline").Return(&scrape.LoginData{}, errors.New("connection refused"))
+
+	signup, err := service.Schedule(ctx, 1, signupRequest(1))
+
+	if err != nil {
+		t.Fatalf("AutoSignup.Schedule() failed: %v", err)
+	}
+
+	if signup.Status != volleynet.SignupScheduled || signup.PlayerID != 1 || signup.PartnerName != "John Doe" {
+		t.Errorf("AutoSignup.Schedule(), want a scheduled signup with John Doe, got %+v", signup)
+	}
+
+	if signup.Credentials == "" || strings.Contains(signup.Credentials, "password") {
+		t.Errorf("AutoSignup.Schedule(), want encrypted credentials, got %q", signup.Credentials)
+	}
+
+	tournament, _ := service.TournamentRepo.Get(ctx, 1)
+
+	if !signup.ExpiresAt.Equal(tournament.Start) {
+		t.Errorf("AutoSignup.Schedule(), want the credentials to expire at the start, got %v", signup.ExpiresAt)
+	}
+
+	wrongLogin := signupRequest(3)
+	wrongLogin.Password = "wrong"
+	female := signupRequest(3)
+	female.PartnerID = 3
+
+	tests := []struct {
+		name    string
+		request SignupRequest
+	}{
+		{"duplicate", signupRequest(1)},
+		{"registration open", signupRequest(2)},
+		{"wrong login", wrongLogin},
+		{"female partner", female},
+		{"no credentials", SignupRequest{TournamentID: 3, PartnerID: 2}},
+	}
+
+	for _, tt := range tests {
+		if _, err := service.Schedule(ctx, 1, tt.request); errors.Cause(err) != scores.ErrorValidation {
+			t.Errorf("AutoSignup.Schedule() %s, want a validation error, got: %v", tt.name, err)
+		}
+	}
+
+	offline := signupRequest(3)
+	offline.Password = "offline"
+
+	if _, err := service.Schedule(ctx, 1, offline); err == nil || errors.Cause(err) == scores.ErrorValidation {
+		t.Errorf("AutoSignup.Schedule() while volleynet is down, want the login error, got: %v", err)
+	}
+
+	if _, err := service.Schedule(ctx, 1, signupRequest(4)); errors.Cause(err) != scores.ErrNotFound {
+		t.Errorf("AutoSignup.Schedule() of a missing tournament, want ErrNotFound, got: %v", err)
+	}
+}
+
+func TestAutoSignupRun(t *testing.T) {
+	ctx := context.Background()
+	service, repos, clientMock, publisher := newAutoSignup(t)
+
+	clientMock.On("Login", "richard", "password").Return(&scrape.LoginData{PlayerInfo: scrape.PlayerInfo{ID: 1}}, nil)
+	clientMock.On("EnterTournament", "John Doe", 2, 1).Return(nil)
+
+	signup, err := service.Schedule(ctx, 1, signupRequest(1))
+
+	if err != nil {
+		t.Fatalf("AutoSignup.Schedule() failed: %v", err)
+	}
+
+	if err := service.Run(ctx); err != nil {
+		t.Fatalf("AutoSignup.Run() failed: %v", err)
+	}
+
+	clientMock.AssertNotCalled(t, "EnterTournament", "John Doe", 2, 1)
+
+	tournament, _ := repos.TournamentRepo.Get(ctx, 1)
+	tournament.RegistrationOpen = true
+
+	if err := repos.TournamentRepo.Update(ctx, tournament); err != nil {
+		t.Fatalf("tournamentRepo.Update() failed: %v", err)
+	}
+
+	if err := service.SignupTournament(ctx, 1); err != nil {
+		t.Fatalf("AutoSignup.SignupTournament() failed: %v", err)
+	}
+
+	clientMock.AssertCalled(t, "EnterTournament", "John Doe", 2, 1)
+
+	signup, _ = repos.SignupRepo.Get(ctx, signup.ID)
+
+	if signup.Status != volleynet.SignupSucceeded || signup.Credentials != "" || signup.AttemptedAt == nil {
+		t.Errorf("AutoSignup.SignupTournament(), want a succeeded signup without credentials, got %+v", signup)
+	}
+
+	if len(publisher.events) != 1 || publisher.events[0].Name != SignupSucceededEventType {
+		t.Errorf("AutoSignup.SignupTournament(), want a succeeded event, got %+v", publisher.events)
+	}
+}
+
+func TestAutoSignupFailed(t *testing.T) {
+	ctx := context.Background()
+	service, repos, clientMock, publisher := newAutoSignup(t)
+
+	clientMock.On("Login", "richard", "password").Return(&scrape.LoginData{PlayerInfo: scrape.PlayerInfo{ID: 1}}, nil)
+	clientMock.On("EnterTournament", "John Doe", 2, 1).Return(errors.New("the tournament is full"))
+
+	signup, err := service.Schedule(ctx, 1, signupRequest(1))
+
+	if err != nil {
+		t.Fatalf("AutoSignup.Schedule() failed: %v", err)
+	}
+
+	tournament, _ := repos.TournamentRepo.Get(ctx, 1)
+	tournament.RegistrationOpen = true
+
+	if err := repos.TournamentRepo.Update(ctx, tournament); err != nil {
+		t.Fatalf("tournamentRepo.Update() failed: %v", err)
+	}
+
+	if err := service.Run(ctx); err != nil {
+		t.Fatalf("AutoSignup.Run() failed: %v", err)
+	}
+
+	signup, _ = repos.SignupRepo.Get(ctx, signup.ID)
+
+	if signup.Status != volleynet.SignupFailed || !strings.Contains(signup.Message, "the tournament is full") ||
+		signup.Credentials != "" {
+		t.Errorf("AutoSignup.Run(), want a failed signup without credentials, got %+v", signup)
+	}
+
+	if len(publisher.events) != 1 || publisher.events[0].Name != SignupFailedEventType {
+		t.Errorf("AutoSignup.Run(), want a failed event, got %+v", publisher.events)
+	}
+}
+
+func TestAutoSignupExpired(t *testing.T) {
+	ctx := context.Background()
+	service, repos, clientMock, _ := newAutoSignup(t)
+
+	clientMock.On("Login", "richard", "password").Return(&scrape.LoginData{PlayerInfo: scrape.PlayerInfo{ID: 1}}, nil)
+
+	service.CredentialTTL = time.Hour
+	signup, err := service.Schedule(ctx, 1, signupRequest(1))
+
+	if err != nil {
+		t.Fatalf("AutoSignup.Schedule() failed: %v", err)
+	}
+
+	service.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	if err := service.Run(ctx); err != nil {
+		t.Fatalf("AutoSignup.Run() failed: %v", err)
+	}
+
+	signup, _ = repos.SignupRepo.Get(ctx, signup.ID)
+
+	if signup.Status != volleynet.SignupExpired || signup.Credentials != "" {
+		t.Errorf("AutoSignup.Run(), want an expired signup without credentials, got %+v", signup)
+	}
+}
+
+func TestAutoSignupCancel(t *testing.T) {
+	ctx := context.Background()
+	service, _, clientMock, _ := newAutoSignup(t)
+
+	clientMock.On("Login", "richard", "password").Return(&scrape.LoginData{PlayerInfo: scrape.PlayerInfo{ID: 1}}, nil)
+
+	signup, err := service.Schedule(ctx, 1, signupRequest(1))
+
+	if err != nil {
+		t.Fatalf("AutoSignup.Schedule() failed: %v", err)
+	}
+
+	if _, err := service.Cancel(ctx, 2, signup.ID); errors.Cause(err) != scores.ErrNotFound {
+		t.Errorf("AutoSignup.Cancel() of another user, want ErrNotFound, got: %v", err)
+	}
+
+	canceled, err := service.Cancel(ctx, 1, signup.ID)
+
+	if err != nil {
+		t.Fatalf("AutoSignup.Cancel() failed: %v", err)
+	}
+
+	if canceled.Status != volleynet.SignupCanceled || canceled.Credentials != "" {
+		t.Errorf("AutoSignup.Cancel(), want a canceled signup without credentials, got %+v", canceled)
+	}
+
+	if _, err := service.Cancel(ctx, 1, signup.ID); errors.Cause(err) != scores.ErrorValidation {
+		t.Errorf("AutoSignup.Cancel() of a canceled signup, want a validation error, got: %v", err)
+	}
+}
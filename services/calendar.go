@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/ical"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+const (
+	// CalendarPurpose is the purpose of the calendar feed tokens.
+	CalendarPurpose = "calendar"
+	// CalendarKeySettingKey is the setting that stores the user's calendar key,
+	// changing it invalidates the previous feed tokens.
+	CalendarKeySettingKey = "calendar-key"
+
+	// calendarHistory is how long finished tournaments stay in the calendar.
+	calendarHistory = 365 * 24 * time.Hour
+)
+
+// States of a player's entry in the calendar.
+const (
+	EntryRegistered    = "registered"
+	EntryQualification = "qualification"
+	EntryWaitingList   = "waiting list"
+	EntryPlayed        = "played"
+	EntryCanceled      = "canceled"
+	EntryWatched       = "watched" // the tournament is on the watchlist of the user
+)
+
+// Calendar builds the tournament calendar of users.
+type Calendar struct {
+	User           *User
+	Signer         *Signer
+	TournamentRepo repo.TournamentRepository
+	TeamRepo       repo.TeamRepository
+	PlayerRepo     repo.PlayerRepository
+	WatchRepo      repo.WatchRepository
+
+	now clock
+}
+
+// Token returns the feed token of a user, it stays valid until it's rotated.
+func (s *Calendar) Token(ctx context.Context, userID int) (string, error) {
+	user, err := s.User.ByID(ctx, userID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if key, ok := user.Settings[CalendarKeySettingKey].(string); ok && key != "" {
+		return s.token(key, userID), nil
+	}
+
+	return s.Rotate(ctx, userID)
+}
+
+// Rotate creates a new feed token of a user and invalidates the previous one.
+func (s *Calendar) Rotate(ctx context.Context, userID int) (string, error) {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "creating calendar key")
+	}
+
+	key := base64.RawURLEncoding.EncodeToString(b)
+
+	err := s.User.UpdateSettings(ctx, userID,
+		&scores.Setting{UserID: userID, Key: CalendarKeySettingKey, Type: "string", Value: key})
+
+	if err != nil {
+		return "", err
+	}
+
+	return s.token(key, userID), nil
+}
+
+// Verify returns true if `token` is the current feed token of the user.
+func (s *Calendar) Verify(ctx context.Context, userID int, token string) bool {
+	user, err := s.User.ByID(ctx, userID)
+
+	if err != nil {
+		return false
+	}
+
+	key, ok := user.Settings[CalendarKeySettingKey].(string)
+
+	return ok && key != "" && s.Signer.Verify(CalendarPurpose+":"+key, userID, token)
+}
+
+func (s *Calendar) token(key string, userID int) string {
+	return s.Signer.Token(CalendarPurpose+":"+key, userID)
+}
+
+// Calendar returns the tournaments of the user's player and the watched
+// tournaments of the user that are upcoming or have been played within the
+// last year. Every tournament is an event, the registration deadline of
+// upcoming tournaments is a separate event.
+func (s *Calendar) Calendar(ctx context.Context, userID int) (*ical.Calendar, error) {
+	user, err := s.User.ByID(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &ical.Calendar{Name: "Beach volleyball tournaments", Events: []*ical.Event{}}
+	now := s.now.time()
+	entered := map[int]bool{}
+
+	if user.PlayerID > 0 {
+		history, err := s.PlayerRepo.History(ctx, user.PlayerID, repo.PlayerHistoryFilter{})
+
+		if err != nil {
+			return nil, errors.Wrap(err, "loading tournaments")
+		}
+
+		for _, entry := range history {
+			if entry.Deregistered || entry.Tournament.Start.Before(now.Add(-calendarHistory)) {
+				continue
+			}
+
+			tournament, err := s.TournamentRepo.Get(ctx, entry.Tournament.ID)
+
+			if err != nil {
+				return nil, errors.Wrap(err, "loading tournament")
+			}
+
+			status, err := s.entryStatus(ctx, tournament, user.PlayerID)
+
+			if err != nil {
+				return nil, err
+			}
+
+			entered[tournament.ID] = true
+			calendar.Events = append(calendar.Events, tournamentEvents(tournament, entry.Partner, status, now)...)
+		}
+	}
+
+	watches, err := s.WatchRepo.Tournaments(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading watchlist")
+	}
+
+	for _, w := range watches {
+		if entered[w.TournamentID] {
+			continue
+		}
+
+		tournament, err := s.TournamentRepo.Get(ctx, w.TournamentID)
+
+		if errors.Cause(err) == scores.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "loading tournament")
+		}
+
+		if tournament.Start.Before(now.Add(-calendarHistory)) {
+			continue
+		}
+
+		calendar.Events = append(calendar.Events, tournamentEvents(tournament, nil, EntryWatched, now)...)
+	}
+
+	return calendar, nil
+}
+
+// entryStatus returns the status of the player's entry in a tournament,
+// the status of entries in upcoming tournaments is predicted by the cut.
+func (s *Calendar) entryStatus(ctx context.Context, tournament *volleynet.Tournament, playerID int) (string, error) {
+	switch tournament.Status {
+	case volleynet.StatusDone:
+		return EntryPlayed, nil
+	case volleynet.StatusCanceled:
+		return EntryCanceled, nil
+	}
+
+	teams, err := s.TeamRepo.ByTournament(ctx, tournament.ID)
+
+	if err != nil {
+		return "", errors.Wrap(err, "loading teams")
+	}
+
+	tournament.Teams = teams
+	PredictCut(tournament)
+
+	for _, t := range teams {
+		if t.Projection == nil || !t.HasPlayer(playerID) {
+			continue
+		}
+
+		switch t.Projection.Status {
+		case volleynet.ProjectionQualification:
+			return EntryQualification, nil
+		case volleynet.ProjectionWaitingList:
+			return EntryWaitingList, nil
+		}
+	}
+
+	return EntryRegistered, nil
+}
+
+// tournamentEvents returns the events of a tournament in the calendar.
+func tournamentEvents(t *volleynet.Tournament, partner *volleynet.Player, status string, now time.Time) []*ical.Event {
+	description := []string{"Status: " + status}
+
+	if partner != nil {
+		description = append(description, "Partner: "+strings.TrimSpace(partner.FirstName+" "+partner.LastName))
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"Organiser", t.Organiser},
+		{"Phone", t.Phone},
+		{"Email", t.Email},
+		{"Website", t.Website},
+	} {
+		if field.value != "" {
+			description = append(description, field.name+": "+field.value)
+		}
+	}
+
+	end := t.End
+
+	if !end.After(t.Start) {
+		end = time.Time{}
+	}
+
+	events := []*ical.Event{{
+		UID:         fmt.Sprintf("tournament-%d@scores", t.ID),
+		Stamp:       now,
+		Start:       t.Start,
+		End:         end,
+		AllDay:      true,
+		Summary:     fmt.Sprintf("%s (%s)", t.Name, status),
+		Description: strings.Join(description, "\n"),
+		Location:    t.Location,
+		URL:         t.Link,
+		Canceled:    t.Status == volleynet.StatusCanceled,
+		Latitude:    t.Latitude,
+		Longitude:   t.Longitude,
+	}}
+
+	if t.EndRegistration != nil && t.Status == volleynet.StatusUpcoming {
+		events = append(events, &ical.Event{
+			UID:     fmt.Sprintf("tournament-%d-registration@scores", t.ID),
+			Stamp:   now,
+			Start:   *t.EndRegistration,
+			Summary: "Registration deadline: " + t.Name,
+			URL:     t.Link,
+		})
+	}
+
+	return events
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/volleynet"
+)
+
+func TestCalendar(t *testing.T) {
+	ctx := context.Background()
+	players := []*volleynet.Player{
+		{ID: 1, Gender: "M"},
+		{ID: 2, Gender: "M", FirstName: "Dominik", LastName: "Rieder"},
+		{ID: 3, Gender: "M"},
+		{ID: 4, Gender: "M"},
+	}
+
+	user := &scores.User{Email: "test@example.com", PlayerID: 1}
+	deadline := time.Date(2019, 6, 20, 0, 0, 0, 0, time.UTC)
+
+	repos := seed(t, fixture{
+		players: players,
+		users:   []*scores.User{user},
+		tournaments: []*volleynet.Tournament{
+			{TournamentInfo: volleynet.TournamentInfo{ID: 1, Name: "Wien", Status: volleynet.StatusDone,
+				Start: time.Date(2019, 5, 1, 9, 0, 0, 0, time.UTC), End: time.Date(2019, 5, 1, 18, 0, 0, 0, time.UTC)},
+				Organiser: "Richard"},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 2, Name: "Graz", Status: volleynet.StatusUpcoming,
+				Start: time.Date(2019, 7, 1, 9, 0, 0, 0, time.UTC)}, EndRegistration: &deadline, MaxTeams: 1},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 3, Name: "Linz", Status: volleynet.StatusDone,
+				Start: time.Date(2017, 7, 1, 9, 0, 0, 0, time.UTC)}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 4, Name: "Salzburg", Status: volleynet.StatusUpcoming,
+				Start: time.Date(2019, 8, 1, 9, 0, 0, 0, time.UTC)}},
+		},
+		teams: []*volleynet.TournamentTeam{
+			{TournamentID: 1, Player1: players[0], Player2: players[1], Result: 1},
+			{TournamentID: 2, Player1: players[0], Player2: players[1], TotalPoints: 50},
+			{TournamentID: 2, Player1: players[2], Player2: players[3], TotalPoints: 100},
+			{TournamentID: 3, Player1: players[0], Player2: players[1], Result: 1},
+			{TournamentID: 4, Player1: players[0], Player2: players[1], Deregistered: true},
+		},
+	})
+
+	// the user is already registered for 1, 3 is too old
+	for _, tournamentID := range []int{1, 3, 4} {
+		_, err := repos.WatchRepo.WatchTournament(ctx, &volleynet.WatchedTournament{UserID: user.ID, TournamentID: tournamentID})
+
+		if err != nil {
+			t.Fatalf("watchRepo.WatchTournament() failed: %v", err)
+		}
+	}
+
+	service := &Calendar{
+		User:           &User{Repo: repos.UserRepo, SettingRepo: repos.SettingRepo},
+		TournamentRepo: repos.TournamentRepo,
+		TeamRepo:       repos.TeamRepo,
+		PlayerRepo:     repos.PlayerRepo,
+		WatchRepo:      repos.WatchRepo,
+		now: func() time.Time {
+			return time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+		},
+	}
+
+	calendar, err := service.Calendar(ctx, user.ID)
+
+	if err != nil {
+		t.Fatalf("Calendar.Calendar() failed: %v", err)
+	}
+
+	want := []struct{ uid, summary string }{
+		{"tournament-2@scores", "Graz (waiting list)"},
+		{"tournament-2-registration@scores", "Registration deadline: Graz"},
+		{"tournament-1@scores", "Wien (played)"},
+		{"tournament-4@scores", "Salzburg (watched)"},
+	}
+
+	if len(calendar.Events) != len(want) {
+		t.Fatalf("Calendar.Calendar(), want %d events, got %d", len(want), len(calendar.Events))
+	}
+
+	for i, w := range want {
+		e := calendar.Events[i]
+
+		if e.UID != w.uid || e.Summary != w.summary {
+			t.Errorf("Calendar.Calendar(), event %d: want %q (%q), got %q (%q)", i, w.summary, w.uid, e.Summary, e.UID)
+		}
+	}
+
+	if !calendar.Events[1].Start.Equal(deadline) {
+		t.Errorf("Calendar.Calendar(), want the registration deadline at %v, got %v", deadline, calendar.Events[1].Start)
+	}
+
+	if want := "Status: played\nPartner: Dominik Rieder\nOrganiser: Richard"; calendar.Events[2].Description != want {
+		t.Errorf("Calendar.Calendar(), want description %q, got %q", want, calendar.Events[2].Description)
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Cipher encrypts secrets that have to be stored for a while (e.g. the
+// volleynet credentials of a scheduled signup) with AES-GCM. Every purpose
+// uses its own key derived from `Secret`, a ciphertext can only be
+// decrypted with the purpose it has been encrypted for.
+type Cipher struct {
+	Secret []byte
+}
+
+// Encrypt encrypts and authenticates `plaintext` for `purpose`.
+func (c *Cipher) Encrypt(purpose string, plaintext []byte) (string, error) {
+	aead, err := c.aead(purpose)
+
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "generating nonce")
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(purpose))
+
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a ciphertext of `Encrypt`, an error is returned if it
+// has been modified or encrypted with another secret or purpose.
+func (c *Cipher) Decrypt(purpose, ciphertext string) ([]byte, error) {
+	aead, err := c.aead(purpose)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(ciphertext)
+
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, errors.New("malformed ciphertext")
+	}
+
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(purpose))
+
+	return plaintext, errors.Wrap(err, "decrypting")
+}
+
+// aead derives the key of `purpose` from the secret.
+func (c *Cipher) aead(purpose string) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, c.Secret)
+	mac.Write([]byte("cipher:" + purpose))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+
+	if err != nil {
+		return nil, errors.Wrap(err, "creating cipher")
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,31 @@
+package services
+
+import "testing"
+
+func TestCipherDecrypt(t *testing.T) {
+	c := &Cipher{Secret: []byte("secret")}
+
+	ciphertext, err := c.Encrypt("signup:1", []byte("password"))
+
+	if err != nil {
+		t.Fatalf("Cipher.Encrypt() failed: %v", err)
+	}
+
+	if plaintext, err := c.Decrypt("signup:1", ciphertext); err != nil || string(plaintext) != "password" {
+		t.Errorf("Cipher.Decrypt(), want \"password\", got %q (%v)", plaintext, err)
+	}
+
+	if _, err := c.Decrypt("signup:2", ciphertext); err == nil {
+		t.Error("Cipher.Decrypt() with a different purpose, want an error, got nil")
+	}
+
+	other := &Cipher{Secret: []byte("other")}
+
+	if _, err := other.Decrypt("signup:1", ciphertext); err == nil {
+		t.Error("Cipher.Decrypt() with a different secret, want an error, got nil")
+	}
+
+	if _, err := c.Decrypt("signup:1", ciphertext[:10]); err == nil {
+		t.Error("Cipher.Decrypt() of a truncated ciphertext, want an error, got nil")
+	}
+}
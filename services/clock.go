@@ -0,0 +1,16 @@
+package services
+
+import "time"
+
+// clock returns the current time, services that depend on the time have
+// one so tests can replace it.
+type clock func() time.Time
+
+// time returns the current time, `time.Now()` if the clock is not set.
+func (c clock) time() time.Time {
+	if c != nil {
+		return c()
+	}
+
+	return time.Now()
+}
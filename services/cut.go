@@ -0,0 +1,130 @@
+package services
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/raphi011/scores/volleynet"
+)
+
+// qualificationPattern matches the size of the qualification in the mode
+// of a tournament, e.g. "Double Elimination 16er-Raster, 8er-Qualifikation".
+var qualificationPattern = regexp.MustCompile(`(?i)(\d+)er[- ]?quali`)
+
+// PredictCut ranks the registered teams of an upcoming tournament by their
+// seed, unseeded teams by their points after the seeded teams, and sets
+// the projected status of every team. Deregistered teams are left out.
+func PredictCut(tournament *volleynet.Tournament) {
+	tournament.Cut = nil
+
+	if tournament.Status != volleynet.StatusUpcoming || tournament.MaxTeams <= 0 {
+		return
+	}
+
+	teams := []*volleynet.TournamentTeam{}
+
+	for _, t := range tournament.Teams {
+		t.Projection = nil
+
+		if !t.Deregistered {
+			teams = append(teams, t)
+		}
+	}
+
+	sort.SliceStable(teams, func(i, j int) bool {
+		a, b := teams[i], teams[j]
+
+		if (a.Seed > 0) != (b.Seed > 0) {
+			return a.Seed > 0
+		}
+
+		if a.Seed != b.Seed {
+			return a.Seed < b.Seed
+		}
+
+		return a.TotalPoints > b.TotalPoints
+	})
+
+	cut := &volleynet.CutPrediction{
+		MainDrawTeams:      tournament.MaxTeams,
+		QualificationTeams: qualificationTeams(tournament.Mode),
+		RegisteredTeams:    len(teams),
+	}
+
+	mainDraw := cut.MainDrawTeams
+
+	if mainDraw > len(teams) {
+		mainDraw = len(teams)
+	}
+
+	if mainDraw > 0 {
+		cut.CutPoints = cutPoints(teams[:mainDraw])
+	}
+
+	for i, t := range teams {
+		rank := i + 1
+		projection := &volleynet.TeamProjection{Rank: rank}
+
+		switch {
+		case rank <= cut.MainDrawTeams:
+			projection.Status = volleynet.ProjectionMainDraw
+
+			// seeded teams can be in the main draw with less points
+			if len(teams) > cut.MainDrawTeams && t.TotalPoints > teams[cut.MainDrawTeams].TotalPoints {
+				projection.PointsToCut = t.TotalPoints - teams[cut.MainDrawTeams].TotalPoints
+			}
+		case rank <= cut.MainDrawTeams+cut.QualificationTeams:
+			projection.Status = volleynet.ProjectionQualification
+		default:
+			projection.Status = volleynet.ProjectionWaitingList
+		}
+
+		if rank > cut.MainDrawTeams {
+			projection.Withdrawals = rank - cut.MainDrawTeams
+
+			// if only seeded teams are in the main draw a team outside of
+			// it can have more points than the cut but still not get in
+			if missing := t.TotalPoints - cut.CutPoints; missing < 0 {
+				projection.PointsToCut = missing
+			}
+		}
+
+		t.Projection = projection
+	}
+
+	tournament.Cut = cut
+}
+
+// cutPoints returns the points of the weakest unseeded team in the main
+// draw, seeded teams get in regardless of their points. If there is no
+// unseeded team the points of the weakest seeded team are returned.
+func cutPoints(mainDraw []*volleynet.TournamentTeam) int {
+	weakest := mainDraw[len(mainDraw)-1]
+
+	for i := len(mainDraw) - 1; i >= 0; i-- {
+		if mainDraw[i].Seed == 0 {
+			return mainDraw[i].TotalPoints
+		}
+
+		if mainDraw[i].TotalPoints < weakest.TotalPoints {
+			weakest = mainDraw[i]
+		}
+	}
+
+	return weakest.TotalPoints
+}
+
+// qualificationTeams returns the size of the qualification of a tournament,
+// 0 if the mode doesn't mention a qualification.
+func qualificationTeams(mode string) int {
+	match := qualificationPattern.FindStringSubmatch(mode)
+
+	if match == nil {
+		return 0
+	}
+
+	teams, _ := strconv.Atoi(match[1])
+
+	return teams
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/raphi011/scores/volleynet"
+)
+
+func TestPredictCut(t *testing.T) {
+	teams := []*volleynet.TournamentTeam{
+		{TotalPoints: 100},
+		{TotalPoints: 300},
+		{TotalPoints: 500, Deregistered: true},
+		{TotalPoints: 200},
+		{TotalPoints: 50},
+		{TotalPoints: 150},
+		{TotalPoints: 20, Seed: 1}, // e.g. a wildcard
+	}
+
+	tournament := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{Status: volleynet.StatusUpcoming},
+		Mode:           "Double Elimination 3er-Raster, 2er-Qualifikation",
+		MaxTeams:       3,
+		Teams:          teams,
+	}
+
+	PredictCut(tournament)
+
+	wantCut := volleynet.CutPrediction{MainDrawTeams: 3, QualificationTeams: 2, RegisteredTeams: 6, CutPoints: 200}
+
+	if tournament.Cut == nil || *tournament.Cut != wantCut {
+		t.Fatalf("PredictCut(), want cut %+v, got %+v", wantCut, tournament.Cut)
+	}
+
+	want := []*volleynet.TeamProjection{
+		{Rank: 5, Status: volleynet.ProjectionQualification, PointsToCut: -100, Withdrawals: 2},
+		{Rank: 2, Status: volleynet.ProjectionMainDraw, PointsToCut: 150},
+		nil,
+		{Rank: 3, Status: volleynet.ProjectionMainDraw, PointsToCut: 50},
+		{Rank: 6, Status: volleynet.ProjectionWaitingList, PointsToCut: -150, Withdrawals: 3},
+		{Rank: 4, Status: volleynet.ProjectionQualification, PointsToCut: -50, Withdrawals: 1},
+		{Rank: 1, Status: volleynet.ProjectionMainDraw, PointsToCut: 0},
+	}
+
+	for i, team := range teams {
+		if (want[i] == nil) != (team.Projection == nil) || (want[i] != nil && *want[i] != *team.Projection) {
+			t.Errorf("PredictCut(), team %d: want projection %+v, got %+v", i, want[i], team.Projection)
+		}
+	}
+}
+
+func TestPredictCutAllTeamsIn(t *testing.T) {
+	tournament := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{Status: volleynet.StatusUpcoming},
+		MaxTeams:       16,
+		Teams:          []*volleynet.TournamentTeam{{TotalPoints: 100}, {TotalPoints: 80}},
+	}
+
+	PredictCut(tournament)
+
+	if tournament.Cut.CutPoints != 80 || tournament.Cut.QualificationTeams != 0 {
+		t.Fatalf("PredictCut(), want the last team to set the cut, got %+v", tournament.Cut)
+	}
+
+	for _, team := range tournament.Teams {
+		if team.Projection.Status != volleynet.ProjectionMainDraw || team.Projection.PointsToCut != 0 {
+			t.Errorf("PredictCut(), want all teams in the main draw, got %+v", team.Projection)
+		}
+	}
+}
+
+func TestPredictCutDoneTournament(t *testing.T) {
+	tournament := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{Status: volleynet.StatusDone},
+		MaxTeams:       16,
+		Teams:          []*volleynet.TournamentTeam{{TotalPoints: 100}},
+	}
+
+	PredictCut(tournament)
+
+	if tournament.Cut != nil || tournament.Teams[0].Projection != nil {
+		t.Fatalf("PredictCut() of a finished tournament should not predict anything")
+	}
+}
+
+func TestPredictCutSeededLastInMainDraw(t *testing.T) {
+	teams := []*volleynet.TournamentTeam{
+		{TotalPoints: 300},
+		{TotalPoints: 400, Seed: 1},
+		{TotalPoints: 20, Seed: 2},
+	}
+
+	tournament := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{Status: volleynet.StatusUpcoming},
+		MaxTeams:       2,
+		Teams:          teams,
+	}
+
+	PredictCut(tournament)
+
+	if tournament.Cut.CutPoints != 20 {
+		t.Fatalf("PredictCut(), want the weakest seeded team to set the cut, got %+v", tournament.Cut)
+	}
+
+	want := volleynet.TeamProjection{Rank: 3, Status: volleynet.ProjectionWaitingList, PointsToCut: 0, Withdrawals: 1}
+
+	if *teams[0].Projection != want {
+		t.Errorf("PredictCut(), want projection %+v, got %+v", want, teams[0].Projection)
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/memory"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// fixture is the data the repositories of a service test are seeded with.
+type fixture struct {
+	players     []*volleynet.Player
+	users       []*scores.User // their ids are set when they are created
+	tournaments []*volleynet.Tournament
+	teams       []*volleynet.TournamentTeam
+}
+
+// seed returns in memory repositories that contain the fixture.
+func seed(t *testing.T, f fixture) *repo.Repositories {
+	t.Helper()
+
+	ctx := context.Background()
+	repos := memory.Repositories()
+
+	for _, p := range f.players {
+		if _, err := repos.PlayerRepo.New(ctx, p); err != nil {
+			t.Fatalf("playerRepo.New() failed: %v", err)
+		}
+	}
+
+	for _, u := range f.users {
+		if _, err := repos.UserRepo.New(ctx, u); err != nil {
+			t.Fatalf("userRepo.New() failed: %v", err)
+		}
+	}
+
+	for _, tournament := range f.tournaments {
+		if _, err := repos.TournamentRepo.New(ctx, tournament); err != nil {
+			t.Fatalf("tournamentRepo.New() failed: %v", err)
+		}
+	}
+
+	if len(f.teams) > 0 {
+		if err := repos.TeamRepo.NewBatch(ctx, f.teams...); err != nil {
+			t.Fatalf("teamRepo.NewBatch() failed: %v", err)
+		}
+	}
+
+	return repos
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/job"
+	"github.com/raphi011/scores/repo"
+)
+
+// maxPageSize limits the # of entries that can be loaded at once.
+const maxPageSize = 200
+
+// JobHistory persists the runs of all jobs for a certain retention period.
+type JobHistory struct {
+	Repo      repo.JobExecutionRepository
+	Retention time.Duration // executions older than this are removed by `Cleanup`
+}
+
+var _ job.History = &JobHistory{}
+
+// Record persists a job run.
+func (s *JobHistory) Record(record job.Record) error {
+	execution := &scores.JobExecution{
+		JobName: record.JobName,
+		Start:   record.Start,
+		End:     record.End,
+		Success: record.Error == nil,
+		Summary: record.Summary,
+	}
+
+	if record.Error != nil {
+		execution.Error = record.Error.Error()
+	}
+
+	// the record is persisted after the run, independent of its context
+	_, err := s.Repo.New(context.Background(), execution)
+
+	return errors.Wrap(err, "recording job execution")
+}
+
+// Executions loads a page (starting at 1) of executions of a job, or of
+// all jobs if `jobName` is empty, latest first.
+func (s *JobHistory) Executions(ctx context.Context, jobName string, page, pageSize int) ([]*scores.JobExecution, error) {
+	if page < 1 || pageSize < 1 || pageSize > maxPageSize {
+		return nil, errors.Wrapf(scores.ErrorValidation, "page must be >= 1 and page size between 1 and %d", maxPageSize)
+	}
+
+	return s.Repo.Page(ctx, jobName, (page-1)*pageSize, pageSize)
+}
+
+// Cleanup removes all executions that are older than the retention period.
+func (s *JobHistory) Cleanup(ctx context.Context) error {
+	if s.Retention <= 0 {
+		return nil
+	}
+
+	_, err := s.Repo.DeleteBefore(ctx, time.Now().Add(-s.Retention))
+
+	return errors.Wrap(err, "cleaning up job history")
+}
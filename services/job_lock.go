@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/job"
+	"github.com/raphi011/scores/repo"
+)
+
+// JobLock grants leases on jobs to this instance (the `Owner`), instances
+// that share a database run each job only once.
+type JobLock struct {
+	Repo  repo.JobLeaseRepository
+	Owner string
+}
+
+var _ job.Locker = &JobLock{}
+
+// Acquire creates, renews or takes over the lease of the job for `ttl`.
+func (s *JobLock) Acquire(jobName string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	return s.Repo.Acquire(context.Background(), &scores.JobLease{
+		JobName:   jobName,
+		Owner:     s.Owner,
+		ExpiresAt: now.Add(ttl),
+	}, now)
+}
+
+// Release gives up the lease of the job.
+func (s *JobLock) Release(jobName string) error {
+	return s.Repo.Release(context.Background(), jobName, s.Owner)
+}
+
+// InstanceID returns an identifier that is unique for every process,
+// e.g. `api-7f9c-1234-1a2b3c4d`.
+func InstanceID() string {
+	host, err := os.Hostname()
+
+	if err != nil {
+		host = "unknown"
+	}
+
+	random := make([]byte, 4)
+	rand.Read(random)
+
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(random))
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/ladder"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// maxDeviations is the max. amount of deviations of a ladder validation.
+const maxDeviations = 20
+
+// Ladder calculates the ladder points of players from their stored results.
+type Ladder struct {
+	RatingRepo     repo.RatingRepository
+	PlayerRepo     repo.PlayerRepository
+	TournamentRepo repo.TournamentRepository
+
+	// BestResults and Window override the defaults of the calculator if set.
+	BestResults int
+	Window      time.Duration
+
+	now clock
+}
+
+// LadderValidation compares the calculated with the scraped ladder points.
+type LadderValidation struct {
+	Players  int     `json:"players"`
+	Matches  int     `json:"matches"`  // # of players whose points are reproduced exactly
+	Accuracy float64 `json:"accuracy"` // share of matches, between 0 and 1
+	// Deviations are the players with the largest differences.
+	Deviations []*LadderDeviation `json:"deviations"`
+}
+
+// LadderDeviation is a player whose calculated points differ from the scraped points.
+type LadderDeviation struct {
+	Player     *volleynet.Player `json:"player"`
+	Calculated int               `json:"calculated"`
+}
+
+// LadderWhatIf is the projected ladder of a player after a placement
+// in an upcoming tournament.
+type LadderWhatIf struct {
+	PlayerID     int `json:"playerId"`
+	TournamentID int `json:"tournamentId"`
+	Result       int `json:"result"`
+	WonPoints    int `json:"wonPoints"` // points of the placement
+
+	Points          int `json:"points"` // calculated points today
+	Rank            int `json:"rank"`
+	ProjectedPoints int `json:"projectedPoints"` // points at the start of the tournament
+	ProjectedRank   int `json:"projectedRank"`
+	// Counting are the results that count after the tournament.
+	Counting []*ladder.Result `json:"counting"`
+}
+
+// Validate calculates the ladder points of the players of the gender's
+// ladder and compares them with their scraped points.
+func (s *Ladder) Validate(ctx context.Context, gender string) (*LadderValidation, error) {
+	players, err := s.PlayerRepo.Ladder(ctx, gender)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading ladder")
+	}
+
+	calculator, err := s.calculator(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	points := calculator.Ladder(s.now.time())
+	validation := &LadderValidation{
+		Players:    len(players),
+		Deviations: []*LadderDeviation{},
+	}
+
+	for _, p := range players {
+		if points[p.ID] == p.TotalPoints {
+			validation.Matches++
+			continue
+		}
+
+		validation.Deviations = append(validation.Deviations, &LadderDeviation{
+			Player:     p,
+			Calculated: points[p.ID],
+		})
+	}
+
+	if validation.Players > 0 {
+		validation.Accuracy = float64(validation.Matches) / float64(validation.Players)
+	}
+
+	sort.SliceStable(validation.Deviations, func(i, j int) bool {
+		return deviation(validation.Deviations[i]) > deviation(validation.Deviations[j])
+	})
+
+	if len(validation.Deviations) > maxDeviations {
+		validation.Deviations = validation.Deviations[:maxDeviations]
+	}
+
+	return validation, nil
+}
+
+// WhatIf projects the ladder points and rank of a player at the start of
+// an upcoming tournament if the player finishes it with `result`.
+func (s *Ladder) WhatIf(ctx context.Context, playerID, tournamentID, result int) (*LadderWhatIf, error) {
+	if result < 1 {
+		return nil, errors.Wrap(scores.ErrorValidation, "the result must be at least 1")
+	}
+
+	player, err := s.PlayerRepo.Get(ctx, playerID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading player")
+	}
+
+	tournament, err := s.TournamentRepo.Get(ctx, tournamentID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading tournament")
+	}
+
+	if tournament.Status != volleynet.StatusUpcoming {
+		return nil, errors.Wrap(scores.ErrorValidation, "the tournament is not upcoming")
+	}
+
+	if _, ok := partnerGender(tournament.Gender, player.Gender); !ok {
+		return nil, errors.Wrapf(scores.ErrorValidation, "a player of gender %q can't play this tournament", player.Gender)
+	}
+
+	if tournament.MaxTeams > 0 && result > tournament.MaxTeams {
+		return nil, errors.Wrapf(scores.ErrorValidation, "the tournament has only %d teams", tournament.MaxTeams)
+	}
+
+	calculator, err := s.calculator(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	wonPoints, ok := calculator.Points(tournament.SubLeagueKey, result)
+
+	if !ok {
+		return nil, errors.Wrapf(scores.ErrorValidation, "the points of placement %d in %q are unknown", result, tournament.SubLeague)
+	}
+
+	extra := &volleynet.TeamResult{
+		TournamentID:    tournament.ID,
+		TournamentStart: tournament.Start,
+		SubLeagueKey:    tournament.SubLeagueKey,
+		Player1ID:       player.ID,
+		Player2ID:       player.ID,
+		Result:          result,
+		WonPoints:       wonPoints,
+	}
+
+	players, err := s.PlayerRepo.ByGender(ctx, player.Gender)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading players")
+	}
+
+	now := s.now.time()
+	at := tournament.Start
+
+	if at.Before(now) {
+		at = now
+	}
+
+	current := calculator.Ladder(now)
+	projected := calculator.Ladder(at, extra)
+
+	return &LadderWhatIf{
+		PlayerID:        player.ID,
+		TournamentID:    tournament.ID,
+		Result:          result,
+		WonPoints:       wonPoints,
+		Points:          current[player.ID],
+		Rank:            ladder.Rank(current[player.ID], others(players, player.ID, current)),
+		ProjectedPoints: projected[player.ID],
+		ProjectedRank:   ladder.Rank(projected[player.ID], others(players, player.ID, projected)),
+		Counting:        calculator.Counting(player.ID, at, extra),
+	}, nil
+}
+
+func (s *Ladder) calculator(ctx context.Context) (*ladder.Calculator, error) {
+	results, err := s.RatingRepo.Results(ctx)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading results")
+	}
+
+	calculator := ladder.NewCalculator(results)
+
+	if s.BestResults > 0 {
+		calculator.BestResults = s.BestResults
+	}
+	if s.Window > 0 {
+		calculator.Window = s.Window
+	}
+
+	return calculator, nil
+}
+
+// others returns the ladder points of all `players` except `playerID`.
+func others(players []*volleynet.Player, playerID int, points map[int]int) []int {
+	others := []int{}
+
+	for _, p := range players {
+		if p.ID != playerID {
+			others = append(others, points[p.ID])
+		}
+	}
+
+	return others
+}
+
+func deviation(d *LadderDeviation) int {
+	diff := d.Calculated - d.Player.TotalPoints
+
+	if diff < 0 {
+		return -diff
+	}
+
+	return diff
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// newLadderService returns a ladder service where today is 2019-05-01 and
+// players 1-4 have 80, 70, 76 and 66 points, player 4 has 60 scraped points.
+func newLadderService(t *testing.T) *Ladder {
+	players := []*volleynet.Player{
+		{ID: 1, Gender: "M", LadderRank: 1, TotalPoints: 80},
+		{ID: 2, Gender: "M", LadderRank: 3, TotalPoints: 70},
+		{ID: 3, Gender: "M", LadderRank: 2, TotalPoints: 76},
+		{ID: 4, Gender: "M", LadderRank: 4, TotalPoints: 60},
+	}
+
+	repos := seed(t, fixture{
+		players: players,
+		tournaments: []*volleynet.Tournament{
+			{TournamentInfo: volleynet.TournamentInfo{ID: 1, Gender: "M", SubLeagueKey: "amateur-league",
+				Start: time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC), Status: volleynet.StatusDone}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 2, Gender: "M", SubLeagueKey: "amateur-league",
+				Start: time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC), Status: volleynet.StatusDone}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 3, Gender: "M", SubLeagueKey: "amateur-league",
+				Start: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), Status: volleynet.StatusUpcoming}, MaxTeams: 16},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 4, Gender: "M", SubLeagueKey: "pro-league",
+				Start: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), Status: volleynet.StatusUpcoming}},
+		},
+		teams: []*volleynet.TournamentTeam{
+			{TournamentID: 1, Player1: players[0], Player2: players[1], Result: 1, WonPoints: 40},
+			{TournamentID: 1, Player1: players[2], Player2: players[3], Result: 2, WonPoints: 36},
+			{TournamentID: 2, Player1: players[0], Player2: players[2], Result: 1, WonPoints: 40},
+			{TournamentID: 2, Player1: players[1], Player2: players[3], Result: 3, WonPoints: 30},
+		},
+	})
+
+	return &Ladder{
+		RatingRepo:     repos.RatingRepo,
+		PlayerRepo:     repos.PlayerRepo,
+		TournamentRepo: repos.TournamentRepo,
+		now: func() time.Time {
+			return time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+		},
+	}
+}
+
+func TestLadderValidate(t *testing.T) {
+	service := newLadderService(t)
+
+	validation, err := service.Validate(context.Background(), "M")
+
+	if err != nil {
+		t.Fatalf("Ladder.Validate() failed: %v", err)
+	}
+
+	if validation.Players != 4 || validation.Matches != 3 || validation.Accuracy != 0.75 {
+		t.Fatalf("Ladder.Validate(), want 3 of 4 matches, got %+v", validation)
+	}
+
+	if len(validation.Deviations) != 1 || validation.Deviations[0].Player.ID != 4 || validation.Deviations[0].Calculated != 66 {
+		t.Fatalf("Ladder.Validate(), want a deviation of player 4, got %+v", validation.Deviations)
+	}
+}
+
+func TestLadderWhatIf(t *testing.T) {
+	service := newLadderService(t)
+
+	whatIf, err := service.WhatIf(context.Background(), 4, 3, 3)
+
+	if err != nil {
+		t.Fatalf("Ladder.WhatIf() failed: %v", err)
+	}
+
+	want := LadderWhatIf{PlayerID: 4, TournamentID: 3, Result: 3, WonPoints: 30,
+		Points: 66, Rank: 4, ProjectedPoints: 96, ProjectedRank: 1}
+
+	if diff := cmp.Diff(want, *whatIf, cmpopts.IgnoreFields(LadderWhatIf{}, "Counting")); diff != "" {
+		t.Fatalf("Ladder.WhatIf() differs:\n%s", diff)
+	}
+
+	if len(whatIf.Counting) != 3 || whatIf.Counting[0].TournamentID != 1 {
+		t.Fatalf("Ladder.WhatIf(), want the 3 counting results, got %+v", whatIf.Counting)
+	}
+}
+
+func TestLadderWhatIfInvalid(t *testing.T) {
+	service := newLadderService(t)
+
+	tests := []struct {
+		name                           string
+		playerID, tournamentID, result int
+	}{
+		{"no result", 4, 3, 0},
+		{"finished tournament", 4, 1, 1},
+		{"more than max. teams", 4, 3, 17},
+		{"unknown sub league", 4, 4, 1},
+	}
+
+	for _, tt := range tests {
+		_, err := service.WhatIf(context.Background(), tt.playerID, tt.tournamentID, tt.result)
+
+		if errors.Cause(err) != scores.ErrorValidation {
+			t.Errorf("%s: Ladder.WhatIf(), want ErrorValidation, got %v", tt.name, err)
+		}
+	}
+
+	if _, err := service.WhatIf(context.Background(), 9, 3, 1); errors.Cause(err) != scores.ErrNotFound {
+		t.Errorf("Ladder.WhatIf() of an unknown player, want ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// maxRecommendations is the max. amount of recommended partners.
+const maxRecommendations = 50
+
+// Weights of the criteria of a partner recommendation, they add up to 100.
+const (
+	weightPoints           = 25
+	weightRank             = 10
+	weightClub             = 10
+	weightUnion            = 5
+	weightJointTournaments = 30
+	weightJointResult      = 20
+)
+
+// jointTournamentsCap is the # of tournaments played together that
+// counts as an established team.
+const jointTournamentsCap = 3
+
+// similarity is the min. similarity of points or ranks that is
+// mentioned as reason for a recommendation.
+const similarity = 0.8
+
+// jointResults are the results of a player with one partner.
+type jointResults struct {
+	tournaments int
+	best        int
+}
+
+// RecommendPartners returns the `limit` players that fit best as partner of
+// a player for an upcoming tournament. Candidates must be allowed to play
+// the tournament with the player (gender, max. points of the team) and must
+// not be registered yet, they are ranked by the similarity of their ladder
+// points and rank, a shared club or country union and the results the
+// players had together.
+func (s *Volleynet) RecommendPartners(ctx context.Context, playerID, tournamentID, limit int) (
+	[]*volleynet.PartnerRecommendation, error) {
+
+	if limit < 1 || limit > maxRecommendations {
+		return nil, errors.Wrapf(scores.ErrorValidation, "the limit must be between 1 and %d", maxRecommendations)
+	}
+
+	player, err := s.PlayerRepo.Get(ctx, playerID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading player")
+	}
+
+	tournament, err := s.TournamentInfo(ctx, tournamentID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading tournament")
+	}
+
+	if tournament.Status != volleynet.StatusUpcoming {
+		return nil, errors.Wrap(scores.ErrorValidation, "the tournament is not upcoming")
+	}
+
+	gender, ok := partnerGender(tournament.Gender, player.Gender)
+
+	if !ok {
+		return nil, errors.Wrapf(scores.ErrorValidation, "a player of gender %q can't play this tournament", player.Gender)
+	}
+
+	registered := registeredPlayers(tournament.Teams)
+
+	if registered[playerID] {
+		return nil, errors.Wrap(scores.ErrorValidation, "the player is already registered")
+	}
+
+	candidates, err := s.PlayerRepo.ByGender(ctx, gender)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading candidates")
+	}
+
+	history, err := s.PlayerRepo.History(ctx, playerID, repo.PlayerHistoryFilter{})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading player history")
+	}
+
+	joint := partnerResults(history)
+	recommendations := []*volleynet.PartnerRecommendation{}
+
+	for _, c := range candidates {
+		teamPoints := player.TotalPoints + c.TotalPoints
+
+		if c.ID == playerID || registered[c.ID] ||
+			(tournament.MaxPoints > 0 && teamPoints > tournament.MaxPoints) {
+			continue
+		}
+
+		recommendations = append(recommendations, recommend(player, c, joint[c.ID]))
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		left, right := recommendations[i], recommendations[j]
+
+		if left.Score != right.Score {
+			return left.Score > right.Score
+		}
+		if left.Player.TotalPoints != right.Player.TotalPoints {
+			return left.Player.TotalPoints > right.Player.TotalPoints
+		}
+
+		return left.Player.ID < right.Player.ID
+	})
+
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return recommendations, nil
+}
+
+// partnerGender returns the gender of the partners of a player in a
+// tournament of `tournamentGender`. Men's and women's tournaments are only
+// open to players of their gender, other (mixed) tournaments are played
+// by a man and a woman.
+func partnerGender(tournamentGender, playerGender string) (string, bool) {
+	switch {
+	case tournamentGender == "M" || tournamentGender == "W":
+		return tournamentGender, playerGender == tournamentGender
+	case playerGender == "M":
+		return "W", true
+	case playerGender == "W":
+		return "M", true
+	default:
+		return "", false
+	}
+}
+
+// registeredPlayers returns the ids of all players that are registered.
+func registeredPlayers(teams []*volleynet.TournamentTeam) map[int]bool {
+	registered := map[int]bool{}
+
+	for _, t := range teams {
+		if !t.Deregistered {
+			registered[t.Player1.ID] = true
+			registered[t.Player2.ID] = true
+		}
+	}
+
+	return registered
+}
+
+// partnerResults returns the joint results of a player per partner.
+func partnerResults(history []*volleynet.PlayerTournament) map[int]jointResults {
+	results := map[int]jointResults{}
+
+	for _, entry := range history {
+		if entry.Deregistered || entry.Partner == nil {
+			continue
+		}
+
+		r := results[entry.Partner.ID]
+		r.tournaments++
+
+		if entry.Result > 0 && (r.best == 0 || entry.Result < r.best) {
+			r.best = entry.Result
+		}
+
+		results[entry.Partner.ID] = r
+	}
+
+	return results
+}
+
+// recommend scores how well `candidate` fits as partner of `player`.
+func recommend(player, candidate *volleynet.Player, joint jointResults) *volleynet.PartnerRecommendation {
+	r := &volleynet.PartnerRecommendation{
+		Player:           candidate,
+		Reasons:          []string{},
+		TeamPoints:       player.TotalPoints + candidate.TotalPoints,
+		JointTournaments: joint.tournaments,
+		BestJointResult:  joint.best,
+	}
+
+	score := 0.0
+
+	points := ratio(player.TotalPoints, candidate.TotalPoints)
+	score += weightPoints * points
+
+	if points >= similarity && candidate.TotalPoints > 0 {
+		r.Reasons = append(r.Reasons, volleynet.ReasonSimilarPoints)
+	}
+
+	if player.LadderRank > 0 && candidate.LadderRank > 0 {
+		rank := ratio(player.LadderRank, candidate.LadderRank)
+		score += weightRank * rank
+
+		if rank >= similarity {
+			r.Reasons = append(r.Reasons, volleynet.ReasonSimilarRank)
+		}
+	}
+
+	if sameText(player.Club, candidate.Club) {
+		score += weightClub
+		r.Reasons = append(r.Reasons, volleynet.ReasonSameClub)
+	}
+
+	if sameText(player.CountryUnion, candidate.CountryUnion) {
+		score += weightUnion
+		r.Reasons = append(r.Reasons, volleynet.ReasonSameUnion)
+	}
+
+	if joint.tournaments > 0 {
+		played := math.Min(float64(joint.tournaments), jointTournamentsCap) / jointTournamentsCap
+		score += weightJointTournaments * played
+
+		if joint.best > 0 {
+			score += weightJointResult / float64(joint.best)
+		}
+
+		r.Reasons = append(r.Reasons, volleynet.ReasonPreviousPartner)
+	}
+
+	r.Score = math.Round(score*10) / 10
+
+	return r
+}
+
+// ratio returns the ratio of the smaller to the larger value, 1 if both are equal.
+func ratio(a, b int) float64 {
+	if a == b {
+		return 1
+	}
+
+	if a > b {
+		a, b = b, a
+	}
+
+	return float64(a) / float64(b)
+}
+
+// sameText returns true if both values are set and equal, ignoring case.
+func sameText(a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+
+	return a != "" && strings.EqualFold(a, b)
+}
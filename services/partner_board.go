@@ -0,0 +1,539 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+const (
+	// maxPartnerListings is the max. amount of open listings of a user.
+	maxPartnerListings = 10
+	// maxListingRange is the max. date range of a listing.
+	maxListingRange = 90 * 24 * time.Hour
+	// maxPartnerText is the max. length of a listing's note and a request's message.
+	maxPartnerText = 500
+)
+
+// PartnerBoard lets players post that they are looking for a partner and
+// other players request to play with them.
+type PartnerBoard struct {
+	Repo           repo.PartnerRepository
+	UserRepo       repo.UserRepository
+	PlayerRepo     repo.PlayerRepository
+	TournamentRepo repo.TournamentRepository
+	TeamRepo       repo.TeamRepository
+
+	now clock
+}
+
+// PartnerRequests are the requests a user has sent and received.
+type PartnerRequests struct {
+	Sent     []*volleynet.PartnerRequest `json:"sent"`
+	Received []*volleynet.PartnerRequest `json:"received"`
+}
+
+// Listings returns the open listings that match the filter and have not
+// ended yet. If the filter contains a tournament the listings for this
+// tournament and the listings whose league and dates cover it are returned.
+func (s *PartnerBoard) Listings(ctx context.Context, filter repo.PartnerListingFilter) (
+	[]*volleynet.PartnerListing, error) {
+
+	if filter.MinPoints < 0 || filter.MaxPoints < 0 ||
+		(filter.MaxPoints > 0 && filter.MaxPoints < filter.MinPoints) {
+		return nil, errors.Wrap(scores.ErrorValidation, "invalid points range")
+	}
+
+	if filter.TournamentID > 0 {
+		tournament, err := s.TournamentRepo.Get(ctx, filter.TournamentID)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "loading tournament")
+		}
+
+		filter.League = tournament.LeagueKey
+		filter.From = day(tournament.Start)
+		filter.To = filter.From
+	}
+
+	if today := day(s.now.time()); filter.From.Before(today) {
+		filter.From = today
+	}
+
+	listings, err := s.Repo.Listings(ctx, filter)
+
+	return listings, errors.Wrap(err, "loading listings")
+}
+
+// UserListings returns all listings of a user, the latest first.
+func (s *PartnerBoard) UserListings(ctx context.Context, userID int) ([]*volleynet.PartnerListing, error) {
+	listings, err := s.Repo.ListingsByUser(ctx, userID)
+
+	return listings, errors.Wrap(err, "loading listings")
+}
+
+// Post validates and persists a new listing for the player of the user.
+// Listings for a tournament take their league and dates from it, other
+// listings need a league and a date range.
+func (s *PartnerBoard) Post(ctx context.Context, userID int, listing *volleynet.PartnerListing) (
+	*volleynet.PartnerListing, error) {
+
+	player, err := s.userPlayer(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(listing.Note) > maxPartnerText {
+		return nil, errors.Wrapf(scores.ErrorValidation, "the note must not be longer than %d characters", maxPartnerText)
+	}
+
+	listings, err := s.Repo.ListingsByUser(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading listings")
+	}
+
+	open := 0
+
+	for _, l := range listings {
+		if l.Status != volleynet.ListingOpen {
+			continue
+		}
+
+		open++
+
+		if listing.TournamentID > 0 && l.TournamentID == listing.TournamentID {
+			return nil, errors.Wrap(scores.ErrorValidation, "there is already a listing for this tournament")
+		}
+	}
+
+	if open >= maxPartnerListings {
+		return nil, errors.Wrapf(scores.ErrorValidation, "a user can have at most %d open listings", maxPartnerListings)
+	}
+
+	if listing.TournamentID > 0 {
+		tournament, err := s.tournament(ctx, listing.TournamentID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := canPlay(tournament, player); err != nil {
+			return nil, err
+		}
+
+		listing.League = tournament.LeagueKey
+		listing.From = day(tournament.Start)
+		listing.To = listing.From
+	} else {
+		listing.From, listing.To = day(listing.From), day(listing.To)
+
+		if err := s.validateRange(listing); err != nil {
+			return nil, err
+		}
+	}
+
+	listing.ID = 0
+	listing.UserID = userID
+	listing.Player = player
+	listing.Note = strings.TrimSpace(listing.Note)
+	listing.Status = volleynet.ListingOpen
+
+	listing, err = s.Repo.NewListing(ctx, listing)
+
+	return listing, errors.Wrap(err, "persisting listing")
+}
+
+// Close closes an open listing of the user and declines its pending requests.
+func (s *PartnerBoard) Close(ctx context.Context, userID, listingID int) error {
+	listing, err := s.listing(ctx, userID, listingID)
+
+	if err != nil {
+		return err
+	}
+
+	closed, err := s.Repo.SetListingStatus(ctx, listing.ID, volleynet.ListingOpen, volleynet.ListingClosed)
+
+	if err != nil {
+		return errors.Wrap(err, "closing listing")
+	} else if !closed {
+		return errors.Wrap(scores.ErrorValidation, "the listing is not open")
+	}
+
+	return s.declinePending(ctx, listing.ID)
+}
+
+// Request sends a request of the user's player to the player of an open
+// listing. If the listing is for a date range the request must name a
+// tournament of the listing's league within the range.
+func (s *PartnerBoard) Request(ctx context.Context, userID int, request *volleynet.PartnerRequest) (
+	*volleynet.PartnerRequest, error) {
+
+	player, err := s.userPlayer(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.Message) > maxPartnerText {
+		return nil, errors.Wrapf(scores.ErrorValidation, "the message must not be longer than %d characters", maxPartnerText)
+	}
+
+	listing, err := s.Repo.Listing(ctx, request.ListingID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading listing")
+	}
+
+	if listing.Status != volleynet.ListingOpen || listing.To.Before(day(s.now.time())) {
+		return nil, errors.Wrap(scores.ErrorValidation, "the listing is not open")
+	}
+
+	if listing.UserID == userID || listing.Player.ID == player.ID {
+		return nil, errors.Wrap(scores.ErrorValidation, "players can't request their own listing")
+	}
+
+	if listing.TournamentID > 0 {
+		request.TournamentID = listing.TournamentID
+	} else if request.TournamentID <= 0 {
+		return nil, errors.Wrap(scores.ErrorValidation, "the listing is for a date range, a tournament is required")
+	}
+
+	tournament, err := s.tournament(ctx, request.TournamentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	start := day(tournament.Start)
+
+	if tournament.LeagueKey != listing.League || start.Before(listing.From) || start.After(listing.To) {
+		return nil, errors.Wrap(scores.ErrorValidation, "the tournament is not covered by the listing")
+	}
+
+	if err := canPlay(tournament, listing.Player, player); err != nil {
+		return nil, err
+	}
+
+	requests, err := s.Repo.RequestsByListing(ctx, listing.ID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading requests")
+	}
+
+	for _, r := range requests {
+		if r.UserID == userID && r.Status == volleynet.RequestPending {
+			return nil, errors.Wrap(scores.ErrorValidation, "there is already a pending request for this listing")
+		}
+	}
+
+	request.ID = 0
+	request.UserID = userID
+	request.Player = player
+	request.Message = strings.TrimSpace(request.Message)
+	request.Status = volleynet.RequestPending
+
+	request, err = s.Repo.NewRequest(ctx, request)
+
+	return request, errors.Wrap(err, "persisting request")
+}
+
+// Requests returns the requests the user has sent and received.
+func (s *PartnerBoard) Requests(ctx context.Context, userID int) (*PartnerRequests, error) {
+	sent, err := s.Repo.RequestsByUser(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading sent requests")
+	}
+
+	received, err := s.Repo.RequestsToUser(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading received requests")
+	}
+
+	return &PartnerRequests{Sent: sent, Received: received}, nil
+}
+
+// Accept accepts a pending request for a listing of the user, the listing
+// is matched and its other pending requests are declined. The returned
+// signup is pre-filled with the partner and the tournament.
+func (s *PartnerBoard) Accept(ctx context.Context, userID, requestID int) (*volleynet.PartnerSignup, error) {
+	request, listing, err := s.receivedRequest(ctx, userID, requestID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != volleynet.RequestPending {
+		return nil, errors.Wrap(scores.ErrorValidation, "the request is not pending")
+	}
+
+	// matching the listing first makes sure only one request is accepted
+	matched, err := s.Repo.SetListingStatus(ctx, listing.ID, volleynet.ListingOpen, volleynet.ListingMatched)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "matching listing")
+	} else if !matched {
+		return nil, errors.Wrap(scores.ErrorValidation, "the listing is not open")
+	}
+
+	accepted, err := s.Repo.SetRequestStatus(ctx, request.ID, volleynet.RequestPending, volleynet.RequestAccepted)
+
+	if err == nil && !accepted {
+		err = errors.Wrap(scores.ErrorValidation, "the request is not pending")
+	}
+
+	if err != nil {
+		// the request has been withdrawn in the meantime, reopen the listing
+		if _, reopenErr := s.Repo.SetListingStatus(ctx, listing.ID, volleynet.ListingMatched, volleynet.ListingOpen); reopenErr != nil {
+			return nil, errors.Wrap(reopenErr, "reopening listing")
+		}
+
+		return nil, errors.Wrap(err, "accepting request")
+	}
+
+	if err := s.declinePending(ctx, listing.ID); err != nil {
+		return nil, err
+	}
+
+	return signup(request.TournamentID, request.Player), nil
+}
+
+// Decline declines a pending request for a listing of the user.
+func (s *PartnerBoard) Decline(ctx context.Context, userID, requestID int) error {
+	request, _, err := s.receivedRequest(ctx, userID, requestID)
+
+	if err != nil {
+		return err
+	}
+
+	return s.setRequestStatus(ctx, request.ID, volleynet.RequestDeclined)
+}
+
+// Withdraw withdraws a pending request the user has sent.
+func (s *PartnerBoard) Withdraw(ctx context.Context, userID, requestID int) error {
+	request, err := s.Repo.Request(ctx, requestID)
+
+	if err != nil {
+		return errors.Wrap(err, "loading request")
+	}
+
+	if request.UserID != userID {
+		return scores.ErrNotFound
+	}
+
+	return s.setRequestStatus(ctx, request.ID, volleynet.RequestWithdrawn)
+}
+
+// Signup returns the pre-filled signup of an accepted request for
+// both the user of the listing and the user that sent the request.
+func (s *PartnerBoard) Signup(ctx context.Context, userID, requestID int) (*volleynet.PartnerSignup, error) {
+	request, err := s.Repo.Request(ctx, requestID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading request")
+	}
+
+	listing, err := s.Repo.Listing(ctx, request.ListingID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading listing")
+	}
+
+	if request.Status != volleynet.RequestAccepted {
+		if request.UserID != userID && listing.UserID != userID {
+			return nil, scores.ErrNotFound
+		}
+
+		return nil, errors.Wrap(scores.ErrorValidation, "the request has not been accepted")
+	}
+
+	switch userID {
+	case listing.UserID:
+		return signup(request.TournamentID, request.Player), nil
+	case request.UserID:
+		return signup(request.TournamentID, listing.Player), nil
+	default:
+		return nil, scores.ErrNotFound
+	}
+}
+
+// userPlayer loads the volleynet player of a user.
+func (s *PartnerBoard) userPlayer(ctx context.Context, userID int) (*volleynet.Player, error) {
+	user, err := s.UserRepo.ByID(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading user")
+	}
+
+	if user.PlayerID <= 0 {
+		return nil, errors.Wrap(scores.ErrorValidation, "the user has no volleynet player, login to volleynet first")
+	}
+
+	player, err := s.PlayerRepo.Get(ctx, user.PlayerID)
+
+	return player, errors.Wrap(err, "loading player")
+}
+
+// tournament loads an upcoming tournament and its teams.
+func (s *PartnerBoard) tournament(ctx context.Context, tournamentID int) (*volleynet.Tournament, error) {
+	tournament, err := s.TournamentRepo.Get(ctx, tournamentID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading tournament")
+	}
+
+	if tournament.Status != volleynet.StatusUpcoming {
+		return nil, errors.Wrap(scores.ErrorValidation, "the tournament is not upcoming")
+	}
+
+	tournament.Teams, err = s.TeamRepo.ByTournament(ctx, tournamentID)
+
+	return tournament, errors.Wrap(err, "loading teams")
+}
+
+// listing loads a listing and makes sure that it belongs to the user,
+// listings of other users are reported as not found.
+func (s *PartnerBoard) listing(ctx context.Context, userID, listingID int) (*volleynet.PartnerListing, error) {
+	listing, err := s.Repo.Listing(ctx, listingID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading listing")
+	}
+
+	if listing.UserID != userID {
+		return nil, scores.ErrNotFound
+	}
+
+	return listing, nil
+}
+
+// receivedRequest loads a request for a listing of the user.
+func (s *PartnerBoard) receivedRequest(ctx context.Context, userID, requestID int) (
+	*volleynet.PartnerRequest, *volleynet.PartnerListing, error) {
+
+	request, err := s.Repo.Request(ctx, requestID)
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "loading request")
+	}
+
+	listing, err := s.listing(ctx, userID, request.ListingID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return request, listing, nil
+}
+
+// setRequestStatus changes the status of a pending request.
+func (s *PartnerBoard) setRequestStatus(ctx context.Context, requestID int, status string) error {
+	changed, err := s.Repo.SetRequestStatus(ctx, requestID, volleynet.RequestPending, status)
+
+	if err != nil {
+		return errors.Wrap(err, "updating request")
+	} else if !changed {
+		return errors.Wrap(scores.ErrorValidation, "the request is not pending")
+	}
+
+	return nil
+}
+
+// declinePending declines all pending requests for a listing.
+func (s *PartnerBoard) declinePending(ctx context.Context, listingID int) error {
+	requests, err := s.Repo.RequestsByListing(ctx, listingID)
+
+	if err != nil {
+		return errors.Wrap(err, "loading requests")
+	}
+
+	for _, r := range requests {
+		if r.Status != volleynet.RequestPending {
+			continue
+		}
+
+		if _, err := s.Repo.SetRequestStatus(ctx, r.ID, volleynet.RequestPending, volleynet.RequestDeclined); err != nil {
+			return errors.Wrap(err, "declining request")
+		}
+	}
+
+	return nil
+}
+
+func (s *PartnerBoard) validateRange(listing *volleynet.PartnerListing) error {
+	if listing.League == "" {
+		return errors.Wrap(scores.ErrorValidation, "a listing without a tournament needs a league")
+	}
+
+	if listing.From.IsZero() || listing.To.Before(listing.From) {
+		return errors.Wrap(scores.ErrorValidation, "invalid date range")
+	}
+
+	if listing.To.Sub(listing.From) > maxListingRange {
+		return errors.Wrapf(scores.ErrorValidation, "the date range must not be longer than %d days", maxListingRange/(24*time.Hour))
+	}
+
+	if listing.To.Before(day(s.now.time())) {
+		return errors.Wrap(scores.ErrorValidation, "the date range has already ended")
+	}
+
+	return nil
+}
+
+// canPlay returns an error if the players are not allowed to play the
+// tournament (together), e.g. because one of them is already registered
+// or they exceed the max. points of a team.
+func canPlay(tournament *volleynet.Tournament, player *volleynet.Player, partner ...*volleynet.Player) error {
+	gender, ok := partnerGender(tournament.Gender, player.Gender)
+
+	if !ok {
+		return errors.Wrapf(scores.ErrorValidation, "a player of gender %q can't play this tournament", player.Gender)
+	}
+
+	registered := registeredPlayers(tournament.Teams)
+
+	if registered[player.ID] {
+		return errors.Wrap(scores.ErrorValidation, "the player is already registered")
+	}
+
+	for _, p := range partner {
+		if p.Gender != gender {
+			return errors.Wrapf(scores.ErrorValidation, "the partner must be of gender %q", gender)
+		}
+
+		if registered[p.ID] {
+			return errors.Wrap(scores.ErrorValidation, "the partner is already registered")
+		}
+
+		if tournament.MaxPoints > 0 && player.TotalPoints+p.TotalPoints > tournament.MaxPoints {
+			return errors.Wrapf(scores.ErrorValidation, "the team exceeds the max. points (%d) of the tournament", tournament.MaxPoints)
+		}
+	}
+
+	return nil
+}
+
+// signup returns a signup with `partner` for the tournament.
+func signup(tournamentID int, partner *volleynet.Player) *volleynet.PartnerSignup {
+	return &volleynet.PartnerSignup{
+		TournamentID: tournamentID,
+		PartnerID:    partner.ID,
+		PartnerName:  strings.TrimSpace(partner.FirstName + " " + partner.LastName),
+	}
+}
+
+// day returns the date of `t` without its time.
+func day(t time.Time) time.Time {
+	year, month, d := t.Date()
+
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
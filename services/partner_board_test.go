@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// newPartnerBoard returns a partner board where today is 2019-06-01 and
+// the users 1-4 are linked to the players 1-4, user 5 has no player.
+func newPartnerBoard(t *testing.T) *PartnerBoard {
+	players := []*volleynet.Player{
+		{ID: 1, Gender: "M", FirstName: "Richard", LastName: "Bosse", TotalPoints: 100},
+		{ID: 2, Gender: "M", FirstName: "Dominik", LastName: "Rieder", TotalPoints: 120},
+		{ID: 3, Gender: "M", TotalPoints: 80},
+		{ID: 4, Gender: "W", TotalPoints: 100},
+	}
+
+	users := []*scores.User{}
+
+	for i, p := range players {
+		users = append(users, &scores.User{Email: fmt.Sprintf("user%d@example.com", i+1), PlayerID: p.ID})
+	}
+
+	repos := seed(t, fixture{
+		players: players,
+		users:   append(users, &scores.User{Email: "noplayer"}),
+		tournaments: []*volleynet.Tournament{
+			{TournamentInfo: volleynet.TournamentInfo{ID: 10, Gender: "M", LeagueKey: "amateur-tour",
+				Start: time.Date(2019, 6, 10, 9, 0, 0, 0, time.UTC), Status: volleynet.StatusUpcoming}, MaxPoints: 230},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 11, Gender: "M", LeagueKey: "amateur-tour",
+				Start: time.Date(2019, 6, 20, 9, 0, 0, 0, time.UTC), Status: volleynet.StatusUpcoming}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 12, Gender: "M", LeagueKey: "pro-tour",
+				Start: time.Date(2019, 6, 20, 9, 0, 0, 0, time.UTC), Status: volleynet.StatusUpcoming}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 13, Gender: "M", LeagueKey: "amateur-tour",
+				Start: time.Date(2019, 5, 20, 9, 0, 0, 0, time.UTC), Status: volleynet.StatusDone}},
+		},
+	})
+
+	return &PartnerBoard{
+		Repo:           repos.PartnerRepo,
+		UserRepo:       repos.UserRepo,
+		PlayerRepo:     repos.PlayerRepo,
+		TournamentRepo: repos.TournamentRepo,
+		TeamRepo:       repos.TeamRepo,
+		now: func() time.Time {
+			return time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+		},
+	}
+}
+
+func TestPartnerBoardAccept(t *testing.T) {
+	ctx := context.Background()
+	board := newPartnerBoard(t)
+
+	listing, err := board.Post(ctx, 1, &volleynet.PartnerListing{TournamentID: 10, Note: " Looking for a blocker "})
+
+	if err != nil {
+		t.Fatalf("PartnerBoard.Post() failed: %v", err)
+	}
+
+	if listing.League != "amateur-tour" || !listing.From.Equal(time.Date(2019, 6, 10, 0, 0, 0, 0, time.UTC)) ||
+		listing.Note != "Looking for a blocker" {
+		t.Fatalf("PartnerBoard.Post(), want the league and date of the tournament, got %+v", listing)
+	}
+
+	first, err := board.Request(ctx, 2, &volleynet.PartnerRequest{ListingID: listing.ID})
+
+	if err != nil {
+		t.Fatalf("PartnerBoard.Request() failed: %v", err)
+	}
+
+	second, err := board.Request(ctx, 3, &volleynet.PartnerRequest{ListingID: listing.ID})
+
+	if err != nil {
+		t.Fatalf("PartnerBoard.Request() failed: %v", err)
+	}
+
+	signup, err := board.Accept(ctx, 1, first.ID)
+
+	if err != nil {
+		t.Fatalf("PartnerBoard.Accept() failed: %v", err)
+	}
+
+	want := volleynet.PartnerSignup{TournamentID: 10, PartnerID: 2, PartnerName: "Dominik Rieder"}
+
+	if *signup != want {
+		t.Fatalf("PartnerBoard.Accept(), want signup %+v, got %+v", want, *signup)
+	}
+
+	signup, err = board.Signup(ctx, 2, first.ID)
+
+	if err != nil || signup.PartnerID != 1 {
+		t.Fatalf("PartnerBoard.Signup(), want partner 1 for the requesting user, got %+v (%v)", signup, err)
+	}
+
+	requests, err := board.Requests(ctx, 3)
+
+	if err != nil {
+		t.Fatalf("PartnerBoard.Requests() failed: %v", err)
+	}
+
+	if len(requests.Sent) != 1 || requests.Sent[0].ID != second.ID || requests.Sent[0].Status != volleynet.RequestDeclined {
+		t.Fatalf("PartnerBoard.Accept() should decline the other requests, got %+v", requests.Sent)
+	}
+
+	if _, err := board.Signup(ctx, 3, first.ID); errors.Cause(err) != scores.ErrNotFound {
+		t.Fatalf("PartnerBoard.Signup() of another user, want ErrNotFound, got %v", err)
+	}
+
+	if _, err := board.Request(ctx, 3, &volleynet.PartnerRequest{ListingID: listing.ID}); errors.Cause(err) != scores.ErrorValidation {
+		t.Fatalf("PartnerBoard.Request() of a matched listing, want ErrorValidation, got %v", err)
+	}
+}
+
+func TestPartnerBoardRangeListing(t *testing.T) {
+	ctx := context.Background()
+	board := newPartnerBoard(t)
+
+	listing, err := board.Post(ctx, 1, &volleynet.PartnerListing{
+		League: "amateur-tour",
+		From:   time.Date(2019, 6, 15, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2019, 6, 30, 0, 0, 0, 0, time.UTC),
+	})
+
+	if err != nil {
+		t.Fatalf("PartnerBoard.Post() failed: %v", err)
+	}
+
+	listings, err := board.Listings(ctx, repo.PartnerListingFilter{TournamentID: 11})
+
+	if err != nil || len(listings) != 1 || listings[0].ID != listing.ID {
+		t.Fatalf("PartnerBoard.Listings() of tournament 11, want the listing, got %v (%v)", listings, err)
+	}
+
+	listings, err = board.Listings(ctx, repo.PartnerListingFilter{TournamentID: 10})
+
+	if err != nil || len(listings) != 0 {
+		t.Fatalf("PartnerBoard.Listings() of tournament 10 is not covered by the listing, got %v (%v)", listings, err)
+	}
+
+	for _, tournamentID := range []int{0, 10, 12} {
+		_, err := board.Request(ctx, 2, &volleynet.PartnerRequest{ListingID: listing.ID, TournamentID: tournamentID})
+
+		if errors.Cause(err) != scores.ErrorValidation {
+			t.Fatalf("PartnerBoard.Request() with tournament %d, want ErrorValidation, got %v", tournamentID, err)
+		}
+	}
+
+	request, err := board.Request(ctx, 2, &volleynet.PartnerRequest{ListingID: listing.ID, TournamentID: 11})
+
+	if err != nil {
+		t.Fatalf("PartnerBoard.Request() failed: %v", err)
+	}
+
+	if _, err := board.Request(ctx, 2, &volleynet.PartnerRequest{ListingID: listing.ID, TournamentID: 11}); errors.Cause(err) != scores.ErrorValidation {
+		t.Fatalf("PartnerBoard.Request() twice, want ErrorValidation, got %v", err)
+	}
+
+	if err := board.Close(ctx, 1, listing.ID); err != nil {
+		t.Fatalf("PartnerBoard.Close() failed: %v", err)
+	}
+
+	if err := board.Withdraw(ctx, 2, request.ID); errors.Cause(err) != scores.ErrorValidation {
+		t.Fatalf("PartnerBoard.Close() should decline the pending requests, got %v", err)
+	}
+}
+
+func TestPartnerBoardInvalid(t *testing.T) {
+	ctx := context.Background()
+	board := newPartnerBoard(t)
+
+	listing, err := board.Post(ctx, 1, &volleynet.PartnerListing{TournamentID: 10})
+
+	if err != nil {
+		t.Fatalf("PartnerBoard.Post() failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		fn   func() error
+	}{
+		{"user without player", scores.ErrorValidation, func() error {
+			_, err := board.Post(ctx, 5, &volleynet.PartnerListing{TournamentID: 11})
+			return err
+		}},
+		{"note too long", scores.ErrorValidation, func() error {
+			_, err := board.Post(ctx, 2, &volleynet.PartnerListing{TournamentID: 11, Note: strings.Repeat("a", 501)})
+			return err
+		}},
+		{"duplicate listing", scores.ErrorValidation, func() error {
+			_, err := board.Post(ctx, 1, &volleynet.PartnerListing{TournamentID: 10})
+			return err
+		}},
+		{"finished tournament", scores.ErrorValidation, func() error {
+			_, err := board.Post(ctx, 2, &volleynet.PartnerListing{TournamentID: 13})
+			return err
+		}},
+		{"wrong gender", scores.ErrorValidation, func() error {
+			_, err := board.Post(ctx, 4, &volleynet.PartnerListing{TournamentID: 11})
+			return err
+		}},
+		{"range too long", scores.ErrorValidation, func() error {
+			_, err := board.Post(ctx, 2, &volleynet.PartnerListing{League: "amateur-tour",
+				From: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2019, 9, 30, 0, 0, 0, 0, time.UTC)})
+			return err
+		}},
+		{"range ended", scores.ErrorValidation, func() error {
+			_, err := board.Post(ctx, 2, &volleynet.PartnerListing{League: "amateur-tour",
+				From: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2019, 5, 30, 0, 0, 0, 0, time.UTC)})
+			return err
+		}},
+		{"own listing", scores.ErrorValidation, func() error {
+			_, err := board.Request(ctx, 1, &volleynet.PartnerRequest{ListingID: listing.ID})
+			return err
+		}},
+		{"partner of other gender", scores.ErrorValidation, func() error {
+			_, err := board.Request(ctx, 4, &volleynet.PartnerRequest{ListingID: listing.ID})
+			return err
+		}},
+		{"close listing of other user", scores.ErrNotFound, func() error {
+			return board.Close(ctx, 2, listing.ID)
+		}},
+		{"invalid points range", scores.ErrorValidation, func() error {
+			_, err := board.Listings(ctx, repo.PartnerListingFilter{MinPoints: 200, MaxPoints: 100})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		if err := tt.fn(); errors.Cause(err) != tt.err {
+			t.Errorf("%s: want %v, got %v", tt.name, tt.err, err)
+		}
+	}
+}
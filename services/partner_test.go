@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/volleynet"
+)
+
+func newPartnerService(t *testing.T) *Volleynet {
+	players := []*volleynet.Player{
+		{ID: 1, Gender: "M", TotalPoints: 100, LadderRank: 50, Club: "BVC Vienna", CountryUnion: "WVV"},
+		{ID: 2, Gender: "M", TotalPoints: 40, LadderRank: 150, CountryUnion: "NVV"},
+		{ID: 3, Gender: "M", TotalPoints: 90, LadderRank: 55, Club: "bvc vienna", CountryUnion: "WVV"},
+		{ID: 4, Gender: "M", TotalPoints: 300, LadderRank: 5},
+		{ID: 5, Gender: "M", TotalPoints: 95, LadderRank: 52},
+		{ID: 6, Gender: "W", TotalPoints: 100, LadderRank: 50},
+		{ID: 7, Gender: "M", TotalPoints: 100, LadderRank: 50},
+		{ID: 8, Gender: "M", TotalPoints: 100, LadderRank: 50},
+	}
+
+	repos := seed(t, fixture{
+		players: players,
+		tournaments: []*volleynet.Tournament{
+			{TournamentInfo: volleynet.TournamentInfo{ID: 1, Gender: "M", Status: volleynet.StatusDone}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 2, Gender: "M", Status: volleynet.StatusDone}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 3, Gender: "M", Status: volleynet.StatusUpcoming}, MaxPoints: 350},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 4, Gender: "X", Status: volleynet.StatusUpcoming}},
+		},
+		teams: []*volleynet.TournamentTeam{
+			{TournamentID: 1, Player1: players[0], Player2: players[1], Result: 1},
+			{TournamentID: 2, Player1: players[1], Player2: players[0], Result: 3},
+			{TournamentID: 3, Player1: players[6], Player2: players[7]},
+		},
+	})
+
+	return &Volleynet{
+		PlayerRepo:     repos.PlayerRepo,
+		TeamRepo:       repos.TeamRepo,
+		TournamentRepo: repos.TournamentRepo,
+	}
+}
+
+func TestRecommendPartners(t *testing.T) {
+	service := newPartnerService(t)
+
+	recommendations, err := service.RecommendPartners(context.Background(), 1, 3, 10)
+
+	if err != nil {
+		t.Fatalf("Volleynet.RecommendPartners() failed: %v", err)
+	}
+
+	ids := []int{}
+
+	for _, r := range recommendations {
+		ids = append(ids, r.Player.ID)
+	}
+
+	// 4 exceeds the max. points of the team, 6 is a woman and 7, 8 are registered
+	want := []int{2, 3, 5}
+
+	if len(ids) != len(want) {
+		t.Fatalf("Volleynet.RecommendPartners(), want players %v, got %v", want, ids)
+	}
+
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("Volleynet.RecommendPartners(), want players %v, got %v", want, ids)
+		}
+	}
+
+	previous := recommendations[0]
+
+	if previous.JointTournaments != 2 || previous.BestJointResult != 1 {
+		t.Errorf("Volleynet.RecommendPartners(), want 2 joint tournaments with best result 1, got %+v", previous)
+	}
+
+	club := recommendations[1]
+
+	if len(club.Reasons) != 4 || club.Reasons[2] != volleynet.ReasonSameClub || club.TeamPoints != 190 {
+		t.Errorf("Volleynet.RecommendPartners(), want similar points, rank, club and union, got %+v", club)
+	}
+
+	recommendations, err = service.RecommendPartners(context.Background(), 1, 3, 1)
+
+	if err != nil || len(recommendations) != 1 {
+		t.Errorf("Volleynet.RecommendPartners(), want 1 recommendation, got %v, %v", recommendations, err)
+	}
+}
+
+func TestRecommendPartnersMixed(t *testing.T) {
+	service := newPartnerService(t)
+
+	recommendations, err := service.RecommendPartners(context.Background(), 1, 4, 10)
+
+	if err != nil {
+		t.Fatalf("Volleynet.RecommendPartners() failed: %v", err)
+	}
+
+	if len(recommendations) != 1 || recommendations[0].Player.ID != 6 {
+		t.Errorf("Volleynet.RecommendPartners(), want only players of the other gender, got %v", recommendations)
+	}
+}
+
+func TestRecommendPartnersInvalid(t *testing.T) {
+	service := newPartnerService(t)
+
+	tests := []struct {
+		name         string
+		playerID     int
+		tournamentID int
+		limit        int
+		want         error
+	}{
+		{"finished tournament", 1, 1, 10, scores.ErrorValidation},
+		{"wrong gender", 6, 3, 10, scores.ErrorValidation},
+		{"registered player", 7, 3, 10, scores.ErrorValidation},
+		{"invalid limit", 1, 3, 0, scores.ErrorValidation},
+		{"unknown player", 9, 3, 10, scores.ErrNotFound},
+		{"unknown tournament", 1, 5, 10, scores.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		_, err := service.RecommendPartners(context.Background(), tt.playerID, tt.tournamentID, tt.limit)
+
+		if errors.Cause(err) != tt.want {
+			t.Errorf("Volleynet.RecommendPartners() %s, want %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
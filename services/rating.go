@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/rating"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// maxLeaderboard is the max. amount of players of a leaderboard.
+const maxLeaderboard = 500
+
+// Rating rates players by their tournament results and keeps their rating history.
+type Rating struct {
+	Repo       repo.RatingRepository
+	PlayerRepo repo.PlayerRepository
+}
+
+// RatingReport summarizes an update of the ratings.
+type RatingReport struct {
+	Tournaments int `json:"tournaments"` // # of rated tournaments
+	Ratings     int `json:"ratings"`     // # of new ratings
+	Deleted     int `json:"deleted"`     // # of ratings that have been recomputed
+}
+
+// Summary summarizes the report, e.g. for the job history.
+func (r *RatingReport) Summary() string {
+	return fmt.Sprintf("rated %d tournaments (%d ratings, %d recomputed)", r.Tournaments, r.Ratings, r.Deleted)
+}
+
+// Update rates all finished tournaments that have not been rated yet in
+// chronological order. If results of a tournament are added that started
+// before an already rated tournament (e.g. by a backfill of last season)
+// all ratings from this tournament on are recomputed.
+func (s *Rating) Update(ctx context.Context) (*RatingReport, error) {
+	results, err := s.Repo.Results(ctx)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading results")
+	}
+
+	ratedIDs, err := s.Repo.RatedTournaments(ctx)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading rated tournaments")
+	}
+
+	rated := map[int]bool{}
+
+	for _, id := range ratedIDs {
+		rated[id] = true
+	}
+
+	tournaments := groupResults(results)
+	first := -1
+
+	for i, t := range tournaments {
+		if !rated[t[0].TournamentID] {
+			first = i
+			break
+		}
+	}
+
+	report := &RatingReport{}
+
+	if first == -1 {
+		return report, nil
+	}
+
+	start := tournaments[first][0].TournamentStart
+
+	// ratings of tournaments after the first unrated one depend on
+	// its ratings, they are deleted and recomputed
+	if report.Deleted, err = s.Repo.DeleteFrom(ctx, start); err != nil {
+		return nil, errors.Wrap(err, "deleting outdated ratings")
+	}
+
+	for first > 0 && !tournaments[first-1][0].TournamentStart.Before(start) {
+		first--
+	}
+
+	current, err := s.Repo.Latest(ctx)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading current ratings")
+	}
+
+	engine := rating.NewEngine(current)
+
+	for _, t := range tournaments[first:] {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		ratings := engine.Rate(t)
+
+		if err := s.Repo.New(ctx, ratings...); err != nil {
+			return report, errors.Wrapf(err, "persisting ratings of tournament %d", t[0].TournamentID)
+		}
+
+		report.Tournaments++
+		report.Ratings += len(ratings)
+	}
+
+	return report, nil
+}
+
+// RequestRecompute makes the next `Run` recompute all ratings. The request
+// is persisted so the run of the instance that holds the lease of the
+// ratings job picks it up, this way a recompute never runs alongside an
+// update.
+func (s *Rating) RequestRecompute(ctx context.Context) error {
+	err := s.Repo.RequestRecompute(ctx)
+
+	return errors.Wrap(err, "requesting recompute")
+}
+
+// Run recomputes all ratings if it has been requested and otherwise rates
+// the new tournaments. A failed recompute is tried again by the next run.
+func (s *Rating) Run(ctx context.Context) (*RatingReport, error) {
+	requestID, err := s.Repo.RecomputeRequest(ctx)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading recompute request")
+	}
+
+	if requestID == 0 {
+		return s.Update(ctx)
+	}
+
+	report, err := s.Recompute(ctx)
+
+	if err != nil {
+		return report, err
+	}
+
+	// requests that arrived during the recompute are kept for the next run
+	err = s.Repo.ClearRecomputeRequests(ctx, requestID)
+
+	return report, errors.Wrap(err, "clearing recompute requests")
+}
+
+// Recompute deletes all ratings and rates all tournaments again.
+func (s *Rating) Recompute(ctx context.Context) (*RatingReport, error) {
+	deleted, err := s.Repo.DeleteFrom(ctx, time.Time{})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "deleting ratings")
+	}
+
+	report, err := s.Update(ctx)
+
+	if report != nil {
+		report.Deleted = deleted
+	}
+
+	return report, err
+}
+
+// Leaderboard returns the `limit` players of a gender with the highest rating.
+func (s *Rating) Leaderboard(ctx context.Context, gender string, limit int) ([]*volleynet.RatedPlayer, error) {
+	if limit < 1 || limit > maxLeaderboard {
+		return nil, errors.Wrapf(scores.ErrorValidation, "the limit must be between 1 and %d", maxLeaderboard)
+	}
+
+	players, err := s.Repo.Leaderboard(ctx, gender, limit)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading leaderboard")
+	}
+
+	for i, p := range players {
+		p.Rank = i + 1
+	}
+
+	return players, nil
+}
+
+// Timeline returns the rating history of a player, the oldest rating first.
+func (s *Rating) Timeline(ctx context.Context, playerID int) ([]*volleynet.Rating, error) {
+	if _, err := s.PlayerRepo.Get(ctx, playerID); err != nil {
+		return nil, errors.Wrap(err, "loading player")
+	}
+
+	ratings, err := s.Repo.ByPlayer(ctx, playerID)
+
+	return ratings, errors.Wrap(err, "loading rating history")
+}
+
+// groupResults groups the ordered results by tournament, tournaments
+// with a field of less than two teams are skipped because they can't
+// be rated.
+func groupResults(results []*volleynet.TeamResult) [][]*volleynet.TeamResult {
+	tournaments := [][]*volleynet.TeamResult{}
+
+	for i := 0; i < len(results); {
+		j := i + 1
+
+		for j < len(results) && results[j].TournamentID == results[i].TournamentID {
+			j++
+		}
+
+		if len(rating.Field(results[i:j])) >= 2 {
+			tournaments = append(tournaments, results[i:j])
+		}
+
+		i = j
+	}
+
+	return tournaments
+}
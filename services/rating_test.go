@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/repo/memory"
+	"github.com/raphi011/scores/volleynet"
+)
+
+// newFinishedTournament creates a finished tournament where the team of
+// players 1 and 2 beat the team of players 3 and 4.
+func newFinishedTournament(t *testing.T, repos *repo.Repositories, id int, start time.Time) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	_, err := repos.TournamentRepo.New(ctx, &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{ID: id, Start: start, Status: volleynet.StatusDone},
+	})
+
+	if err != nil {
+		t.Fatalf("tournamentRepo.New() failed: %v", err)
+	}
+
+	err = repos.TeamRepo.NewBatch(ctx,
+		&volleynet.TournamentTeam{TournamentID: id, Player1: &volleynet.Player{ID: 1}, Player2: &volleynet.Player{ID: 2}, Result: 1},
+		&volleynet.TournamentTeam{TournamentID: id, Player1: &volleynet.Player{ID: 3}, Player2: &volleynet.Player{ID: 4}, Result: 2},
+	)
+
+	if err != nil {
+		t.Fatalf("teamRepo.NewBatch() failed: %v", err)
+	}
+}
+
+func newRatingService() (*Rating, *repo.Repositories) {
+	repos := memory.Repositories()
+
+	return &Rating{Repo: repos.RatingRepo, PlayerRepo: repos.PlayerRepo}, repos
+}
+
+func date(month time.Month) time.Time {
+	return time.Date(2019, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRatingUpdate(t *testing.T) {
+	service, repos := newRatingService()
+
+	newFinishedTournament(t, repos, 1, date(6))
+
+	report, err := service.Update(context.Background())
+
+	if err != nil {
+		t.Fatalf("Rating.Update() failed: %v", err)
+	}
+
+	if report.Tournaments != 1 || report.Ratings != 4 || report.Deleted != 0 {
+		t.Errorf("Rating.Update(), want 1 rated tournament with 4 ratings, got %+v", report)
+	}
+
+	report, err = service.Update(context.Background())
+
+	if err != nil || report.Tournaments != 0 {
+		t.Errorf("Rating.Update(), want no changes without new results, got %+v, %v", report, err)
+	}
+
+	newFinishedTournament(t, repos, 2, date(7))
+
+	report, err = service.Update(context.Background())
+
+	if err != nil || report.Tournaments != 1 || report.Deleted != 0 {
+		t.Errorf("Rating.Update(), want only the new tournament to be rated, got %+v, %v", report, err)
+	}
+
+	history, _ := repos.RatingRepo.ByPlayer(context.Background(), 1)
+
+	if len(history) != 2 || history[1].Tournaments != 2 || history[1].Rating <= history[0].Rating {
+		t.Errorf("Rating.Update(), want the rating of player 1 to increase twice, got %+v", history)
+	}
+}
+
+func TestRatingUpdateBackfill(t *testing.T) {
+	service, repos := newRatingService()
+
+	newFinishedTournament(t, repos, 2, date(7))
+
+	if _, err := service.Update(context.Background()); err != nil {
+		t.Fatalf("Rating.Update() failed: %v", err)
+	}
+
+	// results of an older tournament are synced after the newer one has been rated
+	newFinishedTournament(t, repos, 1, date(6))
+
+	report, err := service.Update(context.Background())
+
+	if err != nil {
+		t.Fatalf("Rating.Update() failed: %v", err)
+	}
+
+	if report.Tournaments != 2 || report.Deleted != 4 {
+		t.Errorf("Rating.Update(), want both tournaments to be rated again, got %+v", report)
+	}
+
+	history, _ := repos.RatingRepo.ByPlayer(context.Background(), 1)
+
+	if len(history) != 2 || history[0].TournamentID != 1 || history[1].TournamentID != 2 {
+		t.Errorf("Rating.Update(), want the ratings in chronological order, got %+v", history)
+	}
+
+	report, err = service.Recompute(context.Background())
+
+	if err != nil || report.Tournaments != 2 || report.Deleted != 8 {
+		t.Errorf("Rating.Recompute(), want all tournaments to be rated again, got %+v, %v", report, err)
+	}
+
+	recomputed, _ := repos.RatingRepo.ByPlayer(context.Background(), 1)
+
+	if len(recomputed) != 2 || recomputed[1].Rating != history[1].Rating {
+		t.Errorf("Rating.Recompute(), want the same ratings, got %+v", recomputed)
+	}
+}
+
+func TestRatingRun(t *testing.T) {
+	service, repos := newRatingService()
+
+	newFinishedTournament(t, repos, 1, date(6))
+
+	if report, err := service.Run(context.Background()); err != nil || report.Tournaments != 1 {
+		t.Fatalf("Rating.Run(), want the tournament to be rated, got %+v, %v", report, err)
+	}
+
+	if report, err := service.Run(context.Background()); err != nil || report.Tournaments != 0 {
+		t.Errorf("Rating.Run(), want no tournament to be rated again, got %+v, %v", report, err)
+	}
+
+	if err := service.RequestRecompute(context.Background()); err != nil {
+		t.Fatalf("Rating.RequestRecompute() failed: %v", err)
+	}
+
+	if report, err := service.Run(context.Background()); err != nil || report.Tournaments != 1 || report.Deleted != 4 {
+		t.Errorf("Rating.Run() after a requested recompute, want all tournaments to be rated again, got %+v, %v", report, err)
+	}
+
+	if report, err := service.Run(context.Background()); err != nil || report.Deleted != 0 {
+		t.Errorf("Rating.Run(), want the recompute to be done once, got %+v, %v", report, err)
+	}
+}
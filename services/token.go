@@ -0,0 +1,29 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+)
+
+// Signer creates and verifies tokens that authenticate a user for a single
+// purpose (e.g. unsubscribing from emails) without a session.
+type Signer struct {
+	Secret []byte
+}
+
+// Token creates the token of a user for `purpose`.
+func (s *Signer) Token(purpose string, userID int) string {
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write([]byte(purpose + ":" + strconv.Itoa(userID)))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if `token` is the user's token for `purpose`.
+func (s *Signer) Verify(purpose string, userID int, token string) bool {
+	expected := s.Token(purpose, userID)
+
+	return hmac.Equal([]byte(expected), []byte(token))
+}
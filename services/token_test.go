@@ -0,0 +1,21 @@
+package services
+
+import "testing"
+
+func TestSignerVerify(t *testing.T) {
+	signer := &Signer{Secret: []byte("secret")}
+
+	token := signer.Token("unsubscribe", 1)
+
+	if !signer.Verify("unsubscribe", 1, token) {
+		t.Error("Signer.Verify(), want true for a valid token, got false")
+	}
+
+	if signer.Verify("unsubscribe", 2, token) {
+		t.Error("Signer.Verify(), want false for a different user, got true")
+	}
+
+	if signer.Verify("calendar", 1, token) {
+		t.Error("Signer.Verify(), want false for a different purpose, got true")
+	}
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/raphi011/scores"
@@ -17,8 +19,8 @@ type User struct {
 }
 
 // HasRole verifies if a user has a certain role
-func (s *User) HasRole(userID int, roleName string) bool {
-	user, err := s.Repo.ByID(userID)
+func (s *User) HasRole(ctx context.Context, userID int, roleName string) bool {
+	user, err := s.Repo.ByID(ctx, userID)
 
 	if err != nil {
 		return false
@@ -28,8 +30,8 @@ func (s *User) HasRole(userID int, roleName string) bool {
 }
 
 // UpdateTournamentFilter updates a users tournament filter
-func (s *User) UpdateTournamentFilter(userID int, filter repo.TournamentFilter) error {
-	return s.UpdateSettings(
+func (s *User) UpdateTournamentFilter(ctx context.Context, userID int, filter repo.TournamentFilter) error {
+	return s.UpdateSettings(ctx,
 		userID,
 		&scores.Setting{UserID: userID, Key: "tournament-filter-league", Type: "strings", Value: scores.ListToString(filter.Leagues)},
 		&scores.Setting{UserID: userID, Key: "tournament-filter-gender", Type: "strings", Value: scores.ListToString(filter.Genders)},
@@ -38,8 +40,8 @@ func (s *User) UpdateTournamentFilter(userID int, filter repo.TournamentFilter)
 }
 
 // UpdateSettings updates settings for a user
-func (s *User) UpdateSettings(userID int, settings ...*scores.Setting) error {
-	currentSettings, err := s.loadSettings(userID)
+func (s *User) UpdateSettings(ctx context.Context, userID int, settings ...*scores.Setting) error {
+	currentSettings, err := s.loadSettings(ctx, userID)
 
 	if err != nil {
 		return err
@@ -54,9 +56,9 @@ func (s *User) UpdateSettings(userID int, settings ...*scores.Setting) error {
 		if previousSetting, ok := currentSettings[setting.Key]; ok && previousSetting.Value == setting.Value {
 			continue
 		} else if ok {
-			err = s.SettingRepo.Update(setting)
+			err = s.SettingRepo.Update(ctx, setting)
 		} else {
-			_, err = s.SettingRepo.Create(setting)
+			_, err = s.SettingRepo.Create(ctx, setting)
 		}
 
 		if err != nil {
@@ -68,14 +70,14 @@ func (s *User) UpdateSettings(userID int, settings ...*scores.Setting) error {
 }
 
 // New creates a new user
-func (s *User) New(email, password string, role string) (*scores.User, error) {
+func (s *User) New(ctx context.Context, email, password string, role string) (*scores.User, error) {
 	passwordInfo, err := s.Password.Hash([]byte(password))
 
 	if err != nil {
 		return nil, errors.Wrap(err, "hashing password")
 	}
 
-	user, err := s.Repo.New(&scores.User{
+	user, err := s.Repo.New(ctx, &scores.User{
 		Email:        email,
 		PasswordInfo: *passwordInfo,
 		Role:         role,
@@ -94,10 +96,11 @@ func (s *User) New(email, password string, role string) (*scores.User, error) {
 
 // SetPassword sets a new password for a user
 func (s *User) SetPassword(
+	ctx context.Context,
 	userID int,
 	password string,
 ) error {
-	user, err := s.Repo.ByID(userID)
+	user, err := s.Repo.ByID(ctx, userID)
 
 	if err != nil {
 		return err
@@ -111,39 +114,63 @@ func (s *User) SetPassword(
 
 	user.PasswordInfo = *passwordInfo
 
-	err = s.Repo.Update(user)
+	err = s.Repo.Update(ctx, user)
 
 	return errors.Wrap(err, "could not update user password")
 }
 
 // ByEmail retrieves a user by email
-func (s *User) ByEmail(email string) (*scores.User, error) {
-	user, err := s.Repo.ByEmail(email)
+func (s *User) ByEmail(ctx context.Context, email string) (*scores.User, error) {
+	user, err := s.Repo.ByEmail(ctx, email)
 
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not load user by email %s", email)
 	}
 
-	user.Settings, err = s.loadSettingsDictionary(user.ID)
+	user.Settings, err = s.loadSettingsDictionary(ctx, user.ID)
 
 	return user, err
 }
 
 // ByID retrieves a user by ID
-func (s *User) ByID(userID int) (*scores.User, error) {
-	user, err := s.Repo.ByID(userID)
+func (s *User) ByID(ctx context.Context, userID int) (*scores.User, error) {
+	user, err := s.Repo.ByID(ctx, userID)
 
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not load user by ID %d", userID)
 	}
 
-	user.Settings, err = s.loadSettingsDictionary(userID)
+	user.Settings, err = s.loadSettingsDictionary(ctx, userID)
 
 	return user, err
 }
 
-func (s *User) loadSettingsDictionary(userID int) (scores.Settings, error) {
-	settings, err := s.SettingRepo.ByUserID(userID)
+// BySetting retrieves the user whose setting `key` has the value `value`.
+func (s *User) BySetting(ctx context.Context, key, value string) (*scores.User, error) {
+	settings, err := s.SettingRepo.ByKey(ctx, key)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load settings by key %q", key)
+	}
+
+	for _, setting := range settings {
+		if setting.Value == value {
+			return s.ByID(ctx, setting.UserID)
+		}
+	}
+
+	return nil, scores.ErrNotFound
+}
+
+// ClearSetting resets the value of a users setting.
+func (s *User) ClearSetting(ctx context.Context, userID int, key string) error {
+	err := s.SettingRepo.Update(ctx, &scores.Setting{UserID: userID, Key: key})
+
+	return errors.Wrapf(err, "clearing user's %d setting key %q", userID, key)
+}
+
+func (s *User) loadSettingsDictionary(ctx context.Context, userID int) (scores.Settings, error) {
+	settings, err := s.SettingRepo.ByUserID(ctx, userID)
 
 	if err != nil {
 		return nil, errors.Wrapf(err, "load settings for user %q", userID)
@@ -154,8 +181,8 @@ func (s *User) loadSettingsDictionary(userID int) (scores.Settings, error) {
 
 type settingsMap map[string]*scores.Setting
 
-func (s *User) loadSettings(userID int) (settingsMap, error) {
-	settings, err := s.SettingRepo.ByUserID(userID)
+func (s *User) loadSettings(ctx context.Context, userID int) (settingsMap, error) {
+	settings, err := s.SettingRepo.ByUserID(ctx, userID)
 
 	if err != nil {
 		return nil, errors.Wrapf(err, "load settings for user %q", userID)
@@ -170,14 +197,36 @@ func (s *User) loadSettings(userID int) (settingsMap, error) {
 	return settingsMap, nil
 }
 
-// All returns all users
-func (s *User) All() ([]*scores.User, error) {
-	return s.Repo.All()
+// All returns all users with their settings
+func (s *User) All(ctx context.Context) ([]*scores.User, error) {
+	users, err := s.Repo.All(ctx)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load users")
+	}
+
+	settings, err := s.SettingRepo.All(ctx)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load settings")
+	}
+
+	byUser := map[int][]*scores.Setting{}
+
+	for _, setting := range settings {
+		byUser[setting.UserID] = append(byUser[setting.UserID], setting)
+	}
+
+	for _, user := range users {
+		user.Settings = scores.ToSettingsDictionary(byUser[user.ID])
+	}
+
+	return users, nil
 }
 
 // SetProfileImage updates a users profile image
-func (s *User) SetProfileImage(userID int, imageURL string) error {
-	user, err := s.Repo.ByID(userID)
+func (s *User) SetProfileImage(ctx context.Context, userID int, imageURL string) error {
+	user, err := s.Repo.ByID(ctx, userID)
 
 	if err != nil {
 		return err
@@ -185,14 +234,14 @@ func (s *User) SetProfileImage(userID int, imageURL string) error {
 
 	user.ProfileImageURL = imageURL
 
-	err = s.Repo.Update(user)
+	err = s.Repo.Update(ctx, user)
 
 	return errors.Wrap(err, "updating profile image")
 }
 
 // SetVolleynetLogin updates the users volleynet login
-func (s *User) SetVolleynetLogin(userID, playerID int, playerLogin string) error {
-	user, err := s.Repo.ByID(userID)
+func (s *User) SetVolleynetLogin(ctx context.Context, userID, playerID int, playerLogin string) error {
+	user, err := s.Repo.ByID(ctx, userID)
 
 	if err != nil {
 		return err
@@ -201,7 +250,7 @@ func (s *User) SetVolleynetLogin(userID, playerID int, playerLogin string) error
 	user.PlayerLogin = playerLogin
 	user.PlayerID = playerID
 
-	err = s.Repo.Update(user)
+	err = s.Repo.Update(ctx, user)
 
 	return errors.Wrap(err, "updatin volleynet login")
 }
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"github.com/raphi011/scores/repo"
 	"github.com/raphi011/scores/volleynet"
@@ -22,8 +24,8 @@ func (s *Volleynet) ValidGender(gender string) bool {
 }
 
 // Ladder loads all players of the passed gender and with a rank > 0
-func (s *Volleynet) Ladder(gender string) ([]*volleynet.Player, error) {
-	return s.PlayerRepo.Ladder(gender)
+func (s *Volleynet) Ladder(ctx context.Context, gender string) ([]*volleynet.Player, error) {
+	return s.PlayerRepo.Ladder(ctx, gender)
 }
 
 // FilterOptions are the available tournament filters.
@@ -34,15 +36,72 @@ type FilterOptions struct {
 }
 
 // SearchTournaments searches for tournaments that satisfy the passed filter.
-func (s *Volleynet) SearchTournaments(filter repo.TournamentFilter) (
+func (s *Volleynet) SearchTournaments(ctx context.Context, filter repo.TournamentFilter) (
+	[]*volleynet.Tournament, error) {
+	return s.TournamentRepo.Search(ctx, filter)
+}
+
+// UpcomingTournaments searches for tournaments that satisfy the passed filter
+// and have not taken place yet.
+func (s *Volleynet) UpcomingTournaments(ctx context.Context, filter repo.TournamentFilter) (
 	[]*volleynet.Tournament, error) {
-	return s.TournamentRepo.Search(filter)
+	tournaments, err := s.TournamentRepo.Search(ctx, filter)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading tournaments")
+	}
+
+	upcoming := []*volleynet.Tournament{}
+
+	for _, t := range tournaments {
+		if t.Status == volleynet.StatusUpcoming {
+			upcoming = append(upcoming, t)
+		}
+	}
+
+	return upcoming, nil
+}
+
+// Registrations returns all upcoming tournaments of the current season
+// a player is signed up for.
+func (s *Volleynet) Registrations(ctx context.Context, playerID int) ([]*volleynet.Tournament, error) {
+	leagues, err := s.Leagues(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tournaments, err := s.UpcomingTournaments(ctx,
+		s.SetDefaultFilters(repo.TournamentFilter{Leagues: leagues}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	tournaments, err = s.addTeams(ctx, tournaments...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	registered := []*volleynet.Tournament{}
+
+	for _, t := range tournaments {
+		for _, team := range t.Teams {
+			if !team.Deregistered && team.HasPlayer(playerID) {
+				registered = append(registered, t)
+				break
+			}
+		}
+	}
+
+	return registered, nil
 }
 
 // SearchPlayers searches for players that satisfy the passed filter.
-func (s *Volleynet) SearchPlayers(filter repo.PlayerFilter) (
+func (s *Volleynet) SearchPlayers(ctx context.Context, filter repo.PlayerFilter) (
 	[]*volleynet.Player, error) {
-	return s.PlayerRepo.Search(filter)
+	return s.PlayerRepo.Search(ctx, filter)
 }
 
 // SetDefaultFilters sets filters to the users's previous setting - or the default value
@@ -63,13 +122,13 @@ func (s *Volleynet) SetDefaultFilters(filter repo.TournamentFilter) repo.Tournam
 }
 
 // TournamentFilterOptions returns available filter options
-func (s *Volleynet) TournamentFilterOptions() (*FilterOptions, error) {
-	leagues, err := s.Leagues()
+func (s *Volleynet) TournamentFilterOptions(ctx context.Context) (*FilterOptions, error) {
+	leagues, err := s.Leagues(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "loading leagues")
 	}
 
-	seasons, err := s.Seasons()
+	seasons, err := s.Seasons(ctx)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "loading seasons")
@@ -85,38 +144,64 @@ func (s *Volleynet) TournamentFilterOptions() (*FilterOptions, error) {
 }
 
 // Leagues loads all available Leagues as Name/Value pairs.
-func (s *Volleynet) Leagues() ([]string, error) {
-	leagues, err := s.TournamentRepo.Leagues()
+func (s *Volleynet) Leagues(ctx context.Context) ([]string, error) {
+	leagues, err := s.TournamentRepo.Leagues(ctx)
 
 	return leagues, errors.Wrap(err, "loading leagues")
 }
 
 // SubLeagues loads all available SubLeagues as Name/Value pairs.
-func (s *Volleynet) SubLeagues() ([]string, error) {
-	leagues, err := s.TournamentRepo.Leagues()
+func (s *Volleynet) SubLeagues(ctx context.Context) ([]string, error) {
+	leagues, err := s.TournamentRepo.Leagues(ctx)
 
 	return leagues, errors.Wrap(err, "loading leagues")
 }
 
 // PreviousPartners returns a list of all partners a player has played with before.
-func (s *Volleynet) PreviousPartners(playerID int) ([]*volleynet.Player, error) {
-	partners, err := s.PlayerRepo.PreviousPartners(playerID)
+func (s *Volleynet) PreviousPartners(ctx context.Context, playerID int) ([]*volleynet.Player, error) {
+	partners, err := s.PlayerRepo.PreviousPartners(ctx, playerID)
 
 	return partners, errors.Wrap(err, "loading parners")
 }
 
+// PlayerHistory returns all tournament entries of a player, the latest tournament first.
+func (s *Volleynet) PlayerHistory(ctx context.Context, playerID int, filter repo.PlayerHistoryFilter) (
+	[]*volleynet.PlayerTournament, error) {
+
+	if _, err := s.PlayerRepo.Get(ctx, playerID); err != nil {
+		return nil, errors.Wrap(err, "loading player")
+	}
+
+	history, err := s.PlayerRepo.History(ctx, playerID, filter)
+
+	return history, errors.Wrap(err, "loading player history")
+}
+
+// PlayerSeasonStats returns the statistics of a player per season, the latest season first.
+func (s *Volleynet) PlayerSeasonStats(ctx context.Context, playerID int, filter repo.PlayerHistoryFilter) (
+	[]*volleynet.SeasonStats, error) {
+
+	if _, err := s.PlayerRepo.Get(ctx, playerID); err != nil {
+		return nil, errors.Wrap(err, "loading player")
+	}
+
+	stats, err := s.PlayerRepo.SeasonStats(ctx, playerID, filter)
+
+	return stats, errors.Wrap(err, "loading player statistics")
+}
+
 // Seasons loads all available seasons.
-func (s *Volleynet) Seasons() ([]string, error) {
-	leagues, err := s.TournamentRepo.Seasons()
+func (s *Volleynet) Seasons(ctx context.Context) ([]string, error) {
+	leagues, err := s.TournamentRepo.Seasons(ctx)
 
 	return leagues, errors.Wrap(err, "loading leagues")
 }
 
-func (s *Volleynet) addTeams(tournaments ...*volleynet.Tournament) ([]*volleynet.Tournament, error) {
+func (s *Volleynet) addTeams(ctx context.Context, tournaments ...*volleynet.Tournament) ([]*volleynet.Tournament, error) {
 	var err error
 
 	for _, t := range tournaments {
-		t.Teams, err = s.TeamRepo.ByTournament(t.ID)
+		t.Teams, err = s.TeamRepo.ByTournament(ctx, t.ID)
 
 		if err != nil {
 			return nil, errors.Wrapf(err, "adding teams of tournamentID %d", t.ID)
@@ -126,18 +211,20 @@ func (s *Volleynet) addTeams(tournaments ...*volleynet.Tournament) ([]*volleynet
 	return tournaments, nil
 }
 
-// TournamentInfo loads a tournament and its teams
-func (s *Volleynet) TournamentInfo(tournamentID int) (
+// TournamentInfo loads a tournament and its teams, upcoming tournaments
+// include the prediction of the main draw cut.
+func (s *Volleynet) TournamentInfo(ctx context.Context, tournamentID int) (
 	*volleynet.Tournament, error) {
-	tournament, err := s.TournamentRepo.Get(tournamentID)
+	tournament, err := s.TournamentRepo.Get(ctx, tournamentID)
 
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := s.addTeams(tournament)
+	result, err := s.addTeams(ctx, tournament)
 
 	if err == nil {
+		PredictCut(result[0])
 		return result[0], nil
 	}
 
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/events"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+const (
+	// DefaultActivityRetention is how long activities are kept if no retention is set.
+	DefaultActivityRetention = 90 * 24 * time.Hour
+
+	// maxWatchlist is the max. amount of watched tournaments and followed players of a user.
+	maxWatchlist = 100
+	// defaultFeedLimit and maxFeedLimit are the default and max. # of activities of a feed.
+	defaultFeedLimit = 50
+	maxFeedLimit     = 200
+)
+
+// Watchlist lets users watch tournaments and follow players without
+// signing up for them, their activities are recorded from the sync events.
+type Watchlist struct {
+	Repo           repo.WatchRepository
+	ActivityRepo   repo.ActivityRepository
+	PlayerRepo     repo.PlayerRepository
+	TournamentRepo repo.TournamentRepository
+
+	// Retention is how long activities are kept, `DefaultActivityRetention` if not set.
+	Retention time.Duration
+
+	now clock
+}
+
+// Tournaments returns the watched tournaments of a user, the latest watched
+// first. Tournaments that don't exist anymore are skipped.
+func (s *Watchlist) Tournaments(ctx context.Context, userID int) ([]*volleynet.Tournament, error) {
+	watches, err := s.Repo.Tournaments(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading watchlist")
+	}
+
+	tournaments := []*volleynet.Tournament{}
+
+	for _, w := range watches {
+		tournament, err := s.TournamentRepo.Get(ctx, w.TournamentID)
+
+		if errors.Cause(err) == scores.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "loading tournament")
+		}
+
+		tournament.Watched = true
+		tournaments = append(tournaments, tournament)
+	}
+
+	return tournaments, nil
+}
+
+// WatchedIDs returns the ids of the tournaments a user watches.
+func (s *Watchlist) WatchedIDs(ctx context.Context, userID int) (map[int]bool, error) {
+	watches, err := s.Repo.Tournaments(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading watchlist")
+	}
+
+	ids := map[int]bool{}
+
+	for _, w := range watches {
+		ids[w.TournamentID] = true
+	}
+
+	return ids, nil
+}
+
+// Watch adds a tournament to the watchlist of a user.
+func (s *Watchlist) Watch(ctx context.Context, userID, tournamentID int) (*volleynet.WatchedTournament, error) {
+	if _, err := s.TournamentRepo.Get(ctx, tournamentID); err != nil {
+		return nil, errors.Wrap(err, "loading tournament")
+	}
+
+	watched, err := s.WatchedIDs(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if watched[tournamentID] {
+		return nil, errors.Wrap(scores.ErrorValidation, "the tournament is already watched")
+	}
+
+	if len(watched) >= maxWatchlist {
+		return nil, errors.Wrapf(scores.ErrorValidation, "a user can watch at most %d tournaments", maxWatchlist)
+	}
+
+	w, err := s.Repo.WatchTournament(ctx, &volleynet.WatchedTournament{
+		UserID:       userID,
+		TournamentID: tournamentID,
+	})
+
+	return w, errors.Wrap(err, "watching tournament")
+}
+
+// Unwatch removes a tournament from the watchlist of a user.
+func (s *Watchlist) Unwatch(ctx context.Context, userID, tournamentID int) error {
+	return errors.Wrap(s.Repo.UnwatchTournament(ctx, userID, tournamentID), "unwatching tournament")
+}
+
+// Players returns the followed players of a user, the latest followed first.
+func (s *Watchlist) Players(ctx context.Context, userID int) ([]*volleynet.FollowedPlayer, error) {
+	follows, err := s.Repo.Players(ctx, userID)
+
+	return follows, errors.Wrap(err, "loading followed players")
+}
+
+// Follow adds a player to the followed players of a user.
+func (s *Watchlist) Follow(ctx context.Context, userID, playerID int) (*volleynet.FollowedPlayer, error) {
+	player, err := s.PlayerRepo.Get(ctx, playerID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading player")
+	}
+
+	follows, err := s.Players(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range follows {
+		if f.Player.ID == playerID {
+			return nil, errors.Wrap(scores.ErrorValidation, "the player is already followed")
+		}
+	}
+
+	if len(follows) >= maxWatchlist {
+		return nil, errors.Wrapf(scores.ErrorValidation, "a user can follow at most %d players", maxWatchlist)
+	}
+
+	f, err := s.Repo.FollowPlayer(ctx, &volleynet.FollowedPlayer{
+		UserID: userID,
+		Player: player,
+	})
+
+	return f, errors.Wrap(err, "following player")
+}
+
+// Unfollow removes a player from the followed players of a user.
+func (s *Watchlist) Unfollow(ctx context.Context, userID, playerID int) error {
+	return errors.Wrap(s.Repo.UnfollowPlayer(ctx, userID, playerID), "unfollowing player")
+}
+
+// Feed returns the latest activities of the watched tournaments and the
+// followed players of a user, if `limit` is 0 the default limit is used.
+func (s *Watchlist) Feed(ctx context.Context, userID, limit int) ([]*volleynet.Activity, error) {
+	if limit < 0 || limit > maxFeedLimit {
+		return nil, errors.Wrapf(scores.ErrorValidation, "the limit must be between 1 and %d", maxFeedLimit)
+	}
+
+	if limit == 0 {
+		limit = defaultFeedLimit
+	}
+
+	filter := repo.ActivityFilter{
+		TournamentIDs: []int{},
+		PlayerIDs:     []int{},
+		Since:         s.now.time().Add(-s.retention()),
+		Limit:         limit,
+	}
+
+	watches, err := s.Repo.Tournaments(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading watchlist")
+	}
+
+	for _, w := range watches {
+		filter.TournamentIDs = append(filter.TournamentIDs, w.TournamentID)
+	}
+
+	follows, err := s.Players(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range follows {
+		filter.PlayerIDs = append(filter.PlayerIDs, f.Player.ID)
+	}
+
+	if len(filter.TournamentIDs) == 0 && len(filter.PlayerIDs) == 0 {
+		return []*volleynet.Activity{}, nil
+	}
+
+	activities, err := s.ActivityRepo.Feed(ctx, filter)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading activities")
+	}
+
+	players := map[int]*volleynet.Player{}
+
+	for _, a := range activities {
+		for _, id := range []int{a.Player1ID, a.Player2ID} {
+			if id == 0 {
+				continue
+			}
+
+			player, ok := players[id]
+
+			if !ok {
+				player, err = s.PlayerRepo.Get(ctx, id)
+
+				if errors.Cause(err) == scores.ErrNotFound {
+					player = nil
+				} else if err != nil {
+					return nil, errors.Wrap(err, "loading player")
+				}
+
+				players[id] = player
+			}
+
+			if player != nil {
+				a.Players = append(a.Players, player)
+			}
+		}
+	}
+
+	return activities, nil
+}
+
+// Listen subscribes to the tournament and player events and records their
+// activities until unsubscribed, failures are passed to `onError`.
+func (s *Watchlist) Listen(subscriber events.Subscriber, onError func(events.Event, error)) events.Unsubscribe {
+	unsubscribes := []events.Unsubscribe{}
+
+	for _, name := range []string{sync.TournamentEventsType, sync.PlayerEventsType} {
+		subscription, unsubscribe := subscriber.Subscribe(name)
+		unsubscribes = append(unsubscribes, unsubscribe)
+
+		go func() {
+			for event := range subscription {
+				if err := s.Record(context.Background(), event); err != nil {
+					onError(event, err)
+				}
+			}
+		}()
+	}
+
+	return func() {
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}
+}
+
+// Record persists the activities of a sync event. Ladder rank changes are
+// only recorded for players that are followed by at least one user.
+func (s *Watchlist) Record(ctx context.Context, event events.Event) error {
+	var activities []*volleynet.Activity
+	var err error
+
+	switch body := event.Body.(type) {
+	case sync.TournamentEvent:
+		activities = tournamentActivities(event.Name, &body)
+	case sync.PlayerEvent:
+		activities, err = s.playerActivities(ctx, event.Name, &body)
+	default:
+		err = errors.Errorf("unexpected event body %T", event.Body)
+	}
+
+	if err != nil || len(activities) == 0 {
+		return err
+	}
+
+	return errors.Wrap(s.ActivityRepo.New(ctx, activities...), "recording activities")
+}
+
+// Cleanup deletes the activities that are older than the retention.
+func (s *Watchlist) Cleanup(ctx context.Context) error {
+	_, err := s.ActivityRepo.DeleteBefore(ctx, s.now.time().Add(-s.retention()))
+
+	return errors.Wrap(err, "cleaning up activities")
+}
+
+func (s *Watchlist) playerActivities(ctx context.Context, name string, event *sync.PlayerEvent) (
+	[]*volleynet.Activity, error) {
+
+	if name != sync.LadderRankEventType {
+		return nil, nil
+	}
+
+	followed, err := s.Repo.IsFollowed(ctx, event.Player.ID)
+
+	if err != nil || !followed {
+		return nil, errors.Wrap(err, "loading followed players")
+	}
+
+	return []*volleynet.Activity{{
+		Type:         volleynet.ActivityLadderRank,
+		Player1ID:    event.Player.ID,
+		LadderRank:   event.Player.LadderRank,
+		PreviousRank: event.PreviousRank,
+	}}, nil
+}
+
+func (s *Watchlist) retention() time.Duration {
+	if s.Retention > 0 {
+		return s.Retention
+	}
+
+	return DefaultActivityRetention
+}
+
+// tournamentActivities returns the activities of a tournament event,
+// the results of a tournament are an activity per team.
+func tournamentActivities(name string, event *sync.TournamentEvent) []*volleynet.Activity {
+	t := event.Tournament
+
+	activity := func(activityType string, team *volleynet.TournamentTeam) *volleynet.Activity {
+		a := &volleynet.Activity{
+			Type:           activityType,
+			TournamentID:   t.ID,
+			TournamentName: t.Name,
+		}
+
+		if team != nil {
+			if team.Player1 != nil {
+				a.Player1ID = team.Player1.ID
+			}
+
+			if team.Player2 != nil {
+				a.Player2ID = team.Player2.ID
+			}
+
+			a.Result = team.Result
+		}
+
+		return a
+	}
+
+	switch name {
+	case sync.RegistrationOpenEventType:
+		return []*volleynet.Activity{activity(volleynet.ActivityRegistrationOpen, nil)}
+	case sync.TeamRegisteredEventType:
+		return []*volleynet.Activity{activity(volleynet.ActivityRegistration, event.Team)}
+	case sync.TeamMainDrawEventType:
+		return []*volleynet.Activity{activity(volleynet.ActivityMainDraw, event.Team)}
+	case sync.ResultsEventType:
+		activities := []*volleynet.Activity{}
+
+		for _, team := range t.Teams {
+			if team.Result > 0 {
+				activities = append(activities, activity(volleynet.ActivityResult, team))
+			}
+		}
+
+		return activities
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/events"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+func newWatchlist(t *testing.T) (*Watchlist, *scores.User) {
+	t.Helper()
+
+	user := &scores.User{Email: "test@example.com"}
+
+	repos := seed(t, fixture{
+		players: []*volleynet.Player{
+			{ID: 1, Gender: "M", FirstName: "Richard"},
+			{ID: 2, Gender: "M"},
+			{ID: 3, Gender: "M"},
+			{ID: 4, Gender: "M"},
+		},
+		users: []*scores.User{user},
+		tournaments: []*volleynet.Tournament{
+			{TournamentInfo: volleynet.TournamentInfo{ID: 1, Name: "Wien", Status: volleynet.StatusUpcoming}},
+			{TournamentInfo: volleynet.TournamentInfo{ID: 2, Name: "Graz", Status: volleynet.StatusUpcoming}},
+		},
+	})
+
+	return &Watchlist{
+		Repo:           repos.WatchRepo,
+		ActivityRepo:   repos.ActivityRepo,
+		PlayerRepo:     repos.PlayerRepo,
+		TournamentRepo: repos.TournamentRepo,
+	}, user
+}
+
+func TestWatchlistWatch(t *testing.T) {
+	ctx := context.Background()
+	service, user := newWatchlist(t)
+
+	if _, err := service.Watch(ctx, user.ID, 1); err != nil {
+		t.Fatalf("Watchlist.Watch() failed: %v", err)
+	}
+
+	if _, err := service.Watch(ctx, user.ID, 1); errors.Cause(err) != scores.ErrorValidation {
+		t.Errorf("Watchlist.Watch() of a watched tournament, want a validation error, got: %v", err)
+	}
+
+	if _, err := service.Watch(ctx, user.ID, 3); errors.Cause(err) != scores.ErrNotFound {
+		t.Errorf("Watchlist.Watch() of a missing tournament, want ErrNotFound, got: %v", err)
+	}
+
+	tournaments, err := service.Tournaments(ctx, user.ID)
+
+	if err != nil {
+		t.Fatalf("Watchlist.Tournaments() failed: %v", err)
+	}
+
+	if len(tournaments) != 1 || tournaments[0].ID != 1 || !tournaments[0].Watched {
+		t.Errorf("Watchlist.Tournaments(), want the watched tournament 1, got %+v", tournaments)
+	}
+
+	if err := service.Unwatch(ctx, user.ID, 1); err != nil {
+		t.Fatalf("Watchlist.Unwatch() failed: %v", err)
+	}
+
+	if err := service.Unwatch(ctx, user.ID, 1); errors.Cause(err) != scores.ErrNotFound {
+		t.Errorf("Watchlist.Unwatch() of an unwatched tournament, want ErrNotFound, got: %v", err)
+	}
+}
+
+func TestWatchlistFollow(t *testing.T) {
+	ctx := context.Background()
+	service, user := newWatchlist(t)
+
+	if _, err := service.Follow(ctx, user.ID, 1); err != nil {
+		t.Fatalf("Watchlist.Follow() failed: %v", err)
+	}
+
+	if _, err := service.Follow(ctx, user.ID, 1); errors.Cause(err) != scores.ErrorValidation {
+		t.Errorf("Watchlist.Follow() of a followed player, want a validation error, got: %v", err)
+	}
+
+	if _, err := service.Follow(ctx, user.ID, 5); errors.Cause(err) != scores.ErrNotFound {
+		t.Errorf("Watchlist.Follow() of a missing player, want ErrNotFound, got: %v", err)
+	}
+
+	follows, err := service.Players(ctx, user.ID)
+
+	if err != nil {
+		t.Fatalf("Watchlist.Players() failed: %v", err)
+	}
+
+	if len(follows) != 1 || follows[0].Player.FirstName != "Richard" {
+		t.Errorf("Watchlist.Players(), want the followed player 1, got %+v", follows)
+	}
+}
+
+func TestWatchlistFeed(t *testing.T) {
+	ctx := context.Background()
+	service, user := newWatchlist(t)
+
+	if _, err := service.Watch(ctx, user.ID, 1); err != nil {
+		t.Fatalf("Watchlist.Watch() failed: %v", err)
+	}
+
+	if _, err := service.Follow(ctx, user.ID, 3); err != nil {
+		t.Fatalf("Watchlist.Follow() failed: %v", err)
+	}
+
+	wien := &volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{ID: 1, Name: "Wien"}}
+	graz := &volleynet.Tournament{TournamentInfo: volleynet.TournamentInfo{ID: 2, Name: "Graz"},
+		Teams: []*volleynet.TournamentTeam{
+			{TournamentID: 2, Player1: &volleynet.Player{ID: 1}, Player2: &volleynet.Player{ID: 2}, Result: 1},
+			{TournamentID: 2, Player1: &volleynet.Player{ID: 3}, Player2: &volleynet.Player{ID: 4}, Result: 2},
+		},
+	}
+
+	recorded := []events.Event{
+		{Name: sync.RegistrationOpenEventType, Body: sync.TournamentEvent{Tournament: wien}},
+		{Name: sync.TeamRegisteredEventType, Body: sync.TournamentEvent{Tournament: graz, Team: graz.Teams[0]}},
+		{Name: sync.ResultsEventType, Body: sync.TournamentEvent{Tournament: graz}},
+		{Name: sync.LadderRankEventType, Body: sync.PlayerEvent{Player: &volleynet.Player{ID: 3, LadderRank: 5}, PreviousRank: 7}},
+		{Name: sync.LadderRankEventType, Body: sync.PlayerEvent{Player: &volleynet.Player{ID: 1, LadderRank: 1}, PreviousRank: 2}},
+	}
+
+	for _, event := range recorded {
+		if err := service.Record(ctx, event); err != nil {
+			t.Fatalf("Watchlist.Record(%q) failed: %v", event.Name, err)
+		}
+	}
+
+	feed, err := service.Feed(ctx, user.ID, 0)
+
+	if err != nil {
+		t.Fatalf("Watchlist.Feed() failed: %v", err)
+	}
+
+	// the registration in Graz, the result of team 1 and the ladder rank
+	// of player 1 don't concern the user
+	want := []string{volleynet.ActivityLadderRank, volleynet.ActivityResult, volleynet.ActivityRegistrationOpen}
+
+	if len(feed) != len(want) {
+		t.Fatalf("Watchlist.Feed(), want %d activities, got %d: %+v", len(want), len(feed), feed)
+	}
+
+	for i, activityType := range want {
+		if feed[i].Type != activityType {
+			t.Errorf("Watchlist.Feed(), activity %d: want type %q, got %q", i, activityType, feed[i].Type)
+		}
+	}
+
+	if feed[0].PreviousRank != 7 || feed[0].LadderRank != 5 || len(feed[0].Players) != 1 {
+		t.Errorf("Watchlist.Feed(), want the rank change of player 3, got %+v", feed[0])
+	}
+
+	if feed[1].Result != 2 || feed[1].TournamentName != "Graz" || len(feed[1].Players) != 2 {
+		t.Errorf("Watchlist.Feed(), want the result of team 2 with its players, got %+v", feed[1])
+	}
+
+	if _, err := service.Feed(ctx, user.ID, maxFeedLimit+1); errors.Cause(err) != scores.ErrorValidation {
+		t.Errorf("Watchlist.Feed() with a too large limit, want a validation error, got: %v", err)
+	}
+
+	service.now = func() time.Time { return time.Now().Add(DefaultActivityRetention + time.Hour) }
+
+	if err := service.Cleanup(ctx); err != nil {
+		t.Fatalf("Watchlist.Cleanup() failed: %v", err)
+	}
+
+	service.now = nil
+	feed, err = service.Feed(ctx, user.ID, 0)
+
+	if err != nil || len(feed) != 0 {
+		t.Errorf("Watchlist.Feed() after the cleanup, want no activities, got %d (%v)", len(feed), err)
+	}
+}
@@ -0,0 +1,249 @@
+package telegram
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/notify"
+	"github.com/raphi011/scores/repo"
+	"github.com/raphi011/scores/services"
+)
+
+// ChatSettingKey is the user setting that stores the id of the linked telegram chat.
+const ChatSettingKey = "telegram-chat-id"
+
+// LinkCodeSettingKey is the user setting that stores the pending link code,
+// it's stored with the user so every instance can redeem it.
+const LinkCodeSettingKey = "telegram-link-code"
+
+// LinkCodeExpiresSettingKey is the user setting that stores when the pending
+// link code expires.
+const LinkCodeExpiresSettingKey = "telegram-link-code-expires"
+
+// pollerName is the name the offset of the consumed updates is stored with.
+const pollerName = "telegram"
+
+// linkCodeValidity is the duration a link code can be used to link a chat.
+const linkCodeValidity = 10 * time.Minute
+
+var _ notify.Channel = &Bot{}
+
+// Bot answers commands sent to the telegram bot and pushes
+// notifications to linked chats.
+type Bot struct {
+	Log    logrus.FieldLogger
+	Client Client
+
+	UserService      *services.User
+	VolleynetService *services.Volleynet
+
+	// OffsetRepo stores the offset of the consumed updates so a restarted
+	// bot (or another instance) doesn't handle them again.
+	OffsetRepo repo.PollOffsetRepository
+
+	lock   sync.Mutex
+	cancel context.CancelFunc
+}
+
+// Name returns the name of the notification channel.
+func (b *Bot) Name() string {
+	return "telegram"
+}
+
+// LinkCode creates a short lived code that links a chat to the user
+// when sent to the bot via `/start <code>`, it replaces the user's
+// previous code.
+func (b *Bot) LinkCode(ctx context.Context, userID int) (string, error) {
+	bytes := make([]byte, 5)
+
+	if _, err := rand.Read(bytes); err != nil {
+		return "", errors.Wrap(err, "generating link code")
+	}
+
+	code := base32.StdEncoding.EncodeToString(bytes)
+	expires := time.Now().Add(linkCodeValidity)
+
+	err := b.UserService.UpdateSettings(ctx, userID,
+		&scores.Setting{UserID: userID, Key: LinkCodeSettingKey, Type: "string", Value: code},
+		&scores.Setting{UserID: userID, Key: LinkCodeExpiresSettingKey, Type: "string", Value: expires.Format(time.RFC3339)},
+	)
+
+	return code, errors.Wrap(err, "storing link code")
+}
+
+// redeemLinkCode returns the userID of a valid link code, a code
+// can only be redeemed once.
+func (b *Bot) redeemLinkCode(ctx context.Context, code string) (int, bool, error) {
+	user, err := b.UserService.BySetting(ctx, LinkCodeSettingKey, code)
+
+	if errors.Cause(err) == scores.ErrNotFound {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+
+	for _, key := range []string{LinkCodeSettingKey, LinkCodeExpiresSettingKey} {
+		if err := b.UserService.ClearSetting(ctx, user.ID, key); err != nil {
+			return 0, false, err
+		}
+	}
+
+	value, _ := user.Settings[LinkCodeExpiresSettingKey].(string)
+	expires, err := time.Parse(time.RFC3339, value)
+
+	if err != nil || time.Now().After(expires) {
+		return 0, false, nil
+	}
+
+	return user.ID, true, nil
+}
+
+// Run polls for updates and handles them until `ctx` is done or `Stop` is
+// called. Telegram only allows one poller per bot, it's run as a job that
+// holds a lease so only one instance polls at a time. Polling resumes at
+// the stored offset, telegram keeps unconfirmed updates for 24 hours.
+func (b *Bot) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	b.lock.Lock()
+	b.cancel = cancel
+	b.lock.Unlock()
+
+	offset, err := b.OffsetRepo.Get(ctx, pollerName)
+
+	if err != nil {
+		return errors.Wrap(err, "loading telegram offset")
+	}
+
+	for ctx.Err() == nil {
+		updates, err := b.Client.Updates(ctx, offset, 60*time.Second)
+
+		if ctx.Err() != nil {
+			break
+		} else if err != nil {
+			b.Log.Warnf("telegram: %v", err)
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(10 * time.Second):
+			}
+
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.ID + 1
+
+			b.HandleUpdate(ctx, update)
+		}
+
+		if len(updates) == 0 {
+			continue
+		}
+
+		// stored even if the bot is being stopped, the handled updates
+		// are only confirmed to telegram by the next poll
+		if err := b.OffsetRepo.Set(context.Background(), pollerName, offset); err != nil {
+			b.Log.Warnf("telegram: storing offset: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Stop stops polling for updates.
+func (b *Bot) Stop() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.cancel != nil {
+		b.cancel()
+		b.cancel = nil
+	}
+}
+
+// HandleUpdate executes a command and replies to the chat.
+func (b *Bot) HandleUpdate(ctx context.Context, update *Update) {
+	if update.Message == nil || !strings.HasPrefix(update.Message.Text, "/") {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	name, args := parseCommand(update.Message.Text)
+
+	cmd, ok := commands[name]
+
+	if !ok {
+		cmd = helpCommand
+	}
+
+	reply, err := cmd(ctx, b, chatID, args)
+
+	if err != nil {
+		b.Log.Warnf("telegram: command %q failed: %v", name, err)
+		reply = "Sorry, something went wrong."
+	}
+
+	err = b.Client.SendMessage(chatID, reply)
+
+	if err != nil {
+		b.Log.Warnf("telegram: %v", err)
+	}
+}
+
+// parseCommand splits a message like "/ladder@scoresbot W" into the
+// command "ladder" and its arguments.
+func parseCommand(text string) (string, []string) {
+	fields := strings.Fields(text)
+	name := strings.TrimPrefix(fields[0], "/")
+
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+
+	return strings.ToLower(name), fields[1:]
+}
+
+// Notify sends the notification to the user's linked chat, if there is one.
+func (b *Bot) Notify(user *scores.User, notification *notify.Notification) error {
+	chatID, ok := linkedChat(user)
+
+	if !ok {
+		return nil
+	}
+
+	text := notificationMessage(user, notification)
+
+	if text == "" {
+		return nil
+	}
+
+	return b.Client.SendMessage(chatID, text)
+}
+
+func linkedChat(user *scores.User) (int64, bool) {
+	value, ok := user.Settings[ChatSettingKey].(string)
+
+	if !ok || value == "" {
+		return 0, false
+	}
+
+	chatID, err := strconv.ParseInt(value, 10, 64)
+
+	return chatID, err == nil
+}
+
+// userByChat loads the user that has linked the chat.
+func (b *Bot) userByChat(ctx context.Context, chatID int64) (*scores.User, error) {
+	return b.UserService.BySetting(ctx, ChatSettingKey, strconv.FormatInt(chatID, 10))
+}
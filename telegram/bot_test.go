@@ -0,0 +1,163 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo/sql"
+	"github.com/raphi011/scores/services"
+	"github.com/raphi011/scores/test"
+)
+
+// fakeTelegram stands in for the telegram bot api and records all sent messages.
+type fakeTelegram struct {
+	messages []sendMessageRequest
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/sendMessage") {
+		msg := sendMessageRequest{}
+		json.NewDecoder(r.Body).Decode(&msg)
+		f.messages = append(f.messages, msg)
+	}
+
+	w.Write([]byte(`{"ok":true,"result":[]}`))
+}
+
+func botMock(t *testing.T) (*Bot, *fakeTelegram, *services.User) {
+	repos, db := sql.RepositoriesTest(t)
+	fake := &fakeTelegram{}
+	server := httptest.NewServer(fake)
+
+	t.Cleanup(server.Close)
+
+	sql.CreatePlayers(t, db,
+		sql.P{ID: 1, Gender: "M", FirstName: "Alex", LastName: "Horst", LadderRank: 1, TotalPoints: 400},
+		sql.P{ID: 2, Gender: "M", FirstName: "Clemens", LastName: "Doppler", LadderRank: 2, TotalPoints: 380},
+	)
+
+	userService := &services.User{
+		Repo:        repos.UserRepo,
+		PlayerRepo:  repos.PlayerRepo,
+		SettingRepo: repos.SettingRepo,
+		Password:    &services.PBKDF2Password{SaltBytes: 16, Iterations: 1},
+	}
+
+	bot := &Bot{
+		Log:         logrus.New(),
+		Client:      &Default{URL: server.URL, Token: "token", HTTPClient: server.Client()},
+		UserService: userService,
+		OffsetRepo:  repos.PollOffsetRepo,
+		VolleynetService: &services.Volleynet{
+			PlayerRepo:     repos.PlayerRepo,
+			TeamRepo:       repos.TeamRepo,
+			TournamentRepo: repos.TournamentRepo,
+		},
+	}
+
+	return bot, fake, userService
+}
+
+// pollClient returns `updates` on the first poll and stops the bot on
+// the next one, it records the polled offsets.
+type pollClient struct {
+	Client
+
+	bot     *Bot
+	updates []*Update
+	offsets []int
+}
+
+func (c *pollClient) Updates(ctx context.Context, offset int, timeout time.Duration) ([]*Update, error) {
+	c.offsets = append(c.offsets, offset)
+	updates := c.updates
+	c.updates = nil
+
+	if updates == nil {
+		c.bot.Stop()
+	}
+
+	return updates, nil
+}
+
+func message(chatID int64, text string) *Update {
+	return &Update{Message: &Message{Chat: Chat{ID: chatID}, Text: text}}
+}
+
+func TestLadderCommand(t *testing.T) {
+	bot, fake, _ := botMock(t)
+
+	bot.HandleUpdate(context.Background(), message(42, "/ladder M"))
+
+	test.Assert(t, "expected 1 message, got %d", len(fake.messages) == 1, len(fake.messages))
+	test.Assert(t, "expected the ladder, got %q",
+		strings.Contains(fake.messages[0].Text, "1. Alex Horst (400)"),
+		fake.messages[0].Text)
+}
+
+func TestLinkChat(t *testing.T) {
+	bot, fake, userService := botMock(t)
+
+	user, err := userService.New(context.Background(), "test@test.at", "test", "user")
+	test.Check(t, "userService.New() failed: %v", err)
+
+	code, err := bot.LinkCode(context.Background(), user.ID)
+	test.Check(t, "bot.LinkCode() failed: %v", err)
+
+	bot.HandleUpdate(context.Background(), message(42, "/start "+code))
+
+	linked, err := bot.userByChat(context.Background(), 42)
+	test.Check(t, "bot.userByChat() failed: %v", err)
+	test.Equal(t, "expected user %d to be linked, got %d", user.ID, linked.ID)
+
+	bot.HandleUpdate(context.Background(), message(43, "/link "+code))
+
+	_, err = bot.userByChat(context.Background(), 43)
+	test.Assert(t, "link codes must only be redeemable once", err != nil)
+	test.Assert(t, "expected 2 messages, got %d", len(fake.messages) == 2, len(fake.messages))
+}
+
+func TestLinkChatExpiredCode(t *testing.T) {
+	bot, _, userService := botMock(t)
+
+	user, err := userService.New(context.Background(), "test@test.at", "test", "user")
+	test.Check(t, "userService.New() failed: %v", err)
+
+	code, err := bot.LinkCode(context.Background(), user.ID)
+	test.Check(t, "bot.LinkCode() failed: %v", err)
+
+	err = userService.UpdateSettings(context.Background(), user.ID, &scores.Setting{
+		UserID: user.ID,
+		Key:    LinkCodeExpiresSettingKey,
+		Type:   "string",
+		Value:  time.Now().Add(-time.Minute).Format(time.RFC3339),
+	})
+	test.Check(t, "userService.UpdateSettings() failed: %v", err)
+
+	bot.HandleUpdate(context.Background(), message(42, "/link "+code))
+
+	_, err = bot.userByChat(context.Background(), 42)
+	test.Assert(t, "expired link codes must not link a chat", err != nil)
+}
+
+func TestRunResumesAtStoredOffset(t *testing.T) {
+	bot, _, _ := botMock(t)
+	client := &pollClient{Client: bot.Client, bot: bot, updates: []*Update{{ID: 7}, {ID: 8}}}
+	bot.Client = client
+
+	err := bot.Run(context.Background())
+	test.Check(t, "bot.Run() failed: %v", err)
+
+	err = bot.Run(context.Background())
+	test.Check(t, "bot.Run() failed: %v", err)
+
+	test.Compare(t, "unexpected polled offsets:\n%s", []int{0, 9, 9}, client.offsets)
+}
@@ -0,0 +1,143 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Client is the interface to the telegram bot api, use DefaultClient()
+// to get a new Client.
+type Client interface {
+	Updates(ctx context.Context, offset int, timeout time.Duration) ([]*Update, error)
+	SendMessage(chatID int64, text string) error
+}
+
+// Update is an incoming update of the bot, e.g. a new message.
+type Update struct {
+	ID      int      `json:"update_id"`
+	Message *Message `json:"message"`
+}
+
+// Message is a message sent to or from the bot.
+type Message struct {
+	ID   int    `json:"message_id"`
+	Chat Chat   `json:"chat"`
+	Text string `json:"text"`
+}
+
+// Chat is the conversation a message belongs to.
+type Chat struct {
+	ID int64 `json:"id"`
+}
+
+type response struct {
+	Ok          bool            `json:"ok"`
+	Description string          `json:"description"`
+	Result      json.RawMessage `json:"result"`
+}
+
+type sendMessageRequest struct {
+	ChatID int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+// Default implements the Client interface.
+type Default struct {
+	URL   string
+	Token string
+
+	HTTPClient *http.Client
+}
+
+// DefaultClient returns a Client that talks to the official telegram bot api.
+func DefaultClient(token string) Client {
+	return &Default{
+		URL:        "https://api.telegram.org",
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 70 * time.Second},
+	}
+}
+
+// Updates long polls for new updates with an id >= `offset`,
+// the request is aborted when `ctx` is done.
+func (c *Default) Updates(ctx context.Context, offset int, timeout time.Duration) ([]*Update, error) {
+	query := url.Values{}
+	query.Add("offset", strconv.Itoa(offset))
+	query.Add("timeout", strconv.Itoa(int(timeout.Seconds())))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.methodURL("getUpdates")+"?"+query.Encode(), nil)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading updates failed")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading updates failed")
+	}
+
+	defer resp.Body.Close()
+
+	updates := []*Update{}
+	err = parseResponse(resp, &updates)
+
+	return updates, errors.Wrap(err, "loading updates failed")
+}
+
+// SendMessage sends a plain text message to a chat.
+func (c *Default) SendMessage(chatID int64, text string) error {
+	body, err := json.Marshal(sendMessageRequest{
+		ChatID: chatID,
+		Text:   text,
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "encoding message failed")
+	}
+
+	resp, err := c.HTTPClient.Post(
+		c.methodURL("sendMessage"),
+		"application/json",
+		bytes.NewReader(body))
+
+	if err != nil {
+		return errors.Wrapf(err, "sending message to chat %d failed", chatID)
+	}
+
+	defer resp.Body.Close()
+
+	err = parseResponse(resp, nil)
+
+	return errors.Wrapf(err, "sending message to chat %d failed", chatID)
+}
+
+func (c *Default) methodURL(method string) string {
+	return fmt.Sprintf("%s/bot%s/%s", c.URL, c.Token, method)
+}
+
+func parseResponse(resp *http.Response, result interface{}) error {
+	r := response{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return errors.Wrapf(err, "decoding response with status %d", resp.StatusCode)
+	}
+
+	if !r.Ok {
+		return fmt.Errorf("telegram api error (%d): %s", resp.StatusCode, r.Description)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(r.Result, result)
+}
@@ -0,0 +1,195 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/repo"
+)
+
+// maxListEntries limits the entries of lists sent to a chat.
+const maxListEntries = 10
+
+// command executes a bot command and returns the reply.
+type command func(ctx context.Context, b *Bot, chatID int64, args []string) (string, error)
+
+var commands = map[string]command{
+	"start":         startCommand,
+	"link":          linkCommand,
+	"unlink":        unlinkCommand,
+	"tournaments":   tournamentsCommand,
+	"ladder":        ladderCommand,
+	"registrations": registrationsCommand,
+	"help":          helpCommand,
+}
+
+const helpText = `Available commands:
+/link <code> - link this chat to your scores account
+/unlink - stop receiving notifications
+/tournaments [league] - upcoming tournaments
+/ladder [M|W] - the top of the ladder
+/registrations - your upcoming tournaments`
+
+func helpCommand(ctx context.Context, b *Bot, chatID int64, args []string) (string, error) {
+	return helpText, nil
+}
+
+// startCommand is sent by telegram when a user opens the bot for the
+// first time, a deep link passes the link code as argument.
+func startCommand(ctx context.Context, b *Bot, chatID int64, args []string) (string, error) {
+	if len(args) > 0 {
+		return linkCommand(ctx, b, chatID, args)
+	}
+
+	return "Welcome to scores!\n\n" + helpText, nil
+}
+
+func linkCommand(ctx context.Context, b *Bot, chatID int64, args []string) (string, error) {
+	if len(args) != 1 {
+		return "Usage: /link <code>, you get the code in your scores profile.", nil
+	}
+
+	userID, ok, err := b.redeemLinkCode(ctx, strings.ToUpper(args[0]))
+
+	if err != nil {
+		return "", err
+	} else if !ok {
+		return "This code is invalid or has expired.", nil
+	}
+
+	err = b.UserService.UpdateSettings(ctx, userID, &scores.Setting{
+		UserID: userID,
+		Key:    ChatSettingKey,
+		Type:   "string",
+		Value:  strconv.FormatInt(chatID, 10),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return "Your account has been linked, you will now receive notifications in this chat.", nil
+}
+
+func unlinkCommand(ctx context.Context, b *Bot, chatID int64, args []string) (string, error) {
+	user, err := b.userByChat(ctx, chatID)
+
+	if errors.Cause(err) == scores.ErrNotFound {
+		return "This chat is not linked to an account.", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	err = b.UserService.ClearSetting(ctx, user.ID, ChatSettingKey)
+
+	if err != nil {
+		return "", err
+	}
+
+	return "Your account has been unlinked.", nil
+}
+
+func tournamentsCommand(ctx context.Context, b *Bot, chatID int64, args []string) (string, error) {
+	filter := repo.TournamentFilter{}
+
+	if len(args) > 0 {
+		filter.Leagues = []string{scores.Sluggify(strings.Join(args, " "))}
+	} else if user, err := b.userByChat(ctx, chatID); err == nil {
+		filter.Leagues, _ = user.Settings["tournament-filter-league"].([]string)
+		filter.Genders, _ = user.Settings["tournament-filter-gender"].([]string)
+	}
+
+	tournaments, err := b.VolleynetService.UpcomingTournaments(ctx,
+		b.VolleynetService.SetDefaultFilters(filter))
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(tournaments) == 0 {
+		return "There are no upcoming tournaments.", nil
+	}
+
+	lines := []string{"Upcoming tournaments:"}
+
+	for i, t := range tournaments {
+		if i == maxListEntries {
+			break
+		}
+
+		lines = append(lines, tournamentLine(t))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func ladderCommand(ctx context.Context, b *Bot, chatID int64, args []string) (string, error) {
+	gender := "M"
+
+	if len(args) > 0 {
+		gender = strings.ToUpper(args[0])
+	}
+
+	if !b.VolleynetService.ValidGender(gender) {
+		return "Usage: /ladder [M|W]", nil
+	}
+
+	players, err := b.VolleynetService.Ladder(ctx, gender)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(players) == 0 {
+		return "The ladder is empty.", nil
+	}
+
+	lines := []string{"Ladder " + gender + ":"}
+
+	for i, p := range players {
+		if i == maxListEntries {
+			break
+		}
+
+		lines = append(lines, fmt.Sprintf("%d. %s %s (%d)", p.LadderRank, p.FirstName, p.LastName, p.TotalPoints))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func registrationsCommand(ctx context.Context, b *Bot, chatID int64, args []string) (string, error) {
+	user, err := b.userByChat(ctx, chatID)
+
+	if errors.Cause(err) == scores.ErrNotFound {
+		return "Link your account first with /link <code>.", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if user.PlayerID == 0 {
+		return "Your account is not linked to a volleynet player yet.", nil
+	}
+
+	tournaments, err := b.VolleynetService.Registrations(ctx, user.PlayerID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(tournaments) == 0 {
+		return "You are not registered for any upcoming tournaments.", nil
+	}
+
+	lines := []string{"Your registrations:"}
+
+	for _, t := range tournaments {
+		lines = append(lines, tournamentLine(t))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,75 @@
+package telegram
+
+import (
+	"fmt"
+
+	"github.com/raphi011/scores"
+	"github.com/raphi011/scores/notify"
+	"github.com/raphi011/scores/services"
+	"github.com/raphi011/scores/volleynet"
+	"github.com/raphi011/scores/volleynet/sync"
+)
+
+func tournamentLine(t *volleynet.Tournament) string {
+	line := fmt.Sprintf("%s %s - %s (%s)",
+		t.Start.Format("02.01."),
+		t.Name,
+		t.League,
+		t.Gender,
+	)
+
+	if t.MaxTeams > 0 {
+		line += fmt.Sprintf(", %d/%d teams", t.SignedupTeams, t.MaxTeams)
+	}
+
+	if t.RegistrationOpen {
+		line += ", registration open"
+	}
+
+	return line
+}
+
+func playerName(p *volleynet.Player) string {
+	if p == nil {
+		return ""
+	}
+
+	return p.FirstName + " " + p.LastName
+}
+
+// notificationMessage renders the notification text for a user, an empty
+// string is returned for unknown notifications.
+func notificationMessage(user *scores.User, n *notify.Notification) string {
+	t := n.Tournament
+
+	switch n.Type {
+	case sync.RegistrationOpenEventType:
+		return fmt.Sprintf("The registration for %s on %s (%s) is now open!\n%s",
+			t.Name, t.Start.Format("02.01.2006"), t.League, t.Link)
+	case sync.TeamRegisteredEventType:
+		return fmt.Sprintf("%s and %s signed up for %s on %s.",
+			playerName(n.Team.Player1), playerName(n.Team.Player2),
+			t.Name, t.Start.Format("02.01.2006"))
+	case sync.TeamMainDrawEventType:
+		return fmt.Sprintf("Good news, %s and %s moved up into the main draw of %s on %s.",
+			playerName(n.Team.Player1), playerName(n.Team.Player2),
+			t.Name, t.Start.Format("02.01.2006"))
+	case sync.ResultsEventType:
+		for _, team := range t.Teams {
+			if team.HasPlayer(user.PlayerID) {
+				return fmt.Sprintf("The results of %s are in, you finished %d. and won %d points.",
+					t.Name, team.Result, team.WonPoints)
+			}
+		}
+
+		return fmt.Sprintf("The results of %s are in.", t.Name)
+	case services.SignupSucceededEventType:
+		return fmt.Sprintf("You and %s have been signed up for %s on %s.\n%s",
+			n.Signup.PartnerName, t.Name, t.Start.Format("02.01.2006"), t.Link)
+	case services.SignupFailedEventType:
+		return fmt.Sprintf("The scheduled signup with %s for %s on %s failed: %s.",
+			n.Signup.PartnerName, t.Name, t.Start.Format("02.01.2006"), n.Signup.Message)
+	}
+
+	return ""
+}
@@ -18,6 +18,10 @@ import (
 	"github.com/raphi011/scores/volleynet/scrape"
 )
 
+// ErrInvalidCredentials is returned by `Login` if volleynet
+// rejected the username or password.
+var ErrInvalidCredentials = errors.New("invalid volleynet credentials")
+
 // Client is the interface to the volleynet api, use DefaultClient()
 // to get a new Client.
 type Client interface {
@@ -61,6 +65,7 @@ func WithLogger(log logrus.FieldLogger) Client {
 
 // Login authenticates the user against the volleynet page, if
 // successfull the Client cookie is set, else an error is returned.
+// `ErrInvalidCredentials` is returned if the login has been rejected.
 func (c *Default) Login(username, password string) (*scrape.LoginData, error) {
 	form := url.Values{}
 	form.Add("login_name", username)
@@ -88,6 +93,11 @@ func (c *Default) Login(username, password string) (*scrape.LoginData, error) {
 		return nil, errors.Wrap(err, "parse login")
 	}
 
+	// a rejected login shows the login form again instead of the profile
+	if loginData.ID == 0 {
+		return nil, ErrInvalidCredentials
+	}
+
 	c.Cookie = resp.Header.Get("Set-Cookie")
 
 	semicolonIndex := strings.Index(c.Cookie, ";")
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -53,3 +55,16 @@ func Test_login(t *testing.T) {
 		t.Error("login(), should return the logged in user")
 	}
 }
+
+func Test_login_rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><form name="login"></form></body></html>`))
+	}))
+	defer server.Close()
+
+	c := &Default{PostURL: server.URL}
+
+	if _, err := c.Login("user", "wrong"); err != ErrInvalidCredentials {
+		t.Errorf("login(), want ErrInvalidCredentials, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package volleynet
+
+// PlayerTournament is the entry of a player in a tournament.
+type PlayerTournament struct {
+	Tournament TournamentInfo `json:"tournament" db:"tournament"`
+	Partner    *Player        `json:"partner" db:"partner"`
+
+	Deregistered bool    `json:"deregistered"`
+	PrizeMoney   float32 `json:"prizeMoney" db:"prize_money"`
+	Result       int     `json:"result"`
+	Seed         int     `json:"seed"`
+	TotalPoints  int     `json:"totalPoints" db:"total_points"`
+	WonPoints    int     `json:"wonPoints" db:"won_points"`
+}
+
+// SeasonStats are the aggregated results of a player in a season, only
+// finished tournaments the player has not deregistered from are counted.
+type SeasonStats struct {
+	Season        string  `json:"season"`
+	Tournaments   int     `json:"tournaments"`
+	AverageResult float64 `json:"averageResult" db:"average_result"`
+	BestResult    int     `json:"bestResult" db:"best_result"`
+	WonPoints     int     `json:"wonPoints" db:"won_points"`
+	PrizeMoney    float32 `json:"prizeMoney" db:"prize_money"`
+	Podiums       int     `json:"podiums"` // # of top 3 results
+}
@@ -0,0 +1,81 @@
+package volleynet
+
+import (
+	"time"
+
+	"github.com/raphi011/scores"
+)
+
+// Reasons why a partner is recommended.
+const (
+	ReasonSimilarPoints   = "similar-points"
+	ReasonSimilarRank     = "similar-rank"
+	ReasonSameClub        = "same-club"
+	ReasonSameUnion       = "same-country-union"
+	ReasonPreviousPartner = "previous-partner"
+)
+
+// PartnerRecommendation is a player that is recommended as partner for a tournament.
+type PartnerRecommendation struct {
+	Player *Player `json:"player"`
+	// Score is between 0 and 100, the higher the better the candidate fits.
+	Score            float64  `json:"score"`
+	Reasons          []string `json:"reasons"`
+	TeamPoints       int      `json:"teamPoints"`       // total points of both players
+	JointTournaments int      `json:"jointTournaments"` // # of tournaments played together
+	BestJointResult  int      `json:"bestJointResult"`  // 0 if they have no results together
+}
+
+// States of a partner listing.
+const (
+	ListingOpen    = "open"
+	ListingMatched = "matched" // a request has been accepted
+	ListingClosed  = "closed"  // closed by its user
+)
+
+// States of a partner request.
+const (
+	RequestPending   = "pending"
+	RequestAccepted  = "accepted"
+	RequestDeclined  = "declined"
+	RequestWithdrawn = "withdrawn"
+)
+
+// PartnerListing is a post on the partner board of a player that is looking
+// for a partner, either for a tournament or for the tournaments of a league
+// within a date range.
+type PartnerListing struct {
+	scores.M
+	scores.Track
+
+	UserID       int       `json:"userId" db:"user_id"`
+	Player       *Player   `json:"player"`
+	TournamentID int       `json:"tournamentId" db:"tournament_id"` // 0 if the listing is for a date range
+	League       string    `json:"league" db:"league_key"`
+	From         time.Time `json:"from" db:"from_date"`
+	To           time.Time `json:"to" db:"to_date"`
+	Note         string    `json:"note"`
+	Status       string    `json:"status"` // can be `ListingOpen`, `ListingMatched` or `ListingClosed`
+}
+
+// PartnerRequest is the request of a player to play a tournament with the
+// player of a listing.
+type PartnerRequest struct {
+	scores.M
+	scores.Track
+
+	ListingID    int     `json:"listingId" db:"listing_id"`
+	UserID       int     `json:"userId" db:"user_id"`
+	Player       *Player `json:"player"`
+	TournamentID int     `json:"tournamentId" db:"tournament_id"`
+	Message      string  `json:"message"`
+	Status       string  `json:"status"` // can be `RequestPending`, `RequestAccepted`, `RequestDeclined` or `RequestWithdrawn`
+}
+
+// PartnerSignup pre-fills the signup of a player with the partner of an
+// accepted request.
+type PartnerSignup struct {
+	TournamentID int    `json:"tournamentId"`
+	PartnerID    int    `json:"partnerId"`
+	PartnerName  string `json:"partnerName"`
+}
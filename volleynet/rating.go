@@ -0,0 +1,37 @@
+package volleynet
+
+import (
+	"time"
+
+	"github.com/raphi011/scores"
+)
+
+// Rating is the rating of a player after a tournament.
+type Rating struct {
+	scores.Track
+	PlayerID        int       `json:"playerId" db:"player_id"`
+	TournamentID    int       `json:"tournamentId" db:"tournament_id"`
+	TournamentStart time.Time `json:"tournamentStart" db:"tournament_start"`
+	Rating          float64   `json:"rating"`
+	Delta           float64   `json:"delta"`       // change of the rating by this tournament
+	Tournaments     int       `json:"tournaments"` // # of rated tournaments of the player, including this one
+}
+
+// RatedPlayer is a player with their current rating.
+type RatedPlayer struct {
+	Player      *Player `json:"player" db:"player"`
+	Rank        int     `json:"rank" db:"-"`
+	Rating      float64 `json:"rating"`
+	Tournaments int     `json:"tournaments"`
+}
+
+// TeamResult is the final placement of a team in a finished tournament.
+type TeamResult struct {
+	TournamentID    int       `db:"tournament_id"`
+	TournamentStart time.Time `db:"tournament_start"`
+	SubLeagueKey    string    `db:"sub_league_key"`
+	Player1ID       int       `db:"player_1_id"`
+	Player2ID       int       `db:"player_2_id"`
+	Result          int
+	WonPoints       int `db:"won_points"` // ladder points each player has won
+}
@@ -0,0 +1,38 @@
+package volleynet
+
+import (
+	"time"
+
+	"github.com/raphi011/scores"
+)
+
+// States of a scheduled signup.
+const (
+	SignupScheduled = "scheduled"
+	SignupRunning   = "running" // the signup is being attempted
+	SignupSucceeded = "succeeded"
+	SignupFailed    = "failed"
+	SignupCanceled  = "canceled" // canceled by its user
+	SignupExpired   = "expired"  // the registration hasn't opened before the credentials expired
+)
+
+// ScheduledSignup is a signup of a user and a partner for a tournament that
+// is performed automatically as soon as the registration of the tournament opens.
+type ScheduledSignup struct {
+	scores.M
+	scores.Track
+
+	UserID       int    `json:"userId" db:"user_id"`
+	PlayerID     int    `json:"playerId" db:"player_id"` // the player of the volleynet login
+	TournamentID int    `json:"tournamentId" db:"tournament_id"`
+	PartnerID    int    `json:"partnerId" db:"partner_id"`
+	PartnerName  string `json:"partnerName" db:"partner_name"`
+	Status       string `json:"status"`  // can be any of the `Signup*` states
+	Message      string `json:"message"` // the reason why the signup has failed or expired
+
+	// Credentials are the encrypted volleynet credentials, they are
+	// cleared once the signup has been attempted, canceled or has expired.
+	Credentials string     `json:"-" db:"credentials"`
+	ExpiresAt   time.Time  `json:"expiresAt" db:"expires_at"`
+	AttemptedAt *time.Time `json:"attemptedAt" db:"attempted_at"`
+}
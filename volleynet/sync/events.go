@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/raphi011/scores/events"
+	"github.com/raphi011/scores/volleynet"
 )
 
 const (
@@ -14,6 +15,23 @@ const (
 	StartScrapeEventType = "volleynet/scrape/start"
 	// EndScrapeEventType TODO
 	EndScrapeEventType = "volleynet/scrape/end"
+
+	// TournamentEventsType matches all events concerning a single tournament.
+	TournamentEventsType = "volleynet/tournament/*"
+	// RegistrationOpenEventType is published when the registration of a tournament opens.
+	RegistrationOpenEventType = "volleynet/tournament/registration-open"
+	// TeamRegisteredEventType is published when a new team signs up for a tournament.
+	TeamRegisteredEventType = "volleynet/tournament/team-registered"
+	// ResultsEventType is published when the results of a tournament are in.
+	ResultsEventType = "volleynet/tournament/results"
+	// TeamMainDrawEventType is published when a team moves up from the waiting
+	// list into the main draw.
+	TeamMainDrawEventType = "volleynet/tournament/team-main-draw"
+
+	// PlayerEventsType matches all events concerning a single player.
+	PlayerEventsType = "volleynet/player/*"
+	// LadderRankEventType is published when the ladder rank of a player changes.
+	LadderRankEventType = "volleynet/player/ladder-rank"
 )
 
 // StartScrapeEvent TODO
@@ -55,3 +73,65 @@ func (s *Service) publishEndScrapeEvent(report *Changes, end time.Time) {
 		})
 	}
 }
+
+// TournamentEvent is the body of all `volleynet/tournament/*` events, `Team` is
+// only set if the event concerns a single team.
+type TournamentEvent struct {
+	ID         string                    `json:"id"`
+	Timestamp  time.Time                 `json:"time"`
+	Tournament *volleynet.Tournament     `json:"tournament"`
+	Team       *volleynet.TournamentTeam `json:"team,omitempty"`
+}
+
+// queueTournamentEvent remembers an event that is published once the
+// changes have been persisted successfully.
+func (c *Changes) queueTournamentEvent(
+	name string,
+	tournament *volleynet.Tournament,
+	team *volleynet.TournamentTeam) {
+
+	c.events = append(c.events, events.Event{
+		Name: name,
+		Body: TournamentEvent{
+			ID:         uuid.New().String(),
+			Tournament: tournament,
+			Team:       team,
+		},
+	})
+}
+
+func (s *Service) publishTournamentEvents(report *Changes, now time.Time) {
+	if s.Subscriptions == nil {
+		return
+	}
+
+	for _, event := range report.events {
+		body := event.Body.(TournamentEvent)
+		body.Timestamp = now
+		event.Body = body
+
+		s.Subscriptions.Publish(event)
+	}
+}
+
+// PlayerEvent is the body of all `volleynet/player/*` events, `PreviousRank`
+// is the ladder rank of the player before the sync.
+type PlayerEvent struct {
+	ID           string            `json:"id"`
+	Timestamp    time.Time         `json:"time"`
+	Player       *volleynet.Player `json:"player"`
+	PreviousRank int               `json:"previousRank"`
+}
+
+func (s *Service) publishPlayerEvents(name string, players []PlayerEvent, now time.Time) {
+	if s.Subscriptions == nil {
+		return
+	}
+
+	for _, body := range players {
+		body.ID = uuid.New().String()
+		body.Timestamp = now
+
+		s.Subscriptions.Publish(events.Event{Name: name, Body: body})
+	}
+}
@@ -1,6 +1,10 @@
 package sync
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/raphi011/scores/volleynet"
 )
@@ -11,8 +15,13 @@ type LadderSyncReport struct {
 	UpdatedPlayers int
 }
 
+// Summary returns the # of changes, e.g. `players: 1 new, 2 updated`.
+func (r *LadderSyncReport) Summary() string {
+	return fmt.Sprintf("players: %d new, %d updated", r.NewPlayers, r.UpdatedPlayers)
+}
+
 // Ladder synchronizes player and rank data of all players of a certain `gender`
-func (s *Service) Ladder(gender string) (*LadderSyncReport, error) {
+func (s *Service) Ladder(ctx context.Context, gender string) (*LadderSyncReport, error) {
 	ranks, err := s.Client.Ladder(gender)
 	report := &LadderSyncReport{}
 
@@ -20,13 +29,14 @@ func (s *Service) Ladder(gender string) (*LadderSyncReport, error) {
 		return nil, errors.Wrap(err, "loading the ladder failed")
 	}
 
-	persisted, err := s.PlayerRepo.ByGender(gender)
+	persisted, err := s.PlayerRepo.ByGender(ctx, gender)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "loading persisted players failed")
 	}
 
 	syncInfos := Players(persisted, ranks...)
+	rankChanges := []PlayerEvent{}
 
 	for _, info := range syncInfos {
 		if info.IsNew {
@@ -35,7 +45,7 @@ func (s *Service) Ladder(gender string) (*LadderSyncReport, error) {
 				info.NewPlayer.FirstName,
 				info.NewPlayer.LastName)
 
-			_, err = s.PlayerRepo.New(info.NewPlayer)
+			_, err = s.PlayerRepo.New(ctx, info.NewPlayer)
 			report.NewPlayers++
 
 		} else {
@@ -46,9 +56,15 @@ func (s *Service) Ladder(gender string) (*LadderSyncReport, error) {
 				merged.FirstName,
 				merged.LastName)
 
-			err = s.PlayerRepo.Update(merged)
+			err = s.PlayerRepo.Update(ctx, merged)
 			report.UpdatedPlayers++
 
+			if merged.LadderRank != info.OldPlayer.LadderRank {
+				rankChanges = append(rankChanges, PlayerEvent{
+					Player:       merged,
+					PreviousRank: info.OldPlayer.LadderRank,
+				})
+			}
 		}
 
 		if err != nil {
@@ -56,6 +72,8 @@ func (s *Service) Ladder(gender string) (*LadderSyncReport, error) {
 		}
 	}
 
+	s.publishPlayerEvents(LadderRankEventType, rankChanges, time.Now())
+
 	return report, nil
 }
 
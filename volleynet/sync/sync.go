@@ -1,6 +1,8 @@
 package sync
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -19,6 +21,8 @@ type Changes struct {
 	Team           TeamChanges
 	ScrapeDuration time.Duration
 	Success        bool
+
+	events []events.Event
 }
 
 // Service allows loading and synchronizing of the volleynetpage.
@@ -35,26 +39,26 @@ type Service struct {
 
 // Tournaments loads tournaments of a certain `gender`, `league` and `season` and
 // synchronizes + updates them (if necessary) in the repository.
-func (s *Service) Tournaments(gender, league string, season int) error {
+func (s *Service) Tournaments(ctx context.Context, gender, league string, season int) (*Changes, error) {
 	report := &Changes{TournamentInfo: TournamentChanges{}, Team: TeamChanges{}}
 	s.publishStartScrapeEvent("tournaments", time.Now())
 
 	current, err := s.Client.Tournaments(gender, league, season)
 
 	if err != nil {
-		return errors.Wrap(err, "loading the client tournament list failed")
+		return nil, errors.Wrap(err, "loading the client tournament list failed")
 	}
 
 	persistedTournaments := []*volleynet.Tournament{}
 	toDownload := []*volleynet.TournamentInfo{}
 
 	for _, t := range current {
-		persisted, err := s.TournamentRepo.Get(t.ID)
+		persisted, err := s.TournamentRepo.Get(ctx, t.ID)
 
 		if errors.Cause(err) == scores.ErrNotFound {
 			persisted = nil
 		} else if err != nil {
-			return errors.Wrap(err, "loading the persisted tournament failed")
+			return nil, errors.Wrap(err, "loading the persisted tournament failed")
 		}
 
 		syncInfo := Tournaments(persisted, t)
@@ -62,10 +66,10 @@ func (s *Service) Tournaments(gender, league string, season int) error {
 		if syncInfo.Type == SyncTournamentNoUpdate {
 			continue
 		} else if syncInfo.Type != SyncTournamentNew {
-			persisted.Teams, err = s.TeamRepo.ByTournament(t.ID)
+			persisted.Teams, err = s.TeamRepo.ByTournament(ctx, t.ID)
 
 			if err != nil {
-				return errors.Wrap(err, "loading the persisted tournament teams failed")
+				return nil, errors.Wrap(err, "loading the persisted tournament teams failed")
 			}
 
 			persistedTournaments = append(persistedTournaments, persisted)
@@ -75,7 +79,7 @@ func (s *Service) Tournaments(gender, league string, season int) error {
 	}
 
 	if len(toDownload) == 0 {
-		return nil
+		return report, nil
 	}
 
 	currentTournaments := make([]*volleynet.Tournament, len(toDownload))
@@ -93,25 +97,41 @@ func (s *Service) Tournaments(gender, league string, season int) error {
 
 	s.syncTournaments(report, persistedTournaments, currentTournaments)
 
-	err = s.persistChanges(report)
+	err = s.persistChanges(ctx, report)
+
+	if err == nil {
+		s.publishTournamentEvents(report, time.Now())
+	}
 
 	s.publishEndScrapeEvent(report, time.Now())
 
-	return errors.Wrap(err, "sync failed")
+	return report, errors.Wrap(err, "sync failed")
+}
+
+// Summary returns the # of changes, e.g. `tournaments: 1 new, 2 updated`.
+func (c *Changes) Summary() string {
+	return fmt.Sprintf("tournaments: %d new, %d updated, %d deleted; teams: %d new, %d updated, %d deleted",
+		len(c.TournamentInfo.New),
+		len(c.TournamentInfo.Update),
+		len(c.TournamentInfo.Delete),
+		len(c.Team.New),
+		len(c.Team.Update),
+		len(c.Team.Delete),
+	)
 }
 
-func (s *Service) persistChanges(report *Changes) error {
-	err := s.addMissingPlayers(report.Team.New)
+func (s *Service) persistChanges(ctx context.Context, report *Changes) error {
+	err := s.addMissingPlayers(ctx, report.Team.New)
 
 	if err != nil {
 		return err
 	}
 
-	err = s.persistTournaments(&report.TournamentInfo)
+	err = s.persistTournaments(ctx, &report.TournamentInfo)
 
 	if err != nil {
 		return err
 	}
 
-	return s.persistTeams(&report.Team)
+	return s.persistTeams(ctx, &report.Team)
 }
@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
@@ -47,10 +48,25 @@ func TestSyncLadder(t *testing.T) {
 
 	clientMock.On("Ladder", gender).Return(clientPlayers, nil)
 
-	report, err := service.Ladder(gender)
+	rankChanges, unsubscribe := service.Subscriptions.(*events.Broker).Subscribe(LadderRankEventType)
+	defer unsubscribe()
+
+	received := make(chan PlayerEvent, 1)
+
+	go func() {
+		event := <-rankChanges
+		received <- event.Body.(PlayerEvent)
+	}()
+
+	report, err := service.Ladder(context.Background(), gender)
 
 	test.Check(t, "service.Ladder() err: %v", err)
 	test.Assert(t, "Service.Ladder(\"M\") want: .UpdatedPlayers = 1, got: %d", report.UpdatedPlayers == 1, report.UpdatedPlayers)
+
+	event := <-received
+
+	test.Assert(t, "Service.Ladder(\"M\") want: rank change from 96 to 60, got: %d to %d",
+		event.PreviousRank == 96 && event.Player.LadderRank == 60, event.PreviousRank, event.Player.LadderRank)
 }
 
 func TestSyncTournamentInformation(t *testing.T) {
@@ -97,7 +113,35 @@ func TestSyncTournaments(t *testing.T) {
 	clientMock.On("Tournaments", gender, league, season).Return(clientTournaments, nil)
 	clientMock.On("ComplementTournament", clientTournaments[0]).Return(clientFullTournament[0], nil)
 
-	err := service.Tournaments("M", "amateur-league", 2018)
+	_, err := service.Tournaments(context.Background(), "M", "amateur-league", 2018)
 
 	test.Check(t, "service.Tournaments() err: %v", err)
 }
+
+func TestSyncTournamentEvents(t *testing.T) {
+	_, service, _ := syncMock(t)
+
+	old := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{ID: 1, Status: volleynet.StatusUpcoming},
+		Teams:          []*volleynet.TournamentTeam{},
+	}
+
+	current := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{ID: 1, Status: volleynet.StatusUpcoming, RegistrationOpen: true},
+		Teams: []*volleynet.TournamentTeam{
+			&volleynet.TournamentTeam{
+				TournamentID: 1,
+				Player1:      &volleynet.Player{ID: 1},
+				Player2:      &volleynet.Player{ID: 2},
+			},
+		},
+	}
+
+	changes := &Changes{}
+
+	service.syncTournaments(changes, []*volleynet.Tournament{old}, []*volleynet.Tournament{current})
+
+	test.Assert(t, "want 2 queued events, got %d", len(changes.events) == 2, len(changes.events))
+	test.Equal(t, "want event %q, got %q", RegistrationOpenEventType, changes.events[0].Name)
+	test.Equal(t, "want event %q, got %q", TeamRegisteredEventType, changes.events[1].Name)
+}
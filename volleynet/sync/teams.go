@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -58,9 +59,9 @@ func hasTeamChanged(old, new *volleynet.TournamentTeam) bool {
 	return *new != *old
 }
 
-func (s *Service) persistTeams(changes *TeamChanges) error {
+func (s *Service) persistTeams(ctx context.Context, changes *TeamChanges) error {
 	for _, new := range changes.New {
-		_, err := s.TeamRepo.New(new)
+		_, err := s.TeamRepo.New(ctx, new)
 
 		if err != nil {
 			return errors.Wrapf(err, "persist new tournament team %+v", new)
@@ -68,7 +69,7 @@ func (s *Service) persistTeams(changes *TeamChanges) error {
 	}
 
 	for _, update := range changes.Update {
-		err := s.TeamRepo.Update(update)
+		err := s.TeamRepo.Update(ctx, update)
 
 		if err != nil {
 			return errors.Wrapf(err, "persist updated tournament team %+v", update)
@@ -76,7 +77,7 @@ func (s *Service) persistTeams(changes *TeamChanges) error {
 	}
 
 	for _, delete := range changes.Delete {
-		err := s.TeamRepo.Delete(delete)
+		err := s.TeamRepo.Delete(ctx, delete)
 
 		if err != nil {
 			return errors.Wrapf(err, "persist deleted tournament team %+v", delete)
@@ -86,11 +87,11 @@ func (s *Service) persistTeams(changes *TeamChanges) error {
 	return nil
 }
 
-func (s *Service) addMissingPlayers(teams []*volleynet.TournamentTeam) error {
+func (s *Service) addMissingPlayers(ctx context.Context, teams []*volleynet.TournamentTeam) error {
 	players := distinctPlayers(teams)
 
 	for _, p := range players {
-		err := s.addPlayerIfNeeded(p)
+		err := s.addPlayerIfNeeded(ctx, p)
 
 		if err != nil {
 			return errors.Wrap(err, "addMissingPlayers failed")
@@ -120,11 +121,11 @@ func distinctPlayers(teams []*volleynet.TournamentTeam) []*volleynet.Player {
 	return distinct
 }
 
-func (s *Service) addPlayerIfNeeded(player *volleynet.Player) error {
-	_, err := s.PlayerRepo.Get(player.ID)
+func (s *Service) addPlayerIfNeeded(ctx context.Context, player *volleynet.Player) error {
+	_, err := s.PlayerRepo.Get(ctx, player.ID)
 
 	if errors.Cause(err) == scores.ErrNotFound {
-		_, err = s.PlayerRepo.New(player)
+		_, err = s.PlayerRepo.New(ctx, player)
 
 		return errors.Wrap(err, "addPlayerIfNeeded")
 	} else if err != nil {
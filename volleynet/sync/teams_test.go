@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"context"
 	"testing"
 
 	"github.com/raphi011/scores/repo/sql"
@@ -46,7 +47,7 @@ func TestAddMissingPlayers(t *testing.T) {
 		},
 	}
 
-	err := service.addMissingPlayers(teams)
+	err := service.addMissingPlayers(context.Background(), teams)
 
 	test.Check(t, "addMissingPlayers() failed: %v", err)
 }
@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"context"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/raphi011/scores"
 
@@ -43,12 +44,18 @@ func (s *Service) syncTournaments(changes *Changes, oldTournaments, newTournamen
 		oldTournament, ok := oldTournamentMap[key]
 		if !ok {
 			changes.TournamentInfo.New = append(changes.TournamentInfo.New, newTournament)
+
+			if newTournament.RegistrationOpen {
+				changes.queueTournamentEvent(RegistrationOpenEventType, newTournament, nil)
+			}
 		} else {
 			mergedTournament := MergeTournament(oldTournament, newTournament)
 
 			if hasTournamentChanged(oldTournament, mergedTournament) {
 				changes.TournamentInfo.Update = append(changes.TournamentInfo.Update, mergedTournament)
 			}
+
+			queueTournamentEvents(changes, oldTournament, mergedTournament, newTournament.Teams)
 		}
 
 		oldTeams := []*volleynet.TournamentTeam{}
@@ -56,7 +63,19 @@ func (s *Service) syncTournaments(changes *Changes, oldTournaments, newTournamen
 			oldTeams = oldTournament.Teams
 		}
 
+		newTeamCount := len(changes.Team.New)
+
 		s.syncTournamentTeams(&changes.Team, oldTeams, newTournament.Teams)
+
+		// teams of tournaments we see for the first time are not announced,
+		// otherwise the first sync of a season would flood the subscribers
+		if oldTournament != nil && newTournament.Status == volleynet.StatusUpcoming {
+			for _, team := range changes.Team.New[newTeamCount:] {
+				changes.queueTournamentEvent(TeamRegisteredEventType, newTournament, team)
+			}
+
+			queueMainDrawEvents(changes, oldTeams, newTournament)
+		}
 	}
 
 	for key, oldTournament := range oldTournamentMap {
@@ -66,6 +85,37 @@ func (s *Service) syncTournaments(changes *Changes, oldTournaments, newTournamen
 	}
 }
 
+// queueTournamentEvents compares the persisted and merged tournament and queues
+// events for all noteworthy changes, `teams` are the currently scraped teams.
+func queueTournamentEvents(
+	changes *Changes,
+	old, merged *volleynet.Tournament,
+	teams []*volleynet.TournamentTeam) {
+
+	tournament := *merged
+	tournament.Teams = teams
+
+	if !old.RegistrationOpen && merged.RegistrationOpen {
+		changes.queueTournamentEvent(RegistrationOpenEventType, &tournament, nil)
+	}
+
+	if old.Status == volleynet.StatusUpcoming && merged.Status == volleynet.StatusDone {
+		changes.queueTournamentEvent(ResultsEventType, &tournament, nil)
+	}
+}
+
+// queueMainDrawEvents queues an event for every team that has moved from
+// the waiting list into the main draw.
+func queueMainDrawEvents(changes *Changes, oldTeams []*volleynet.TournamentTeam, tournament *volleynet.Tournament) {
+	for _, team := range tournament.Teams {
+		old := FindTeam(oldTeams, team.TournamentID, team.Player1.ID, team.Player2.ID)
+
+		if old != nil && !old.InMainDraw(tournament.MaxTeams) && team.InMainDraw(tournament.MaxTeams) {
+			changes.queueTournamentEvent(TeamMainDrawEventType, tournament, team)
+		}
+	}
+}
+
 func createTournamentMap(tournaments []*volleynet.Tournament) map[int]*volleynet.Tournament {
 	tournamentMap := make(map[int]*volleynet.Tournament)
 
@@ -114,9 +164,9 @@ func Tournaments(persisted *volleynet.Tournament, current *volleynet.TournamentI
 	}
 }
 
-func (s *Service) persistTournaments(changes *TournamentChanges) error {
+func (s *Service) persistTournaments(ctx context.Context, changes *TournamentChanges) error {
 	for _, new := range changes.New {
-		_, err := s.TournamentRepo.New(new)
+		_, err := s.TournamentRepo.New(ctx, new)
 
 		if err != nil {
 			return errors.Wrap(err, "persisting new tournament failed")
@@ -124,7 +174,7 @@ func (s *Service) persistTournaments(changes *TournamentChanges) error {
 	}
 
 	for _, update := range changes.Update {
-		err := s.TournamentRepo.Update(update)
+		err := s.TournamentRepo.Update(ctx, update)
 
 		if err != nil {
 			return errors.Wrap(err, "persisting updated tournament failed")
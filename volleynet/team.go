@@ -18,4 +18,34 @@ type TournamentTeam struct {
 	Seed         int     `json:"seed"`
 	TotalPoints  int     `json:"totalPoints" db:"total_points"`
 	WonPoints    int     `json:"wonPoints" db:"won_points"`
+
+	Projection *TeamProjection `json:"projection,omitempty" db:"-"`
+}
+
+// TeamProjection is the projected status of a registered team of an
+// upcoming tournament.
+type TeamProjection struct {
+	Rank   int    `json:"rank"`   // position among the registered teams
+	Status string `json:"status"` // can be `ProjectionMainDraw`, `ProjectionQualification` or `ProjectionWaitingList`
+	// PointsToCut is the lead over the best team outside of the main draw
+	// (0 if all teams get in) or, if negative, the points that are missing
+	// to the weakest unseeded team in the main draw (never positive for
+	// teams outside of the main draw).
+	PointsToCut int `json:"pointsToCut"`
+	// Withdrawals is the # of teams ranked higher that have to withdraw
+	// for the team to get into the main draw.
+	Withdrawals int `json:"withdrawals"`
+}
+
+// HasPlayer returns true if the player is a member of the team, scraped
+// teams can be missing their players.
+func (t *TournamentTeam) HasPlayer(playerID int) bool {
+	return (t.Player1 != nil && t.Player1.ID == playerID) ||
+		(t.Player2 != nil && t.Player2.ID == playerID)
+}
+
+// InMainDraw returns true if the team's seed is within the maximum number
+// of teams of the tournament's main draw.
+func (t *TournamentTeam) InMainDraw(maxTeams int) bool {
+	return !t.Deregistered && maxTeams > 0 && t.Seed > 0 && t.Seed <= maxTeams
 }
@@ -56,4 +56,23 @@ type Tournament struct {
 	MaxPoints       int               `json:"maxPoints" db:"max_points"`
 	Latitude        float32           `json:"latitude" db:"loc_lat"`
 	Longitude       float32           `json:"longitude" db:"loc_lon"`
+	Cut             *CutPrediction    `json:"cut,omitempty" db:"-"`     // only set for upcoming tournaments
+	Watched         bool              `json:"watched,omitempty" db:"-"` // set if the tournament is on the watchlist of the user
+}
+
+// Projected statuses of a registered team.
+const (
+	ProjectionMainDraw      = "main-draw"
+	ProjectionQualification = "qualification"
+	ProjectionWaitingList   = "waiting-list"
+)
+
+// CutPrediction predicts which of the registered teams of an upcoming
+// tournament get into the main draw.
+type CutPrediction struct {
+	MainDrawTeams      int `json:"mainDrawTeams"`
+	QualificationTeams int `json:"qualificationTeams"` // 0 if the tournament has no qualification
+	RegisteredTeams    int `json:"registeredTeams"`    // without deregistered teams
+	// CutPoints are the points of the weakest unseeded team in the main draw.
+	CutPoints int `json:"cutPoints"`
 }
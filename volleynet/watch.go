@@ -0,0 +1,51 @@
+package volleynet
+
+import "github.com/raphi011/scores"
+
+// WatchedTournament is a tournament on the watchlist of a user.
+type WatchedTournament struct {
+	scores.M
+	scores.Track
+
+	UserID       int `json:"userId" db:"user_id"`
+	TournamentID int `json:"tournamentId" db:"tournament_id"`
+}
+
+// FollowedPlayer is a player that is followed by a user.
+type FollowedPlayer struct {
+	scores.M
+	scores.Track
+
+	UserID int     `json:"userId" db:"user_id"`
+	Player *Player `json:"player"`
+}
+
+// Types of activities.
+const (
+	ActivityRegistrationOpen = "registration-open"
+	ActivityRegistration     = "registration" // a team has signed up
+	ActivityMainDraw         = "main-draw"    // a team has moved up into the main draw
+	ActivityResult           = "result"
+	ActivityLadderRank       = "ladder-rank"
+)
+
+// Activity is something that has happened to a tournament or a player
+// during a sync, e.g. a team has signed up for a tournament.
+type Activity struct {
+	scores.M
+	scores.Track
+
+	Type           string `json:"type"`
+	TournamentID   int    `json:"tournamentId" db:"tournament_id"` // 0 for ladder activities
+	TournamentName string `json:"tournamentName" db:"tournament_name"`
+	// Player1ID and Player2ID are the players of the team, 0 if the activity
+	// concerns the tournament, Player2ID is 0 for ladder activities.
+	Player1ID    int `json:"player1Id" db:"player_1_id"`
+	Player2ID    int `json:"player2Id" db:"player_2_id"`
+	Result       int `json:"result"`
+	LadderRank   int `json:"ladderRank" db:"ladder_rank"`
+	PreviousRank int `json:"previousRank" db:"previous_rank"`
+
+	// Players are the players of the activity, they are not persisted.
+	Players []*Player `json:"players,omitempty" db:"-"`
+}